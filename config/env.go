@@ -20,6 +20,19 @@ type env struct {
 
 	TIMEZONE string
 	DOMAIN   string
+	APP_ENV  string // development allows the log and file notification channels
+
+	// Patient notifications, see features/notifications/channels
+	NOTIFICATION_CHANNEL string // sms or whatsapp, log and file only in development
+	NOTIFICATION_FILE    string // file channel
+	NOTIFICATION_URL     string // sms and whatsapp gateway
+	NOTIFICATION_TOKEN   string
+
+	SMTP_HOST     string
+	SMTP_PORT     string
+	SMTP_USERNAME string
+	SMTP_PASSWORD string
+	SMTP_FROM     string
 }
 
 var ENV env
@@ -46,4 +59,16 @@ func LoadENV(path string) {
 
 	ENV.TIMEZONE = os.Getenv("TIMEZONE")
 	ENV.DOMAIN = os.Getenv("DOMAIN")
+	ENV.APP_ENV = os.Getenv("APP_ENV")
+
+	ENV.NOTIFICATION_CHANNEL = os.Getenv("NOTIFICATION_CHANNEL")
+	ENV.NOTIFICATION_FILE = os.Getenv("NOTIFICATION_FILE")
+	ENV.NOTIFICATION_URL = os.Getenv("NOTIFICATION_URL")
+	ENV.NOTIFICATION_TOKEN = os.Getenv("NOTIFICATION_TOKEN")
+
+	ENV.SMTP_HOST = os.Getenv("SMTP_HOST")
+	ENV.SMTP_PORT = os.Getenv("SMTP_PORT")
+	ENV.SMTP_USERNAME = os.Getenv("SMTP_USERNAME")
+	ENV.SMTP_PASSWORD = os.Getenv("SMTP_PASSWORD")
+	ENV.SMTP_FROM = os.Getenv("SMTP_FROM")
 }
@@ -10,11 +10,12 @@ type ErrPayload struct {
 }
 
 const (
-	KindBadRequest    ErrKind = http.StatusBadRequest
-	KindUnauthorized  ErrKind = http.StatusUnauthorized
-	KindNotFound      ErrKind = http.StatusNotFound
-	KindUnprocessable ErrKind = http.StatusUnprocessableEntity
-	KindServerError   ErrKind = http.StatusInternalServerError
+	KindBadRequest      ErrKind = http.StatusBadRequest
+	KindUnauthorized    ErrKind = http.StatusUnauthorized
+	KindNotFound        ErrKind = http.StatusNotFound
+	KindUnprocessable   ErrKind = http.StatusUnprocessableEntity
+	KindTooManyRequests ErrKind = http.StatusTooManyRequests
+	KindServerError     ErrKind = http.StatusInternalServerError
 )
 
 type Error struct {
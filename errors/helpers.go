@@ -1,6 +1,10 @@
 package errors
 
-import "github.com/pkg/errors"
+import (
+	"log"
+
+	"github.com/pkg/errors"
+)
 
 func New(message string) error {
 	return errors.New(message)
@@ -63,7 +67,8 @@ func Payload(err error) ErrPayload {
 		return ErrPayload{}
 	}
 
-	if e.Payload != (ErrPayload{}) {
+	// Data is compared to nil, comparing the struct panics when Data holds a map or slice
+	if e.Payload.Data != nil {
 		return e.Payload
 	}
 
@@ -82,3 +87,12 @@ func Ops(e *Error) []Op {
 	res = append(res, Ops(subErr)...)
 	return res
 }
+
+// Log prints an error nobody waits for, like one of a background run, with the ops it went through
+func Log(err error) {
+	if e, ok := err.(*Error); ok {
+		log.Printf("%v: %v\n", Ops(e), err)
+		return
+	}
+	log.Println(err)
+}
@@ -2,22 +2,60 @@ package factory
 
 import (
 	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/middleware"
+
+	accountsBusiness "github.com/final-project-alterra/hospital-management-system-api/features/accounts/business"
+	accountsData "github.com/final-project-alterra/hospital-management-system-api/features/accounts/data"
+
+	auditsBusiness "github.com/final-project-alterra/hospital-management-system-api/features/audits/business"
+	auditsData "github.com/final-project-alterra/hospital-management-system-api/features/audits/data"
+	auditsPresentation "github.com/final-project-alterra/hospital-management-system-api/features/audits/presentation"
 
 	adminsBusiness "github.com/final-project-alterra/hospital-management-system-api/features/admins/business"
 	adminsData "github.com/final-project-alterra/hospital-management-system-api/features/admins/data"
 	adminsPresentation "github.com/final-project-alterra/hospital-management-system-api/features/admins/presentation"
 
+	closuresBusiness "github.com/final-project-alterra/hospital-management-system-api/features/closures/business"
+	closuresData "github.com/final-project-alterra/hospital-management-system-api/features/closures/data"
+	closuresPresentation "github.com/final-project-alterra/hospital-management-system-api/features/closures/presentation"
+
 	doctorsBusiness "github.com/final-project-alterra/hospital-management-system-api/features/doctors/business"
 	doctorsData "github.com/final-project-alterra/hospital-management-system-api/features/doctors/data"
 	doctorsPresentation "github.com/final-project-alterra/hospital-management-system-api/features/doctors/presentation"
 
+	"github.com/final-project-alterra/hospital-management-system-api/features/jobs"
+	jobsBusiness "github.com/final-project-alterra/hospital-management-system-api/features/jobs/business"
+	jobsData "github.com/final-project-alterra/hospital-management-system-api/features/jobs/data"
+	jobsPresentation "github.com/final-project-alterra/hospital-management-system-api/features/jobs/presentation"
+	leavesBusiness "github.com/final-project-alterra/hospital-management-system-api/features/leaves/business"
+	leavesData "github.com/final-project-alterra/hospital-management-system-api/features/leaves/data"
+	leavesPresentation "github.com/final-project-alterra/hospital-management-system-api/features/leaves/presentation"
+
+	medicinesBusiness "github.com/final-project-alterra/hospital-management-system-api/features/medicines/business"
+	medicinesData "github.com/final-project-alterra/hospital-management-system-api/features/medicines/data"
+	medicinesPresentation "github.com/final-project-alterra/hospital-management-system-api/features/medicines/presentation"
+
+	notificationsBusiness "github.com/final-project-alterra/hospital-management-system-api/features/notifications/business"
+	notificationsChannels "github.com/final-project-alterra/hospital-management-system-api/features/notifications/channels"
+	notificationsData "github.com/final-project-alterra/hospital-management-system-api/features/notifications/data"
+	notificationsPresentation "github.com/final-project-alterra/hospital-management-system-api/features/notifications/presentation"
+
 	nursesBusiness "github.com/final-project-alterra/hospital-management-system-api/features/nurses/business"
 	nursesData "github.com/final-project-alterra/hospital-management-system-api/features/nurses/data"
 	nursesPresentation "github.com/final-project-alterra/hospital-management-system-api/features/nurses/presentation"
 
+	staffsBusiness "github.com/final-project-alterra/hospital-management-system-api/features/staffs/business"
+	staffsData "github.com/final-project-alterra/hospital-management-system-api/features/staffs/data"
+	staffsPresentation "github.com/final-project-alterra/hospital-management-system-api/features/staffs/presentation"
+
 	authsBusiness "github.com/final-project-alterra/hospital-management-system-api/features/auth/business"
+	authsData "github.com/final-project-alterra/hospital-management-system-api/features/auth/data"
+	authsNotifier "github.com/final-project-alterra/hospital-management-system-api/features/auth/notifier"
 	authsPresentation "github.com/final-project-alterra/hospital-management-system-api/features/auth/presentation"
 
+	permissionsBusiness "github.com/final-project-alterra/hospital-management-system-api/features/permissions/business"
+	permissionsPresentation "github.com/final-project-alterra/hospital-management-system-api/features/permissions/presentation"
+
 	patientsBusiness "github.com/final-project-alterra/hospital-management-system-api/features/patients/business"
 	patientsData "github.com/final-project-alterra/hospital-management-system-api/features/patients/data"
 	patientsPresentation "github.com/final-project-alterra/hospital-management-system-api/features/patients/presentation"
@@ -28,12 +66,21 @@ import (
 )
 
 type Presenter struct {
-	AuthPresentation     *authsPresentation.AuthPresetation
-	AdminPresentation    *adminsPresentation.AdminPresentation
-	DoctorPresentation   *doctorsPresentation.DoctorPresentation
-	NursePresentation    *nursesPresentation.NursePresentation
-	PatientPresentation  *patientsPresentation.PatientPresentation
-	SchedulePresentation *schedulesPresentation.SchedulePresentation
+	AuthPresentation       *authsPresentation.AuthPresetation
+	AdminPresentation      *adminsPresentation.AdminPresentation
+	PermissionPresentation *permissionsPresentation.PermissionPresentation
+	AuditPresentation      *auditsPresentation.AuditPresentation
+	DoctorPresentation     *doctorsPresentation.DoctorPresentation
+	NursePresentation      *nursesPresentation.NursePresentation
+	StaffPresentation      *staffsPresentation.StaffPresentation
+	PatientPresentation    *patientsPresentation.PatientPresentation
+	SchedulePresentation   *schedulesPresentation.SchedulePresentation
+	ClosurePresentation    *closuresPresentation.ClosurePresentation
+	LeavePresentation      *leavesPresentation.LeavePresentation
+	JobPresentation        *jobsPresentation.JobPresentation
+	MedicinePresentation   *medicinesPresentation.MedicinePresentation
+
+	NotificationPresentation *notificationsPresentation.NotificationPresentation
 }
 
 func New() *Presenter {
@@ -44,63 +91,165 @@ func New() *Presenter {
 	patientBuilder := patientsBusiness.NewPatientBusinessBuilder()
 	scheduleBuilder := schedulesBusiness.NewScheduleBusinessBuilder()
 
+	permissionBusiness := permissionsBusiness.NewPermissionBusinessBuilder().Build()
+
+	accountData := accountsData.NewMySQLRepo(config.DB)
+	auditData := auditsData.NewMySQLRepo(config.DB)
+	authData := authsData.NewMySQLRepo(config.DB)
 	adminData := adminsData.NewMySQLRepo(config.DB)
 	doctorData := doctorsData.NewMySQLRepo(config.DB)
 	nurseData := nursesData.NewMySQLRepo(config.DB)
+	staffData := staffsData.NewMySQLRepo(config.DB)
 	patientData := patientsData.NewMySQLRepo(config.DB)
 	scheduleData := schedulesData.NewMySQLRepo(config.DB)
+	closureData := closuresData.NewMySQLRepo(config.DB)
+	leaveData := leavesData.NewMySQLRepo(config.DB)
+	jobData := jobsData.NewMySQLRepo(config.DB)
+	notificationData := notificationsData.NewMySQLRepo(config.DB)
+	medicineData := medicinesData.NewMySQLRepo(config.DB)
 
-	pureDoctorBusiness := doctorBuilder.SetData(doctorData).Build()
-	pureNurseBusiness := nurseBuilder.SetData(nurseData).Build()
-	pureScheduleBusiness := scheduleBuilder.SetData(scheduleData).Build()
+	sessionBusiness := authBuilder.SetData(authData).Build()
+	accountBusiness := accountsBusiness.NewAccountBusinessBuilder().
+		SetData(accountData).
+		SetAuthBusiness(sessionBusiness).
+		Build()
+	auditBusiness := auditsBusiness.NewAuditBusinessBuilder().SetData(auditData).Build()
+	medicineBusiness := medicinesBusiness.NewMedicineBusinessBuilder().
+		SetData(medicineData).
+		SetAuditBusiness(auditBusiness).
+		Build()
+	notificationChannel, err := notificationsChannels.New()
+	if err != nil {
+		panic(err)
+	}
+	authNotifier, err := authsNotifier.New(notificationChannel)
+	if err != nil {
+		panic(err)
+	}
+	// notifications only look doctors and patients up, the full businesses depend on schedules
+	notificationBusiness := notificationsBusiness.NewNotificationBusinessBuilder().
+		SetData(notificationData).
+		SetChannel(notificationChannel).
+		SetPatientBusiness(patientBuilder.SetData(patientData).Build()).
+		SetDoctorBusiness(doctorBuilder.SetData(doctorData).Build()).
+		Build()
+	// one hub, so queue events of the businesses shared with other features reach the displays
+	queueHub := schedulesBusiness.NewQueueHub()
+	pureScheduleBusiness := scheduleBuilder.
+		SetData(scheduleData).
+		SetAuditBusiness(auditBusiness).
+		SetNotifier(notificationBusiness).
+		SetQueueHub(queueHub).
+		Build()
+	closureBusiness := closuresBusiness.NewClosureBusinessBuilder().
+		SetData(closureData).
+		SetScheduleBusiness(pureScheduleBusiness).
+		SetAuditBusiness(auditBusiness).
+		Build()
 
 	adminBusiness := adminBuilder.
 		SetData(adminData).
-		SetDoctorBusiness(pureDoctorBusiness).
-		SetNurseBusiness(pureNurseBusiness).
+		SetAccountBusiness(accountBusiness).
+		SetAuditBusiness(auditBusiness).
 		Build()
 	doctorBusiness := doctorBuilder.
 		SetData(doctorData).
 		SetAdminBusiness(adminBusiness).
-		SetNurseBusiness(pureNurseBusiness).
+		SetAccountBusiness(accountBusiness).
 		SetScheduleBusiness(pureScheduleBusiness).
+		SetAuditBusiness(auditBusiness).
 		Build()
 	nurseBusiness := nurseBuilder.
 		SetData(nurseData).
 		SetAdminBusiness(adminBusiness).
-		SetDoctorBusiness(doctorBusiness).
+		SetAccountBusiness(accountBusiness).
 		SetScheduleBusiness(pureScheduleBusiness).
+		SetAuditBusiness(auditBusiness).
+		Build()
+	staffBusiness := staffsBusiness.NewStaffBusinessBuilder().
+		SetData(staffData).
+		SetAdminBusiness(adminBusiness).
+		SetAccountBusiness(accountBusiness).
+		SetAuditBusiness(auditBusiness).
 		Build()
 	patientBusiness := patientBuilder.
 		SetData(patientData).
-		SetAdminBusiness(adminBusiness).
+		SetAccountBusiness(accountBusiness).
 		SetScheduleBusiness(pureScheduleBusiness).
+		SetAuditBusiness(auditBusiness).
 		Build()
 	authBusiness := authBuilder.
-		SetAdminBusiness(adminBusiness).
-		SetDoctorBusiness(doctorBusiness).
-		SetNurseBusiness(nurseBusiness).
+		SetData(authData).
+		SetNotifier(authNotifier).
+		SetAccountBusiness(accountBusiness).
+		SetPatientBusiness(patientBusiness).
 		Build()
 	scheduleBusiness := scheduleBuilder.
 		SetData(scheduleData).
 		SetDoctorBusiness(doctorBusiness).
 		SetNurseBusiness(nurseBusiness).
 		SetPatientBusiness(patientBusiness).
+		SetClosureBusiness(closureBusiness).
+		SetMedicineBusiness(medicineBusiness).
+		SetPermissionBusiness(permissionBusiness).
+		SetAuditBusiness(auditBusiness).
+		SetNotifier(notificationBusiness).
+		SetQueueHub(queueHub).
+		Build()
+	leaveBusiness := leavesBusiness.NewLeaveBusinessBuilder().
+		SetData(leaveData).
+		SetScheduleBusiness(scheduleBusiness).
+		SetAuditBusiness(auditBusiness).
+		Build()
+
+	middleware.SetAuthBusiness(authBusiness)
+	middleware.SetPermissionBusiness(permissionBusiness)
+
+	jobBusiness := jobsBusiness.NewJobBusinessBuilder().
+		SetData(jobData).
+		SetAuditBusiness(auditBusiness).
 		Build()
 
+	for _, job := range []jobs.JobCore{
+		schedulesBusiness.NoShowJob(scheduleBusiness),
+		schedulesBusiness.ReminderJob(scheduleBusiness),
+	} {
+		if err := jobBusiness.Register(job); err != nil {
+			panic(err)
+		}
+	}
+	jobBusiness.Start()
+
 	adminPresentation := adminsPresentation.NewAdminPresentation(adminBusiness)
 	doctorPresentation := doctorsPresentation.NewDoctorPresentation(doctorBusiness)
 	nursePresentation := nursesPresentation.NewNursePresentation(nurseBusiness)
+	staffPresentation := staffsPresentation.NewStaffPresentation(staffBusiness)
 	patientPresentation := patientsPresentation.NewPatientPresentation(patientBusiness)
 	authPresentation := authsPresentation.NewAuthPresentation(authBusiness)
 	schedulePresentation := schedulesPresentation.NewSchedulePresentation(scheduleBusiness)
+	permissionPresentation := permissionsPresentation.NewPermissionPresentation(permissionBusiness)
+	auditPresentation := auditsPresentation.NewAuditPresentation(auditBusiness)
+	closurePresentation := closuresPresentation.NewClosurePresentation(closureBusiness)
+	leavePresentation := leavesPresentation.NewLeavePresentation(leaveBusiness)
+	jobPresentation := jobsPresentation.NewJobPresentation(jobBusiness)
+	medicinePresentation := medicinesPresentation.NewMedicinePresentation(medicineBusiness)
+	notificationPresentation := notificationsPresentation.NewNotificationPresentation(notificationBusiness)
 
 	return &Presenter{
-		AuthPresentation:     authPresentation,
-		AdminPresentation:    adminPresentation,
-		DoctorPresentation:   doctorPresentation,
-		NursePresentation:    nursePresentation,
-		PatientPresentation:  patientPresentation,
-		SchedulePresentation: schedulePresentation,
+		AuthPresentation:       authPresentation,
+		AdminPresentation:      adminPresentation,
+		PermissionPresentation: permissionPresentation,
+		AuditPresentation:      auditPresentation,
+		DoctorPresentation:     doctorPresentation,
+		NursePresentation:      nursePresentation,
+		StaffPresentation:      staffPresentation,
+		PatientPresentation:    patientPresentation,
+		SchedulePresentation:   schedulePresentation,
+		ClosurePresentation:    closurePresentation,
+		LeavePresentation:      leavePresentation,
+		JobPresentation:        jobPresentation,
+		MedicinePresentation:   medicinePresentation,
+
+		NotificationPresentation: notificationPresentation,
 	}
 }
@@ -0,0 +1,37 @@
+package business
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	"github.com/final-project-alterra/hospital-management-system-api/features/auth"
+)
+
+type accountBusinessBuilder struct {
+	data         accounts.IData
+	authBusiness auth.IBusiness
+}
+
+func NewAccountBusinessBuilder() *accountBusinessBuilder {
+	return &accountBusinessBuilder{}
+}
+
+func (a *accountBusinessBuilder) SetData(data accounts.IData) *accountBusinessBuilder {
+	a.data = data
+	return a
+}
+
+func (a *accountBusinessBuilder) SetAuthBusiness(auth auth.IBusiness) *accountBusinessBuilder {
+	a.authBusiness = auth
+	return a
+}
+
+func (a *accountBusinessBuilder) Build() accounts.IBusiness {
+	accountBusiness := &accountBusiness{
+		data:         a.data,
+		authBusiness: a.authBusiness,
+	}
+
+	a.data = nil
+	a.authBusiness = nil
+
+	return accountBusiness
+}
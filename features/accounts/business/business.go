@@ -0,0 +1,141 @@
+package business
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	"github.com/final-project-alterra/hospital-management-system-api/features/auth"
+	"github.com/final-project-alterra/hospital-management-system-api/utils/hash"
+)
+
+type accountBusiness struct {
+	data         accounts.IData
+	authBusiness auth.IBusiness
+}
+
+func (a *accountBusiness) FindAccountByEmail(email string) (accounts.AccountCore, error) {
+	const op errors.Op = "accounts.business.FindAccountByEmail"
+
+	account, err := a.data.SelectAccountByEmail(email)
+	if err != nil {
+		return accounts.AccountCore{}, errors.E(err, op)
+	}
+	return account, nil
+}
+
+func (a *accountBusiness) FindAccountByUser(userId int, role string) (accounts.AccountCore, error) {
+	const op errors.Op = "accounts.business.FindAccountByUser"
+
+	account, err := a.data.SelectAccountByUser(userId, role)
+	if err != nil {
+		return accounts.AccountCore{}, errors.E(err, op)
+	}
+	return account, nil
+}
+
+func (a *accountBusiness) CheckEmail(email string) error {
+	const op errors.Op = "accounts.business.CheckEmail"
+	var errMessage errors.ErrClientMessage = "Email already exist"
+
+	_, err := a.data.SelectAccountByEmail(email)
+	if err == nil {
+		err = errors.New("Email already exist")
+		return errors.E(err, op, errMessage, errors.KindUnprocessable)
+	}
+
+	if errors.Kind(err) != errors.KindNotFound {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (a *accountBusiness) CreateAccount(account accounts.AccountCore) error {
+	const op errors.Op = "accounts.business.CreateAccount"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	err := a.CheckEmail(account.Email)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	account.Password, err = hash.Generate(account.Password)
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+
+	err = a.data.InsertAccount(account)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (a *accountBusiness) EditAccountPassword(userId int, role string, oldPassword string, newPassword string) error {
+	const op errors.Op = "accounts.business.EditAccountPassword"
+	var errMessage errors.ErrClientMessage = "Wrong old password"
+
+	account, err := a.data.SelectAccountByUser(userId, role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	doesMatch := hash.Validate(account.Password, oldPassword)
+	if !doesMatch {
+		err = errors.New("Wrong password")
+		return errors.E(err, op, errMessage, errors.KindUnprocessable)
+	}
+
+	err = a.updatePassword(account, newPassword)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	// Whoever knew the old password must be signed out
+	err = a.authBusiness.RevokeUserSessions(userId, role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (a *accountBusiness) ResetAccountPassword(userId int, role string, newPassword string) error {
+	const op errors.Op = "accounts.business.ResetAccountPassword"
+
+	account, err := a.data.SelectAccountByUser(userId, role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = a.updatePassword(account, newPassword)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (a *accountBusiness) RemoveAccountByUser(userId int, role string) error {
+	const op errors.Op = "accounts.business.RemoveAccountByUser"
+
+	err := a.data.DeleteAccountByUser(userId, role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+// Private methods
+func (a *accountBusiness) updatePassword(account accounts.AccountCore, newPassword string) error {
+	const op errors.Op = "accounts.business.updatePassword"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	var err error
+	account.Password, err = hash.Generate(newPassword)
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+
+	err = a.data.UpdateAccount(account)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package business_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	ab "github.com/final-project-alterra/hospital-management-system-api/features/accounts/business"
+	acm "github.com/final-project-alterra/hospital-management-system-api/features/accounts/mocks"
+	aum "github.com/final-project-alterra/hospital-management-system-api/features/auth/mocks"
+	"github.com/final-project-alterra/hospital-management-system-api/utils/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	repo         acm.IData
+	authBusiness aum.IBusiness
+	business     accounts.IBusiness
+
+	account1 accounts.AccountCore
+
+	errServer   error
+	errNotFound error
+)
+
+func TestMain(m *testing.M) {
+	business = ab.NewAccountBusinessBuilder().
+		SetData(&repo).
+		SetAuthBusiness(&authBusiness).
+		Build()
+
+	password, err := hash.Generate("password")
+	if err != nil {
+		panic(err)
+	}
+
+	account1 = accounts.AccountCore{
+		ID:       1,
+		Email:    "doctor@mail.com",
+		Password: password,
+		Role:     "doctor",
+		UserID:   1,
+	}
+
+	errNotFound = errors.E(errors.New("not found"), errors.KindNotFound)
+	errServer = errors.E(errors.New("server error"), errors.KindServerError)
+
+	os.Exit(m.Run())
+}
+
+func TestFindAccountByEmail(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectAccountByEmail", account1.Email).
+			Return(account1, nil).
+			Once()
+
+		result, err := business.FindAccountByEmail(account1.Email)
+		assert.Nil(t, err)
+		assert.Equal(t, account1.UserID, result.UserID)
+	})
+
+	t.Run("valid - when account is not found", func(t *testing.T) {
+		repo.
+			On("SelectAccountByEmail", "unknown@mail.com").
+			Return(accounts.AccountCore{}, errNotFound).
+			Once()
+
+		_, err := business.FindAccountByEmail("unknown@mail.com")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+}
+
+func TestCheckEmail(t *testing.T) {
+	t.Run("valid - when email is available", func(t *testing.T) {
+		repo.
+			On("SelectAccountByEmail", "new@mail.com").
+			Return(accounts.AccountCore{}, errNotFound).
+			Once()
+
+		err := business.CheckEmail("new@mail.com")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when email is used by another role", func(t *testing.T) {
+		repo.
+			On("SelectAccountByEmail", account1.Email).
+			Return(account1, nil).
+			Once()
+
+		err := business.CheckEmail(account1.Email)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when SelectAccountByEmail return server error", func(t *testing.T) {
+		repo.
+			On("SelectAccountByEmail", "new@mail.com").
+			Return(accounts.AccountCore{}, errServer).
+			Once()
+
+		err := business.CheckEmail("new@mail.com")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestCreateAccount(t *testing.T) {
+	newAccount := accounts.AccountCore{
+		Email:    "nurse@mail.com",
+		Password: "password",
+		Role:     "nurse",
+		UserID:   2,
+	}
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectAccountByEmail", newAccount.Email).
+			Return(accounts.AccountCore{}, errNotFound).
+			Once()
+
+		repo.
+			On("InsertAccount", mock.MatchedBy(func(a accounts.AccountCore) bool {
+				return a.UserID == newAccount.UserID && hash.Validate(a.Password, newAccount.Password)
+			})).
+			Return(nil).
+			Once()
+
+		err := business.CreateAccount(newAccount)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when email already exists", func(t *testing.T) {
+		repo.
+			On("SelectAccountByEmail", newAccount.Email).
+			Return(account1, nil).
+			Once()
+
+		err := business.CreateAccount(newAccount)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when InsertAccount return error", func(t *testing.T) {
+		repo.
+			On("SelectAccountByEmail", newAccount.Email).
+			Return(accounts.AccountCore{}, errNotFound).
+			Once()
+
+		repo.
+			On("InsertAccount", mock.AnythingOfType("accounts.AccountCore")).
+			Return(errServer).
+			Once()
+
+		err := business.CreateAccount(newAccount)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestEditAccountPassword(t *testing.T) {
+	t.Run("valid - when old password matches", func(t *testing.T) {
+		repo.
+			On("SelectAccountByUser", account1.UserID, account1.Role).
+			Return(account1, nil).
+			Once()
+
+		repo.
+			On("UpdateAccount", mock.MatchedBy(func(a accounts.AccountCore) bool {
+				return hash.Validate(a.Password, "new password")
+			})).
+			Return(nil).
+			Once()
+
+		authBusiness.
+			On("RevokeUserSessions", account1.UserID, account1.Role).
+			Return(nil).
+			Once()
+
+		err := business.EditAccountPassword(account1.UserID, account1.Role, "password", "new password")
+		assert.Nil(t, err)
+		authBusiness.AssertCalled(t, "RevokeUserSessions", account1.UserID, account1.Role)
+	})
+
+	t.Run("valid - when old password does not match", func(t *testing.T) {
+		repo.
+			On("SelectAccountByUser", account1.UserID, account1.Role).
+			Return(account1, nil).
+			Once()
+
+		err := business.EditAccountPassword(account1.UserID, account1.Role, "wrong password", "new password")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when account is not found", func(t *testing.T) {
+		repo.
+			On("SelectAccountByUser", account1.UserID, account1.Role).
+			Return(accounts.AccountCore{}, errNotFound).
+			Once()
+
+		err := business.EditAccountPassword(account1.UserID, account1.Role, "password", "new password")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+}
+
+func TestResetAccountPassword(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectAccountByUser", account1.UserID, account1.Role).
+			Return(account1, nil).
+			Once()
+
+		repo.
+			On("UpdateAccount", mock.MatchedBy(func(a accounts.AccountCore) bool {
+				return hash.Validate(a.Password, "new password")
+			})).
+			Return(nil).
+			Once()
+
+		err := business.ResetAccountPassword(account1.UserID, account1.Role, "new password")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when UpdateAccount return error", func(t *testing.T) {
+		repo.
+			On("SelectAccountByUser", account1.UserID, account1.Role).
+			Return(account1, nil).
+			Once()
+
+		repo.
+			On("UpdateAccount", mock.AnythingOfType("accounts.AccountCore")).
+			Return(errServer).
+			Once()
+
+		err := business.ResetAccountPassword(account1.UserID, account1.Role, "new password")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestRemoveAccountByUser(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("DeleteAccountByUser", account1.UserID, account1.Role).
+			Return(nil).
+			Once()
+
+		err := business.RemoveAccountByUser(account1.UserID, account1.Role)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when DeleteAccountByUser return error", func(t *testing.T) {
+		repo.
+			On("DeleteAccountByUser", account1.UserID, account1.Role).
+			Return(errServer).
+			Once()
+
+		err := business.RemoveAccountByUser(account1.UserID, account1.Role)
+		assert.Error(t, err)
+	})
+}
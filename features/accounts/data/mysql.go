@@ -0,0 +1,96 @@
+package data
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	"gorm.io/gorm"
+)
+
+type mySQLRepo struct {
+	db *gorm.DB
+}
+
+func NewMySQLRepo(db *gorm.DB) *mySQLRepo {
+	return &mySQLRepo{db}
+}
+
+func (r *mySQLRepo) SelectAccountByEmail(email string) (accounts.AccountCore, error) {
+	const op errors.Op = "accounts.data.SelectAccountByEmail"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	var account Account
+	err := r.db.Where("email = ?", email).First(&account).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMessage = "Account not found"
+			return accounts.AccountCore{}, errors.E(err, op, errMessage, errors.KindNotFound)
+		default:
+			return accounts.AccountCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+	return account.toAccountCore(), nil
+}
+
+func (r *mySQLRepo) SelectAccountByUser(userId int, role string) (accounts.AccountCore, error) {
+	const op errors.Op = "accounts.data.SelectAccountByUser"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	var account Account
+	err := r.db.Where("user_id = ? AND role = ?", userId, role).First(&account).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMessage = "Account not found"
+			return accounts.AccountCore{}, errors.E(err, op, errMessage, errors.KindNotFound)
+		default:
+			return accounts.AccountCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+	return account.toAccountCore(), nil
+}
+
+func (r *mySQLRepo) InsertAccount(account accounts.AccountCore) error {
+	const op errors.Op = "accounts.data.InsertAccount"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	data := Account{
+		Email:    account.Email,
+		Password: account.Password,
+		Role:     account.Role,
+		UserID:   account.UserID,
+	}
+
+	err := r.db.Create(&data).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) UpdateAccount(account accounts.AccountCore) error {
+	const op errors.Op = "accounts.data.UpdateAccount"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	updated := map[string]interface{}{
+		"email":    account.Email,
+		"password": account.Password,
+	}
+
+	err := r.db.Model(&Account{}).Where("id = ?", account.ID).Updates(updated).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) DeleteAccountByUser(userId int, role string) error {
+	const op errors.Op = "accounts.data.DeleteAccountByUser"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.Where("user_id = ? AND role = ?", userId, role).Delete(&Account{}).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
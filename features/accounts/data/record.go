@@ -0,0 +1,30 @@
+package data
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+)
+
+// Not soft deleted, so the email of a removed account can be registered again
+type Account struct {
+	ID        uint   `gorm:"primarykey"`
+	Email     string `gorm:"type:varchar(100);uniqueIndex;not null"`
+	Password  string `gorm:"type:varchar(128);not null"`
+	Role      string `gorm:"type:varchar(16);not null;uniqueIndex:idx_account_user"`
+	UserID    int    `gorm:"not null;uniqueIndex:idx_account_user"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (a Account) toAccountCore() accounts.AccountCore {
+	return accounts.AccountCore{
+		ID:        int(a.ID),
+		Email:     a.Email,
+		Password:  a.Password,
+		Role:      a.Role,
+		UserID:    a.UserID,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
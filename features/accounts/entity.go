@@ -0,0 +1,32 @@
+package accounts
+
+import "time"
+
+// AccountCore is the login identity of a user, email is unique across every role
+type AccountCore struct {
+	ID        int
+	Email     string
+	Password  string
+	Role      string
+	UserID    int // id in the role's own table (admins, doctors, nurses)
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type IBusiness interface {
+	FindAccountByEmail(email string) (AccountCore, error)
+	FindAccountByUser(userId int, role string) (AccountCore, error)
+	CheckEmail(email string) error
+	CreateAccount(account AccountCore) error
+	EditAccountPassword(userId int, role string, oldPassword string, newPassword string) error
+	ResetAccountPassword(userId int, role string, newPassword string) error
+	RemoveAccountByUser(userId int, role string) error
+}
+
+type IData interface {
+	SelectAccountByEmail(email string) (AccountCore, error)
+	SelectAccountByUser(userId int, role string) (AccountCore, error)
+	InsertAccount(account AccountCore) error
+	UpdateAccount(account AccountCore) error
+	DeleteAccountByUser(userId int, role string) error
+}
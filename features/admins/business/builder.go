@@ -1,15 +1,15 @@
 package business
 
 import (
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
 	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
-	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
 )
 
 type adminBusinessBuilder struct {
-	adminRepo      admins.IData
-	doctorBusiness doctors.IBusiness
-	nurseBusiness  nurses.IBusiness
+	adminRepo       admins.IData
+	accountBusiness accounts.IBusiness
+	auditBusiness   audits.IBusiness
 }
 
 func NewAdminBusinessBuilder() *adminBusinessBuilder {
@@ -20,25 +20,26 @@ func (b *adminBusinessBuilder) SetData(data admins.IData) *adminBusinessBuilder
 	b.adminRepo = data
 	return b
 }
-func (b *adminBusinessBuilder) SetDoctorBusiness(db doctors.IBusiness) *adminBusinessBuilder {
-	b.doctorBusiness = db
+func (b *adminBusinessBuilder) SetAccountBusiness(ab accounts.IBusiness) *adminBusinessBuilder {
+	b.accountBusiness = ab
 	return b
 }
-func (b *adminBusinessBuilder) SetNurseBusiness(nb nurses.IBusiness) *adminBusinessBuilder {
-	b.nurseBusiness = nb
+
+func (b *adminBusinessBuilder) SetAuditBusiness(ab audits.IBusiness) *adminBusinessBuilder {
+	b.auditBusiness = ab
 	return b
 }
 
 func (b *adminBusinessBuilder) Build() admins.IBusiness {
 	adminBusiness := &adminBusiness{
-		data:           b.adminRepo,
-		doctorBusiness: b.doctorBusiness,
-		nurseBusiness:  b.nurseBusiness,
+		data:            b.adminRepo,
+		accountBusiness: b.accountBusiness,
+		auditBusiness:   b.auditBusiness,
 	}
 
 	b.adminRepo = nil
-	b.doctorBusiness = nil
-	b.nurseBusiness = nil
+	b.accountBusiness = nil
+	b.auditBusiness = nil
 
 	return adminBusiness
 }
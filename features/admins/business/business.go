@@ -4,18 +4,18 @@ import (
 	"path"
 
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
 	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
-	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/utils/files"
-	"github.com/final-project-alterra/hospital-management-system-api/utils/hash"
 	"github.com/final-project-alterra/hospital-management-system-api/utils/project"
 )
 
 type adminBusiness struct {
-	data           admins.IData
-	doctorBusiness doctors.IBusiness
-	nurseBusiness  nurses.IBusiness
+	data            admins.IData
+	accountBusiness accounts.IBusiness
+	auditBusiness   audits.IBusiness
 }
 
 func (ab *adminBusiness) FindAdmins() ([]admins.AdminCore, error) {
@@ -65,20 +65,31 @@ func (ab *adminBusiness) CreateAdmin(admin admins.AdminCore) error {
 	}
 
 	// Check wheter email is already registered
-	if err = ab.checkEmail(admin.Email); err != nil {
+	if err = ab.accountBusiness.CheckEmail(admin.Email); err != nil {
 		return errors.E(err, op)
 	}
 
-	admin.Password, err = hash.Generate(admin.Password)
+	adminId, err := ab.data.InsertAdmin(admin)
 	if err != nil {
-		errMessage = "Something went wrong"
-		return errors.E(err, op, errMessage, errors.KindServerError)
+		return errors.E(err, op)
 	}
 
-	err = ab.data.InsertAdmin(admin)
+	account := accounts.AccountCore{
+		Email:    admin.Email,
+		Password: admin.Password,
+		Role:     permissions.RoleAdmin,
+		UserID:   adminId,
+	}
+	err = ab.accountBusiness.CreateAccount(account)
 	if err != nil {
+		// Admin without account can not login, so roll it back
+		_ = ab.data.DeleteAdminById(adminId, admin.CreatedBy)
 		return errors.E(err, op)
 	}
+
+	admin.ID = adminId
+	admin.Password = ""
+	ab.audit(op, admin.CreatedBy, adminId, nil, admin)
 	return nil
 }
 
@@ -108,6 +119,7 @@ func (ab *adminBusiness) EditAdmin(admin admins.AdminCore) error {
 		}
 	}
 
+	before := existingAdmin
 	existingAdmin.UpdatedBy = admin.UpdatedBy
 	existingAdmin.Name = admin.Name
 	existingAdmin.BirthDate = admin.BirthDate
@@ -119,6 +131,8 @@ func (ab *adminBusiness) EditAdmin(admin admins.AdminCore) error {
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	ab.audit(op, admin.UpdatedBy, existingAdmin.ID, before, existingAdmin)
 	return nil
 }
 
@@ -153,6 +167,7 @@ func (ab *adminBusiness) EditAdminProfileImage(admin admins.AdminCore) error {
 	}
 	olImage := path.Join(project.GetMainDir(), "files", existingAdmin.ImageUrl)
 
+	before := existingAdmin
 	existingAdmin.ImageUrl = admin.ImageUrl
 	existingAdmin.UpdatedBy = admin.UpdatedBy
 
@@ -162,6 +177,7 @@ func (ab *adminBusiness) EditAdminProfileImage(admin admins.AdminCore) error {
 		return errors.E(err, op)
 	}
 
+	ab.audit(op, admin.UpdatedBy, existingAdmin.ID, before, existingAdmin)
 	go func() { _ = files.Remove(olImage) }()
 	return nil
 }
@@ -192,24 +208,19 @@ func (ab *adminBusiness) EditAdminPassword(id int, updatedBy int, oldPassword st
 		}
 	}
 
-	doesMatch := hash.Validate(existingAdmin.Password, oldPassword)
-	if !doesMatch {
-		err = errors.New("Wrong password")
-		errMessage = "Wrong old password"
-		return errors.E(err, op, errMessage, errors.KindUnprocessable)
-	}
-
-	existingAdmin.UpdatedBy = updatedBy
-	existingAdmin.Password, err = hash.Generate(newPassword)
+	err = ab.accountBusiness.EditAccountPassword(id, permissions.RoleAdmin, oldPassword, newPassword)
 	if err != nil {
 		return errors.E(err, op)
 	}
 
+	existingAdmin.UpdatedBy = updatedBy
 	err = ab.data.UpdateAdmin(existingAdmin)
 	if err != nil {
 		return errors.E(err, op)
 	}
 
+	// Password itself is never part of the audit log
+	ab.audit(op, updatedBy, id, nil, nil)
 	return nil
 }
 
@@ -245,68 +256,27 @@ func (ab *adminBusiness) RemoveAdminById(id int, updatedBy int) error {
 		return errors.E(err, op)
 	}
 
+	err = ab.accountBusiness.RemoveAccountByUser(id, permissions.RoleAdmin)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	ab.audit(op, updatedBy, id, existingAdmin, nil)
 	go func() { _ = files.Remove(existingImage) }()
 	return nil
 }
 
 // Private methods
-func (ab *adminBusiness) checkEmail(email string) error {
-	const op errors.Op = "admins.business.checkEmail"
-	var errMsg errors.ErrClientMessage = "Email already exist"
-
-	adminCh := make(chan error)
-	doctorCh := make(chan error)
-	nurseCh := make(chan error)
-
-	go func() {
-		_, err := ab.data.SelectAdminByEmail(email)
-		if err != nil {
-			if errors.Kind(err) == errors.KindNotFound {
-				err = nil
-			}
-			adminCh <- err
-			return
-		}
-		adminCh <- errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
-	}()
-
-	go func() {
-		_, err := ab.doctorBusiness.FindDoctorByEmail(email)
-		if err != nil {
-			if errors.Kind(err) == errors.KindNotFound {
-				err = nil
-			}
-			doctorCh <- err
-			return
-		}
-		doctorCh <- errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
-	}()
-
-	go func() {
-		_, err := ab.nurseBusiness.FindNurseByEmail(email)
-		if err != nil {
-			if errors.Kind(err) == errors.KindNotFound {
-				err = nil
-			}
-			nurseCh <- err
-			return
-		}
-		nurseCh <- errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
-	}()
 
-	adminErr := <-adminCh
-	doctorErr := <-doctorCh
-	nurseErr := <-nurseCh
-
-	if adminErr == nil && doctorErr == nil && nurseErr == nil {
-		return nil
-	}
-
-	if adminErr != nil {
-		return adminErr
-	}
-	if doctorErr != nil {
-		return doctorErr
-	}
-	return nurseErr
+// audit records a change made by an admin, every admin mutation is done by another admin
+func (ab *adminBusiness) audit(op errors.Op, actorId int, adminId int, before interface{}, after interface{}) {
+	ab.auditBusiness.Record(audits.AuditLogCore{
+		ActorID:   actorId,
+		ActorRole: permissions.RoleAdmin,
+		Operation: string(op),
+		Entity:    audits.EntityAdmin,
+		EntityID:  adminId,
+		Before:    before,
+		After:     after,
+	})
 }
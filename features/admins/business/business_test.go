@@ -6,27 +6,26 @@ import (
 	"time"
 
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
 	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
-	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
 	"github.com/final-project-alterra/hospital-management-system-api/utils/files"
-	"github.com/final-project-alterra/hospital-management-system-api/utils/hash"
 	"github.com/final-project-alterra/hospital-management-system-api/utils/project"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	accountMock "github.com/final-project-alterra/hospital-management-system-api/features/accounts/mocks"
 	ab "github.com/final-project-alterra/hospital-management-system-api/features/admins/business"
 	adminMock "github.com/final-project-alterra/hospital-management-system-api/features/admins/mocks"
-	doctorMock "github.com/final-project-alterra/hospital-management-system-api/features/doctors/mocks"
-	nurseMock "github.com/final-project-alterra/hospital-management-system-api/features/nurses/mocks"
+	auditMock "github.com/final-project-alterra/hospital-management-system-api/features/audits/mocks"
 )
 
 var (
 	adminsData adminMock.IData
 
-	adminsBusiness  admins.IBusiness
-	doctorsBusiness doctorMock.IBusiness
-	nursesBusiness  nurseMock.IBusiness
+	adminsBusiness   admins.IBusiness
+	accountsBusiness accountMock.IBusiness
+	auditsBusiness   auditMock.IBusiness
 
 	adminValue admins.AdminCore
 	newAdmin   admins.AdminCore
@@ -39,10 +38,12 @@ var (
 func TestMain(m *testing.M) {
 	adminsBusiness = ab.NewAdminBusinessBuilder().
 		SetData(&adminsData).
-		SetDoctorBusiness(&doctorsBusiness).
-		SetNurseBusiness(&nursesBusiness).
+		SetAccountBusiness(&accountsBusiness).
+		SetAuditBusiness(&auditsBusiness).
 		Build()
 
+	auditsBusiness.On("Record", mock.AnythingOfType("audits.AuditLogCore")).Return()
+
 	errNotFound = errors.E(errors.New("not found"), errors.KindNotFound)
 	errServer = errors.E(errors.New("error"), errors.KindServerError)
 
@@ -165,29 +166,30 @@ func TestCreateAdmin(t *testing.T) {
 			Return(adminValue, nil).
 			Once()
 
-		adminsData.
-			On("SelectAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
-			Once()
-
-		doctorsBusiness.
-			On("FindDoctorByEmail", mock.AnythingOfType("string")).
-			Return(doctors.DoctorCore{}, errNotFound).
-			Once()
-
-		nursesBusiness.
-			On("FindNurseByEmail", mock.AnythingOfType("string")).
-			Return(nurses.NurseCore{}, errNotFound).
+		accountsBusiness.
+			On("CheckEmail", newAdmin.Email).
+			Return(nil).
 			Once()
 
 		adminsData.
 			On("InsertAdmin", mock.AnythingOfType("admins.AdminCore")).
+			Return(2, nil).
+			Once()
+
+		accountsBusiness.
+			On("CreateAccount", mock.MatchedBy(func(account accounts.AccountCore) bool {
+				return account.UserID == 2 && account.Role == "admin" && account.Email == newAdmin.Email
+			})).
 			Return(nil).
 			Once()
 
 		err := adminsBusiness.CreateAdmin(newAdmin)
 
 		assert.Nil(t, err)
+		auditsBusiness.AssertCalled(t, "Record", mock.MatchedBy(func(log audits.AuditLogCore) bool {
+			admin, ok := log.After.(admins.AdminCore)
+			return ok && log.Operation == "admins.business.CreateAdmin" && log.EntityID == 2 && admin.Password == ""
+		}))
 	})
 
 	t.Run("valid - when admin who add new admin is not found", func(t *testing.T) {
@@ -215,59 +217,20 @@ func TestCreateAdmin(t *testing.T) {
 	})
 
 	t.Run("valid - when email already registered", func(t *testing.T) {
-		for i := 0; i < 3; i++ {
-			adminsData.
-				On("SelectAdminById", mock.AnythingOfType("int")).
-				Return(admins.AdminCore{}, nil).
-				Once()
+		adminsData.
+			On("SelectAdminById", mock.AnythingOfType("int")).
+			Return(admins.AdminCore{}, nil).
+			Once()
 
-			switch i {
-			case 0:
-				adminsData.
-					On("SelectAdminByEmail", mock.AnythingOfType("string")).
-					Return(adminValue, nil).
-					Once()
-				doctorsBusiness.
-					On("FindDoctorByEmail", mock.AnythingOfType("string")).
-					Return(doctors.DoctorCore{}, errNotFound).
-					Once()
-				nursesBusiness.
-					On("FindNurseByEmail", mock.AnythingOfType("string")).
-					Return(nurses.NurseCore{}, errNotFound).
-					Once()
-			case 1:
-				adminsData.
-					On("SelectAdminByEmail", mock.AnythingOfType("string")).
-					Return(admins.AdminCore{}, errNotFound).
-					Once()
-				doctorsBusiness.
-					On("FindDoctorByEmail", mock.AnythingOfType("string")).
-					Return(doctors.DoctorCore{ID: 1}, nil).
-					Once()
-				nursesBusiness.
-					On("FindNurseByEmail", mock.AnythingOfType("string")).
-					Return(nurses.NurseCore{}, errNotFound).
-					Once()
-			case 2:
-				adminsData.
-					On("SelectAdminByEmail", mock.AnythingOfType("string")).
-					Return(admins.AdminCore{}, errNotFound).
-					Once()
-				doctorsBusiness.
-					On("FindDoctorByEmail", mock.AnythingOfType("string")).
-					Return(doctors.DoctorCore{}, errNotFound).
-					Once()
-				nursesBusiness.
-					On("FindNurseByEmail", mock.AnythingOfType("string")).
-					Return(nurses.NurseCore{ID: 1}, nil).
-					Once()
-			}
+		accountsBusiness.
+			On("CheckEmail", newAdmin.Email).
+			Return(errors.E(errors.New("Email already exist"), errors.KindUnprocessable)).
+			Once()
 
-			err := adminsBusiness.CreateAdmin(newAdmin)
+		err := adminsBusiness.CreateAdmin(newAdmin)
 
-			assert.Error(t, err)
-			assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
-		}
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 
 	t.Run("valid - when unknwon error occurs on InsertAdmin", func(t *testing.T) {
@@ -276,24 +239,46 @@ func TestCreateAdmin(t *testing.T) {
 			Return(admins.AdminCore{}, nil).
 			Once()
 
+		accountsBusiness.
+			On("CheckEmail", newAdmin.Email).
+			Return(nil).
+			Once()
+
 		adminsData.
-			On("SelectAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
+			On("InsertAdmin", mock.AnythingOfType("admins.AdminCore")).
+			Return(0, errServer).
+			Once()
+
+		err := adminsBusiness.CreateAdmin(newAdmin)
+
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+
+	t.Run("valid - when CreateAccount error, the new admin is rolled back", func(t *testing.T) {
+		adminsData.
+			On("SelectAdminById", mock.AnythingOfType("int")).
+			Return(admins.AdminCore{}, nil).
+			Once()
+
+		accountsBusiness.
+			On("CheckEmail", newAdmin.Email).
+			Return(nil).
 			Once()
 
 		adminsData.
 			On("InsertAdmin", mock.AnythingOfType("admins.AdminCore")).
-			Return(errServer).
+			Return(2, nil).
 			Once()
 
-		doctorsBusiness.
-			On("FindDoctorByEmail", mock.AnythingOfType("string")).
-			Return(doctors.DoctorCore{}, errNotFound).
+		accountsBusiness.
+			On("CreateAccount", mock.AnythingOfType("accounts.AccountCore")).
+			Return(errServer).
 			Once()
 
-		nursesBusiness.
-			On("FindNurseByEmail", mock.AnythingOfType("string")).
-			Return(nurses.NurseCore{}, errNotFound).
+		adminsData.
+			On("DeleteAdminById", 2, newAdmin.CreatedBy).
+			Return(nil).
 			Once()
 
 		err := adminsBusiness.CreateAdmin(newAdmin)
@@ -396,23 +381,22 @@ func TestEditAdmin(t *testing.T) {
 
 func TestEditAdminPassword(t *testing.T) {
 	t.Run("valid - editing admin password", func(t *testing.T) {
-		hashed, err := hash.Generate(adminValue.Password)
-		if err != nil {
-			panic(err)
-		}
-		adminValue.Password = hashed
-
 		adminsData.
 			On("SelectAdminById", mock.AnythingOfType("int")).
 			Return(adminValue, nil).
 			Twice()
 
+		accountsBusiness.
+			On("EditAccountPassword", 2, "admin", "admin", "admin123").
+			Return(nil).
+			Once()
+
 		adminsData.
 			On("UpdateAdmin", mock.AnythingOfType("admins.AdminCore")).
 			Return(nil).
 			Once()
 
-		err = adminsBusiness.EditAdminPassword(2, 1, "admin", "admin123")
+		err := adminsBusiness.EditAdminPassword(2, 1, "admin", "admin123")
 
 		assert.Nil(t, err)
 	})
@@ -476,47 +460,39 @@ func TestEditAdminPassword(t *testing.T) {
 	})
 
 	t.Run("valid - when editing admin password failed", func(t *testing.T) {
-		hashed, err := hash.Generate("admin")
-		if err != nil {
-			panic(err)
-		}
-		adminValue.Password = hashed
-
 		adminsData.
 			On("SelectAdminById", mock.AnythingOfType("int")).
 			Return(adminValue, nil).
 			Twice()
 
+		accountsBusiness.
+			On("EditAccountPassword", 2, "admin", "admin", "admin123").
+			Return(nil).
+			Once()
+
 		adminsData.
 			On("UpdateAdmin", mock.AnythingOfType("admins.AdminCore")).
 			Return(errServer).
 			Once()
 
-		err = adminsBusiness.EditAdminPassword(2, 1, "admin", "admin123")
+		err := adminsBusiness.EditAdminPassword(2, 1, "admin", "admin123")
 
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
 
 	t.Run("valid - when password doesnot match", func(t *testing.T) {
-		copy := adminValue
-		hashed, err := hash.Generate(adminValue.Password)
-		if err != nil {
-			panic(err)
-		}
-		copy.Password = hashed
-
 		adminsData.
 			On("SelectAdminById", mock.AnythingOfType("int")).
-			Return(copy, nil).
+			Return(adminValue, nil).
 			Twice()
 
-		adminsData.
-			On("UpdateAdmin", mock.AnythingOfType("admins.AdminCore")).
-			Return(nil).
+		accountsBusiness.
+			On("EditAccountPassword", 2, "admin", "admin-salah", "admin123").
+			Return(errors.E(errors.New("Wrong password"), errors.KindUnprocessable)).
 			Once()
 
-		err = adminsBusiness.EditAdminPassword(2, 1, "admin-salah", "admin123")
+		err := adminsBusiness.EditAdminPassword(2, 1, "admin-salah", "admin123")
 
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
@@ -597,6 +573,11 @@ func TestRemoveAdminById(t *testing.T) {
 			Return(nil).
 			Once()
 
+		accountsBusiness.
+			On("RemoveAccountByUser", 2, "admin").
+			Return(nil).
+			Once()
+
 		err := adminsBusiness.RemoveAdminById(2, 1)
 		assert.Nil(t, err)
 	})
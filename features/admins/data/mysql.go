@@ -65,7 +65,7 @@ func (r *MySQLRepo) SelectAdminByEmail(email string) (admins.AdminCore, error) {
 	return data.ToAdminCore(), nil
 }
 
-func (r *MySQLRepo) InsertAdmin(admin admins.AdminCore) error {
+func (r *MySQLRepo) InsertAdmin(admin admins.AdminCore) (int, error) {
 	const op errors.Op = "admins.data.InsertAdmin"
 	var errMessage errors.ErrClientMessage = "Something went wrong"
 
@@ -74,7 +74,6 @@ func (r *MySQLRepo) InsertAdmin(admin admins.AdminCore) error {
 	data := Admin{
 		CreatedBy: &createdBy,
 		Email:     admin.Email,
-		Password:  admin.Password,
 		Name:      admin.Name,
 		BirthDate: admin.BirthDate,
 		ImageUrl:  admin.ImageUrl,
@@ -85,9 +84,9 @@ func (r *MySQLRepo) InsertAdmin(admin admins.AdminCore) error {
 
 	err := r.db.Create(&data).Error
 	if err != nil {
-		return errors.E(err, op, errMessage, errors.KindServerError)
+		return 0, errors.E(err, op, errMessage, errors.KindServerError)
 	}
-	return nil
+	return int(data.ID), nil
 }
 
 func (r *MySQLRepo) UpdateAdmin(admin admins.AdminCore) error {
@@ -105,7 +104,6 @@ func (r *MySQLRepo) UpdateAdmin(admin admins.AdminCore) error {
 		CreatedBy: &createdBy,
 		UpdatedBy: &updatedBy,
 		Email:     admin.Email,
-		Password:  admin.Password,
 		Name:      admin.Name,
 		BirthDate: admin.BirthDate,
 		ImageUrl:  admin.ImageUrl,
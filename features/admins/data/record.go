@@ -16,7 +16,6 @@ type Admin struct {
 	Updating []Admin `gorm:"foreignkey:UpdatedBy"`
 
 	Email     string `gorm:"type:varchar(100);unique_index;not null"`
-	Password  string `gorm:"type:varchar(100);not null"`
 	Name      string `gorm:"type:varchar(100);not null"`
 	Phone     string `gorm:"type:varchar(100)"`
 	Gender    string `gorm:"type:varchar(1);not null"`
@@ -43,7 +42,6 @@ func (a Admin) ToAdminCore() admins.AdminCore {
 		CreatedBy: createdBy,
 		UpdatedBy: updatedBy,
 		Email:     a.Email,
-		Password:  a.Password,
 		Name:      a.Name,
 		BirthDate: strings.Split(a.BirthDate, "T")[0],
 		ImageUrl:  a.ImageUrl,
@@ -7,7 +7,7 @@ type AdminCore struct {
 	CreatedBy int
 	UpdatedBy int
 	Email     string
-	Password  string
+	Password  string // only filled on create, stored in accounts
 	Name      string
 	BirthDate string
 	ImageUrl  string
@@ -33,7 +33,7 @@ type IData interface {
 	SelectAdmins() ([]AdminCore, error)
 	SelectAdminById(id int) (AdminCore, error)
 	SelectAdminByEmail(email string) (AdminCore, error)
-	InsertAdmin(admin AdminCore) error
+	InsertAdmin(admin AdminCore) (int, error)
 	UpdateAdmin(admin AdminCore) error
 	DeleteAdminById(id int, updatedBy int) error
 }
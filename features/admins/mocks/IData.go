@@ -27,17 +27,24 @@ func (_m *IData) DeleteAdminById(id int, updatedBy int) error {
 }
 
 // InsertAdmin provides a mock function with given fields: admin
-func (_m *IData) InsertAdmin(admin admins.AdminCore) error {
+func (_m *IData) InsertAdmin(admin admins.AdminCore) (int, error) {
 	ret := _m.Called(admin)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(admins.AdminCore) error); ok {
+	var r0 int
+	if rf, ok := ret.Get(0).(func(admins.AdminCore) int); ok {
 		r0 = rf(admin)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(admins.AdminCore) error); ok {
+		r1 = rf(admin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // SelectAdminByEmail provides a mock function with given fields: email
@@ -0,0 +1,26 @@
+package business
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/audits"
+
+type auditBusinessBuilder struct {
+	data audits.IData
+}
+
+func NewAuditBusinessBuilder() *auditBusinessBuilder {
+	return &auditBusinessBuilder{}
+}
+
+func (b *auditBusinessBuilder) SetData(data audits.IData) *auditBusinessBuilder {
+	b.data = data
+	return b
+}
+
+func (b *auditBusinessBuilder) Build() audits.IBusiness {
+	auditBusiness := &auditBusiness{
+		data: b.data,
+	}
+
+	b.data = nil
+
+	return auditBusiness
+}
@@ -0,0 +1,81 @@
+package business
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+)
+
+type auditBusiness struct {
+	data audits.IData
+}
+
+// Record is called after the change has been committed, so a failure here
+// is only logged and never turns a successful request into an error
+func (a *auditBusiness) Record(auditLog audits.AuditLogCore) {
+	const op errors.Op = "audits.business.Record"
+
+	err := a.data.InsertAuditLog(auditLog)
+	if err != nil {
+		errors.Log(errors.E(err, op))
+	}
+}
+
+func (a *auditBusiness) FindAuditLogs(q audits.AuditLogQuery) ([]audits.AuditLogCore, error) {
+	const op errors.Op = "audits.business.FindAuditLogs"
+	var errMessage errors.ErrClientMessage = "Start of time range must be before its end"
+
+	if !q.From.IsZero() && !q.To.IsZero() && q.From.After(q.To) {
+		err := errors.New("Invalid time range")
+		return []audits.AuditLogCore{}, errors.E(err, op, errMessage, errors.KindBadRequest)
+	}
+
+	switch {
+	case q.Limit <= 0:
+		q.Limit = audits.DEFAULT_LIMIT
+	case q.Limit > audits.MAX_LIMIT:
+		q.Limit = audits.MAX_LIMIT
+	}
+
+	logs, err := a.data.SelectAuditLogs(q)
+	if err != nil {
+		return []audits.AuditLogCore{}, errors.E(err, op)
+	}
+	return logs, nil
+}
+
+func (a *auditBusiness) RecordAccess(logs []audits.AccessLogCore) error {
+	const op errors.Op = "audits.business.RecordAccess"
+
+	if len(logs) == 0 {
+		return nil
+	}
+
+	err := a.data.InsertAccessLogs(logs)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (a *auditBusiness) FindAccessLogs(q audits.AccessLogQuery) ([]audits.AccessLogCore, error) {
+	const op errors.Op = "audits.business.FindAccessLogs"
+	var errMessage errors.ErrClientMessage = "Start of time range must be before its end"
+
+	if !q.From.IsZero() && !q.To.IsZero() && q.From.After(q.To) {
+		err := errors.New("Invalid time range")
+		return []audits.AccessLogCore{}, errors.E(err, op, errMessage, errors.KindBadRequest)
+	}
+
+	switch {
+	case q.Limit <= 0:
+		q.Limit = audits.DEFAULT_LIMIT
+	case q.Limit > audits.MAX_LIMIT:
+		q.Limit = audits.MAX_LIMIT
+	}
+
+	logs, err := a.data.SelectAccessLogs(q)
+	if err != nil {
+		return []audits.AccessLogCore{}, errors.E(err, op)
+	}
+	return logs, nil
+}
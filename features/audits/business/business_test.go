@@ -0,0 +1,202 @@
+package business_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	ab "github.com/final-project-alterra/hospital-management-system-api/features/audits/business"
+	am "github.com/final-project-alterra/hospital-management-system-api/features/audits/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	repo     am.IData
+	business audits.IBusiness
+
+	log1 audits.AuditLogCore
+
+	errServer error
+)
+
+func TestMain(m *testing.M) {
+	business = ab.NewAuditBusinessBuilder().
+		SetData(&repo).
+		Build()
+
+	log1 = audits.AuditLogCore{
+		ID:        1,
+		ActorID:   1,
+		ActorRole: "admin",
+		Operation: "patients.business.EditPatient",
+		Entity:    audits.EntityPatient,
+		EntityID:  1,
+	}
+
+	errServer = errors.E(errors.New("server error"), errors.KindServerError)
+
+	os.Exit(m.Run())
+}
+
+func TestRecord(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("InsertAuditLog", log1).
+			Return(nil).
+			Once()
+
+		business.Record(log1)
+		repo.AssertCalled(t, "InsertAuditLog", log1)
+	})
+
+	t.Run("valid - when InsertAuditLog return error", func(t *testing.T) {
+		repo.
+			On("InsertAuditLog", log1).
+			Return(errServer).
+			Once()
+
+		assert.NotPanics(t, func() { business.Record(log1) })
+	})
+}
+
+func TestFindAuditLogs(t *testing.T) {
+	now := time.Now()
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		q := audits.AuditLogQuery{Entity: audits.EntityPatient, From: now.Add(-time.Hour), To: now, Limit: 10}
+		repo.
+			On("SelectAuditLogs", q).
+			Return([]audits.AuditLogCore{log1}, nil).
+			Once()
+
+		result, err := business.FindAuditLogs(q)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+	})
+
+	t.Run("valid - when time range is reversed", func(t *testing.T) {
+		q := audits.AuditLogQuery{From: now, To: now.Add(-time.Hour)}
+
+		_, err := business.FindAuditLogs(q)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
+	})
+
+	t.Run("valid - when limit is not set", func(t *testing.T) {
+		repo.
+			On("SelectAuditLogs", mock.MatchedBy(func(q audits.AuditLogQuery) bool {
+				return q.Limit == audits.DEFAULT_LIMIT
+			})).
+			Return([]audits.AuditLogCore{}, nil).
+			Once()
+
+		_, err := business.FindAuditLogs(audits.AuditLogQuery{})
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when limit is too large", func(t *testing.T) {
+		repo.
+			On("SelectAuditLogs", mock.MatchedBy(func(q audits.AuditLogQuery) bool {
+				return q.Limit == audits.MAX_LIMIT
+			})).
+			Return([]audits.AuditLogCore{}, nil).
+			Once()
+
+		_, err := business.FindAuditLogs(audits.AuditLogQuery{Limit: audits.MAX_LIMIT + 1})
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when SelectAuditLogs return error", func(t *testing.T) {
+		q := audits.AuditLogQuery{ActorID: 2, Limit: 10}
+		repo.
+			On("SelectAuditLogs", q).
+			Return([]audits.AuditLogCore{}, errServer).
+			Once()
+
+		_, err := business.FindAuditLogs(q)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestRecordAccess(t *testing.T) {
+	accessLogs := []audits.AccessLogCore{{ReaderID: 2, ReaderRole: "doctor", PatientID: 1, Operation: "patients.business.ViewPatientById"}}
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("InsertAccessLogs", accessLogs).
+			Return(nil).
+			Once()
+
+		err := business.RecordAccess(accessLogs)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when there is nothing to record", func(t *testing.T) {
+		err := business.RecordAccess([]audits.AccessLogCore{})
+		assert.Nil(t, err)
+		repo.AssertNotCalled(t, "InsertAccessLogs", []audits.AccessLogCore{})
+	})
+
+	t.Run("valid - when InsertAccessLogs return error", func(t *testing.T) {
+		repo.
+			On("InsertAccessLogs", accessLogs).
+			Return(errServer).
+			Once()
+
+		err := business.RecordAccess(accessLogs)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestFindAccessLogs(t *testing.T) {
+	now := time.Now()
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		q := audits.AccessLogQuery{PatientID: 1, Limit: 10}
+		repo.
+			On("SelectAccessLogs", q).
+			Return([]audits.AccessLogCore{{ID: 1, PatientID: 1}}, nil).
+			Once()
+
+		result, err := business.FindAccessLogs(q)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+	})
+
+	t.Run("valid - when time range is reversed", func(t *testing.T) {
+		q := audits.AccessLogQuery{PatientID: 1, From: now, To: now.Add(-time.Hour)}
+
+		_, err := business.FindAccessLogs(q)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
+	})
+
+	t.Run("valid - when limit is not set", func(t *testing.T) {
+		repo.
+			On("SelectAccessLogs", mock.MatchedBy(func(q audits.AccessLogQuery) bool {
+				return q.PatientID == 2 && q.Limit == audits.DEFAULT_LIMIT
+			})).
+			Return([]audits.AccessLogCore{}, nil).
+			Once()
+
+		_, err := business.FindAccessLogs(audits.AccessLogQuery{PatientID: 2})
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when SelectAccessLogs return error", func(t *testing.T) {
+		q := audits.AccessLogQuery{PatientID: 3, Limit: 10}
+		repo.
+			On("SelectAccessLogs", q).
+			Return([]audits.AccessLogCore{}, errServer).
+			Once()
+
+		_, err := business.FindAccessLogs(q)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
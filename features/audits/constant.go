@@ -0,0 +1,24 @@
+package audits
+
+const (
+	EntityAdmin        = "admins"
+	EntityDoctor       = "doctors"
+	EntityNurse        = "nurses"
+	EntityStaff        = "staffs"
+	EntityPatient      = "patients"
+	EntityRoom         = "rooms"
+	EntitySpeciality   = "specialities"
+	EntityWorkSchedule = "work-schedules"
+	EntityOutpatient   = "outpatients"
+	EntityClosure      = "closures"
+	EntityLeave        = "leaves"
+	EntityJob          = "jobs"
+	EntityPrescription = "prescriptions"
+	EntityMedicine     = "medicines"
+	EntityBatch        = "medicine-batches"
+
+	ActorSystem = "system" // actor role of changes made by background jobs, with actor id 0
+
+	DEFAULT_LIMIT = 100
+	MAX_LIMIT     = 1000
+)
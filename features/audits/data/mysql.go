@@ -0,0 +1,135 @@
+package data
+
+import (
+	"encoding/json"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"gorm.io/gorm"
+)
+
+type mySQLRepo struct {
+	db *gorm.DB
+}
+
+func NewMySQLRepo(db *gorm.DB) *mySQLRepo {
+	return &mySQLRepo{db}
+}
+
+func (r *mySQLRepo) InsertAuditLog(log audits.AuditLogCore) error {
+	const op errors.Op = "audits.data.InsertAuditLog"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	before, err := json.Marshal(log.Before)
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+
+	after, err := json.Marshal(log.After)
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+
+	data := AuditLog{
+		ActorID:   log.ActorID,
+		ActorRole: log.ActorRole,
+		Operation: log.Operation,
+		Entity:    log.Entity,
+		EntityID:  log.EntityID,
+		Before:    string(before),
+		After:     string(after),
+	}
+
+	err = r.db.Create(&data).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) SelectAuditLogs(q audits.AuditLogQuery) ([]audits.AuditLogCore, error) {
+	const op errors.Op = "audits.data.SelectAuditLogs"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	query := r.db.Model(&AuditLog{})
+	if q.Entity != "" {
+		query = query.Where("entity = ?", q.Entity)
+	}
+	if q.EntityID != 0 {
+		query = query.Where("entity_id = ?", q.EntityID)
+	}
+	if q.ActorID != 0 {
+		query = query.Where("actor_id = ?", q.ActorID)
+	}
+	if q.ActorRole != "" {
+		query = query.Where("actor_role = ?", q.ActorRole)
+	}
+	if !q.From.IsZero() {
+		query = query.Where("created_at >= ?", q.From)
+	}
+	if !q.To.IsZero() {
+		query = query.Where("created_at <= ?", q.To)
+	}
+
+	var logs []AuditLog
+	err := query.Order("id DESC").Limit(q.Limit).Find(&logs).Error
+	if err != nil {
+		return []audits.AuditLogCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return toSliceAuditLogCore(logs), nil
+}
+
+func (r *mySQLRepo) InsertAccessLogs(logs []audits.AccessLogCore) error {
+	const op errors.Op = "audits.data.InsertAccessLogs"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	data := make([]AccessLog, len(logs))
+	for i, l := range logs {
+		data[i] = AccessLog{
+			ReaderID:   l.ReaderID,
+			ReaderRole: l.ReaderRole,
+			PatientID:  l.PatientID,
+			Operation:  l.Operation,
+			BreakGlass: l.BreakGlass,
+			Reason:     l.Reason,
+		}
+	}
+
+	err := r.db.Create(&data).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) SelectAccessLogs(q audits.AccessLogQuery) ([]audits.AccessLogCore, error) {
+	const op errors.Op = "audits.data.SelectAccessLogs"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	query := r.db.Model(&AccessLog{})
+	if q.PatientID != 0 {
+		query = query.Where("patient_id = ?", q.PatientID)
+	}
+	if q.ReaderID != 0 {
+		query = query.Where("reader_id = ?", q.ReaderID)
+	}
+	if q.ReaderRole != "" {
+		query = query.Where("reader_role = ?", q.ReaderRole)
+	}
+	if q.BreakGlass {
+		query = query.Where("break_glass = ?", true)
+	}
+	if !q.From.IsZero() {
+		query = query.Where("created_at >= ?", q.From)
+	}
+	if !q.To.IsZero() {
+		query = query.Where("created_at <= ?", q.To)
+	}
+
+	var logs []AccessLog
+	err := query.Order("id DESC").Limit(q.Limit).Find(&logs).Error
+	if err != nil {
+		return []audits.AccessLogCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return toSliceAccessLogCore(logs), nil
+}
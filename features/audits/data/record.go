@@ -0,0 +1,76 @@
+package data
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+)
+
+// AuditLog is append-only, it is never updated nor deleted
+type AuditLog struct {
+	ID        uint      `gorm:"primarykey"`
+	ActorID   int       `gorm:"not null;index:idx_audit_log_actor"`
+	ActorRole string    `gorm:"type:varchar(16);not null;index:idx_audit_log_actor"`
+	Operation string    `gorm:"type:varchar(100);not null"`
+	Entity    string    `gorm:"type:varchar(50);not null;index:idx_audit_log_entity"`
+	EntityID  int       `gorm:"not null;index:idx_audit_log_entity"`
+	Before    string    `gorm:"type:text"` // json
+	After     string    `gorm:"type:text"` // json
+	CreatedAt time.Time `gorm:"index"`
+}
+
+func (a AuditLog) toAuditLogCore() audits.AuditLogCore {
+	return audits.AuditLogCore{
+		ID:        int(a.ID),
+		ActorID:   a.ActorID,
+		ActorRole: a.ActorRole,
+		Operation: a.Operation,
+		Entity:    a.Entity,
+		EntityID:  a.EntityID,
+		Before:    json.RawMessage(a.Before),
+		After:     json.RawMessage(a.After),
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func toSliceAuditLogCore(a []AuditLog) []audits.AuditLogCore {
+	result := make([]audits.AuditLogCore, len(a))
+	for i := range a {
+		result[i] = a[i].toAuditLogCore()
+	}
+	return result
+}
+
+// AccessLog is append-only, it is never updated nor deleted
+type AccessLog struct {
+	ID         uint      `gorm:"primarykey"`
+	ReaderID   int       `gorm:"not null;index:idx_access_log_reader"`
+	ReaderRole string    `gorm:"type:varchar(16);not null;index:idx_access_log_reader"`
+	PatientID  int       `gorm:"not null;index"`
+	Operation  string    `gorm:"type:varchar(100);not null"`
+	BreakGlass bool      `gorm:"not null;default:false;index"`
+	Reason     string    `gorm:"type:varchar(255)"`
+	CreatedAt  time.Time `gorm:"index"`
+}
+
+func (a AccessLog) toAccessLogCore() audits.AccessLogCore {
+	return audits.AccessLogCore{
+		ID:         int(a.ID),
+		ReaderID:   a.ReaderID,
+		ReaderRole: a.ReaderRole,
+		PatientID:  a.PatientID,
+		Operation:  a.Operation,
+		BreakGlass: a.BreakGlass,
+		Reason:     a.Reason,
+		CreatedAt:  a.CreatedAt,
+	}
+}
+
+func toSliceAccessLogCore(a []AccessLog) []audits.AccessLogCore {
+	result := make([]audits.AccessLogCore, len(a))
+	for i := range a {
+		result[i] = a[i].toAccessLogCore()
+	}
+	return result
+}
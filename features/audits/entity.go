@@ -0,0 +1,66 @@
+package audits
+
+import "time"
+
+// AuditLogCore is one append-only entry of who changed what
+type AuditLogCore struct {
+	ID        int
+	ActorID   int
+	ActorRole string
+	Operation string // errors.Op of the business method, e.g. "patients.business.EditPatient"
+	Entity    string
+	EntityID  int
+	Before    interface{} // nil on create
+	After     interface{} // nil on delete
+	CreatedAt time.Time
+}
+
+// AuditLogQuery filters audit logs, zero value fields are not filtered
+type AuditLogQuery struct {
+	Entity    string
+	EntityID  int
+	ActorID   int
+	ActorRole string
+	From      time.Time
+	To        time.Time
+	Limit     int
+}
+
+// AccessLogCore is one read of a patient's identity or clinical data
+type AccessLogCore struct {
+	ID         int
+	ReaderID   int
+	ReaderRole string
+	PatientID  int
+	Operation  string // errors.Op of the business method that returned the data
+	BreakGlass bool   // clinical data was read outside the reader's care team
+	Reason     string // given by the reader on break glass
+	CreatedAt  time.Time
+}
+
+// AccessLogQuery filters access logs, zero value fields are not filtered
+type AccessLogQuery struct {
+	PatientID  int
+	ReaderID   int
+	ReaderRole string
+	BreakGlass bool // only break glass reads when true
+	From       time.Time
+	To         time.Time
+	Limit      int
+}
+
+type IBusiness interface {
+	Record(log AuditLogCore)
+	FindAuditLogs(q AuditLogQuery) ([]AuditLogCore, error)
+
+	RecordAccess(logs []AccessLogCore) error // a read that can not be logged must not be served
+	FindAccessLogs(q AccessLogQuery) ([]AccessLogCore, error)
+}
+
+type IData interface {
+	InsertAuditLog(log AuditLogCore) error
+	SelectAuditLogs(q AuditLogQuery) ([]AuditLogCore, error)
+
+	InsertAccessLogs(logs []AccessLogCore) error
+	SelectAccessLogs(q AccessLogQuery) ([]AccessLogCore, error)
+}
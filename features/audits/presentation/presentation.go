@@ -0,0 +1,121 @@
+package presentation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits/presentation/request"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits/presentation/response"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type AuditPresentation struct {
+	business audits.IBusiness
+	validate *validator.Validate
+}
+
+func NewAuditPresentation(business audits.IBusiness) *AuditPresentation {
+	return &AuditPresentation{
+		business: business,
+		validate: validator.New(),
+	}
+}
+
+func (p *AuditPresentation) GetAuditLogs(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving audit logs"
+	const op errors.Op = "audits.presentation.GetAuditLogs"
+	var errMessage errors.ErrClientMessage
+
+	var req request.QueryParamsRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	q, err := req.ToAuditLogQuery()
+	if err != nil {
+		errMessage = "Invalid time range. Makesure it is in the format of RFC3339 or YYYY-MM-DD"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	logs, err := p.business.FindAuditLogs(q)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListAuditLogs(logs))
+}
+
+func (p *AuditPresentation) GetAccessLogs(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving access logs"
+	const op errors.Op = "audits.presentation.GetAccessLogs"
+	var errMessage errors.ErrClientMessage
+
+	var req request.AccessLogQueryParamsRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	q, err := req.ToAccessLogQuery(req.PatientID)
+	if err != nil {
+		errMessage = "Invalid time range. Makesure it is in the format of RFC3339 or YYYY-MM-DD"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	logs, err := p.business.FindAccessLogs(q)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListAccessLogs(logs))
+}
+
+func (p *AuditPresentation) GetPatientAccessLogs(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving patient access logs"
+	const op errors.Op = "audits.presentation.GetPatientAccessLogs"
+	var errMessage errors.ErrClientMessage
+
+	patientId, err := strconv.Atoi(c.Param("patientId"))
+	if err != nil || patientId < 1 {
+		errMessage = "Invalid patient id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	var req request.AccessLogQueryParamsRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	q, err := req.ToAccessLogQuery(patientId)
+	if err != nil {
+		errMessage = "Invalid time range. Makesure it is in the format of RFC3339 or YYYY-MM-DD"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	logs, err := p.business.FindAccessLogs(q)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListAccessLogs(logs))
+}
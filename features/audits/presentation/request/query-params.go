@@ -0,0 +1,98 @@
+package request
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+)
+
+const DATE_LAYOUT = "2006-01-02"
+
+// From and To accept RFC3339 or YYYY-MM-DD, a date in To includes the whole day
+type QueryParamsRequest struct {
+	Entity    string `query:"entity"`
+	EntityID  int    `query:"entityId" validate:"gte=0"`
+	ActorID   int    `query:"actorId" validate:"gte=0"`
+	ActorRole string `query:"actorRole"`
+	From      string `query:"from"`
+	To        string `query:"to"`
+	Limit     int    `query:"limit" validate:"gte=0"`
+}
+
+func (q QueryParamsRequest) ToAuditLogQuery() (audits.AuditLogQuery, error) {
+	query := audits.AuditLogQuery{
+		Entity:    q.Entity,
+		EntityID:  q.EntityID,
+		ActorID:   q.ActorID,
+		ActorRole: q.ActorRole,
+		Limit:     q.Limit,
+	}
+
+	var err error
+	if q.From != "" {
+		query.From, err = parseTime(q.From, false)
+		if err != nil {
+			return audits.AuditLogQuery{}, err
+		}
+	}
+	if q.To != "" {
+		query.To, err = parseTime(q.To, true)
+		if err != nil {
+			return audits.AuditLogQuery{}, err
+		}
+	}
+	return query, nil
+}
+
+func parseTime(value string, endOfDay bool) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+
+	t, err = time.ParseInLocation(DATE_LAYOUT, value, config.GetTimeLoc())
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return t, nil
+}
+
+// PatientID is ignored when the patient is already given in the path
+type AccessLogQueryParamsRequest struct {
+	PatientID  int    `query:"patientId" validate:"gte=0"`
+	ReaderID   int    `query:"readerId" validate:"gte=0"`
+	ReaderRole string `query:"readerRole"`
+	BreakGlass bool   `query:"breakGlass"`
+	From       string `query:"from"`
+	To         string `query:"to"`
+	Limit      int    `query:"limit" validate:"gte=0"`
+}
+
+func (q AccessLogQueryParamsRequest) ToAccessLogQuery(patientId int) (audits.AccessLogQuery, error) {
+	query := audits.AccessLogQuery{
+		PatientID:  patientId,
+		ReaderID:   q.ReaderID,
+		ReaderRole: q.ReaderRole,
+		BreakGlass: q.BreakGlass,
+		Limit:      q.Limit,
+	}
+
+	var err error
+	if q.From != "" {
+		query.From, err = parseTime(q.From, false)
+		if err != nil {
+			return audits.AccessLogQuery{}, err
+		}
+	}
+	if q.To != "" {
+		query.To, err = parseTime(q.To, true)
+		if err != nil {
+			return audits.AccessLogQuery{}, err
+		}
+	}
+	return query, nil
+}
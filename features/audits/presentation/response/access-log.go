@@ -0,0 +1,39 @@
+package response
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+)
+
+type AccessLogResponse struct {
+	ID         int       `json:"id"`
+	ReaderID   int       `json:"readerId"`
+	ReaderRole string    `json:"readerRole"`
+	PatientID  int       `json:"patientId"`
+	Operation  string    `json:"operation"`
+	BreakGlass bool      `json:"breakGlass"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func AccessLog(a audits.AccessLogCore) AccessLogResponse {
+	return AccessLogResponse{
+		ID:         a.ID,
+		ReaderID:   a.ReaderID,
+		ReaderRole: a.ReaderRole,
+		PatientID:  a.PatientID,
+		Operation:  a.Operation,
+		BreakGlass: a.BreakGlass,
+		Reason:     a.Reason,
+		CreatedAt:  a.CreatedAt,
+	}
+}
+
+func ListAccessLogs(a []audits.AccessLogCore) []AccessLogResponse {
+	result := make([]AccessLogResponse, len(a))
+	for i := range a {
+		result[i] = AccessLog(a[i])
+	}
+	return result
+}
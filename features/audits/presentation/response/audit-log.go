@@ -0,0 +1,41 @@
+package response
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+)
+
+type AuditLogResponse struct {
+	ID        int         `json:"id"`
+	ActorID   int         `json:"actorId"`
+	ActorRole string      `json:"actorRole"`
+	Operation string      `json:"operation"`
+	Entity    string      `json:"entity"`
+	EntityID  int         `json:"entityId"`
+	Before    interface{} `json:"before"`
+	After     interface{} `json:"after"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+func AuditLog(a audits.AuditLogCore) AuditLogResponse {
+	return AuditLogResponse{
+		ID:        a.ID,
+		ActorID:   a.ActorID,
+		ActorRole: a.ActorRole,
+		Operation: a.Operation,
+		Entity:    a.Entity,
+		EntityID:  a.EntityID,
+		Before:    a.Before,
+		After:     a.After,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func ListAuditLogs(a []audits.AuditLogCore) []AuditLogResponse {
+	result := make([]AuditLogResponse, len(a))
+	for i := range a {
+		result[i] = AuditLog(a[i])
+	}
+	return result
+}
@@ -1,5 +1,109 @@
 package auth
 
+import "time"
+
+type TokenCore struct {
+	AccessToken  string
+	RefreshToken string
+	MFAToken     string // only filled when the second login step is required
+}
+
+type SessionCore struct {
+	ID           int
+	UserID       int
+	Role         string
+	RefreshToken string // sha256 hash of the refresh token, never the raw value
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type PasswordResetCore struct {
+	ID        int
+	UserID    int
+	Role      string
+	Token     string // sha256 hash of the reset token, never the raw value
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// PatientCodeCore is the one-time code a patient signs in to the portal with
+type PatientCodeCore struct {
+	ID        int
+	PatientID int
+	Code      string // sha256 hash of the code, never the raw value
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type LoginAttemptCore struct {
+	ID           int
+	Key          string // "email:<address>" or "ip:<address>"
+	Failures     int
+	LastFailedAt time.Time
+	LockedUntil  time.Time // zero when not locked
+}
+
+type TwoFactorCore struct {
+	ID           int
+	UserID       int
+	Role         string
+	Secret       string    // base32 TOTP secret
+	EnabledAt    time.Time // zero while enrollment is not verified yet
+	LastUsedStep int64     // TOTP step of the last accepted code, a code can not be used twice
+}
+
+type TwoFactorEnrollmentCore struct {
+	Secret string
+	URL    string // otpauth:// uri for the authenticator app
+}
+
 type IBusiness interface {
-	Login(email string, password string) (string, error)
+	Login(email string, password string, ip string) (TokenCore, error)
+	Refresh(refreshToken string) (TokenCore, error)
+	Logout(sessionId int) error
+	RevokeUserSessions(userId int, role string) error
+	ValidateSession(sessionId int, userId int, role string) error
+	RequestPasswordReset(email string) error
+	ConfirmPasswordReset(token string, newPassword string) error
+	UnlockAccount(email string) error
+	EnrollTwoFactor(userId int, role string) (TwoFactorEnrollmentCore, error)
+	VerifyTwoFactor(userId int, role string, code string) ([]string, error)
+	LoginTwoFactor(mfaToken string, code string, ip string) (TokenCore, error)
+
+	// Patients sign in with their NIK and a one-time code sent to their phone
+	RequestPatientCode(nik string, ip string) error
+	LoginPatient(nik string, code string, ip string) (TokenCore, error)
+}
+
+type IData interface {
+	SelectSessionById(id int) (SessionCore, error)
+	SelectSessionByRefreshToken(refreshToken string) (SessionCore, error)
+	InsertSession(session SessionCore) (int, error)
+	UpdateSession(session SessionCore, oldRefreshToken string) error // fails when the token was rotated in the meantime
+	DeleteSessionById(id int) error
+	DeleteSessionsByUser(userId int, role string) error
+
+	SelectPasswordResetByToken(token string) (PasswordResetCore, error)
+	InsertPasswordReset(passwordReset PasswordResetCore) error
+	DeletePasswordResetsByUser(userId int, role string) error
+
+	SelectPatientCodeByPatient(patientId int) (PatientCodeCore, error)
+	InsertPatientCode(patientCode PatientCodeCore) error
+	DeletePatientCodesByPatient(patientId int) error
+
+	SelectLoginAttemptByKey(key string) (LoginAttemptCore, error)
+	SaveLoginAttempt(attempt LoginAttemptCore) error
+	DeleteLoginAttemptByKey(key string) error
+
+	SelectTwoFactorByUser(userId int, role string) (TwoFactorCore, error)
+	SaveTwoFactor(twoFactor TwoFactorCore) error
+	ReplaceRecoveryCodes(userId int, role string, codes []string) error
+	DeleteRecoveryCode(userId int, role string, code string) error
+}
+
+// INotifier delivers reset tokens to the account owner (email, sms, etc)
+type INotifier interface {
+	SendPasswordResetToken(email string, token string) error
+	SendPatientCode(phone string, code string) error
 }
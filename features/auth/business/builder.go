@@ -1,16 +1,16 @@
 package business
 
 import (
-	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
 	"github.com/final-project-alterra/hospital-management-system-api/features/auth"
-	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
 )
 
 type authBusinessBuilder struct {
-	adminBusiness  admins.IBusiness
-	doctorBusiness doctors.IBusiness
-	nurseBusiness  nurses.IBusiness
+	data            auth.IData
+	notifier        auth.INotifier
+	accountBusiness accounts.IBusiness
+	patientBusiness patients.IBusiness
 }
 
 func NewAuthBusinessBuilder() *authBusinessBuilder {
@@ -19,29 +19,36 @@ func NewAuthBusinessBuilder() *authBusinessBuilder {
 
 func (a *authBusinessBuilder) Build() auth.IBusiness {
 	authBusiness := &authBusiness{
-		adminBusiness:  a.adminBusiness,
-		doctorBusiness: a.doctorBusiness,
-		nurseBusiness:  a.nurseBusiness,
+		data:            a.data,
+		notifier:        a.notifier,
+		accountBusiness: a.accountBusiness,
+		patientBusiness: a.patientBusiness,
 	}
 
-	a.adminBusiness = nil
-	a.doctorBusiness = nil
-	a.nurseBusiness = nil
+	a.data = nil
+	a.notifier = nil
+	a.accountBusiness = nil
+	a.patientBusiness = nil
 
 	return authBusiness
 }
 
-func (a *authBusinessBuilder) SetAdminBusiness(ab admins.IBusiness) *authBusinessBuilder {
-	a.adminBusiness = ab
+func (a *authBusinessBuilder) SetData(data auth.IData) *authBusinessBuilder {
+	a.data = data
 	return a
 }
 
-func (a *authBusinessBuilder) SetDoctorBusiness(db doctors.IBusiness) *authBusinessBuilder {
-	a.doctorBusiness = db
+func (a *authBusinessBuilder) SetNotifier(notifier auth.INotifier) *authBusinessBuilder {
+	a.notifier = notifier
 	return a
 }
 
-func (a *authBusinessBuilder) SetNurseBusiness(nb nurses.IBusiness) *authBusinessBuilder {
-	a.nurseBusiness = nb
+func (a *authBusinessBuilder) SetAccountBusiness(ab accounts.IBusiness) *authBusinessBuilder {
+	a.accountBusiness = ab
+	return a
+}
+
+func (a *authBusinessBuilder) SetPatientBusiness(pb patients.IBusiness) *authBusinessBuilder {
+	a.patientBusiness = pb
 	return a
 }
@@ -1,98 +1,757 @@
 package business
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
 	"time"
 
 	"github.com/final-project-alterra/hospital-management-system-api/config"
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
-	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	"github.com/final-project-alterra/hospital-management-system-api/features/auth"
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/utils/hash"
+	"github.com/final-project-alterra/hospital-management-system-api/utils/totp"
 	"github.com/golang-jwt/jwt"
 )
 
+const (
+	ACCESS_TOKEN_DURATION  = 15 * time.Minute
+	REFRESH_TOKEN_DURATION = 7 * 24 * time.Hour
+
+	PASSWORD_RESET_TOKEN_DURATION = 30 * time.Minute
+
+	MAX_EMAIL_FAILED_LOGINS = 5
+	MAX_IP_FAILED_LOGINS    = 20
+	FAILED_LOGIN_WINDOW     = 15 * time.Minute // failures older than this are forgotten
+	LOGIN_LOCKOUT_DURATION  = 15 * time.Minute
+
+	MFA_TOKEN_DURATION   = 5 * time.Minute // time given to enter the two-factor code after the password
+	RECOVERY_CODES_COUNT = 10
+	TOTP_ISSUER          = "Hospital Management System"
+
+	PATIENT_CODE_DURATION        = 5 * time.Minute
+	PATIENT_CODE_RESEND_INTERVAL = time.Minute // a new code is not sent sooner, every code is an sms
+	PATIENT_CODE_DIGITS          = 6
+
+	// Bcrypt hash with the cost of utils/hash that unknown emails are checked against, so
+	// they take as long as a wrong password
+	DUMMY_PASSWORD_HASH = "$2a$14$gST67DNNMGqXCEbeADym5eoJEZhOVjK2ZI2gUmdJ9W4sbucSh/yyG"
+)
+
 type authBusiness struct {
-	adminBusiness  admins.IBusiness
-	doctorBusiness doctors.IBusiness
-	nurseBusiness  nurses.IBusiness
+	data            auth.IData
+	notifier        auth.INotifier
+	accountBusiness accounts.IBusiness
+	patientBusiness patients.IBusiness
 }
 
-func (a *authBusiness) Login(email string, password string) (string, error) {
+func (a *authBusiness) Login(email string, password string, ip string) (auth.TokenCore, error) {
 	const op errors.Op = "auth.business.Login"
 	var errMessage errors.ErrClientMessage = "Wrong email or password"
 
-	// Check admin
-	admin, err := a.adminBusiness.FindAdminByEmail(email)
-	if err == nil {
-		doesMatch := hash.Validate(admin.Password, password)
-		if !doesMatch {
-			err = errors.New("Wrong email or password")
-			return "", errors.E(err, op, errMessage, errors.KindUnauthorized)
+	emailKey := "email:" + strings.ToLower(email)
+	ipKey := "ip:" + ip
+
+	err := a.checkLockout(emailKey, ipKey)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	account, err := a.accountBusiness.FindAccountByEmail(email)
+	if err != nil && errors.Kind(err) != errors.KindNotFound {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	// Unknown email and wrong password must be indistinguishable, in the answer and in
+	// the time it takes
+	passwordHash := account.Password
+	if err != nil {
+		passwordHash = DUMMY_PASSWORD_HASH
+	}
+	if !hash.Validate(passwordHash, password) || err != nil {
+		if err = a.recordFailedLogin(emailKey, MAX_EMAIL_FAILED_LOGINS); err != nil {
+			return auth.TokenCore{}, errors.E(err, op)
+		}
+		if err = a.recordFailedLogin(ipKey, MAX_IP_FAILED_LOGINS); err != nil {
+			return auth.TokenCore{}, errors.E(err, op)
 		}
 
-		token, err := a.createToken(admin.ID, "admin")
+		err = errors.New("Wrong email or password")
+		return auth.TokenCore{}, errors.E(err, op, errMessage, errors.KindUnauthorized)
+	}
+
+	err = a.data.DeleteLoginAttemptByKey(emailKey)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	isTwoFactorEnabled, err := a.isTwoFactorEnabled(account.UserID, account.Role)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+	if isTwoFactorEnabled {
+		mfaToken, err := a.createMFAToken(account.UserID, account.Role)
 		if err != nil {
-			return "", errors.E(err, op)
+			return auth.TokenCore{}, errors.E(err, op)
+		}
+		return auth.TokenCore{MFAToken: mfaToken}, nil
+	}
+
+	token, err := a.createSession(account.UserID, account.Role)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+	return token, nil
+}
+
+func (a *authBusiness) LoginTwoFactor(mfaToken string, code string, ip string) (auth.TokenCore, error) {
+	const op errors.Op = "auth.business.LoginTwoFactor"
+	var errMessage errors.ErrClientMessage = "Wrong two-factor code"
+
+	userId, role, err := parseMFAToken(mfaToken)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	twoFactorKey := fmt.Sprintf("2fa:%s:%d", role, userId)
+	ipKey := "ip:" + ip
+
+	err = a.checkLockout(twoFactorKey, ipKey)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	twoFactor, err := a.data.SelectTwoFactorByUser(userId, role)
+	if err != nil {
+		switch errors.Kind(err) {
+		case errors.KindNotFound:
+			return auth.TokenCore{}, errors.E(err, op, errMessage, errors.KindUnauthorized)
+		default:
+			return auth.TokenCore{}, errors.E(err, op)
+		}
+	}
+
+	isValid, err := a.useTwoFactorCode(twoFactor, code)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	if !isValid {
+		if err = a.recordFailedLogin(twoFactorKey, MAX_EMAIL_FAILED_LOGINS); err != nil {
+			return auth.TokenCore{}, errors.E(err, op)
+		}
+		if err = a.recordFailedLogin(ipKey, MAX_IP_FAILED_LOGINS); err != nil {
+			return auth.TokenCore{}, errors.E(err, op)
 		}
-		return token, nil
+
+		err = errors.New("Wrong two-factor code")
+		return auth.TokenCore{}, errors.E(err, op, errMessage, errors.KindUnauthorized)
+	}
+
+	err = a.data.DeleteLoginAttemptByKey(twoFactorKey)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	token, err := a.createSession(userId, role)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+	return token, nil
+}
+
+func (a *authBusiness) Refresh(refreshToken string) (auth.TokenCore, error) {
+	const op errors.Op = "auth.business.Refresh"
+	var errMessage errors.ErrClientMessage = "Invalid refresh token"
+
+	session, err := a.data.SelectSessionByRefreshToken(hashToken(refreshToken))
+	if err != nil {
+		switch errors.Kind(err) {
+		case errors.KindNotFound:
+			return auth.TokenCore{}, errors.E(err, op, errMessage, errors.KindUnauthorized)
+		default:
+			return auth.TokenCore{}, errors.E(err, op)
+		}
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		_ = a.data.DeleteSessionById(session.ID)
+		err = errors.New("Refresh token has expired")
+		errMessage = "Refresh token has expired"
+		return auth.TokenCore{}, errors.E(err, op, errMessage, errors.KindUnauthorized)
 	}
 
-	if errors.Kind(err) != errors.KindNotFound {
-		return "", errors.E(err, op)
+	if err = a.checkAccount(session.UserID, session.Role); err != nil {
+		_ = a.data.DeleteSessionById(session.ID)
+		return auth.TokenCore{}, errors.E(err, op)
 	}
 
-	// Check doctor
-	doctor, err := a.doctorBusiness.FindDoctorByEmail(email)
-	if err == nil {
-		doesMatch := hash.Validate(doctor.Password, password)
-		if !doesMatch {
-			err = errors.New("Wring email or password")
-			return "", errors.E(err, op, errMessage, errors.KindUnauthorized)
+	// Rotate refresh token, so a leaked one can only be used once
+	newRefreshToken, err := generateToken()
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+	oldRefreshToken := session.RefreshToken
+	session.RefreshToken = hashToken(newRefreshToken)
+	session.ExpiresAt = time.Now().Add(REFRESH_TOKEN_DURATION)
+
+	// Only one of concurrent refreshes with the same token wins the rotation
+	err = a.data.UpdateSession(session, oldRefreshToken)
+	if err != nil {
+		switch errors.Kind(err) {
+		case errors.KindNotFound:
+			return auth.TokenCore{}, errors.E(err, op, errMessage, errors.KindUnauthorized)
+		default:
+			return auth.TokenCore{}, errors.E(err, op)
 		}
-		token, err := a.createToken(doctor.ID, "doctor")
+	}
+
+	accessToken, err := a.createToken(session.ID, session.UserID, session.Role)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	return auth.TokenCore{AccessToken: accessToken, RefreshToken: newRefreshToken}, nil
+}
+
+func (a *authBusiness) Logout(sessionId int) error {
+	const op errors.Op = "auth.business.Logout"
+
+	err := a.data.DeleteSessionById(sessionId)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (a *authBusiness) RevokeUserSessions(userId int, role string) error {
+	const op errors.Op = "auth.business.RevokeUserSessions"
+
+	err := a.data.DeleteSessionsByUser(userId, role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (a *authBusiness) ValidateSession(sessionId int, userId int, role string) error {
+	const op errors.Op = "auth.business.ValidateSession"
+	var errMessage errors.ErrClientMessage = "Session has been revoked"
+
+	session, err := a.data.SelectSessionById(sessionId)
+	if err != nil {
+		switch errors.Kind(err) {
+		case errors.KindNotFound:
+			return errors.E(err, op, errMessage, errors.KindUnauthorized)
+		default:
+			return errors.E(err, op)
+		}
+	}
+
+	if session.UserID != userId || session.Role != role {
+		err = errors.New("Session does not belong to user")
+		return errors.E(err, op, errMessage, errors.KindUnauthorized)
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		err = errors.New("Session has expired")
+		errMessage = "Session has expired"
+		return errors.E(err, op, errMessage, errors.KindUnauthorized)
+	}
+
+	// Deleted account must not keep its access until the token expires
+	if err = a.checkAccount(userId, role); err != nil {
+		_ = a.data.DeleteSessionById(sessionId)
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (a *authBusiness) RequestPasswordReset(email string) error {
+	const op errors.Op = "auth.business.RequestPasswordReset"
+
+	account, err := a.accountBusiness.FindAccountByEmail(email)
+	if err != nil {
+		// Unknown email gets the same answer, so this can not be used to probe accounts
+		if errors.Kind(err) == errors.KindNotFound {
+			return nil
+		}
+		return errors.E(err, op)
+	}
+
+	// Only the latest requested token is usable
+	err = a.data.DeletePasswordResetsByUser(account.UserID, account.Role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	passwordReset := auth.PasswordResetCore{
+		UserID:    account.UserID,
+		Role:      account.Role,
+		Token:     hashToken(token),
+		ExpiresAt: time.Now().Add(PASSWORD_RESET_TOKEN_DURATION),
+	}
+
+	err = a.data.InsertPasswordReset(passwordReset)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	// The stored address, the input may only match it case-insensitively. A failed delivery
+	// gets the same answer as an unknown email too.
+	err = a.notifier.SendPasswordResetToken(account.Email, token)
+	if err != nil {
+		errors.Log(errors.E(err, op))
+	}
+	return nil
+}
+
+func (a *authBusiness) ConfirmPasswordReset(token string, newPassword string) error {
+	const op errors.Op = "auth.business.ConfirmPasswordReset"
+	var errMessage errors.ErrClientMessage = "Invalid or expired password reset token"
+
+	passwordReset, err := a.data.SelectPasswordResetByToken(hashToken(token))
+	if err != nil {
+		switch errors.Kind(err) {
+		case errors.KindNotFound:
+			return errors.E(err, op, errMessage, errors.KindBadRequest)
+		default:
+			return errors.E(err, op)
+		}
+	}
+
+	if time.Now().After(passwordReset.ExpiresAt) {
+		_ = a.data.DeletePasswordResetsByUser(passwordReset.UserID, passwordReset.Role)
+		err = errors.New("Password reset token has expired")
+		return errors.E(err, op, errMessage, errors.KindBadRequest)
+	}
+
+	err = a.accountBusiness.ResetAccountPassword(passwordReset.UserID, passwordReset.Role, newPassword)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	// Token is single use
+	err = a.data.DeletePasswordResetsByUser(passwordReset.UserID, passwordReset.Role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	// Whoever knew the old password must be signed out
+	err = a.data.DeleteSessionsByUser(passwordReset.UserID, passwordReset.Role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (a *authBusiness) UnlockAccount(email string) error {
+	const op errors.Op = "auth.business.UnlockAccount"
+
+	err := a.data.DeleteLoginAttemptByKey("email:" + strings.ToLower(email))
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (a *authBusiness) EnrollTwoFactor(userId int, role string) (auth.TwoFactorEnrollmentCore, error) {
+	const op errors.Op = "auth.business.EnrollTwoFactor"
+	var errMessage errors.ErrClientMessage = "Two-factor authentication is already enabled"
+
+	account, err := a.accountBusiness.FindAccountByUser(userId, role)
+	if err != nil {
+		return auth.TwoFactorEnrollmentCore{}, errors.E(err, op)
+	}
+
+	twoFactor, err := a.data.SelectTwoFactorByUser(userId, role)
+	if err != nil && errors.Kind(err) != errors.KindNotFound {
+		return auth.TwoFactorEnrollmentCore{}, errors.E(err, op)
+	}
+
+	if !twoFactor.EnabledAt.IsZero() {
+		err = errors.New("Two-factor authentication is already enabled")
+		return auth.TwoFactorEnrollmentCore{}, errors.E(err, op, errMessage, errors.KindUnprocessable)
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return auth.TwoFactorEnrollmentCore{}, errors.E(err, op)
+	}
+
+	// Enrolling again before verifying simply replaces the pending secret
+	twoFactor.UserID = userId
+	twoFactor.Role = role
+	twoFactor.Secret = secret
+	twoFactor.LastUsedStep = 0
+
+	err = a.data.SaveTwoFactor(twoFactor)
+	if err != nil {
+		return auth.TwoFactorEnrollmentCore{}, errors.E(err, op)
+	}
+
+	enrollment := auth.TwoFactorEnrollmentCore{
+		Secret: secret,
+		URL:    totp.URL(TOTP_ISSUER, account.Email, secret),
+	}
+	return enrollment, nil
+}
+
+func (a *authBusiness) VerifyTwoFactor(userId int, role string, code string) ([]string, error) {
+	const op errors.Op = "auth.business.VerifyTwoFactor"
+	var errMessage errors.ErrClientMessage = "Two-factor authentication has not been enrolled"
+
+	twoFactor, err := a.data.SelectTwoFactorByUser(userId, role)
+	if err != nil {
+		switch errors.Kind(err) {
+		case errors.KindNotFound:
+			return nil, errors.E(err, op, errMessage, errors.KindUnprocessable)
+		default:
+			return nil, errors.E(err, op)
+		}
+	}
+
+	if !twoFactor.EnabledAt.IsZero() {
+		err = errors.New("Two-factor authentication is already enabled")
+		errMessage = "Two-factor authentication is already enabled"
+		return nil, errors.E(err, op, errMessage, errors.KindUnprocessable)
+	}
+
+	step, ok := totp.Verify(twoFactor.Secret, code, time.Now())
+	if !ok {
+		err = errors.New("Wrong two-factor code")
+		errMessage = "Wrong two-factor code"
+		return nil, errors.E(err, op, errMessage, errors.KindUnprocessable)
+	}
+
+	recoveryCodes := []string{}
+	hashedCodes := []string{}
+	for i := 0; i < RECOVERY_CODES_COUNT; i++ {
+		recoveryCode, err := generateRecoveryCode()
 		if err != nil {
-			return "", errors.E(err, op)
+			return nil, errors.E(err, op)
 		}
-		return token, nil
+		recoveryCodes = append(recoveryCodes, recoveryCode)
+		hashedCodes = append(hashedCodes, hashToken(recoveryCode))
 	}
 
-	if errors.Kind(err) != errors.KindNotFound {
-		return "", errors.E(err, op)
+	err = a.data.ReplaceRecoveryCodes(userId, role, hashedCodes)
+	if err != nil {
+		return nil, errors.E(err, op)
 	}
 
-	// Check nurse
-	nurse, err := a.nurseBusiness.FindNurseByEmail(email)
-	if err == nil {
-		doesMatch := hash.Validate(nurse.Password, password)
-		if !doesMatch {
-			err = errors.New("Wring email or password")
-			return "", errors.E(err, op, errMessage, errors.KindUnauthorized)
+	twoFactor.EnabledAt = time.Now()
+	twoFactor.LastUsedStep = step
+
+	err = a.data.SaveTwoFactor(twoFactor)
+	if err != nil {
+		return nil, errors.E(err, op)
+	}
+
+	// Raw codes are only shown once, only their hash is stored
+	return recoveryCodes, nil
+}
+
+func (a *authBusiness) RequestPatientCode(nik string, ip string) error {
+	const op errors.Op = "auth.business.RequestPatientCode"
+
+	err := a.checkLockout("nik:"+nik, "ip:"+ip)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	patient, err := a.patientBusiness.FindPatientByNIK(nik)
+	if err != nil {
+		// Unknown NIK gets the same answer, so this can not be used to probe patients
+		if errors.Kind(err) == errors.KindNotFound {
+			return nil
+		}
+		return errors.E(err, op)
+	}
+
+	lastCode, err := a.data.SelectPatientCodeByPatient(patient.ID)
+	if err != nil && errors.Kind(err) != errors.KindNotFound {
+		return errors.E(err, op)
+	}
+	// The code sent recently is still on its way, skipped silently like an unknown NIK
+	if err == nil && time.Since(lastCode.CreatedAt) < PATIENT_CODE_RESEND_INTERVAL {
+		return nil
+	}
+
+	// Only the latest requested code is usable
+	err = a.data.DeletePatientCodesByPatient(patient.ID)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	code, err := generatePatientCode()
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	patientCode := auth.PatientCodeCore{
+		PatientID: patient.ID,
+		Code:      hashToken(code),
+		ExpiresAt: time.Now().Add(PATIENT_CODE_DURATION),
+	}
+
+	err = a.data.InsertPatientCode(patientCode)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	// A patient without phone or a failing channel gets the same answer as an unknown NIK
+	err = a.notifier.SendPatientCode(patient.Phone, code)
+	if err != nil {
+		errors.Log(errors.E(err, op))
+	}
+	return nil
+}
+
+func (a *authBusiness) LoginPatient(nik string, code string, ip string) (auth.TokenCore, error) {
+	const op errors.Op = "auth.business.LoginPatient"
+	var errMessage errors.ErrClientMessage = "Wrong NIK or login code"
+
+	nikKey := "nik:" + nik
+	ipKey := "ip:" + ip
+
+	err := a.checkLockout(nikKey, ipKey)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	isValid, patientId, err := a.usePatientCode(nik, strings.TrimSpace(code))
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	// Unknown NIK, wrong code and expired code must be indistinguishable
+	if !isValid {
+		if err = a.recordFailedLogin(nikKey, MAX_EMAIL_FAILED_LOGINS); err != nil {
+			return auth.TokenCore{}, errors.E(err, op)
+		}
+		if err = a.recordFailedLogin(ipKey, MAX_IP_FAILED_LOGINS); err != nil {
+			return auth.TokenCore{}, errors.E(err, op)
 		}
-		token, err := a.createToken(nurse.ID, "nurse")
+
+		err = errors.New("Wrong NIK or login code")
+		return auth.TokenCore{}, errors.E(err, op, errMessage, errors.KindUnauthorized)
+	}
+
+	err = a.data.DeleteLoginAttemptByKey(nikKey)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	token, err := a.createSession(patientId, permissions.RolePatient)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+	return token, nil
+}
+
+// Private methods
+func (a *authBusiness) createSession(userId int, role string) (auth.TokenCore, error) {
+	const op errors.Op = "auth.business.createSession"
+
+	refreshToken, err := generateToken()
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	session := auth.SessionCore{
+		UserID:       userId,
+		Role:         role,
+		RefreshToken: hashToken(refreshToken),
+		ExpiresAt:    time.Now().Add(REFRESH_TOKEN_DURATION),
+	}
+
+	sessionId, err := a.data.InsertSession(session)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	accessToken, err := a.createToken(sessionId, userId, role)
+	if err != nil {
+		return auth.TokenCore{}, errors.E(err, op)
+	}
+
+	return auth.TokenCore{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func (a *authBusiness) checkLockout(keys ...string) error {
+	const op errors.Op = "auth.business.checkLockout"
+	var errMessage errors.ErrClientMessage = "Too many failed login attempts, please try again later"
+
+	for _, key := range keys {
+		attempt, err := a.data.SelectLoginAttemptByKey(key)
 		if err != nil {
-			return "", errors.E(err, op)
+			if errors.Kind(err) == errors.KindNotFound {
+				continue
+			}
+			return errors.E(err, op)
+		}
+
+		if time.Now().Before(attempt.LockedUntil) {
+			err = errors.New("Login is locked for " + key)
+			return errors.E(err, op, errMessage, errors.KindTooManyRequests)
+		}
+	}
+	return nil
+}
+
+func (a *authBusiness) recordFailedLogin(key string, maxFailures int) error {
+	const op errors.Op = "auth.business.recordFailedLogin"
+
+	attempt, err := a.data.SelectLoginAttemptByKey(key)
+	if err != nil {
+		if errors.Kind(err) != errors.KindNotFound {
+			return errors.E(err, op)
 		}
-		return token, nil
+		attempt = auth.LoginAttemptCore{Key: key}
+	}
+
+	now := time.Now()
+	if now.Sub(attempt.LastFailedAt) > FAILED_LOGIN_WINDOW {
+		attempt.Failures = 0
 	}
 
-	if errors.Kind(err) != errors.KindNotFound {
-		return "", errors.E(err, op)
+	attempt.Failures++
+	attempt.LastFailedAt = now
+	if attempt.Failures >= maxFailures {
+		attempt.Failures = 0
+		attempt.LockedUntil = now.Add(LOGIN_LOCKOUT_DURATION)
 	}
 
-	err = errors.New("Account does not exsist")
-	errMessage = "Account does not exsist"
-	return "", errors.E(err, op, errMessage, errors.KindNotFound)
+	err = a.data.SaveLoginAttempt(attempt)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
 }
 
-func (a *authBusiness) createToken(id int, role string) (string, error) {
-	const op errors.Op = "auth.business.createToken"
+func (a *authBusiness) checkAccount(userId int, role string) error {
+	const op errors.Op = "auth.business.checkAccount"
+	var errMessage errors.ErrClientMessage = "Account does not exsist"
+
+	var err error
+	if role == permissions.RolePatient {
+		_, err = a.patientBusiness.FindPatientById(userId)
+	} else {
+		_, err = a.accountBusiness.FindAccountByUser(userId, role)
+	}
+	if err != nil {
+		switch errors.Kind(err) {
+		case errors.KindNotFound:
+			return errors.E(err, op, errMessage, errors.KindUnauthorized)
+		default:
+			return errors.E(err, op)
+		}
+	}
+	return nil
+}
+
+func (a *authBusiness) isTwoFactorEnabled(userId int, role string) (bool, error) {
+	const op errors.Op = "auth.business.isTwoFactorEnabled"
+
+	twoFactor, err := a.data.SelectTwoFactorByUser(userId, role)
+	if err != nil {
+		if errors.Kind(err) == errors.KindNotFound {
+			return false, nil
+		}
+		return false, errors.E(err, op)
+	}
+	return !twoFactor.EnabledAt.IsZero(), nil
+}
+
+// useTwoFactorCode accepts either a TOTP code or an unused recovery code
+func (a *authBusiness) useTwoFactorCode(twoFactor auth.TwoFactorCore, code string) (bool, error) {
+	const op errors.Op = "auth.business.useTwoFactorCode"
+
+	if twoFactor.EnabledAt.IsZero() {
+		return false, nil
+	}
+
+	code = strings.ToLower(strings.TrimSpace(code))
+
+	step, ok := totp.Verify(twoFactor.Secret, code, time.Now())
+	if ok {
+		// Replayed code, e.g. sniffed from the previous login
+		if step <= twoFactor.LastUsedStep {
+			return false, nil
+		}
+
+		twoFactor.LastUsedStep = step
+		err := a.data.SaveTwoFactor(twoFactor)
+		if err != nil {
+			return false, errors.E(err, op)
+		}
+		return true, nil
+	}
+
+	err := a.data.DeleteRecoveryCode(twoFactor.UserID, twoFactor.Role, hashToken(code))
+	if err != nil {
+		if errors.Kind(err) == errors.KindNotFound {
+			return false, nil
+		}
+		return false, errors.E(err, op)
+	}
+	return true, nil
+}
+
+// usePatientCode checks the code of the patient with nik, a valid code is used up
+func (a *authBusiness) usePatientCode(nik string, code string) (bool, int, error) {
+	const op errors.Op = "auth.business.usePatientCode"
+
+	patient, err := a.patientBusiness.FindPatientByNIK(nik)
+	if err != nil {
+		if errors.Kind(err) == errors.KindNotFound {
+			return false, 0, nil
+		}
+		return false, 0, errors.E(err, op)
+	}
+
+	patientCode, err := a.data.SelectPatientCodeByPatient(patient.ID)
+	if err != nil {
+		if errors.Kind(err) == errors.KindNotFound {
+			return false, 0, nil
+		}
+		return false, 0, errors.E(err, op)
+	}
+
+	if time.Now().After(patientCode.ExpiresAt) || patientCode.Code != hashToken(code) {
+		return false, 0, nil
+	}
+
+	// Code is single use
+	err = a.data.DeletePatientCodesByPatient(patient.ID)
+	if err != nil {
+		return false, 0, errors.E(err, op)
+	}
+	return true, patient.ID, nil
+}
+
+func (a *authBusiness) createMFAToken(userId int, role string) (string, error) {
+	const op errors.Op = "auth.business.createMFAToken"
 	var errMessage errors.ErrClientMessage = "Something went wrong"
 
+	// No sessionId claim, so IsAuth never accepts it as an access token
 	claims := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"userId": id,
+		"mfa":    true,
+		"userId": userId,
 		"role":   role,
-		"exp":    time.Now().Add(6 * time.Hour).Unix(),
+		"exp":    time.Now().Add(MFA_TOKEN_DURATION).Unix(),
 	})
 
 	token, err := claims.SignedString([]byte(config.ENV.JWT_SECRET))
@@ -101,3 +760,87 @@ func (a *authBusiness) createToken(id int, role string) (string, error) {
 	}
 	return token, nil
 }
+
+func (a *authBusiness) createToken(sessionId int, userId int, role string) (string, error) {
+	const op errors.Op = "auth.business.createToken"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	claims := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sessionId": sessionId,
+		"userId":    userId,
+		"role":      role,
+		"exp":       time.Now().Add(ACCESS_TOKEN_DURATION).Unix(),
+	})
+
+	token, err := claims.SignedString([]byte(config.ENV.JWT_SECRET))
+	if err != nil {
+		return "", errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return token, nil
+}
+
+func generateToken() (string, error) {
+	const op errors.Op = "auth.business.generateToken"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func parseMFAToken(mfaToken string) (int, string, error) {
+	const op errors.Op = "auth.business.parseMFAToken"
+	var errMessage errors.ErrClientMessage = "Invalid or expired two-factor token"
+
+	keyFunction := func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(config.ENV.JWT_SECRET), nil
+	}
+
+	token, err := jwt.Parse(mfaToken, keyFunction)
+	if err != nil {
+		return 0, "", errors.E(err, op, errMessage, errors.KindUnauthorized)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	isMFA, _ := claims["mfa"].(bool)
+	userId, hasUserId := claims["userId"].(float64)
+	role, hasRole := claims["role"].(string)
+	if !ok || !token.Valid || !isMFA || !hasUserId || !hasRole {
+		err = errors.New("Invalid two-factor token claims")
+		return 0, "", errors.E(err, op, errMessage, errors.KindUnauthorized)
+	}
+	return int(userId), role, nil
+}
+
+func generateRecoveryCode() (string, error) {
+	const op errors.Op = "auth.business.generateRecoveryCode"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func generatePatientCode() (string, error) {
+	const op errors.Op = "auth.business.generatePatientCode"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(PATIENT_CODE_DIGITS), nil)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return fmt.Sprintf("%0*d", PATIENT_CODE_DIGITS, n), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
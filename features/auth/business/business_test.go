@@ -1,35 +1,43 @@
 package business_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
-	amock "github.com/final-project-alterra/hospital-management-system-api/features/admins/mocks"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
 	"github.com/final-project-alterra/hospital-management-system-api/features/auth"
 	authBusiness "github.com/final-project-alterra/hospital-management-system-api/features/auth/business"
-	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
 	"github.com/final-project-alterra/hospital-management-system-api/utils/hash"
+	"github.com/final-project-alterra/hospital-management-system-api/utils/totp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
 
-	dmock "github.com/final-project-alterra/hospital-management-system-api/features/doctors/mocks"
-
-	nmock "github.com/final-project-alterra/hospital-management-system-api/features/nurses/mocks"
+	acmock "github.com/final-project-alterra/hospital-management-system-api/features/accounts/mocks"
+	authMock "github.com/final-project-alterra/hospital-management-system-api/features/auth/mocks"
+	pmock "github.com/final-project-alterra/hospital-management-system-api/features/patients/mocks"
 )
 
 var (
 	business auth.IBusiness
 
-	adminBusiness  amock.IBusiness
-	doctorBusiness dmock.IBusiness
-	nurseBusiness  nmock.IBusiness
+	authData        authMock.IData
+	notifier        authMock.INotifier
+	accountBusiness acmock.IBusiness
+	patientBusiness pmock.IBusiness
+
+	admin  accounts.AccountCore
+	doctor accounts.AccountCore
+	nurse  accounts.AccountCore
 
-	admin  admins.AdminCore
-	doctor doctors.DoctorCore
-	nurse  nurses.NurseCore
+	patient patients.PatientCore
+
+	session auth.SessionCore
 
 	errServer   error
 	errNotFound error
@@ -37,9 +45,10 @@ var (
 
 func TestMain(m *testing.M) {
 	business = authBusiness.NewAuthBusinessBuilder().
-		SetAdminBusiness(&adminBusiness).
-		SetDoctorBusiness(&doctorBusiness).
-		SetNurseBusiness(&nurseBusiness).
+		SetData(&authData).
+		SetNotifier(&notifier).
+		SetAccountBusiness(&accountBusiness).
+		SetPatientBusiness(&patientBusiness).
 		Build()
 
 	password, err := hash.Generate("12345678")
@@ -47,22 +56,42 @@ func TestMain(m *testing.M) {
 		panic(err)
 	}
 
-	admin = admins.AdminCore{
+	admin = accounts.AccountCore{
 		ID:       1,
 		Email:    "admin@mail.com",
 		Password: password,
+		Role:     "admin",
+		UserID:   1,
 	}
 
-	doctor = doctors.DoctorCore{
+	doctor = accounts.AccountCore{
 		ID:       1,
 		Email:    "doctor@mail.com",
 		Password: password,
+		Role:     "doctor",
+		UserID:   1,
 	}
 
-	nurse = nurses.NurseCore{
+	nurse = accounts.AccountCore{
 		ID:       1,
 		Email:    "nurse@mail.com",
 		Password: password,
+		Role:     "nurse",
+		UserID:   1,
+	}
+
+	patient = patients.PatientCore{
+		ID:    1,
+		NIK:   "3201010101010001",
+		Name:  "Patient",
+		Phone: "081234567890",
+	}
+
+	session = auth.SessionCore{
+		ID:        1,
+		UserID:    1,
+		Role:      "admin",
+		ExpiresAt: time.Now().Add(time.Hour),
 	}
 
 	errNotFound = errors.E(errors.New("not found"), errors.KindNotFound)
@@ -72,169 +101,1282 @@ func TestMain(m *testing.M) {
 }
 
 func TestLogin(t *testing.T) {
+	ip := "127.0.0.1"
+
 	t.Run("valid - when admin authentication success", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminByEmail", admin.Email).
+		expectNotLocked(admin.Email, ip)
+
+		accountBusiness.
+			On("FindAccountByEmail", admin.Email).
 			Return(admin, nil).
 			Once()
 
-		token, err := business.Login("admin@mail.com", "12345678")
+		authData.
+			On("DeleteLoginAttemptByKey", "email:"+admin.Email).
+			Return(nil).
+			Once()
+
+		authData.
+			On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+			Return(auth.TwoFactorCore{}, errNotFound).
+			Once()
+
+		authData.
+			On("InsertSession", mock.AnythingOfType("auth.SessionCore")).
+			Return(1, nil).
+			Once()
+
+		token, err := business.Login("admin@mail.com", "12345678", ip)
 		assert.Nil(t, err)
-		assert.NotEqual(t, "", token)
+		assert.NotEqual(t, "", token.AccessToken)
+		assert.NotEqual(t, "", token.RefreshToken)
 	})
 
 	t.Run("valid - when admin authentication failed", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminByEmail", admin.Email).
+		expectNotLocked(admin.Email, ip)
+		expectFailedLogin(admin.Email, ip)
+
+		accountBusiness.
+			On("FindAccountByEmail", admin.Email).
 			Return(admin, nil).
 			Once()
 
-		token, err := business.Login("admin@mail.com", "wrong password")
+		token, err := business.Login("admin@mail.com", "wrong password", ip)
 		assert.Error(t, err)
-		assert.Equal(t, "", token)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+		assert.Equal(t, "", token.AccessToken)
 	})
 
-	t.Run("valid - when FindAdminByEmail returne server error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminByEmail", admin.Email).
-			Return(admins.AdminCore{}, errServer).
+	t.Run("valid - when FindAccountByEmail return server error", func(t *testing.T) {
+		expectNotLocked(admin.Email, ip)
+
+		accountBusiness.
+			On("FindAccountByEmail", admin.Email).
+			Return(accounts.AccountCore{}, errServer).
 			Once()
 
-		token, err := business.Login("admin@mail.com", "wrong password")
+		token, err := business.Login("admin@mail.com", "wrong password", ip)
 		assert.Error(t, err)
-		assert.Equal(t, "", token)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+		assert.Equal(t, "", token.AccessToken)
 	})
 
 	t.Run("valid - when doctor authentication success", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
-			Once()
+		expectNotLocked(doctor.Email, ip)
 
-		doctorBusiness.
-			On("FindDoctorByEmail", doctor.Email).
+		accountBusiness.
+			On("FindAccountByEmail", doctor.Email).
 			Return(doctor, nil).
 			Once()
 
-		token, err := business.Login("doctor@mail.com", "12345678")
+		authData.
+			On("DeleteLoginAttemptByKey", "email:"+doctor.Email).
+			Return(nil).
+			Once()
+
+		authData.
+			On("SelectTwoFactorByUser", doctor.UserID, doctor.Role).
+			Return(auth.TwoFactorCore{}, errNotFound).
+			Once()
+
+		authData.
+			On("InsertSession", mock.AnythingOfType("auth.SessionCore")).
+			Return(1, nil).
+			Once()
+
+		token, err := business.Login("doctor@mail.com", "12345678", ip)
 		assert.Nil(t, err)
-		assert.NotEqual(t, "", token)
+		assert.NotEqual(t, "", token.AccessToken)
+		assert.NotEqual(t, "", token.RefreshToken)
 	})
 
-	t.Run("valid - when doctor authentication failed", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
+	t.Run("valid - when nurse authentication success", func(t *testing.T) {
+		expectNotLocked(nurse.Email, ip)
+
+		accountBusiness.
+			On("FindAccountByEmail", nurse.Email).
+			Return(nurse, nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorByEmail", doctor.Email).
-			Return(doctor, nil).
+		authData.
+			On("DeleteLoginAttemptByKey", "email:"+nurse.Email).
+			Return(nil).
+			Once()
+
+		authData.
+			On("SelectTwoFactorByUser", nurse.UserID, nurse.Role).
+			Return(auth.TwoFactorCore{}, errNotFound).
 			Once()
 
-		token, err := business.Login("doctor@mail.com", "wrong password")
+		authData.
+			On("InsertSession", mock.AnythingOfType("auth.SessionCore")).
+			Return(1, nil).
+			Once()
+
+		token, err := business.Login("nurse@mail.com", "12345678", ip)
+		assert.Nil(t, err)
+		assert.NotEqual(t, "", token.AccessToken)
+		assert.NotEqual(t, "", token.RefreshToken)
+	})
+
+	t.Run("valid - when unknown email gets the same response as wrong password", func(t *testing.T) {
+		expectNotLocked(nurse.Email, ip)
+		expectFailedLogin(nurse.Email, ip)
+
+		accountBusiness.
+			On("FindAccountByEmail", nurse.Email).
+			Return(nurse, nil).
+			Once()
+
+		_, wrongPasswordErr := business.Login("nurse@mail.com", "wrong password", ip)
+
+		expectNotLocked("unknown@mail.com", ip)
+		expectFailedLogin("unknown@mail.com", ip)
+
+		accountBusiness.
+			On("FindAccountByEmail", "unknown@mail.com").
+			Return(accounts.AccountCore{}, errNotFound).
+			Once()
+
+		_, unknownEmailErr := business.Login("unknown@mail.com", "wrong password", ip)
+
+		assert.Error(t, wrongPasswordErr)
+		assert.Error(t, unknownEmailErr)
+		assert.Equal(t, errors.Kind(wrongPasswordErr), errors.Kind(unknownEmailErr))
+		assert.Equal(t, errors.ClientMessage(wrongPasswordErr), errors.ClientMessage(unknownEmailErr))
+
+		// Unknown emails are checked against a hash as expensive as a real one
+		dummyCost, err := bcrypt.Cost([]byte(authBusiness.DUMMY_PASSWORD_HASH))
+		assert.Nil(t, err)
+		realCost, _ := bcrypt.Cost([]byte(nurse.Password))
+		assert.Equal(t, realCost, dummyCost)
+	})
+
+	t.Run("valid - when email is locked", func(t *testing.T) {
+		authData.
+			On("SelectLoginAttemptByKey", "email:"+admin.Email).
+			Return(auth.LoginAttemptCore{LockedUntil: time.Now().Add(time.Minute)}, nil).
+			Once()
+
+		token, err := business.Login("admin@mail.com", "12345678", ip)
 		assert.Error(t, err)
-		assert.Equal(t, "", token)
+		assert.Equal(t, errors.KindTooManyRequests, errors.Kind(err))
+		assert.Equal(t, "", token.AccessToken)
 	})
 
-	t.Run("valid - when FindDoctorByEmail return server error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
+	t.Run("valid - when ip is locked", func(t *testing.T) {
+		authData.
+			On("SelectLoginAttemptByKey", "email:"+admin.Email).
+			Return(auth.LoginAttemptCore{}, errNotFound).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorByEmail", mock.AnythingOfType("string")).
-			Return(doctors.DoctorCore{}, errServer).
+		authData.
+			On("SelectLoginAttemptByKey", "ip:"+ip).
+			Return(auth.LoginAttemptCore{LockedUntil: time.Now().Add(time.Minute)}, nil).
 			Once()
 
-		token, err := business.Login("doctor@mail.com", "wrong password")
+		token, err := business.Login("admin@mail.com", "12345678", ip)
 		assert.Error(t, err)
-		assert.Equal(t, "", token)
+		assert.Equal(t, errors.KindTooManyRequests, errors.Kind(err))
+		assert.Equal(t, "", token.AccessToken)
 	})
 
-	t.Run("valid - when nurse authentication success", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
+	t.Run("valid - when last allowed failure locks the email", func(t *testing.T) {
+		failures := auth.LoginAttemptCore{
+			ID:           1,
+			Key:          "email:" + admin.Email,
+			Failures:     authBusiness.MAX_EMAIL_FAILED_LOGINS - 1,
+			LastFailedAt: time.Now(),
+		}
+
+		expectNotLocked(admin.Email, ip)
+
+		accountBusiness.
+			On("FindAccountByEmail", admin.Email).
+			Return(admin, nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorByEmail", mock.AnythingOfType("string")).
-			Return(doctors.DoctorCore{}, errNotFound).
+		authData.
+			On("SelectLoginAttemptByKey", "email:"+admin.Email).
+			Return(failures, nil).
 			Once()
 
-		nurseBusiness.
-			On("FindNurseByEmail", nurse.Email).
-			Return(nurse, nil).
+		authData.
+			On("SaveLoginAttempt", mock.MatchedBy(func(a auth.LoginAttemptCore) bool {
+				return a.Key == failures.Key && a.LockedUntil.After(time.Now())
+			})).
+			Return(nil).
+			Once()
+
+		authData.
+			On("SelectLoginAttemptByKey", "ip:"+ip).
+			Return(auth.LoginAttemptCore{}, errNotFound).
+			Once()
+
+		authData.
+			On("SaveLoginAttempt", mock.MatchedBy(func(a auth.LoginAttemptCore) bool {
+				return a.Key == "ip:"+ip && a.Failures == 1 && a.LockedUntil.IsZero()
+			})).
+			Return(nil).
+			Once()
+
+		_, err := business.Login("admin@mail.com", "wrong password", ip)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when InsertSession return server error", func(t *testing.T) {
+		expectNotLocked(admin.Email, ip)
+
+		accountBusiness.
+			On("FindAccountByEmail", admin.Email).
+			Return(admin, nil).
+			Once()
+
+		authData.
+			On("DeleteLoginAttemptByKey", "email:"+admin.Email).
+			Return(nil).
+			Once()
+
+		authData.
+			On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+			Return(auth.TwoFactorCore{}, errNotFound).
+			Once()
+
+		authData.
+			On("InsertSession", mock.AnythingOfType("auth.SessionCore")).
+			Return(0, errServer).
+			Once()
+
+		token, err := business.Login("admin@mail.com", "12345678", ip)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+		assert.Equal(t, "", token.AccessToken)
+	})
+}
+
+func TestUnlockAccount(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		authData.
+			On("DeleteLoginAttemptByKey", "email:"+admin.Email).
+			Return(nil).
+			Once()
+
+		err := business.UnlockAccount("Admin@Mail.com")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when DeleteLoginAttemptByKey error", func(t *testing.T) {
+		authData.
+			On("DeleteLoginAttemptByKey", "email:"+admin.Email).
+			Return(errServer).
+			Once()
+
+		err := business.UnlockAccount(admin.Email)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func expectNotLocked(email string, ip string) {
+	authData.
+		On("SelectLoginAttemptByKey", "email:"+email).
+		Return(auth.LoginAttemptCore{}, errNotFound).
+		Once()
+
+	authData.
+		On("SelectLoginAttemptByKey", "ip:"+ip).
+		Return(auth.LoginAttemptCore{}, errNotFound).
+		Once()
+}
+
+func expectFailedLogin(email string, ip string) {
+	authData.
+		On("SelectLoginAttemptByKey", "email:"+email).
+		Return(auth.LoginAttemptCore{}, errNotFound).
+		Once()
+
+	authData.
+		On("SelectLoginAttemptByKey", "ip:"+ip).
+		Return(auth.LoginAttemptCore{}, errNotFound).
+		Once()
+
+	authData.
+		On("SaveLoginAttempt", mock.AnythingOfType("auth.LoginAttemptCore")).
+		Return(nil).
+		Twice()
+}
+
+func TestRefresh(t *testing.T) {
+	t.Run("valid - when refresh token is valid", func(t *testing.T) {
+		authData.
+			On("SelectSessionByRefreshToken", mock.AnythingOfType("string")).
+			Return(session, nil).
+			Once()
+
+		accountBusiness.
+			On("FindAccountByUser", session.UserID, session.Role).
+			Return(admin, nil).
+			Once()
+
+		authData.
+			On("UpdateSession", mock.AnythingOfType("auth.SessionCore"), session.RefreshToken).
+			Return(nil).
+			Once()
+
+		token, err := business.Refresh("refresh-token")
+		assert.Nil(t, err)
+		assert.NotEqual(t, "", token.AccessToken)
+		assert.NotEqual(t, "refresh-token", token.RefreshToken)
+	})
+
+	t.Run("valid - when refresh token is unknown", func(t *testing.T) {
+		authData.
+			On("SelectSessionByRefreshToken", mock.AnythingOfType("string")).
+			Return(auth.SessionCore{}, errNotFound).
+			Once()
+
+		_, err := business.Refresh("refresh-token")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when session has expired", func(t *testing.T) {
+		expired := session
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+		authData.
+			On("SelectSessionByRefreshToken", mock.AnythingOfType("string")).
+			Return(expired, nil).
+			Once()
+
+		authData.
+			On("DeleteSessionById", expired.ID).
+			Return(nil).
+			Once()
+
+		_, err := business.Refresh("refresh-token")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when account has been removed", func(t *testing.T) {
+		authData.
+			On("SelectSessionByRefreshToken", mock.AnythingOfType("string")).
+			Return(session, nil).
+			Once()
+
+		accountBusiness.
+			On("FindAccountByUser", session.UserID, session.Role).
+			Return(accounts.AccountCore{}, errNotFound).
+			Once()
+
+		authData.
+			On("DeleteSessionById", session.ID).
+			Return(nil).
+			Once()
+
+		_, err := business.Refresh("refresh-token")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when refresh token was rotated in the meantime", func(t *testing.T) {
+		authData.
+			On("SelectSessionByRefreshToken", mock.AnythingOfType("string")).
+			Return(session, nil).
+			Once()
+
+		accountBusiness.
+			On("FindAccountByUser", session.UserID, session.Role).
+			Return(admin, nil).
+			Once()
+
+		authData.
+			On("UpdateSession", mock.AnythingOfType("auth.SessionCore"), session.RefreshToken).
+			Return(errNotFound).
+			Once()
+
+		_, err := business.Refresh("refresh-token")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when UpdateSession return server error", func(t *testing.T) {
+		authData.
+			On("SelectSessionByRefreshToken", mock.AnythingOfType("string")).
+			Return(session, nil).
+			Once()
+
+		accountBusiness.
+			On("FindAccountByUser", session.UserID, session.Role).
+			Return(admin, nil).
+			Once()
+
+		authData.
+			On("UpdateSession", mock.AnythingOfType("auth.SessionCore"), session.RefreshToken).
+			Return(errServer).
+			Once()
+
+		_, err := business.Refresh("refresh-token")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestLogout(t *testing.T) {
+	t.Run("valid - when logout success", func(t *testing.T) {
+		authData.
+			On("DeleteSessionById", 1).
+			Return(nil).
+			Once()
+
+		err := business.Logout(1)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when DeleteSessionById return server error", func(t *testing.T) {
+		authData.
+			On("DeleteSessionById", 1).
+			Return(errServer).
+			Once()
+
+		err := business.Logout(1)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	t.Run("valid - when revoking sessions success", func(t *testing.T) {
+		authData.
+			On("DeleteSessionsByUser", 1, "doctor").
+			Return(nil).
+			Once()
+
+		err := business.RevokeUserSessions(1, "doctor")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when DeleteSessionsByUser return server error", func(t *testing.T) {
+		authData.
+			On("DeleteSessionsByUser", 1, "doctor").
+			Return(errServer).
 			Once()
 
-		token, err := business.Login("nurse@mail.com", "12345678")
+		err := business.RevokeUserSessions(1, "doctor")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestValidateSession(t *testing.T) {
+	t.Run("valid - when session is active", func(t *testing.T) {
+		authData.
+			On("SelectSessionById", session.ID).
+			Return(session, nil).
+			Once()
+
+		accountBusiness.
+			On("FindAccountByUser", session.UserID, session.Role).
+			Return(admin, nil).
+			Once()
+
+		err := business.ValidateSession(session.ID, session.UserID, session.Role)
 		assert.Nil(t, err)
-		assert.NotEqual(t, "", token)
 	})
 
-	t.Run("valid - when nurse authentication failed", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
+	t.Run("valid - when session has been revoked", func(t *testing.T) {
+		authData.
+			On("SelectSessionById", session.ID).
+			Return(auth.SessionCore{}, errNotFound).
+			Once()
+
+		err := business.ValidateSession(session.ID, session.UserID, session.Role)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when session belongs to another user", func(t *testing.T) {
+		authData.
+			On("SelectSessionById", session.ID).
+			Return(session, nil).
+			Once()
+
+		err := business.ValidateSession(session.ID, 2, session.Role)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when account has been removed", func(t *testing.T) {
+		authData.
+			On("SelectSessionById", session.ID).
+			Return(session, nil).
+			Once()
+
+		accountBusiness.
+			On("FindAccountByUser", session.UserID, session.Role).
+			Return(accounts.AccountCore{}, errNotFound).
+			Once()
+
+		authData.
+			On("DeleteSessionById", session.ID).
+			Return(nil).
+			Once()
+
+		err := business.ValidateSession(session.ID, session.UserID, session.Role)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when SelectSessionById return server error", func(t *testing.T) {
+		authData.
+			On("SelectSessionById", session.ID).
+			Return(auth.SessionCore{}, errServer).
+			Once()
+
+		err := business.ValidateSession(session.ID, session.UserID, session.Role)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	t.Run("valid - when admin requests password reset", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByEmail", admin.Email).
+			Return(admin, nil).
+			Once()
+
+		authData.
+			On("DeletePasswordResetsByUser", admin.UserID, "admin").
+			Return(nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorByEmail", mock.AnythingOfType("string")).
-			Return(doctors.DoctorCore{}, errNotFound).
+		authData.
+			On("InsertPasswordReset", mock.MatchedBy(func(p auth.PasswordResetCore) bool {
+				return p.UserID == admin.UserID && p.Role == "admin" && p.ExpiresAt.After(time.Now())
+			})).
+			Return(nil).
 			Once()
 
-		nurseBusiness.
-			On("FindNurseByEmail", nurse.Email).
+		notifier.
+			On("SendPasswordResetToken", admin.Email, mock.AnythingOfType("string")).
+			Return(nil).
+			Once()
+
+		err := business.RequestPasswordReset(admin.Email)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when nurse requests password reset", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByEmail", nurse.Email).
 			Return(nurse, nil).
 			Once()
 
-		token, err := business.Login("nurse@mail.com", "wrong password")
+		authData.
+			On("DeletePasswordResetsByUser", nurse.UserID, "nurse").
+			Return(nil).
+			Once()
+
+		authData.
+			On("InsertPasswordReset", mock.AnythingOfType("auth.PasswordResetCore")).
+			Return(nil).
+			Once()
+
+		notifier.
+			On("SendPasswordResetToken", nurse.Email, mock.AnythingOfType("string")).
+			Return(nil).
+			Once()
+
+		err := business.RequestPasswordReset(nurse.Email)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when email is not registered", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByEmail", "unknown@mail.com").
+			Return(accounts.AccountCore{}, errNotFound).
+			Once()
+
+		err := business.RequestPasswordReset("unknown@mail.com")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when FindAccountByEmail return server error", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByEmail", admin.Email).
+			Return(accounts.AccountCore{}, errServer).
+			Once()
+
+		err := business.RequestPasswordReset(admin.Email)
 		assert.Error(t, err)
-		assert.Equal(t, "", token)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+
+	t.Run("valid - when notifier failed", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByEmail", admin.Email).
+			Return(admin, nil).
+			Once()
+
+		authData.
+			On("DeletePasswordResetsByUser", admin.UserID, "admin").
+			Return(nil).
+			Once()
+
+		authData.
+			On("InsertPasswordReset", mock.AnythingOfType("auth.PasswordResetCore")).
+			Return(nil).
+			Once()
+
+		notifier.
+			On("SendPasswordResetToken", admin.Email, mock.AnythingOfType("string")).
+			Return(errors.New("smtp down")).
+			Once()
+
+		err := business.RequestPasswordReset(admin.Email)
+		assert.Nil(t, err)
 	})
 
-	t.Run("valid - when FindNurseByEmail return server error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
+	t.Run("valid - token goes to the stored email", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByEmail", "ADMIN@MAIL.COM").
+			Return(admin, nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorByEmail", mock.AnythingOfType("string")).
-			Return(doctors.DoctorCore{}, errNotFound).
+		authData.
+			On("DeletePasswordResetsByUser", admin.UserID, "admin").
+			Return(nil).
 			Once()
 
-		nurseBusiness.
-			On("FindNurseByEmail", mock.AnythingOfType("string")).
-			Return(nurses.NurseCore{}, errServer).
+		authData.
+			On("InsertPasswordReset", mock.AnythingOfType("auth.PasswordResetCore")).
+			Return(nil).
 			Once()
 
-		token, err := business.Login("nurse@mail.com", "wrong password")
+		notifier.
+			On("SendPasswordResetToken", admin.Email, mock.AnythingOfType("string")).
+			Return(nil).
+			Once()
+
+		err := business.RequestPasswordReset("ADMIN@MAIL.COM")
+		assert.Nil(t, err)
+	})
+}
+
+func TestConfirmPasswordReset(t *testing.T) {
+	passwordReset := auth.PasswordResetCore{
+		ID:        1,
+		UserID:    doctor.UserID,
+		Role:      "doctor",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("valid - when token is valid", func(t *testing.T) {
+		authData.
+			On("SelectPasswordResetByToken", mock.AnythingOfType("string")).
+			Return(passwordReset, nil).
+			Once()
+
+		accountBusiness.
+			On("ResetAccountPassword", doctor.UserID, "doctor", "new password").
+			Return(nil).
+			Once()
+
+		authData.
+			On("DeletePasswordResetsByUser", doctor.UserID, "doctor").
+			Return(nil).
+			Once()
+
+		authData.
+			On("DeleteSessionsByUser", doctor.UserID, "doctor").
+			Return(nil).
+			Once()
+
+		err := business.ConfirmPasswordReset("token", "new password")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when token is unknown or already used", func(t *testing.T) {
+		authData.
+			On("SelectPasswordResetByToken", mock.AnythingOfType("string")).
+			Return(auth.PasswordResetCore{}, errNotFound).
+			Once()
+
+		err := business.ConfirmPasswordReset("token", "new password")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
+	})
+
+	t.Run("valid - when token has expired", func(t *testing.T) {
+		expired := passwordReset
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+		authData.
+			On("SelectPasswordResetByToken", mock.AnythingOfType("string")).
+			Return(expired, nil).
+			Once()
+
+		authData.
+			On("DeletePasswordResetsByUser", doctor.UserID, "doctor").
+			Return(nil).
+			Once()
+
+		err := business.ConfirmPasswordReset("token", "new password")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
+	})
+
+	t.Run("valid - when ResetAccountPassword error", func(t *testing.T) {
+		authData.
+			On("SelectPasswordResetByToken", mock.AnythingOfType("string")).
+			Return(passwordReset, nil).
+			Once()
+
+		accountBusiness.
+			On("ResetAccountPassword", doctor.UserID, "doctor", "new password").
+			Return(errServer).
+			Once()
+
+		err := business.ConfirmPasswordReset("token", "new password")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+// RFC 6238 test secret "12345678901234567890" in base32
+const totpSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func currentTOTPCode() (string, int64) {
+	step := totp.Step(time.Now())
+	return totp.Code([]byte("12345678901234567890"), step, totp.DIGITS), step
+}
+
+func enabledTwoFactor() auth.TwoFactorCore {
+	return auth.TwoFactorCore{
+		ID:        1,
+		UserID:    admin.UserID,
+		Role:      admin.Role,
+		Secret:    totpSecret,
+		EnabledAt: time.Now().Add(-time.Hour),
+	}
+}
+
+// loginWithPassword goes through the first login step of an admin with 2FA enabled
+func loginWithPassword(t *testing.T, ip string) string {
+	expectNotLocked(admin.Email, ip)
+
+	accountBusiness.
+		On("FindAccountByEmail", admin.Email).
+		Return(admin, nil).
+		Once()
+
+	authData.
+		On("DeleteLoginAttemptByKey", "email:"+admin.Email).
+		Return(nil).
+		Once()
+
+	authData.
+		On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+		Return(enabledTwoFactor(), nil).
+		Once()
+
+	token, err := business.Login(admin.Email, "12345678", ip)
+	assert.Nil(t, err)
+	assert.Equal(t, "", token.AccessToken)
+	return token.MFAToken
+}
+
+func TestLoginTwoFactor(t *testing.T) {
+	ip := "127.0.0.1"
+	twoFactorKey := "2fa:admin:1"
+
+	expectTwoFactorNotLocked := func() {
+		authData.
+			On("SelectLoginAttemptByKey", twoFactorKey).
+			Return(auth.LoginAttemptCore{}, errNotFound).
+			Once()
+
+		authData.
+			On("SelectLoginAttemptByKey", "ip:"+ip).
+			Return(auth.LoginAttemptCore{}, errNotFound).
+			Once()
+	}
+
+	expectFailedTwoFactor := func() {
+		expectTwoFactorNotLocked()
+
+		authData.
+			On("SaveLoginAttempt", mock.AnythingOfType("auth.LoginAttemptCore")).
+			Return(nil).
+			Twice()
+	}
+
+	t.Run("valid - when password step asks for a two-factor code", func(t *testing.T) {
+		mfaToken := loginWithPassword(t, ip)
+		assert.NotEqual(t, "", mfaToken)
+	})
+
+	t.Run("valid - when TOTP code is correct", func(t *testing.T) {
+		mfaToken := loginWithPassword(t, ip)
+		code, step := currentTOTPCode()
+
+		expectTwoFactorNotLocked()
+
+		authData.
+			On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+			Return(enabledTwoFactor(), nil).
+			Once()
+
+		authData.
+			On("SaveTwoFactor", mock.MatchedBy(func(tf auth.TwoFactorCore) bool {
+				return tf.LastUsedStep == step
+			})).
+			Return(nil).
+			Once()
+
+		authData.
+			On("DeleteLoginAttemptByKey", twoFactorKey).
+			Return(nil).
+			Once()
+
+		authData.
+			On("InsertSession", mock.AnythingOfType("auth.SessionCore")).
+			Return(1, nil).
+			Once()
+
+		token, err := business.LoginTwoFactor(mfaToken, code, ip)
+		assert.Nil(t, err)
+		assert.NotEqual(t, "", token.AccessToken)
+		assert.NotEqual(t, "", token.RefreshToken)
+	})
+
+	t.Run("valid - when TOTP code has already been used", func(t *testing.T) {
+		mfaToken := loginWithPassword(t, ip)
+		code, step := currentTOTPCode()
+
+		used := enabledTwoFactor()
+		used.LastUsedStep = step
+
+		expectTwoFactorNotLocked()
+
+		authData.
+			On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+			Return(used, nil).
+			Once()
+
+		expectFailedTwoFactor()
+
+		_, err := business.LoginTwoFactor(mfaToken, code, ip)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when recovery code is used", func(t *testing.T) {
+		mfaToken := loginWithPassword(t, ip)
+
+		expectTwoFactorNotLocked()
+
+		authData.
+			On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+			Return(enabledTwoFactor(), nil).
+			Once()
+
+		authData.
+			On("DeleteRecoveryCode", admin.UserID, admin.Role, mock.AnythingOfType("string")).
+			Return(nil).
+			Once()
+
+		authData.
+			On("DeleteLoginAttemptByKey", twoFactorKey).
+			Return(nil).
+			Once()
+
+		authData.
+			On("InsertSession", mock.AnythingOfType("auth.SessionCore")).
+			Return(1, nil).
+			Once()
+
+		token, err := business.LoginTwoFactor(mfaToken, "a1b2c3d4e5", ip)
+		assert.Nil(t, err)
+		assert.NotEqual(t, "", token.AccessToken)
+	})
+
+	t.Run("valid - when code is wrong", func(t *testing.T) {
+		mfaToken := loginWithPassword(t, ip)
+
+		expectTwoFactorNotLocked()
+
+		authData.
+			On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+			Return(enabledTwoFactor(), nil).
+			Once()
+
+		authData.
+			On("DeleteRecoveryCode", admin.UserID, admin.Role, mock.AnythingOfType("string")).
+			Return(errNotFound).
+			Once()
+
+		expectFailedTwoFactor()
+
+		_, err := business.LoginTwoFactor(mfaToken, "wrong code", ip)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when two-factor is locked", func(t *testing.T) {
+		mfaToken := loginWithPassword(t, ip)
+
+		authData.
+			On("SelectLoginAttemptByKey", twoFactorKey).
+			Return(auth.LoginAttemptCore{LockedUntil: time.Now().Add(time.Minute)}, nil).
+			Once()
+
+		_, err := business.LoginTwoFactor(mfaToken, "123456", ip)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindTooManyRequests, errors.Kind(err))
+	})
+
+	t.Run("valid - when mfa token is invalid", func(t *testing.T) {
+		_, err := business.LoginTwoFactor("not a token", "123456", ip)
 		assert.Error(t, err)
-		assert.Equal(t, "", token)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
 	})
+}
 
-	t.Run("valid - when no account found", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
+func TestEnrollTwoFactor(t *testing.T) {
+	t.Run("valid - when two-factor is not set up yet", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByUser", admin.UserID, admin.Role).
+			Return(admin, nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorByEmail", mock.AnythingOfType("string")).
-			Return(doctors.DoctorCore{}, errNotFound).
+		authData.
+			On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+			Return(auth.TwoFactorCore{}, errNotFound).
+			Once()
+
+		authData.
+			On("SaveTwoFactor", mock.MatchedBy(func(tf auth.TwoFactorCore) bool {
+				return tf.UserID == admin.UserID && tf.Secret != "" && tf.EnabledAt.IsZero()
+			})).
+			Return(nil).
+			Once()
+
+		enrollment, err := business.EnrollTwoFactor(admin.UserID, admin.Role)
+		assert.Nil(t, err)
+		assert.NotEqual(t, "", enrollment.Secret)
+		assert.Contains(t, enrollment.URL, "otpauth://totp/")
+	})
+
+	t.Run("valid - when two-factor is already enabled", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByUser", admin.UserID, admin.Role).
+			Return(admin, nil).
 			Once()
 
-		nurseBusiness.
-			On("FindNurseByEmail", mock.AnythingOfType("string")).
-			Return(nurses.NurseCore{}, errNotFound).
+		authData.
+			On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+			Return(enabledTwoFactor(), nil).
 			Once()
 
-		token, err := business.Login("nurse@mail.com", "wrong password")
+		_, err := business.EnrollTwoFactor(admin.UserID, admin.Role)
 		assert.Error(t, err)
-		assert.Equal(t, "", token)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
+}
+
+func TestVerifyTwoFactor(t *testing.T) {
+	pending := enabledTwoFactor()
+	pending.EnabledAt = time.Time{}
+
+	t.Run("valid - when code is correct", func(t *testing.T) {
+		code, step := currentTOTPCode()
+
+		authData.
+			On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+			Return(pending, nil).
+			Once()
+
+		authData.
+			On("ReplaceRecoveryCodes", admin.UserID, admin.Role, mock.MatchedBy(func(codes []string) bool {
+				return len(codes) == authBusiness.RECOVERY_CODES_COUNT
+			})).
+			Return(nil).
+			Once()
+
+		authData.
+			On("SaveTwoFactor", mock.MatchedBy(func(tf auth.TwoFactorCore) bool {
+				return !tf.EnabledAt.IsZero() && tf.LastUsedStep == step
+			})).
+			Return(nil).
+			Once()
+
+		recoveryCodes, err := business.VerifyTwoFactor(admin.UserID, admin.Role, code)
+		assert.Nil(t, err)
+		assert.Len(t, recoveryCodes, authBusiness.RECOVERY_CODES_COUNT)
+	})
+
+	t.Run("valid - when code is wrong", func(t *testing.T) {
+		authData.
+			On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+			Return(pending, nil).
+			Once()
+
+		_, err := business.VerifyTwoFactor(admin.UserID, admin.Role, "000000")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when enrollment has not been started", func(t *testing.T) {
+		authData.
+			On("SelectTwoFactorByUser", admin.UserID, admin.Role).
+			Return(auth.TwoFactorCore{}, errNotFound).
+			Once()
+
+		_, err := business.VerifyTwoFactor(admin.UserID, admin.Role, "123456")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+}
+
+func TestRequestPatientCode(t *testing.T) {
+	ip := "127.0.0.1"
+
+	t.Run("valid - when patient requests login code", func(t *testing.T) {
+		expectNotLockedKeys("nik:"+patient.NIK, "ip:"+ip)
+
+		patientBusiness.
+			On("FindPatientByNIK", patient.NIK).
+			Return(patient, nil).
+			Once()
+
+		authData.
+			On("SelectPatientCodeByPatient", patient.ID).
+			Return(auth.PatientCodeCore{}, errNotFound).
+			Once()
+
+		authData.
+			On("DeletePatientCodesByPatient", patient.ID).
+			Return(nil).
+			Once()
+
+		authData.
+			On("InsertPatientCode", mock.MatchedBy(func(p auth.PatientCodeCore) bool {
+				return p.PatientID == patient.ID && len(p.Code) == 64 && p.ExpiresAt.After(time.Now())
+			})).
+			Return(nil).
+			Once()
+
+		notifier.
+			On("SendPatientCode", patient.Phone, mock.MatchedBy(func(code string) bool {
+				return len(code) == authBusiness.PATIENT_CODE_DIGITS
+			})).
+			Return(nil).
+			Once()
+
+		err := business.RequestPatientCode(patient.NIK, ip)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when login code can not be delivered", func(t *testing.T) {
+		expectNotLockedKeys("nik:"+patient.NIK, "ip:"+ip)
+
+		patientBusiness.
+			On("FindPatientByNIK", patient.NIK).
+			Return(patient, nil).
+			Once()
+
+		authData.
+			On("SelectPatientCodeByPatient", patient.ID).
+			Return(auth.PatientCodeCore{}, errNotFound).
+			Once()
+
+		authData.
+			On("DeletePatientCodesByPatient", patient.ID).
+			Return(nil).
+			Once()
+
+		authData.
+			On("InsertPatientCode", mock.AnythingOfType("auth.PatientCodeCore")).
+			Return(nil).
+			Once()
+
+		notifier.
+			On("SendPatientCode", patient.Phone, mock.AnythingOfType("string")).
+			Return(errors.New("gateway down")).
+			Once()
+
+		err := business.RequestPatientCode(patient.NIK, ip)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when NIK is not registered", func(t *testing.T) {
+		expectNotLockedKeys("nik:unknown", "ip:"+ip)
+
+		patientBusiness.
+			On("FindPatientByNIK", "unknown").
+			Return(patients.PatientCore{}, errNotFound).
+			Once()
+
+		err := business.RequestPatientCode("unknown", ip)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when code was sent recently", func(t *testing.T) {
+		expectNotLockedKeys("nik:"+patient.NIK, "ip:"+ip)
+
+		patientBusiness.
+			On("FindPatientByNIK", patient.NIK).
+			Return(patient, nil).
+			Once()
+
+		authData.
+			On("SelectPatientCodeByPatient", patient.ID).
+			Return(auth.PatientCodeCore{CreatedAt: time.Now()}, nil).
+			Once()
+
+		sent := len(notifier.Calls)
+		err := business.RequestPatientCode(patient.NIK, ip)
+		assert.Nil(t, err)
+		assert.Equal(t, sent, len(notifier.Calls))
+	})
+
+	t.Run("valid - when FindPatientByNIK return server error", func(t *testing.T) {
+		expectNotLockedKeys("nik:"+patient.NIK, "ip:"+ip)
+
+		patientBusiness.
+			On("FindPatientByNIK", patient.NIK).
+			Return(patients.PatientCore{}, errServer).
+			Once()
+
+		err := business.RequestPatientCode(patient.NIK, ip)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestLoginPatient(t *testing.T) {
+	ip := "127.0.0.1"
+	code := "123456"
+
+	sum := sha256.Sum256([]byte(code))
+	patientCode := auth.PatientCodeCore{
+		ID:        1,
+		PatientID: patient.ID,
+		Code:      hex.EncodeToString(sum[:]),
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	t.Run("valid - when code is valid", func(t *testing.T) {
+		expectNotLockedKeys("nik:"+patient.NIK, "ip:"+ip)
+
+		patientBusiness.
+			On("FindPatientByNIK", patient.NIK).
+			Return(patient, nil).
+			Once()
+
+		authData.
+			On("SelectPatientCodeByPatient", patient.ID).
+			Return(patientCode, nil).
+			Once()
+
+		authData.
+			On("DeletePatientCodesByPatient", patient.ID).
+			Return(nil).
+			Once()
+
+		authData.
+			On("DeleteLoginAttemptByKey", "nik:"+patient.NIK).
+			Return(nil).
+			Once()
+
+		authData.
+			On("InsertSession", mock.MatchedBy(func(s auth.SessionCore) bool {
+				return s.UserID == patient.ID && s.Role == "patient"
+			})).
+			Return(1, nil).
+			Once()
+
+		token, err := business.LoginPatient(patient.NIK, code, ip)
+		assert.Nil(t, err)
+		assert.NotEqual(t, "", token.AccessToken)
+		assert.NotEqual(t, "", token.RefreshToken)
+	})
+
+	t.Run("valid - when code is wrong", func(t *testing.T) {
+		expectNotLockedKeys("nik:"+patient.NIK, "ip:"+ip)
+		expectFailedLoginKeys("nik:"+patient.NIK, "ip:"+ip)
+
+		patientBusiness.
+			On("FindPatientByNIK", patient.NIK).
+			Return(patient, nil).
+			Once()
+
+		authData.
+			On("SelectPatientCodeByPatient", patient.ID).
+			Return(patientCode, nil).
+			Once()
+
+		token, err := business.LoginPatient(patient.NIK, "654321", ip)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+		assert.Equal(t, "", token.AccessToken)
+	})
+
+	t.Run("valid - when code has expired", func(t *testing.T) {
+		expectNotLockedKeys("nik:"+patient.NIK, "ip:"+ip)
+		expectFailedLoginKeys("nik:"+patient.NIK, "ip:"+ip)
+
+		expired := patientCode
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+		patientBusiness.
+			On("FindPatientByNIK", patient.NIK).
+			Return(patient, nil).
+			Once()
+
+		authData.
+			On("SelectPatientCodeByPatient", patient.ID).
+			Return(expired, nil).
+			Once()
+
+		_, err := business.LoginPatient(patient.NIK, code, ip)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when NIK is not registered", func(t *testing.T) {
+		expectNotLockedKeys("nik:unknown", "ip:"+ip)
+		expectFailedLoginKeys("nik:unknown", "ip:"+ip)
+
+		patientBusiness.
+			On("FindPatientByNIK", "unknown").
+			Return(patients.PatientCore{}, errNotFound).
+			Once()
+
+		_, err := business.LoginPatient("unknown", code, ip)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+}
+
+func expectNotLockedKeys(keys ...string) {
+	for _, key := range keys {
+		authData.
+			On("SelectLoginAttemptByKey", key).
+			Return(auth.LoginAttemptCore{}, errNotFound).
+			Once()
+	}
+}
+
+func expectFailedLoginKeys(keys ...string) {
+	expectNotLockedKeys(keys...)
 
+	authData.
+		On("SaveLoginAttempt", mock.AnythingOfType("auth.LoginAttemptCore")).
+		Return(nil).
+		Times(len(keys))
 }
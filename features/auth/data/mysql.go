@@ -0,0 +1,335 @@
+package data
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/auth"
+	"gorm.io/gorm"
+)
+
+type mySQLRepo struct {
+	db *gorm.DB
+}
+
+func NewMySQLRepo(db *gorm.DB) *mySQLRepo {
+	return &mySQLRepo{db}
+}
+
+func (r *mySQLRepo) SelectSessionById(id int) (auth.SessionCore, error) {
+	const op errors.Op = "auth.data.SelectSessionById"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	var session Session
+	err := r.db.First(&session, id).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMessage = "Session not found"
+			return auth.SessionCore{}, errors.E(err, op, errMessage, errors.KindNotFound)
+		default:
+			return auth.SessionCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+	return session.toSessionCore(), nil
+}
+
+func (r *mySQLRepo) SelectSessionByRefreshToken(refreshToken string) (auth.SessionCore, error) {
+	const op errors.Op = "auth.data.SelectSessionByRefreshToken"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	var session Session
+	err := r.db.Where("refresh_token = ?", refreshToken).First(&session).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMessage = "Session not found"
+			return auth.SessionCore{}, errors.E(err, op, errMessage, errors.KindNotFound)
+		default:
+			return auth.SessionCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+	return session.toSessionCore(), nil
+}
+
+func (r *mySQLRepo) InsertSession(session auth.SessionCore) (int, error) {
+	const op errors.Op = "auth.data.InsertSession"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	data := Session{
+		UserID:       session.UserID,
+		Role:         session.Role,
+		RefreshToken: session.RefreshToken,
+		ExpiresAt:    session.ExpiresAt,
+	}
+
+	err := r.db.Create(&data).Error
+	if err != nil {
+		return 0, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return int(data.ID), nil
+}
+
+func (r *mySQLRepo) UpdateSession(session auth.SessionCore, oldRefreshToken string) error {
+	const op errors.Op = "auth.data.UpdateSession"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	updated := map[string]interface{}{
+		"refresh_token": session.RefreshToken,
+		"expires_at":    session.ExpiresAt,
+	}
+
+	result := r.db.Model(&Session{}).
+		Where("id = ? AND refresh_token = ?", session.ID, oldRefreshToken).
+		Updates(updated)
+	if result.Error != nil {
+		return errors.E(result.Error, op, errMessage, errors.KindServerError)
+	}
+	if result.RowsAffected == 0 {
+		errMessage = "Session not found"
+		return errors.E(errors.New("Refresh token was already used"), op, errMessage, errors.KindNotFound)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) DeleteSessionById(id int) error {
+	const op errors.Op = "auth.data.DeleteSessionById"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.Delete(&Session{}, id).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) DeleteSessionsByUser(userId int, role string) error {
+	const op errors.Op = "auth.data.DeleteSessionsByUser"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.Where("user_id = ? AND role = ?", userId, role).Delete(&Session{}).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) SelectPasswordResetByToken(token string) (auth.PasswordResetCore, error) {
+	const op errors.Op = "auth.data.SelectPasswordResetByToken"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	var passwordReset PasswordReset
+	err := r.db.Where("token = ?", token).First(&passwordReset).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMessage = "Password reset token not found"
+			return auth.PasswordResetCore{}, errors.E(err, op, errMessage, errors.KindNotFound)
+		default:
+			return auth.PasswordResetCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+	return passwordReset.toPasswordResetCore(), nil
+}
+
+func (r *mySQLRepo) InsertPasswordReset(passwordReset auth.PasswordResetCore) error {
+	const op errors.Op = "auth.data.InsertPasswordReset"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	data := PasswordReset{
+		UserID:    passwordReset.UserID,
+		Role:      passwordReset.Role,
+		Token:     passwordReset.Token,
+		ExpiresAt: passwordReset.ExpiresAt,
+	}
+
+	err := r.db.Create(&data).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) DeletePasswordResetsByUser(userId int, role string) error {
+	const op errors.Op = "auth.data.DeletePasswordResetsByUser"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.Where("user_id = ? AND role = ?", userId, role).Delete(&PasswordReset{}).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) SelectPatientCodeByPatient(patientId int) (auth.PatientCodeCore, error) {
+	const op errors.Op = "auth.data.SelectPatientCodeByPatient"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	var patientCode PatientCode
+	err := r.db.Where("patient_id = ?", patientId).Order("id DESC").First(&patientCode).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMessage = "Patient code not found"
+			return auth.PatientCodeCore{}, errors.E(err, op, errMessage, errors.KindNotFound)
+		default:
+			return auth.PatientCodeCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+	return patientCode.toPatientCodeCore(), nil
+}
+
+func (r *mySQLRepo) InsertPatientCode(patientCode auth.PatientCodeCore) error {
+	const op errors.Op = "auth.data.InsertPatientCode"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	data := PatientCode{
+		PatientID: patientCode.PatientID,
+		Code:      patientCode.Code,
+		ExpiresAt: patientCode.ExpiresAt,
+	}
+
+	err := r.db.Create(&data).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) DeletePatientCodesByPatient(patientId int) error {
+	const op errors.Op = "auth.data.DeletePatientCodesByPatient"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.Where("patient_id = ?", patientId).Delete(&PatientCode{}).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) SelectLoginAttemptByKey(key string) (auth.LoginAttemptCore, error) {
+	const op errors.Op = "auth.data.SelectLoginAttemptByKey"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	var attempt LoginAttempt
+	err := r.db.Where("`key` = ?", key).First(&attempt).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMessage = "Login attempt not found"
+			return auth.LoginAttemptCore{}, errors.E(err, op, errMessage, errors.KindNotFound)
+		default:
+			return auth.LoginAttemptCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+	return attempt.toLoginAttemptCore(), nil
+}
+
+func (r *mySQLRepo) SaveLoginAttempt(attempt auth.LoginAttemptCore) error {
+	const op errors.Op = "auth.data.SaveLoginAttempt"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	data := LoginAttempt{
+		ID:           uint(attempt.ID),
+		Key:          attempt.Key,
+		Failures:     attempt.Failures,
+		LastFailedAt: attempt.LastFailedAt,
+	}
+	if !attempt.LockedUntil.IsZero() {
+		data.LockedUntil = &attempt.LockedUntil
+	}
+
+	err := r.db.Save(&data).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) DeleteLoginAttemptByKey(key string) error {
+	const op errors.Op = "auth.data.DeleteLoginAttemptByKey"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.Where("`key` = ?", key).Delete(&LoginAttempt{}).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) SelectTwoFactorByUser(userId int, role string) (auth.TwoFactorCore, error) {
+	const op errors.Op = "auth.data.SelectTwoFactorByUser"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	var twoFactor TwoFactor
+	err := r.db.Where("user_id = ? AND role = ?", userId, role).First(&twoFactor).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMessage = "Two-factor authentication is not set up"
+			return auth.TwoFactorCore{}, errors.E(err, op, errMessage, errors.KindNotFound)
+		default:
+			return auth.TwoFactorCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+	return twoFactor.toTwoFactorCore(), nil
+}
+
+func (r *mySQLRepo) SaveTwoFactor(twoFactor auth.TwoFactorCore) error {
+	const op errors.Op = "auth.data.SaveTwoFactor"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	data := TwoFactor{
+		UserID:       twoFactor.UserID,
+		Role:         twoFactor.Role,
+		Secret:       twoFactor.Secret,
+		LastUsedStep: twoFactor.LastUsedStep,
+	}
+	data.ID = uint(twoFactor.ID)
+	if !twoFactor.EnabledAt.IsZero() {
+		data.EnabledAt = &twoFactor.EnabledAt
+	}
+
+	err := r.db.Save(&data).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) ReplaceRecoveryCodes(userId int, role string, codes []string) error {
+	const op errors.Op = "auth.data.ReplaceRecoveryCodes"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	data := []RecoveryCode{}
+	for _, code := range codes {
+		data = append(data, RecoveryCode{UserID: userId, Role: role, Code: code})
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND role = ?", userId, role).Delete(&RecoveryCode{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&data).Error
+	})
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) DeleteRecoveryCode(userId int, role string, code string) error {
+	const op errors.Op = "auth.data.DeleteRecoveryCode"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	result := r.db.Where("user_id = ? AND role = ? AND code = ?", userId, role, code).Delete(&RecoveryCode{})
+	if result.Error != nil {
+		return errors.E(result.Error, op, errMessage, errors.KindServerError)
+	}
+
+	// Deleting is how a code gets used, so nothing deleted means it was invalid or already used
+	if result.RowsAffected == 0 {
+		errMessage = "Recovery code not found"
+		return errors.E(errors.New("Recovery code not found"), op, errMessage, errors.KindNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package data
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/auth"
+	"gorm.io/gorm"
+)
+
+type Session struct {
+	gorm.Model
+	UserID       int       `gorm:"not null;index:idx_session_user"`
+	Role         string    `gorm:"type:varchar(16);not null;index:idx_session_user"`
+	RefreshToken string    `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt    time.Time `gorm:"not null"`
+}
+
+func (s Session) toSessionCore() auth.SessionCore {
+	return auth.SessionCore{
+		ID:           int(s.ID),
+		UserID:       s.UserID,
+		Role:         s.Role,
+		RefreshToken: s.RefreshToken,
+		ExpiresAt:    s.ExpiresAt,
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
+
+type PasswordReset struct {
+	gorm.Model
+	UserID    int       `gorm:"not null;index:idx_password_reset_user"`
+	Role      string    `gorm:"type:varchar(16);not null;index:idx_password_reset_user"`
+	Token     string    `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+}
+
+func (p PasswordReset) toPasswordResetCore() auth.PasswordResetCore {
+	return auth.PasswordResetCore{
+		ID:        int(p.ID),
+		UserID:    p.UserID,
+		Role:      p.Role,
+		Token:     p.Token,
+		ExpiresAt: p.ExpiresAt,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+type PatientCode struct {
+	gorm.Model
+	PatientID int       `gorm:"not null;index"`
+	Code      string    `gorm:"type:varchar(64);not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+}
+
+func (p PatientCode) toPatientCodeCore() auth.PatientCodeCore {
+	return auth.PatientCodeCore{
+		ID:        int(p.ID),
+		PatientID: p.PatientID,
+		Code:      p.Code,
+		ExpiresAt: p.ExpiresAt,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+type LoginAttempt struct {
+	ID           uint      `gorm:"primarykey"`
+	Key          string    `gorm:"type:varchar(255);uniqueIndex;not null"`
+	Failures     int       `gorm:"not null;default:0"`
+	LastFailedAt time.Time `gorm:"not null"`
+	LockedUntil  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (l LoginAttempt) toLoginAttemptCore() auth.LoginAttemptCore {
+	attempt := auth.LoginAttemptCore{
+		ID:           int(l.ID),
+		Key:          l.Key,
+		Failures:     l.Failures,
+		LastFailedAt: l.LastFailedAt,
+	}
+	if l.LockedUntil != nil {
+		attempt.LockedUntil = *l.LockedUntil
+	}
+	return attempt
+}
+
+type TwoFactor struct {
+	gorm.Model
+	UserID       int    `gorm:"not null;uniqueIndex:idx_two_factor_user"`
+	Role         string `gorm:"type:varchar(16);not null;uniqueIndex:idx_two_factor_user"`
+	Secret       string `gorm:"type:varchar(64);not null"`
+	EnabledAt    *time.Time
+	LastUsedStep int64 `gorm:"not null;default:0"`
+}
+
+func (t TwoFactor) toTwoFactorCore() auth.TwoFactorCore {
+	twoFactor := auth.TwoFactorCore{
+		ID:           int(t.ID),
+		UserID:       t.UserID,
+		Role:         t.Role,
+		Secret:       t.Secret,
+		LastUsedStep: t.LastUsedStep,
+	}
+	if t.EnabledAt != nil {
+		twoFactor.EnabledAt = *t.EnabledAt
+	}
+	return twoFactor
+}
+
+type RecoveryCode struct {
+	ID        uint   `gorm:"primarykey"`
+	UserID    int    `gorm:"not null;index:idx_recovery_code_user"`
+	Role      string `gorm:"type:varchar(16);not null;index:idx_recovery_code_user"`
+	Code      string `gorm:"type:varchar(64);not null"` // sha256 hash
+	CreatedAt time.Time
+}
@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/features/auth"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications/channels"
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+)
+
+// channelNotifier delivers tokens through the notification channels, reset tokens by
+// email and patient codes to the phone
+type channelNotifier struct {
+	mail  notifications.IChannel // nil when SMTP is not configured
+	phone notifications.IChannel
+}
+
+// New returns the notifier matching the configured notification channel. Only the log
+// channel, which channels.New allows in development only, gets the log notifier. Anywhere
+// else reset tokens must be mailed, so SMTP has to be configured.
+func New(channel notifications.IChannel) (auth.INotifier, error) {
+	if channel.Name() == channels.ChannelLog {
+		return NewLogNotifier(), nil
+	}
+
+	if config.ENV.SMTP_HOST == "" {
+		return nil, errors.New("SMTP_HOST is not set, password reset tokens can not be delivered")
+	}
+	mail := channels.
+		NewEmailChannel(config.ENV.SMTP_HOST, config.ENV.SMTP_PORT, config.ENV.SMTP_USERNAME, config.ENV.SMTP_PASSWORD, config.ENV.SMTP_FROM).
+		WithSubject("Password reset")
+	return NewChannelNotifier(mail, channel), nil
+}
+
+func NewChannelNotifier(mail notifications.IChannel, phone notifications.IChannel) *channelNotifier {
+	return &channelNotifier{mail: mail, phone: phone}
+}
+
+func (c *channelNotifier) SendPasswordResetToken(email string, token string) error {
+	if c.mail == nil {
+		return errors.New("no email channel is configured for password reset tokens")
+	}
+
+	message := fmt.Sprintf("Your password reset token is %s. Ignore this message if you did not ask to reset your password.", token)
+	return c.mail.Send(email, message)
+}
+
+func (c *channelNotifier) SendPatientCode(phone string, code string) error {
+	recipient := c.phone.Recipient(patients.PatientCore{Phone: phone})
+	if recipient == "" {
+		return fmt.Errorf("patient can not be reached on %s", c.phone.Name())
+	}
+
+	message := fmt.Sprintf("Your login code is %s. Do not share it with anyone.", code)
+	return c.phone.Send(recipient, message)
+}
@@ -0,0 +1,78 @@
+package notifier_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/features/auth/notifier"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications/channels"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.log")
+	file := channels.NewFileChannel(path)
+
+	t.Run("valid - patient code goes to the phone channel", func(t *testing.T) {
+		n := notifier.NewChannelNotifier(nil, file)
+
+		err := n.SendPatientCode("0811-2233", "123456")
+		assert.Nil(t, err)
+
+		content, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Contains(t, string(content), `"to":"0811-2233"`)
+		assert.Contains(t, string(content), "123456")
+	})
+
+	t.Run("error - when patient has no phone", func(t *testing.T) {
+		n := notifier.NewChannelNotifier(nil, file)
+
+		err := n.SendPatientCode("", "123456")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - reset token goes to the mail channel", func(t *testing.T) {
+		mailPath := filepath.Join(t.TempDir(), "mails.log")
+		n := notifier.NewChannelNotifier(channels.NewFileChannel(mailPath), file)
+
+		err := n.SendPasswordResetToken("admin@mail.com", "reset-token")
+		assert.Nil(t, err)
+
+		content, err := os.ReadFile(mailPath)
+		assert.Nil(t, err)
+		assert.True(t, strings.Contains(string(content), "reset-token"))
+	})
+
+	t.Run("error - when no mail channel is configured", func(t *testing.T) {
+		n := notifier.NewChannelNotifier(nil, file)
+
+		err := n.SendPasswordResetToken("admin@mail.com", "reset-token")
+		assert.Error(t, err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("valid - log channel keeps the log notifier", func(t *testing.T) {
+		n, err := notifier.New(channels.NewLogChannel())
+		assert.Nil(t, err)
+		assert.Equal(t, notifier.NewLogNotifier(), n)
+	})
+
+	t.Run("valid - when SMTP is configured", func(t *testing.T) {
+		config.ENV.SMTP_HOST = "localhost"
+		defer func() { config.ENV.SMTP_HOST = "" }()
+
+		n, err := notifier.New(channels.NewSMSChannel("http://localhost", "secret"))
+		assert.Nil(t, err)
+		assert.NotNil(t, n)
+	})
+
+	t.Run("error - when SMTP is not configured", func(t *testing.T) {
+		_, err := notifier.New(channels.NewSMSChannel("http://localhost", "secret"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,23 @@
+package notifier
+
+import (
+	"log"
+)
+
+// logNotifier delivers nothing and only notes in the server log that a token was issued,
+// meant for local development. It is never used outside APP_ENV=development, see New.
+type logNotifier struct{}
+
+func NewLogNotifier() *logNotifier {
+	return &logNotifier{}
+}
+
+func (l *logNotifier) SendPasswordResetToken(email string, token string) error {
+	log.Printf("Password reset token issued for %s, not delivered by the log notifier\n", email)
+	return nil
+}
+
+func (l *logNotifier) SendPatientCode(phone string, code string) error {
+	log.Printf("Patient login code issued for %s, not delivered by the log notifier\n", phone)
+	return nil
+}
@@ -40,9 +40,261 @@ func (p *AuthPresetation) PostLogin(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnauthorized))
 	}
 
-	token, err := p.business.Login(req.Email, req.Password)
+	token, err := p.business.Login(req.Email, req.Password, c.RealIP())
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
+
+	if token.MFAToken != "" {
+		message = "Two-factor code required"
+	}
 	return response.Success(c, status, message, response.Token(token))
 }
+
+func (p *AuthPresetation) PostLoginTwoFactor(c echo.Context) error {
+	status := http.StatusOK
+	message := "Login success"
+	const op errors.Op = "auth.presentation.PostLoginTwoFactor"
+	var errMessage errors.ErrClientMessage
+
+	var req request.LoginTwoFactorRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid two-factor token or code"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnauthorized))
+	}
+
+	token, err := p.business.LoginTwoFactor(req.MFAToken, req.Code, c.RealIP())
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.Token(token))
+}
+
+func (p *AuthPresetation) PostPatientCode(c echo.Context) error {
+	status := http.StatusOK
+	message := "If the NIK is registered, a login code has been sent to the patient's phone"
+	const op errors.Op = "auth.presentation.PostPatientCode"
+	var errMessage errors.ErrClientMessage
+
+	var req request.PatientCodeRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid NIK"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err := p.business.RequestPatientCode(req.NIK, c.RealIP())
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (p *AuthPresetation) PostPatientLogin(c echo.Context) error {
+	status := http.StatusOK
+	message := "Login success"
+	const op errors.Op = "auth.presentation.PostPatientLogin"
+	var errMessage errors.ErrClientMessage
+
+	var req request.PatientLoginRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid NIK or login code"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnauthorized))
+	}
+
+	token, err := p.business.LoginPatient(req.NIK, req.Code, c.RealIP())
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.Token(token))
+}
+
+func (p *AuthPresetation) PostRefresh(c echo.Context) error {
+	status := http.StatusOK
+	message := "Token refreshed"
+	const op errors.Op = "auth.presentation.PostRefresh"
+	var errMessage errors.ErrClientMessage
+
+	var req request.RefreshRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid refresh token"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnauthorized))
+	}
+
+	token, err := p.business.Refresh(req.RefreshToken)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.Token(token))
+}
+
+func (p *AuthPresetation) PostLogout(c echo.Context) error {
+	status := http.StatusOK
+	message := "Logout success"
+	const op errors.Op = "auth.presentation.PostLogout"
+
+	sessionId := c.Get("sessionId").(int)
+
+	err := p.business.Logout(sessionId)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (p *AuthPresetation) PutRevokeSessions(c echo.Context) error {
+	status := http.StatusOK
+	message := "Sessions revoked"
+	const op errors.Op = "auth.presentation.PutRevokeSessions"
+	var errMessage errors.ErrClientMessage
+
+	var req request.RevokeSessionsRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid user id or role"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err := p.business.RevokeUserSessions(req.UserID, req.Role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (p *AuthPresetation) PostPasswordReset(c echo.Context) error {
+	status := http.StatusOK
+	message := "If the email is registered, a password reset token has been sent"
+	const op errors.Op = "auth.presentation.PostPasswordReset"
+	var errMessage errors.ErrClientMessage
+
+	var req request.PasswordResetRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid email"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err := p.business.RequestPasswordReset(req.Email)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (p *AuthPresetation) PostConfirmPasswordReset(c echo.Context) error {
+	status := http.StatusOK
+	message := "Password has been reset"
+	const op errors.Op = "auth.presentation.PostConfirmPasswordReset"
+	var errMessage errors.ErrClientMessage
+
+	var req request.ConfirmPasswordResetRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid token or new password"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err := p.business.ConfirmPasswordReset(req.Token, req.NewPassword)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (p *AuthPresetation) PutUnlockAccount(c echo.Context) error {
+	status := http.StatusOK
+	message := "Account unlocked"
+	const op errors.Op = "auth.presentation.PutUnlockAccount"
+	var errMessage errors.ErrClientMessage
+
+	var req request.UnlockAccountRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid email"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err := p.business.UnlockAccount(req.Email)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (p *AuthPresetation) PostEnrollTwoFactor(c echo.Context) error {
+	status := http.StatusOK
+	message := "Scan the secret with an authenticator app, then verify it"
+	const op errors.Op = "auth.presentation.PostEnrollTwoFactor"
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+
+	enrollment, err := p.business.EnrollTwoFactor(userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.TwoFactorEnrollment(enrollment))
+}
+
+func (p *AuthPresetation) PostVerifyTwoFactor(c echo.Context) error {
+	status := http.StatusOK
+	message := "Two-factor authentication enabled, keep the recovery codes somewhere safe"
+	const op errors.Op = "auth.presentation.PostVerifyTwoFactor"
+	var errMessage errors.ErrClientMessage
+
+	var req request.VerifyTwoFactorRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid two-factor code"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+
+	recoveryCodes, err := p.business.VerifyTwoFactor(userId, role, req.Code)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
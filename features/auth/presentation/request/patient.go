@@ -0,0 +1,10 @@
+package request
+
+type PatientCodeRequest struct {
+	NIK string `json:"nik" validate:"required,max=32"`
+}
+
+type PatientLoginRequest struct {
+	NIK  string `json:"nik" validate:"required,max=32"`
+	Code string `json:"code" validate:"required"`
+}
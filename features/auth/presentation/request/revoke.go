@@ -0,0 +1,6 @@
+package request
+
+type RevokeSessionsRequest struct {
+	UserID int    `json:"userId" validate:"required,gt=0"`
+	Role   string `json:"role" validate:"required,oneof=admin doctor nurse"`
+}
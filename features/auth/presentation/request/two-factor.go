@@ -0,0 +1,10 @@
+package request
+
+type LoginTwoFactorRequest struct {
+	MFAToken string `json:"mfaToken" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+type VerifyTwoFactorRequest struct {
+	Code string `json:"code" validate:"required"`
+}
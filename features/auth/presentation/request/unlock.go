@@ -0,0 +1,5 @@
+package request
+
+type UnlockAccountRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
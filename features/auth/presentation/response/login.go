@@ -1,11 +1,17 @@
 package response
 
+import "github.com/final-project-alterra/hospital-management-system-api/features/auth"
+
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	MFAToken     string `json:"mfaToken,omitempty"`
 }
 
-func Token(token string) LoginResponse {
+func Token(token auth.TokenCore) LoginResponse {
 	return LoginResponse{
-		Token: token,
+		Token:        token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		MFAToken:     token.MFAToken,
 	}
 }
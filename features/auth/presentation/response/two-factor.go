@@ -0,0 +1,19 @@
+package response
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/auth"
+
+type TwoFactorEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URL    string `json:"url"`
+}
+
+func TwoFactorEnrollment(enrollment auth.TwoFactorEnrollmentCore) TwoFactorEnrollmentResponse {
+	return TwoFactorEnrollmentResponse{
+		Secret: enrollment.Secret,
+		URL:    enrollment.URL,
+	}
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
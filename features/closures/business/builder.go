@@ -0,0 +1,46 @@
+package business
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+type closureBusinessBuilder struct {
+	data             closures.IData
+	scheduleBusiness schedules.IBusiness
+	auditBusiness    audits.IBusiness
+}
+
+func NewClosureBusinessBuilder() *closureBusinessBuilder {
+	return &closureBusinessBuilder{}
+}
+
+func (b *closureBusinessBuilder) SetData(data closures.IData) *closureBusinessBuilder {
+	b.data = data
+	return b
+}
+
+func (b *closureBusinessBuilder) SetScheduleBusiness(sb schedules.IBusiness) *closureBusinessBuilder {
+	b.scheduleBusiness = sb
+	return b
+}
+
+func (b *closureBusinessBuilder) SetAuditBusiness(ab audits.IBusiness) *closureBusinessBuilder {
+	b.auditBusiness = ab
+	return b
+}
+
+func (b *closureBusinessBuilder) Build() closures.IBusiness {
+	closureBusiness := &closureBusiness{
+		data:             b.data,
+		scheduleBusiness: b.scheduleBusiness,
+		auditBusiness:    b.auditBusiness,
+	}
+
+	b.data = nil
+	b.scheduleBusiness = nil
+	b.auditBusiness = nil
+
+	return closureBusiness
+}
@@ -0,0 +1,239 @@
+package business
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+type closureBusiness struct {
+	data             closures.IData
+	scheduleBusiness schedules.IBusiness
+	auditBusiness    audits.IBusiness
+}
+
+func (cb *closureBusiness) FindClosures(q closures.ClosureQuery) ([]closures.ClosureCore, error) {
+	const op errors.Op = "closures.business.FindClosures"
+	var errMessage errors.ErrClientMessage = "Start date must not be after end date"
+
+	if q.StartDate != "" && q.EndDate != "" {
+		start, errStart := time.Parse("2006-01-02", q.StartDate)
+		end, errEnd := time.Parse("2006-01-02", q.EndDate)
+		if errStart != nil || errEnd != nil || start.After(end) {
+			err := errors.New("Invalid date range")
+			return []closures.ClosureCore{}, errors.E(err, op, errMessage, errors.KindBadRequest)
+		}
+	}
+
+	data, err := cb.data.SelectClosures(q)
+	if err != nil {
+		return []closures.ClosureCore{}, errors.E(err, op)
+	}
+	return data, nil
+}
+
+func (cb *closureBusiness) FindClosureById(id int) (closures.ClosureCore, error) {
+	const op errors.Op = "closures.business.FindClosureById"
+
+	closure, err := cb.data.SelectClosureById(id)
+	if err != nil {
+		return closures.ClosureCore{}, errors.E(err, op)
+	}
+	return closure, nil
+}
+
+func (cb *closureBusiness) FindClosedDates(dates []string) (map[string]bool, error) {
+	const op errors.Op = "closures.business.FindClosedDates"
+
+	closed := make(map[string]bool)
+	if len(dates) == 0 {
+		return closed, nil
+	}
+
+	data, err := cb.data.SelectClosuresByDates(dates)
+	if err != nil {
+		return map[string]bool{}, errors.E(err, op)
+	}
+
+	for i := range data {
+		closed[data[i].Date] = true
+	}
+	return closed, nil
+}
+
+func (cb *closureBusiness) CreateClosure(closure closures.ClosureCore, userId int, role string) (closures.ImpactCore, error) {
+	const op errors.Op = "closures.business.CreateClosure"
+	var errMessage errors.ErrClientMessage = "Clinic is already closed on this date"
+
+	existing, err := cb.data.SelectClosuresByDates([]string{closure.Date})
+	if err != nil {
+		return closures.ImpactCore{}, errors.E(err, op)
+	}
+	if len(existing) > 0 {
+		err = errors.New("Duplicate closure date")
+		return closures.ImpactCore{}, errors.E(err, op, errMessage, errors.KindUnprocessable)
+	}
+
+	impact, err := cb.findImpact([]string{closure.Date})
+	if err != nil {
+		return closures.ImpactCore{}, errors.E(err, op)
+	}
+
+	closure.CreatedBy = userId
+	closure.UpdatedBy = userId
+	ids, err := cb.data.InsertClosures([]closures.ClosureCore{closure})
+	if err != nil {
+		return closures.ImpactCore{}, errors.E(err, op)
+	}
+
+	closure.ID = ids[0]
+	cb.audit(op, userId, role, closure.ID, nil, closure)
+	return impact, nil
+}
+
+func (cb *closureBusiness) ImportClosures(closureList []closures.ClosureCore, userId int, role string) (closures.ImportResultCore, error) {
+	const op errors.Op = "closures.business.ImportClosures"
+	var errMessage errors.ErrClientMessage = "Calendar has no dates to import"
+
+	if len(closureList) == 0 {
+		err := errors.New("Empty import")
+		return closures.ImportResultCore{}, errors.E(err, op, errMessage, errors.KindUnprocessable)
+	}
+	if len(closureList) > closures.MAX_IMPORT_DATES {
+		err := errors.New("Too many dates")
+		errMessage = "Calendar has too many dates to import at once"
+		return closures.ImportResultCore{}, errors.E(err, op, errMessage, errors.KindUnprocessable)
+	}
+
+	dates := make([]string, len(closureList))
+	for i := range closureList {
+		dates[i] = closureList[i].Date
+	}
+
+	closed, err := cb.FindClosedDates(dates)
+	if err != nil {
+		return closures.ImportResultCore{}, errors.E(err, op)
+	}
+
+	// a date is imported once, the first event on it gives the reason
+	result := closures.ImportResultCore{Closures: []closures.ClosureCore{}, SkippedDates: []string{}}
+	newDates := []string{}
+	for _, closure := range closureList {
+		if closed[closure.Date] {
+			result.SkippedDates = append(result.SkippedDates, closure.Date)
+			continue
+		}
+		closed[closure.Date] = true
+
+		closure.CreatedBy = userId
+		closure.UpdatedBy = userId
+		result.Closures = append(result.Closures, closure)
+		newDates = append(newDates, closure.Date)
+	}
+
+	if len(result.Closures) == 0 {
+		result.Impact = closures.ImpactCore{WorkSchedules: []schedules.WorkScheduleCore{}}
+		return result, nil
+	}
+
+	result.Impact, err = cb.findImpact(newDates)
+	if err != nil {
+		return closures.ImportResultCore{}, errors.E(err, op)
+	}
+
+	ids, err := cb.data.InsertClosures(result.Closures)
+	if err != nil {
+		return closures.ImportResultCore{}, errors.E(err, op)
+	}
+
+	for i := range ids {
+		result.Closures[i].ID = ids[i]
+		cb.audit(op, userId, role, ids[i], nil, result.Closures[i])
+	}
+	return result, nil
+}
+
+func (cb *closureBusiness) EditClosure(closure closures.ClosureCore, userId int, role string) (closures.ImpactCore, error) {
+	const op errors.Op = "closures.business.EditClosure"
+	var errMessage errors.ErrClientMessage = "Clinic is already closed on this date"
+
+	existing, err := cb.data.SelectClosureById(closure.ID)
+	if err != nil {
+		return closures.ImpactCore{}, errors.E(err, op)
+	}
+
+	others, err := cb.data.SelectClosuresByDates([]string{closure.Date})
+	if err != nil {
+		return closures.ImpactCore{}, errors.E(err, op)
+	}
+	for i := range others {
+		if others[i].ID != closure.ID {
+			err = errors.New("Duplicate closure date")
+			return closures.ImpactCore{}, errors.E(err, op, errMessage, errors.KindUnprocessable)
+		}
+	}
+
+	impact, err := cb.findImpact([]string{closure.Date})
+	if err != nil {
+		return closures.ImpactCore{}, errors.E(err, op)
+	}
+
+	before := existing
+	existing.Date = closure.Date
+	existing.Reason = closure.Reason
+	existing.UpdatedBy = userId
+
+	err = cb.data.UpdateClosure(existing)
+	if err != nil {
+		return closures.ImpactCore{}, errors.E(err, op)
+	}
+
+	cb.audit(op, userId, role, existing.ID, before, existing)
+	return impact, nil
+}
+
+func (cb *closureBusiness) RemoveClosureById(id int, userId int, role string) error {
+	const op errors.Op = "closures.business.RemoveClosureById"
+
+	existing, err := cb.data.SelectClosureById(id)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = cb.data.DeleteClosureById(id)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	cb.audit(op, userId, role, id, existing, nil)
+	return nil
+}
+
+// Private methods
+
+// findImpact lists work schedules on the dates, it is looked up before the closure is
+// stored so a failure leaves nothing half done
+func (cb *closureBusiness) findImpact(dates []string) (closures.ImpactCore, error) {
+	const op errors.Op = "closures.business.findImpact"
+
+	workSchedules, err := cb.scheduleBusiness.FindWorkSchedulesByDates(dates)
+	if err != nil {
+		return closures.ImpactCore{}, errors.E(err, op)
+	}
+	return closures.ImpactCore{WorkSchedules: workSchedules}, nil
+}
+
+func (cb *closureBusiness) audit(op errors.Op, actorId int, actorRole string, entityId int, before interface{}, after interface{}) {
+	cb.auditBusiness.Record(audits.AuditLogCore{
+		ActorID:   actorId,
+		ActorRole: actorRole,
+		Operation: string(op),
+		Entity:    audits.EntityClosure,
+		EntityID:  entityId,
+		Before:    before,
+		After:     after,
+	})
+}
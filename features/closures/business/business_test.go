@@ -0,0 +1,417 @@
+package business_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	aum "github.com/final-project-alterra/hospital-management-system-api/features/audits/mocks"
+	cb "github.com/final-project-alterra/hospital-management-system-api/features/closures/business"
+	cm "github.com/final-project-alterra/hospital-management-system-api/features/closures/mocks"
+	sm "github.com/final-project-alterra/hospital-management-system-api/features/schedules/mocks"
+)
+
+var (
+	repo     cm.IData
+	business closures.IBusiness
+
+	scheduleBusiness sm.IBusiness
+	auditBusiness    aum.IBusiness
+
+	closure1      closures.ClosureCore
+	workSchedule1 schedules.WorkScheduleCore
+
+	any string
+
+	errNotFound error
+	errServer   error
+)
+
+func TestMain(m *testing.M) {
+	business = cb.NewClosureBusinessBuilder().
+		SetData(&repo).
+		SetScheduleBusiness(&scheduleBusiness).
+		SetAuditBusiness(&auditBusiness).
+		Build()
+
+	auditBusiness.On("Record", mock.AnythingOfType("audits.AuditLogCore")).Return()
+
+	closure1 = closures.ClosureCore{ID: 1, Date: "2030-08-17", Reason: "Independence Day"}
+	workSchedule1 = schedules.WorkScheduleCore{
+		ID:   1,
+		Date: "2030-08-17",
+		Outpatients: []schedules.OutpatientCore{
+			{ID: 1, Status: schedules.StatusWaiting, Patient: schedules.PatientCore{ID: 1}},
+		},
+	}
+
+	any = mock.Anything
+
+	errNotFound = errors.E(errors.New("not found"), errors.KindNotFound)
+	errServer = errors.E(errors.New("server"), errors.KindServerError)
+
+	os.Exit(m.Run())
+}
+
+func TestFindClosures(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectClosures", any).
+			Return([]closures.ClosureCore{closure1}, nil).
+			Once()
+
+		result, err := business.FindClosures(closures.ClosureQuery{StartDate: "2030-01-01", EndDate: "2030-12-31"})
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("valid - when start date is after end date", func(t *testing.T) {
+		_, err := business.FindClosures(closures.ClosureQuery{StartDate: "2030-12-31", EndDate: "2030-01-01"})
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
+	})
+
+	t.Run("valid - SelectClosures error", func(t *testing.T) {
+		repo.
+			On("SelectClosures", any).
+			Return([]closures.ClosureCore{}, errServer).
+			Once()
+
+		_, err := business.FindClosures(closures.ClosureQuery{})
+		assert.Error(t, err)
+	})
+}
+
+func TestFindClosureById(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectClosureById", 1).
+			Return(closure1, nil).
+			Once()
+
+		result, err := business.FindClosureById(1)
+		assert.Nil(t, err)
+		assert.Equal(t, closure1, result)
+	})
+
+	t.Run("valid - SelectClosureById error", func(t *testing.T) {
+		repo.
+			On("SelectClosureById", 1).
+			Return(closures.ClosureCore{}, errNotFound).
+			Once()
+
+		_, err := business.FindClosureById(1)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+}
+
+func TestFindClosedDates(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectClosuresByDates", []string{"2030-08-16", "2030-08-17"}).
+			Return([]closures.ClosureCore{closure1}, nil).
+			Once()
+
+		result, err := business.FindClosedDates([]string{"2030-08-16", "2030-08-17"})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]bool{"2030-08-17": true}, result)
+	})
+
+	t.Run("valid - when there is no date", func(t *testing.T) {
+		result, err := business.FindClosedDates([]string{})
+		assert.Nil(t, err)
+		assert.Len(t, result, 0)
+	})
+
+	t.Run("valid - SelectClosuresByDates error", func(t *testing.T) {
+		repo.
+			On("SelectClosuresByDates", any).
+			Return([]closures.ClosureCore{}, errServer).
+			Once()
+
+		_, err := business.FindClosedDates([]string{"2030-08-17"})
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateClosure(t *testing.T) {
+	newClosure := closures.ClosureCore{Date: "2030-08-17", Reason: "Independence Day"}
+
+	t.Run("valid - reports work schedules on the date", func(t *testing.T) {
+		repo.
+			On("SelectClosuresByDates", []string{"2030-08-17"}).
+			Return([]closures.ClosureCore{}, nil).
+			Once()
+
+		scheduleBusiness.
+			On("FindWorkSchedulesByDates", []string{"2030-08-17"}).
+			Return([]schedules.WorkScheduleCore{workSchedule1}, nil).
+			Once()
+
+		repo.
+			On("InsertClosures", mock.MatchedBy(func(c []closures.ClosureCore) bool {
+				return len(c) == 1 && c[0].Date == "2030-08-17" && c[0].CreatedBy == 1
+			})).
+			Return([]int{1}, nil).
+			Once()
+
+		impact, err := business.CreateClosure(newClosure, 1, "admin")
+		assert.Nil(t, err)
+		assert.Equal(t, []schedules.WorkScheduleCore{workSchedule1}, impact.WorkSchedules)
+	})
+
+	t.Run("valid - when the date is already closed", func(t *testing.T) {
+		repo.
+			On("SelectClosuresByDates", []string{"2030-08-17"}).
+			Return([]closures.ClosureCore{closure1}, nil).
+			Once()
+
+		_, err := business.CreateClosure(newClosure, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - FindWorkSchedulesByDates error", func(t *testing.T) {
+		repo.
+			On("SelectClosuresByDates", any).
+			Return([]closures.ClosureCore{}, nil).
+			Once()
+
+		scheduleBusiness.
+			On("FindWorkSchedulesByDates", any).
+			Return([]schedules.WorkScheduleCore{}, errServer).
+			Once()
+
+		_, err := business.CreateClosure(newClosure, 1, "admin")
+		assert.Error(t, err)
+		repo.AssertNumberOfCalls(t, "InsertClosures", 1)
+	})
+
+	t.Run("valid - InsertClosures error", func(t *testing.T) {
+		repo.
+			On("SelectClosuresByDates", any).
+			Return([]closures.ClosureCore{}, nil).
+			Once()
+
+		scheduleBusiness.
+			On("FindWorkSchedulesByDates", any).
+			Return([]schedules.WorkScheduleCore{}, nil).
+			Once()
+
+		repo.
+			On("InsertClosures", any).
+			Return([]int{}, errServer).
+			Once()
+
+		_, err := business.CreateClosure(newClosure, 1, "admin")
+		assert.Error(t, err)
+	})
+}
+
+func TestImportClosures(t *testing.T) {
+	t.Run("valid - skips closed and repeated dates", func(t *testing.T) {
+		imported := []closures.ClosureCore{
+			{Date: "2030-08-17", Reason: "Independence Day"},
+			{Date: "2030-12-25", Reason: "Christmas Day"},
+			{Date: "2030-12-25", Reason: "Christmas Day (observed)"},
+		}
+
+		repo.
+			On("SelectClosuresByDates", []string{"2030-08-17", "2030-12-25", "2030-12-25"}).
+			Return([]closures.ClosureCore{closure1}, nil).
+			Once()
+
+		scheduleBusiness.
+			On("FindWorkSchedulesByDates", []string{"2030-12-25"}).
+			Return([]schedules.WorkScheduleCore{}, nil).
+			Once()
+
+		repo.
+			On("InsertClosures", mock.MatchedBy(func(c []closures.ClosureCore) bool {
+				return len(c) == 1 && c[0].Date == "2030-12-25" && c[0].Reason == "Christmas Day"
+			})).
+			Return([]int{2}, nil).
+			Once()
+
+		result, err := business.ImportClosures(imported, 1, "admin")
+		assert.Nil(t, err)
+		assert.Len(t, result.Closures, 1)
+		assert.Equal(t, 2, result.Closures[0].ID)
+		assert.Equal(t, []string{"2030-08-17", "2030-12-25"}, result.SkippedDates)
+	})
+
+	t.Run("valid - when every date is already closed", func(t *testing.T) {
+		repo.
+			On("SelectClosuresByDates", []string{"2030-08-17"}).
+			Return([]closures.ClosureCore{closure1}, nil).
+			Once()
+
+		result, err := business.ImportClosures([]closures.ClosureCore{{Date: "2030-08-17"}}, 1, "admin")
+		assert.Nil(t, err)
+		assert.Len(t, result.Closures, 0)
+		assert.Equal(t, []string{"2030-08-17"}, result.SkippedDates)
+	})
+
+	t.Run("valid - when there is nothing to import", func(t *testing.T) {
+		_, err := business.ImportClosures([]closures.ClosureCore{}, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when there are too many dates", func(t *testing.T) {
+		_, err := business.ImportClosures(make([]closures.ClosureCore, closures.MAX_IMPORT_DATES+1), 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - InsertClosures error", func(t *testing.T) {
+		repo.
+			On("SelectClosuresByDates", any).
+			Return([]closures.ClosureCore{}, nil).
+			Once()
+
+		scheduleBusiness.
+			On("FindWorkSchedulesByDates", any).
+			Return([]schedules.WorkScheduleCore{}, nil).
+			Once()
+
+		repo.
+			On("InsertClosures", any).
+			Return([]int{}, errServer).
+			Once()
+
+		_, err := business.ImportClosures([]closures.ClosureCore{{Date: "2030-01-01"}}, 1, "admin")
+		assert.Error(t, err)
+	})
+}
+
+func TestEditClosure(t *testing.T) {
+	moved := closures.ClosureCore{ID: 1, Date: "2030-08-18", Reason: "Independence Day (observed)"}
+
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectClosureById", 1).
+			Return(closure1, nil).
+			Once()
+
+		repo.
+			On("SelectClosuresByDates", []string{"2030-08-18"}).
+			Return([]closures.ClosureCore{}, nil).
+			Once()
+
+		scheduleBusiness.
+			On("FindWorkSchedulesByDates", []string{"2030-08-18"}).
+			Return([]schedules.WorkScheduleCore{}, nil).
+			Once()
+
+		repo.
+			On("UpdateClosure", mock.MatchedBy(func(c closures.ClosureCore) bool {
+				return c.ID == 1 && c.Date == moved.Date && c.Reason == moved.Reason && c.UpdatedBy == 2
+			})).
+			Return(nil).
+			Once()
+
+		_, err := business.EditClosure(moved, 2, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when another closure is on the date", func(t *testing.T) {
+		repo.
+			On("SelectClosureById", 1).
+			Return(closure1, nil).
+			Once()
+
+		repo.
+			On("SelectClosuresByDates", []string{"2030-08-18"}).
+			Return([]closures.ClosureCore{{ID: 2, Date: "2030-08-18"}}, nil).
+			Once()
+
+		_, err := business.EditClosure(moved, 2, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - SelectClosureById error", func(t *testing.T) {
+		repo.
+			On("SelectClosureById", 1).
+			Return(closures.ClosureCore{}, errNotFound).
+			Once()
+
+		_, err := business.EditClosure(moved, 2, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+
+	t.Run("valid - UpdateClosure error", func(t *testing.T) {
+		repo.
+			On("SelectClosureById", 1).
+			Return(closure1, nil).
+			Once()
+
+		repo.
+			On("SelectClosuresByDates", any).
+			Return([]closures.ClosureCore{closure1}, nil).
+			Once()
+
+		scheduleBusiness.
+			On("FindWorkSchedulesByDates", any).
+			Return([]schedules.WorkScheduleCore{}, nil).
+			Once()
+
+		repo.
+			On("UpdateClosure", any).
+			Return(errServer).
+			Once()
+
+		_, err := business.EditClosure(moved, 2, "admin")
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoveClosureById(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectClosureById", 1).
+			Return(closure1, nil).
+			Once()
+
+		repo.
+			On("DeleteClosureById", 1).
+			Return(nil).
+			Once()
+
+		err := business.RemoveClosureById(1, 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - SelectClosureById error", func(t *testing.T) {
+		repo.
+			On("SelectClosureById", 1).
+			Return(closures.ClosureCore{}, errNotFound).
+			Once()
+
+		err := business.RemoveClosureById(1, 1, "admin")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - DeleteClosureById error", func(t *testing.T) {
+		repo.
+			On("SelectClosureById", 1).
+			Return(closure1, nil).
+			Once()
+
+		repo.
+			On("DeleteClosureById", 1).
+			Return(errServer).
+			Once()
+
+		err := business.RemoveClosureById(1, 1, "admin")
+		assert.Error(t, err)
+	})
+}
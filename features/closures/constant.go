@@ -0,0 +1,6 @@
+package closures
+
+const (
+	MAX_IMPORT_DATES = 1000 // dates of a single .ics import
+	MAX_IMPORT_SIZE  = 1 << 20
+)
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures"
+	"gorm.io/gorm"
+)
+
+type mySQLRepo struct {
+	db *gorm.DB
+}
+
+func NewMySQLRepo(db *gorm.DB) *mySQLRepo {
+	return &mySQLRepo{db: db}
+}
+
+func (r *mySQLRepo) SelectClosures(q closures.ClosureQuery) ([]closures.ClosureCore, error) {
+	const op errors.Op = "closures.data.SelectClosures"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	tx := r.db.Order("date")
+	if q.StartDate != "" {
+		tx = tx.Where("date >= ?", q.StartDate)
+	}
+	if q.EndDate != "" {
+		tx = tx.Where("date <= ?", q.EndDate)
+	}
+
+	closureRecords := []Closure{}
+	err := tx.Find(&closureRecords).Error
+	if err != nil {
+		return []closures.ClosureCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return toSliceClosureCore(closureRecords), nil
+}
+
+func (r *mySQLRepo) SelectClosureById(id int) (closures.ClosureCore, error) {
+	const op errors.Op = "closures.data.SelectClosureById"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	closureRecord := Closure{}
+	err := r.db.First(&closureRecord, id).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMessage = "Closure not found"
+			return closures.ClosureCore{}, errors.E(err, op, errMessage, errors.KindNotFound)
+		default:
+			return closures.ClosureCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+	return closureRecord.toClosureCore(), nil
+}
+
+func (r *mySQLRepo) SelectClosuresByDates(dates []string) ([]closures.ClosureCore, error) {
+	const op errors.Op = "closures.data.SelectClosuresByDates"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	closureRecords := []Closure{}
+	err := r.db.Where("date IN ?", dates).Order("date").Find(&closureRecords).Error
+	if err != nil {
+		return []closures.ClosureCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return toSliceClosureCore(closureRecords), nil
+}
+
+func (r *mySQLRepo) InsertClosures(closureList []closures.ClosureCore) ([]int, error) {
+	const op errors.Op = "closures.data.InsertClosures"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	closureRecords := make([]Closure, len(closureList))
+	for i := range closureList {
+		closureRecords[i] = toClosureRecord(closureList[i])
+	}
+
+	err := r.db.Create(&closureRecords).Error
+	if err != nil {
+		return []int{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+
+	ids := make([]int, len(closureRecords))
+	for i := range closureRecords {
+		ids[i] = int(closureRecords[i].ID)
+	}
+	return ids, nil
+}
+
+func (r *mySQLRepo) UpdateClosure(closure closures.ClosureCore) error {
+	const op errors.Op = "closures.data.UpdateClosure"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	closureRecord := toClosureRecord(closure)
+	err := r.db.Save(&closureRecord).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) DeleteClosureById(id int) error {
+	const op errors.Op = "closures.data.DeleteClosureById"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.Delete(&Closure{}, id).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package data
+
+import (
+	"strings"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures"
+	"gorm.io/gorm"
+)
+
+type Closure struct {
+	gorm.Model
+	CreatedBy int
+	UpdatedBy int
+	Date      string `gorm:"type:date;not null;index"`
+	Reason    string `gorm:"type:varchar(255);not null"`
+}
+
+func (c Closure) toClosureCore() closures.ClosureCore {
+	return closures.ClosureCore{
+		ID:        int(c.ID),
+		CreatedBy: c.CreatedBy,
+		UpdatedBy: c.UpdatedBy,
+		Date:      strings.Split(c.Date, "T")[0],
+		Reason:    c.Reason,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func toSliceClosureCore(c []Closure) []closures.ClosureCore {
+	result := make([]closures.ClosureCore, len(c))
+	for i := range c {
+		result[i] = c[i].toClosureCore()
+	}
+	return result
+}
+
+func toClosureRecord(c closures.ClosureCore) Closure {
+	return Closure{
+		Model:     gorm.Model{ID: uint(c.ID), CreatedAt: c.CreatedAt},
+		CreatedBy: c.CreatedBy,
+		UpdatedBy: c.UpdatedBy,
+		Date:      c.Date,
+		Reason:    c.Reason,
+	}
+}
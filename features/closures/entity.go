@@ -0,0 +1,56 @@
+package closures
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+// ClosureCore is a date the clinic is closed on, e.g. a public holiday
+type ClosureCore struct {
+	ID        int
+	CreatedBy int
+	UpdatedBy int
+	Date      string
+	Reason    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ClosureQuery filters closures by date, empty dates are not filtered
+type ClosureQuery struct {
+	StartDate string
+	EndDate   string
+}
+
+// ImpactCore lists work schedules already on closed dates with their waiting outpatients.
+// They are only reported, staff decide whether to move or remove them.
+type ImpactCore struct {
+	WorkSchedules []schedules.WorkScheduleCore
+}
+
+// ImportResultCore is the outcome of a bulk import, dates already closed are skipped
+type ImportResultCore struct {
+	Closures     []ClosureCore
+	SkippedDates []string
+	Impact       ImpactCore
+}
+
+type IBusiness interface {
+	FindClosures(q ClosureQuery) ([]ClosureCore, error)
+	FindClosureById(id int) (ClosureCore, error)
+	FindClosedDates(dates []string) (map[string]bool, error) // used by schedules to skip closed dates
+	CreateClosure(closure ClosureCore, userId int, role string) (ImpactCore, error)
+	ImportClosures(closureList []ClosureCore, userId int, role string) (ImportResultCore, error)
+	EditClosure(closure ClosureCore, userId int, role string) (ImpactCore, error)
+	RemoveClosureById(id int, userId int, role string) error
+}
+
+type IData interface {
+	SelectClosures(q ClosureQuery) ([]ClosureCore, error)
+	SelectClosureById(id int) (ClosureCore, error)
+	SelectClosuresByDates(dates []string) ([]ClosureCore, error)
+	InsertClosures(closureList []ClosureCore) ([]int, error)
+	UpdateClosure(closure ClosureCore) error
+	DeleteClosureById(id int) error
+}
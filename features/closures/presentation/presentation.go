@@ -0,0 +1,185 @@
+package presentation
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures"
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures/presentation/request"
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures/presentation/response"
+	"github.com/final-project-alterra/hospital-management-system-api/utils/ical"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type ClosurePresentation struct {
+	business closures.IBusiness
+	validate *validator.Validate
+}
+
+func NewClosurePresentation(business closures.IBusiness) *ClosurePresentation {
+	return &ClosurePresentation{
+		business: business,
+		validate: validator.New(),
+	}
+}
+
+func (cp *ClosurePresentation) GetClosures(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving closures"
+	const op errors.Op = "closures.presentation.GetClosures"
+	var errMessage errors.ErrClientMessage
+
+	var req request.QueryParamsRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := cp.validate.Struct(req); err != nil {
+		errMessage = "Invalid query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	data, err := cp.business.FindClosures(req.ToClosureQuery())
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListClosures(data))
+}
+
+func (cp *ClosurePresentation) GetDetailClosure(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving closure"
+	const op errors.Op = "closures.presentation.GetDetailClosure"
+	var errMessage errors.ErrClientMessage
+
+	closureId, err := strconv.Atoi(c.Param("closureId"))
+	if err != nil || closureId < 1 {
+		errMessage = "Invalid closure id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	closure, err := cp.business.FindClosureById(closureId)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.DetailClosure(closure))
+}
+
+func (cp *ClosurePresentation) PostClosure(c echo.Context) error {
+	status := http.StatusCreated
+	message := "Success creating closure"
+	const op errors.Op = "closures.presentation.PostClosure"
+	var errMessage errors.ErrClientMessage
+
+	closure := request.CreateClosureRequest{}
+	err := c.Bind(&closure)
+	if err != nil {
+		errMessage = "Unable to parse payload request"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = cp.validate.Struct(closure)
+	if err != nil {
+		errMessage = "Invalid. Makesure all field is filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	impact, err := cp.business.CreateClosure(closure.ToClosureCore(), userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.Impact(impact))
+}
+
+// PostImportClosures reads an iCalendar file from the "file" form field, every day of
+// every event becomes a closure
+func (cp *ClosurePresentation) PostImportClosures(c echo.Context) error {
+	status := http.StatusCreated
+	message := "Success importing closures"
+	const op errors.Op = "closures.presentation.PostImportClosures"
+	var errMessage errors.ErrClientMessage = "Unable to read calendar file"
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+	if file.Size > closures.MAX_IMPORT_SIZE {
+		err = errors.New("File is too large")
+		errMessage = "Calendar file must not be larger than 1 MB"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindServerError))
+	}
+	defer src.Close()
+
+	events, err := ical.Parse(io.LimitReader(src, closures.MAX_IMPORT_SIZE))
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	result, err := cp.business.ImportClosures(request.ToClosureCores(events), userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ImportResult(result))
+}
+
+func (cp *ClosurePresentation) PutEditClosure(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success updating closure"
+	const op errors.Op = "closures.presentation.PutEditClosure"
+	var errMessage errors.ErrClientMessage
+
+	closure := request.UpdateClosureRequest{}
+	err := c.Bind(&closure)
+	if err != nil {
+		errMessage = "Unable to parse payload request"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = cp.validate.Struct(closure)
+	if err != nil {
+		errMessage = "Invalid. Makesure all field is filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	impact, err := cp.business.EditClosure(closure.ToClosureCore(), userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.Impact(impact))
+}
+
+func (cp *ClosurePresentation) DeleteClosure(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success deleting closure"
+	const op errors.Op = "closures.presentation.DeleteClosure"
+	var errMessage errors.ErrClientMessage
+
+	closureId, err := strconv.Atoi(c.Param("closureId"))
+	if err != nil || closureId < 1 {
+		errMessage = "Invalid closure id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = cp.business.RemoveClosureById(closureId, userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
@@ -0,0 +1,55 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures"
+	"github.com/final-project-alterra/hospital-management-system-api/utils/ical"
+)
+
+const DEFAULT_IMPORT_REASON = "Closed"
+
+type CreateClosureRequest struct {
+	Date   string `json:"date" validate:"required,datetime=2006-01-02"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+func (r CreateClosureRequest) ToClosureCore() closures.ClosureCore {
+	return closures.ClosureCore{
+		Date:   r.Date,
+		Reason: strings.TrimSpace(r.Reason),
+	}
+}
+
+type UpdateClosureRequest struct {
+	ID     int    `json:"id" validate:"required,gt=0"`
+	Date   string `json:"date" validate:"required,datetime=2006-01-02"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+func (r UpdateClosureRequest) ToClosureCore() closures.ClosureCore {
+	return closures.ClosureCore{
+		ID:     r.ID,
+		Date:   r.Date,
+		Reason: strings.TrimSpace(r.Reason),
+	}
+}
+
+// ToClosureCores turns every day of the events into a closure, the summary is the reason
+func ToClosureCores(events []ical.Event) []closures.ClosureCore {
+	result := []closures.ClosureCore{}
+	for _, event := range events {
+		reason := event.Summary
+		if reason == "" {
+			reason = DEFAULT_IMPORT_REASON
+		}
+		if len(reason) > 255 {
+			reason = reason[:255]
+		}
+
+		for _, date := range event.Dates() {
+			result = append(result, closures.ClosureCore{Date: date, Reason: reason})
+		}
+	}
+	return result
+}
@@ -0,0 +1,15 @@
+package request
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/closures"
+
+type QueryParamsRequest struct {
+	StartDate string `query:"startDate" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `query:"endDate" validate:"omitempty,datetime=2006-01-02"`
+}
+
+func (q QueryParamsRequest) ToClosureQuery() closures.ClosureQuery {
+	return closures.ClosureQuery{
+		StartDate: q.StartDate,
+		EndDate:   q.EndDate,
+	}
+}
@@ -0,0 +1,100 @@
+package response
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+type ClosureResponse struct {
+	ID        int       `json:"id"`
+	CreatedBy int       `json:"createdBy"`
+	UpdatedBy int       `json:"updatedBy"`
+	Date      string    `json:"date"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func DetailClosure(c closures.ClosureCore) ClosureResponse {
+	return ClosureResponse{
+		ID:        c.ID,
+		CreatedBy: c.CreatedBy,
+		UpdatedBy: c.UpdatedBy,
+		Date:      c.Date,
+		Reason:    c.Reason,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func ListClosures(c []closures.ClosureCore) []ClosureResponse {
+	result := make([]ClosureResponse, len(c))
+	for i := range c {
+		result[i] = DetailClosure(c[i])
+	}
+	return result
+}
+
+type WaitingOutpatientResponse struct {
+	ID        int `json:"id"`
+	PatientID int `json:"patientId"`
+}
+
+type AffectedWorkScheduleResponse struct {
+	ID                 int                         `json:"id"`
+	Group              string                      `json:"group"`
+	Date               string                      `json:"date"`
+	StartTime          string                      `json:"startTime"`
+	EndTime            string                      `json:"endTime"`
+	DoctorID           int                         `json:"doctorId"`
+	NurseID            int                         `json:"nurseId"`
+	TotalWaiting       int                         `json:"totalWaiting"`
+	WaitingOutpatients []WaitingOutpatientResponse `json:"waitingOutpatients"`
+}
+
+type ImpactResponse struct {
+	WorkSchedules []AffectedWorkScheduleResponse `json:"workSchedules"`
+}
+
+func Impact(i closures.ImpactCore) ImpactResponse {
+	result := ImpactResponse{WorkSchedules: make([]AffectedWorkScheduleResponse, len(i.WorkSchedules))}
+	for n, ws := range i.WorkSchedules {
+		result.WorkSchedules[n] = affectedWorkSchedule(ws)
+	}
+	return result
+}
+
+type ImportResultResponse struct {
+	Closures     []ClosureResponse `json:"closures"`
+	SkippedDates []string          `json:"skippedDates"`
+	Impact       ImpactResponse    `json:"impact"`
+}
+
+func ImportResult(r closures.ImportResultCore) ImportResultResponse {
+	return ImportResultResponse{
+		Closures:     ListClosures(r.Closures),
+		SkippedDates: r.SkippedDates,
+		Impact:       Impact(r.Impact),
+	}
+}
+
+func affectedWorkSchedule(ws schedules.WorkScheduleCore) AffectedWorkScheduleResponse {
+	outpatients := make([]WaitingOutpatientResponse, len(ws.Outpatients))
+	for i, o := range ws.Outpatients {
+		outpatients[i] = WaitingOutpatientResponse{ID: o.ID, PatientID: o.Patient.ID}
+	}
+
+	return AffectedWorkScheduleResponse{
+		ID:                 ws.ID,
+		Group:              ws.Group,
+		Date:               ws.Date,
+		StartTime:          ws.StartTime,
+		EndTime:            ws.EndTime,
+		DoctorID:           ws.Doctor.ID,
+		NurseID:            ws.Nurse.ID,
+		TotalWaiting:       ws.TotalWaiting,
+		WaitingOutpatients: outpatients,
+	}
+}
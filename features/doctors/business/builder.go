@@ -1,17 +1,19 @@
 package business
 
 import (
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
 	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
 	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
 )
 
 type doctorBusinessBuilder struct {
 	doctorRepo       doctors.IData
 	adminBusiness    admins.IBusiness
-	nurseBusiness    nurses.IBusiness
+	accountBusiness  accounts.IBusiness
 	scheduleBusiness schedules.IBusiness
+	auditBusiness    audits.IBusiness
 }
 
 func NewDoctorBusinessBuilder() *doctorBusinessBuilder {
@@ -28,8 +30,8 @@ func (b *doctorBusinessBuilder) SetAdminBusiness(ab admins.IBusiness) *doctorBus
 	return b
 }
 
-func (b *doctorBusinessBuilder) SetNurseBusiness(nb nurses.IBusiness) *doctorBusinessBuilder {
-	b.nurseBusiness = nb
+func (b *doctorBusinessBuilder) SetAccountBusiness(ab accounts.IBusiness) *doctorBusinessBuilder {
+	b.accountBusiness = ab
 	return b
 }
 
@@ -38,18 +40,25 @@ func (b *doctorBusinessBuilder) SetScheduleBusiness(sb schedules.IBusiness) *doc
 	return b
 }
 
+func (b *doctorBusinessBuilder) SetAuditBusiness(ab audits.IBusiness) *doctorBusinessBuilder {
+	b.auditBusiness = ab
+	return b
+}
+
 func (b *doctorBusinessBuilder) Build() doctors.IBusiness {
 	doctorBusiness := &doctorBusiness{
 		data:             b.doctorRepo,
 		adminBusiness:    b.adminBusiness,
-		nurseBusiness:    b.nurseBusiness,
+		accountBusiness:  b.accountBusiness,
 		scheduleBusiness: b.scheduleBusiness,
+		auditBusiness:    b.auditBusiness,
 	}
 
 	b.doctorRepo = nil
 	b.adminBusiness = nil
-	b.nurseBusiness = nil
+	b.accountBusiness = nil
 	b.scheduleBusiness = nil
+	b.auditBusiness = nil
 
 	return doctorBusiness
 }
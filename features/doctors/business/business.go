@@ -4,20 +4,22 @@ import (
 	"path"
 
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
 	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
 	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
 	"github.com/final-project-alterra/hospital-management-system-api/utils/files"
-	"github.com/final-project-alterra/hospital-management-system-api/utils/hash"
 	"github.com/final-project-alterra/hospital-management-system-api/utils/project"
 )
 
 type doctorBusiness struct {
 	data             doctors.IData
 	adminBusiness    admins.IBusiness
-	nurseBusiness    nurses.IBusiness
+	accountBusiness  accounts.IBusiness
 	scheduleBusiness schedules.IBusiness
+	auditBusiness    audits.IBusiness
 }
 
 func (d *doctorBusiness) FindDoctors() ([]doctors.DoctorCore, error) {
@@ -53,6 +55,17 @@ func (d *doctorBusiness) FindDoctorById(id int) (doctors.DoctorCore, error) {
 	return doctorData, nil
 }
 
+func (d *doctorBusiness) FindDoctorsByRoomId(roomId int) ([]doctors.DoctorCore, error) {
+	const op errors.Op = "doctors.business.FindDoctorsByRoomId"
+
+	doctorsData, err := d.data.SelectDoctorsByRoomId(roomId)
+	if err != nil {
+		return []doctors.DoctorCore{}, errors.E(err, op)
+	}
+
+	return doctorsData, nil
+}
+
 func (d *doctorBusiness) FindDoctorByEmail(email string) (doctors.DoctorCore, error) {
 	const op errors.Op = "doctors.business.FindDoctorByEmail"
 
@@ -93,26 +106,60 @@ func (d *doctorBusiness) CreateDoctor(doctor doctors.DoctorCore) error {
 	}
 
 	// Check wheter email is already registered
-	if err = d.checkEmail(doctor.Email); err != nil {
+	if err = d.accountBusiness.CheckEmail(doctor.Email); err != nil {
 		return errors.E(err, op)
 	}
 
-	doctor.Password, err = hash.Generate(doctor.Password)
+	doctorId, err := d.data.InsertDoctor(doctor)
 	if err != nil {
-		errMessage = "Something went wrong"
-		return errors.E(err, op, errMessage, errors.KindServerError)
+		return errors.E(err, op)
 	}
 
-	err = d.data.InsertDoctor(doctor)
+	account := accounts.AccountCore{
+		Email:    doctor.Email,
+		Password: doctor.Password,
+		Role:     permissions.RoleDoctor,
+		UserID:   doctorId,
+	}
+	err = d.accountBusiness.CreateAccount(account)
 	if err != nil {
+		// Doctor without account can not login, so roll it back
+		_ = d.data.DeleteDoctorById(doctorId, doctor.CreatedBy)
 		return errors.E(err, op)
 	}
+
+	doctor.ID = doctorId
+	doctor.Password = ""
+	d.audit(op, doctor.CreatedBy, permissions.RoleAdmin, audits.EntityDoctor, doctorId, nil, doctor)
 	return nil
 }
 
-func (d *doctorBusiness) EditDoctor(doctor doctors.DoctorCore) error {
+// checkEditor lets an admin edit any doctor, while a doctor may only edit their own record
+func (d *doctorBusiness) checkEditor(doctorId int, updatedBy int, role string, errMessage errors.ErrClientMessage) error {
+	const op errors.Op = "doctors.business.checkEditor"
+
+	if role == permissions.RoleDoctor && updatedBy == doctorId {
+		return nil
+	}
+	if role != permissions.RoleAdmin {
+		errMessage = "Doctor can only edit their own profile"
+		return errors.E(errors.New("editing another doctor"), op, errMessage, errors.KindUnauthorized)
+	}
+
+	_, err := d.adminBusiness.FindAdminById(updatedBy)
+	if err != nil {
+		switch errors.Kind(err) {
+		case errors.KindNotFound:
+			return errors.E(err, op, errMessage)
+		default:
+			return errors.E(err, op)
+		}
+	}
+	return nil
+}
+
+func (d *doctorBusiness) EditDoctor(doctor doctors.DoctorCore, role string) error {
 	const op errors.Op = "doctors.business.EditDoctor"
-	var errMessage errors.ErrClientMessage
 
 	existingDoctor, err := d.data.SelectDoctorById(doctor.ID)
 	if err != nil {
@@ -131,18 +178,16 @@ func (d *doctorBusiness) EditDoctor(doctor doctors.DoctorCore) error {
 		return errors.E(err, op)
 	}
 
-	_, err = d.adminBusiness.FindAdminById(doctor.UpdatedBy)
+	err = d.checkEditor(doctor.ID, doctor.UpdatedBy, role, "Admin who wanted to update was not found")
 	if err != nil {
-		switch errors.Kind(err) {
-		case errors.KindNotFound:
-			errMessage = "Admin who wanted to update was not found"
-			return errors.E(err, op, errMessage)
-		default:
-			return errors.E(err, op)
-		}
+		return errors.E(err, op)
 	}
 
-	existingDoctor.UpdatedBy = doctor.UpdatedBy
+	before := existingDoctor
+	// UpdatedBy refers to an admin, changes made by the doctor are only in the audit log
+	if role == permissions.RoleAdmin {
+		existingDoctor.UpdatedBy = doctor.UpdatedBy
+	}
 	existingDoctor.Room.ID = doctor.Room.ID
 	existingDoctor.Speciality.ID = doctor.Speciality.ID
 	existingDoctor.Name = doctor.Name
@@ -155,22 +200,18 @@ func (d *doctorBusiness) EditDoctor(doctor doctors.DoctorCore) error {
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	d.audit(op, doctor.UpdatedBy, role, audits.EntityDoctor, existingDoctor.ID, before, existingDoctor)
 	return nil
 }
 
-func (d *doctorBusiness) EditDoctorPassword(id int, updatedBy int, oldPassword string, newPassword string) error {
+func (d *doctorBusiness) EditDoctorPassword(id int, updatedBy int, role string, oldPassword string, newPassword string) error {
 	const op errors.Op = "doctors.business.EditDoctorPassword"
 	var errMessage errors.ErrClientMessage
 
-	_, err := d.adminBusiness.FindAdminById(updatedBy)
+	err := d.checkEditor(id, updatedBy, role, "Admin who wants to change doctor passowrd is not found")
 	if err != nil {
-		switch errors.Kind(err) {
-		case errors.KindNotFound:
-			errMessage = "Admin who wants to change doctor passowrd is not found"
-			return errors.E(err, op, errMessage)
-		default:
-			return errors.E(err, op)
-		}
+		return errors.E(err, op)
 	}
 
 	existingDoctor, err := d.data.SelectDoctorById(id)
@@ -184,33 +225,30 @@ func (d *doctorBusiness) EditDoctorPassword(id int, updatedBy int, oldPassword s
 		}
 	}
 
-	doesMatch := hash.Validate(existingDoctor.Password, oldPassword)
-	if !doesMatch {
-		err = errors.New("Wrong password")
-		errMessage = "Wrong old password"
-		return errors.E(err, op, errMessage, errors.KindUnprocessable)
-	}
-
-	existingDoctor.UpdatedBy = updatedBy
-	existingDoctor.Password, err = hash.Generate(newPassword)
+	err = d.accountBusiness.EditAccountPassword(id, permissions.RoleDoctor, oldPassword, newPassword)
 	if err != nil {
 		return errors.E(err, op)
 	}
 
-	err = d.data.UpdateDoctor(existingDoctor)
-	if err != nil {
-		return errors.E(err, op)
+	if role == permissions.RoleAdmin {
+		existingDoctor.UpdatedBy = updatedBy
+		err = d.data.UpdateDoctor(existingDoctor)
+		if err != nil {
+			return errors.E(err, op)
+		}
 	}
 
+	// Password itself is never part of the audit log
+	d.audit(op, updatedBy, role, audits.EntityDoctor, id, nil, nil)
 	return nil
 }
 
-func (d *doctorBusiness) EditDoctorImageProfile(doctor doctors.DoctorCore) error {
+func (d *doctorBusiness) EditDoctorImageProfile(doctor doctors.DoctorCore, role string) error {
 	const op errors.Op = "doctors.business.EditDoctorImageProfile"
 
 	newImage := path.Join(project.GetMainDir(), "files", doctor.ImageUrl)
 
-	_, err := d.adminBusiness.FindAdminById(doctor.UpdatedBy)
+	err := d.checkEditor(doctor.ID, doctor.UpdatedBy, role, "Admin who wants to change doctor image is not found")
 	if err != nil {
 		go func() { _ = files.Remove(newImage) }()
 		return errors.E(err, op)
@@ -223,8 +261,11 @@ func (d *doctorBusiness) EditDoctorImageProfile(doctor doctors.DoctorCore) error
 	}
 	oldImage := path.Join(project.GetMainDir(), "files", existingDoctor.ImageUrl)
 
+	before := existingDoctor
 	existingDoctor.ImageUrl = doctor.ImageUrl
-	existingDoctor.UpdatedBy = doctor.UpdatedBy
+	if role == permissions.RoleAdmin {
+		existingDoctor.UpdatedBy = doctor.UpdatedBy
+	}
 
 	err = d.data.UpdateDoctor(existingDoctor)
 	if err != nil {
@@ -232,6 +273,7 @@ func (d *doctorBusiness) EditDoctorImageProfile(doctor doctors.DoctorCore) error
 		return errors.E(err, op)
 	}
 
+	d.audit(op, doctor.UpdatedBy, role, audits.EntityDoctor, existingDoctor.ID, before, existingDoctor)
 	go func() { _ = files.Remove(oldImage) }()
 
 	return nil
@@ -268,6 +310,12 @@ func (d *doctorBusiness) RemoveDoctorById(id int, updatedBy int) error {
 		return errors.E(err, op)
 	}
 
+	err = d.accountBusiness.RemoveAccountByUser(id, permissions.RoleDoctor)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	d.audit(op, updatedBy, permissions.RoleAdmin, audits.EntityDoctor, id, existingDoctor, nil)
 	go func() { _ = files.Remove(existingImage) }()
 	return nil
 }
@@ -292,17 +340,20 @@ func (d *doctorBusiness) FindSpecialityById(id int) (doctors.SpecialityCore, err
 	return speciality, nil
 }
 
-func (d *doctorBusiness) CreateSpeciality(speciality doctors.SpecialityCore) error {
+func (d *doctorBusiness) CreateSpeciality(speciality doctors.SpecialityCore, userId int, role string) error {
 	const op errors.Op = "doctors.business.CreateSpeciality"
 
-	err := d.data.InsertSpeciality(speciality)
+	specialityId, err := d.data.InsertSpeciality(speciality)
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	speciality.ID = specialityId
+	d.audit(op, userId, role, audits.EntitySpeciality, specialityId, nil, speciality)
 	return nil
 }
 
-func (d *doctorBusiness) EditSpeciality(speciality doctors.SpecialityCore) error {
+func (d *doctorBusiness) EditSpeciality(speciality doctors.SpecialityCore, userId int, role string) error {
 	const op errors.Op = "doctors.business.EditSpeciality"
 
 	existingSpeciality, err := d.data.SelectSpecialityById(speciality.ID)
@@ -310,18 +361,26 @@ func (d *doctorBusiness) EditSpeciality(speciality doctors.SpecialityCore) error
 		return errors.E(err, op)
 	}
 
+	before := existingSpeciality
 	existingSpeciality.Name = speciality.Name
 	err = d.data.UpdateSpeciality(existingSpeciality)
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	d.audit(op, userId, role, audits.EntitySpeciality, existingSpeciality.ID, before, existingSpeciality)
 	return nil
 }
 
-func (d *doctorBusiness) RemoveSpeciality(id int) error {
+func (d *doctorBusiness) RemoveSpeciality(id int, userId int, role string) error {
 	const op errors.Op = "doctors.business.RemoveSpeciality"
 	var errMessage errors.ErrClientMessage
 
+	existingSpeciality, err := d.data.SelectSpecialityById(id)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
 	data, err := d.data.SelectDoctorsBySpecialityId(id)
 	if err != nil {
 		return errors.E(err, op)
@@ -336,6 +395,8 @@ func (d *doctorBusiness) RemoveSpeciality(id int) error {
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	d.audit(op, userId, role, audits.EntitySpeciality, id, existingSpeciality, nil)
 	return nil
 }
 
@@ -348,7 +409,7 @@ func (d *doctorBusiness) FindRooms() ([]doctors.RoomCore, error) {
 	return rooms, nil
 }
 
-func (d *doctorBusiness) CreateRoom(room doctors.RoomCore) error {
+func (d *doctorBusiness) CreateRoom(room doctors.RoomCore, userId int, role string) error {
 	const op errors.Op = "doctors.business.CreateRoom"
 	var errMessage errors.ErrClientMessage
 
@@ -362,14 +423,17 @@ func (d *doctorBusiness) CreateRoom(room doctors.RoomCore) error {
 		return errors.E(err, op)
 	}
 
-	err = d.data.InsertRoom(room)
+	roomId, err := d.data.InsertRoom(room)
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	room.ID = roomId
+	d.audit(op, userId, role, audits.EntityRoom, roomId, nil, room)
 	return nil
 }
 
-func (d *doctorBusiness) EditRoom(room doctors.RoomCore) error {
+func (d *doctorBusiness) EditRoom(room doctors.RoomCore, userId int, role string) error {
 	const op errors.Op = "doctors.business.EditRoom"
 	var errMessage errors.ErrClientMessage
 
@@ -388,6 +452,7 @@ func (d *doctorBusiness) EditRoom(room doctors.RoomCore) error {
 		return errors.E(err, op)
 	}
 
+	before := existingRoom
 	existingRoom.Floor = room.Floor
 	existingRoom.Code = room.Code
 
@@ -395,13 +460,20 @@ func (d *doctorBusiness) EditRoom(room doctors.RoomCore) error {
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	d.audit(op, userId, role, audits.EntityRoom, existingRoom.ID, before, existingRoom)
 	return nil
 }
 
-func (d *doctorBusiness) RemoveRoomById(id int) error {
+func (d *doctorBusiness) RemoveRoomById(id int, userId int, role string) error {
 	const op errors.Op = "doctors.business.RemoveRoomById"
 	var errMessage errors.ErrClientMessage
 
+	existingRoom, err := d.data.SelectRoomById(id)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
 	data, err := d.data.SelectDoctorsByRoomId(id)
 	if err != nil {
 		return errors.E(err, op)
@@ -416,67 +488,22 @@ func (d *doctorBusiness) RemoveRoomById(id int) error {
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	d.audit(op, userId, role, audits.EntityRoom, id, existingRoom, nil)
 	return nil
 }
 
 // Private methods
-func (d *doctorBusiness) checkEmail(email string) error {
-	const op errors.Op = "admins.business.checkEmail"
-	var errMsg errors.ErrClientMessage = "Email already exist"
-
-	adminCh := make(chan error)
-	doctorCh := make(chan error)
-	nurseCh := make(chan error)
-
-	go func() {
-		_, err := d.data.SelectDoctorByEmail(email)
-		if err != nil {
-			if errors.Kind(err) == errors.KindNotFound {
-				err = nil
-			}
-			doctorCh <- err
-			return
-		}
-		doctorCh <- errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
-	}()
-
-	go func() {
-		_, err := d.adminBusiness.FindAdminByEmail(email)
-		if err != nil {
-			if errors.Kind(err) == errors.KindNotFound {
-				err = nil
-			}
-			adminCh <- err
-			return
-		}
-		adminCh <- errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
-	}()
-
-	go func() {
-		_, err := d.nurseBusiness.FindNurseByEmail(email)
-		if err != nil {
-			if errors.Kind(err) == errors.KindNotFound {
-				err = nil
-			}
-			nurseCh <- err
-			return
-		}
-		nurseCh <- errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
-	}()
-
-	adminErr := <-adminCh
-	doctorErr := <-doctorCh
-	nurseErr := <-nurseCh
 
-	if adminErr == nil && doctorErr == nil && nurseErr == nil {
-		return nil
-	}
-
-	if adminErr != nil {
-		return adminErr
-	}
-	if doctorErr != nil {
-		return doctorErr
-	}
-	return nurseErr
+// audit records a change on doctors, specialities or rooms
+func (d *doctorBusiness) audit(op errors.Op, actorId int, actorRole string, entity string, entityId int, before interface{}, after interface{}) {
+	d.auditBusiness.Record(audits.AuditLogCore{
+		ActorID:   actorId,
+		ActorRole: actorRole,
+		Operation: string(op),
+		Entity:    entity,
+		EntityID:  entityId,
+		Before:    before,
+		After:     after,
+	})
 }
@@ -5,16 +5,17 @@ import (
 	"testing"
 
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	acm "github.com/final-project-alterra/hospital-management-system-api/features/accounts/mocks"
 	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
 	am "github.com/final-project-alterra/hospital-management-system-api/features/admins/mocks"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	aum "github.com/final-project-alterra/hospital-management-system-api/features/audits/mocks"
 	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
 	d "github.com/final-project-alterra/hospital-management-system-api/features/doctors/business"
 	dm "github.com/final-project-alterra/hospital-management-system-api/features/doctors/mocks"
-	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
-	nm "github.com/final-project-alterra/hospital-management-system-api/features/nurses/mocks"
 	sm "github.com/final-project-alterra/hospital-management-system-api/features/schedules/mocks"
 	"github.com/final-project-alterra/hospital-management-system-api/utils/files"
-	"github.com/final-project-alterra/hospital-management-system-api/utils/hash"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -24,8 +25,9 @@ var (
 
 	adminBusiness    am.IBusiness
 	doctorBusiness   doctors.IBusiness
-	nurseBusiness    nm.IBusiness
+	accountBusiness  acm.IBusiness
 	scheduleBusiness sm.IBusiness
+	auditBusiness    aum.IBusiness
 
 	adminMaster admins.AdminCore
 	doctorHan   doctors.DoctorCore
@@ -41,10 +43,13 @@ func TestMain(m *testing.M) {
 	doctorBusiness = d.NewDoctorBusinessBuilder().
 		SetData(&doctorData).
 		SetAdminBusiness(&adminBusiness).
-		SetNurseBusiness(&nurseBusiness).
+		SetAccountBusiness(&accountBusiness).
 		SetScheduleBusiness(&scheduleBusiness).
+		SetAuditBusiness(&auditBusiness).
 		Build()
 
+	auditBusiness.On("Record", mock.AnythingOfType("audits.AuditLogCore")).Return()
+
 	adminMaster = admins.AdminCore{
 		ID:   1,
 		Name: "Master admin",
@@ -152,6 +157,33 @@ func TestFindDoctorById(t *testing.T) {
 	})
 }
 
+func TestFindDoctorsByRoomId(t *testing.T) {
+	t.Run("valid - find doctors by room id", func(t *testing.T) {
+		doctorData.
+			On("SelectDoctorsByRoomId", room1.ID).
+			Return([]doctors.DoctorCore{doctorHan}, nil).
+			Once()
+
+		result, err := doctorBusiness.FindDoctorsByRoomId(room1.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+	})
+
+	t.Run("valid - error occurs on find doctors by room id", func(t *testing.T) {
+		doctorData.
+			On("SelectDoctorsByRoomId", room1.ID).
+			Return([]doctors.DoctorCore{}, errServer).
+			Once()
+
+		result, err := doctorBusiness.FindDoctorsByRoomId(room1.ID)
+
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+		assert.Equal(t, 0, len(result))
+	})
+}
+
 func TestFindDoctorByEmail(t *testing.T) {
 	t.Run("valid - FindDoctorByEmail", func(t *testing.T) {
 		doctorData.
@@ -196,23 +228,20 @@ func TestCreateDoctor(t *testing.T) {
 			Return(room1, nil).
 			Once()
 
-		doctorData.
-			On("SelectDoctorByEmail", mock.AnythingOfType("string")).
-			Return(doctors.DoctorCore{}, errNotFound).
-			Once()
-
-		adminBusiness.
-			On("FindAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
-			Once()
-
-		nurseBusiness.
-			On("FindNurseByEmail", mock.AnythingOfType("string")).
-			Return(nurses.NurseCore{}, errNotFound).
+		accountBusiness.
+			On("CheckEmail", doctorHan.Email).
+			Return(nil).
 			Once()
 
 		doctorData.
 			On("InsertDoctor", mock.AnythingOfType("doctors.DoctorCore")).
+			Return(2, nil).
+			Once()
+
+		accountBusiness.
+			On("CreateAccount", mock.MatchedBy(func(account accounts.AccountCore) bool {
+				return account.UserID == 2 && account.Role == "doctor" && account.Password == doctorHan.Password
+			})).
 			Return(nil).
 			Once()
 
@@ -301,68 +330,29 @@ func TestCreateDoctor(t *testing.T) {
 		adminBusiness.
 			On("FindAdminById", mock.AnythingOfType("int")).
 			Return(adminMaster, nil).
-			Times(3)
+			Once()
 
 		doctorData.
 			On("SelectSpecialityById", mock.AnythingOfType("int")).
 			Return(speciality1, nil).
-			Times(3)
+			Once()
 
 		doctorData.
 			On("SelectRoomById", mock.AnythingOfType("int")).
 			Return(room1, nil).
-			Times(3)
-
-		for i := 0; i < 3; i++ {
-			switch i {
-			case 0:
-				adminBusiness.
-					On("FindAdminByEmail", mock.AnythingOfType("string")).
-					Return(admins.AdminCore{ID: 1}, nil).
-					Once()
-				doctorData.
-					On("SelectDoctorByEmail", mock.AnythingOfType("string")).
-					Return(doctors.DoctorCore{}, errNotFound).
-					Once()
-				nurseBusiness.
-					On("FindNurseByEmail", mock.AnythingOfType("string")).
-					Return(nurses.NurseCore{}, errNotFound).
-					Once()
-			case 1:
-				adminBusiness.
-					On("FindAdminByEmail", mock.AnythingOfType("string")).
-					Return(admins.AdminCore{}, errNotFound).
-					Once()
-				doctorData.
-					On("SelectDoctorByEmail", mock.AnythingOfType("string")).
-					Return(doctors.DoctorCore{ID: 1}, nil).
-					Once()
-				nurseBusiness.
-					On("FindNurseByEmail", mock.AnythingOfType("string")).
-					Return(nurses.NurseCore{}, errNotFound).
-					Once()
-			case 2:
-				adminBusiness.
-					On("FindAdminByEmail", mock.AnythingOfType("string")).
-					Return(admins.AdminCore{}, errNotFound).
-					Once()
-				doctorData.
-					On("SelectDoctorByEmail", mock.AnythingOfType("string")).
-					Return(doctors.DoctorCore{}, errNotFound).
-					Once()
-				nurseBusiness.
-					On("FindNurseByEmail", mock.AnythingOfType("string")).
-					Return(nurses.NurseCore{ID: 1}, nil).
-					Once()
-			}
-
-			newDoctor := doctorHan
-			newDoctor.ID = 0
-			newDoctor.CreatedBy = adminMaster.ID
-			err := doctorBusiness.CreateDoctor(newDoctor)
-
-			assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
-		}
+			Once()
+
+		accountBusiness.
+			On("CheckEmail", doctorHan.Email).
+			Return(errUnprocessable).
+			Once()
+
+		newDoctor := doctorHan
+		newDoctor.ID = 0
+		newDoctor.CreatedBy = adminMaster.ID
+		err := doctorBusiness.CreateDoctor(newDoctor)
+
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 
 	t.Run("valid - when InsertDoctor error", func(t *testing.T) {
@@ -381,26 +371,60 @@ func TestCreateDoctor(t *testing.T) {
 			Return(room1, nil).
 			Once()
 
+		accountBusiness.
+			On("CheckEmail", doctorHan.Email).
+			Return(nil).
+			Once()
+
 		doctorData.
-			On("SelectDoctorByEmail", mock.AnythingOfType("string")).
-			Return(doctors.DoctorCore{}, errNotFound).
+			On("InsertDoctor", mock.AnythingOfType("doctors.DoctorCore")).
+			Return(0, errServer).
 			Once()
 
+		newDoctor := doctorHan
+		newDoctor.ID = 0
+		newDoctor.CreatedBy = adminMaster.ID
+		err := doctorBusiness.CreateDoctor(newDoctor)
+
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+
+	t.Run("valid - when CreateAccount error, the new doctor is rolled back", func(t *testing.T) {
 		adminBusiness.
-			On("FindAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
+			On("FindAdminById", mock.AnythingOfType("int")).
+			Return(adminMaster, nil).
 			Once()
 
-		nurseBusiness.
-			On("FindNurseByEmail", mock.AnythingOfType("string")).
-			Return(nurses.NurseCore{}, errNotFound).
+		doctorData.
+			On("SelectSpecialityById", mock.AnythingOfType("int")).
+			Return(speciality1, nil).
+			Once()
+
+		doctorData.
+			On("SelectRoomById", mock.AnythingOfType("int")).
+			Return(room1, nil).
+			Once()
+
+		accountBusiness.
+			On("CheckEmail", doctorHan.Email).
+			Return(nil).
 			Once()
 
 		doctorData.
 			On("InsertDoctor", mock.AnythingOfType("doctors.DoctorCore")).
+			Return(2, nil).
+			Once()
+
+		accountBusiness.
+			On("CreateAccount", mock.AnythingOfType("accounts.AccountCore")).
 			Return(errServer).
 			Once()
 
+		doctorData.
+			On("DeleteDoctorById", 2, adminMaster.ID).
+			Return(nil).
+			Once()
+
 		newDoctor := doctorHan
 		newDoctor.ID = 0
 		newDoctor.CreatedBy = adminMaster.ID
@@ -441,7 +465,7 @@ func TestEditDoctor(t *testing.T) {
 		updatedDoctor.ID = 1
 		updatedDoctor.CreatedBy = adminMaster.ID
 		updatedDoctor.UpdatedBy = adminMaster.ID
-		err := doctorBusiness.EditDoctor(updatedDoctor)
+		err := doctorBusiness.EditDoctor(updatedDoctor, "admin")
 
 		assert.Nil(t, err)
 	})
@@ -456,7 +480,7 @@ func TestEditDoctor(t *testing.T) {
 		updatedDoctor.ID = 1
 		updatedDoctor.CreatedBy = adminMaster.ID
 		updatedDoctor.UpdatedBy = adminMaster.ID
-		err := doctorBusiness.EditDoctor(updatedDoctor)
+		err := doctorBusiness.EditDoctor(updatedDoctor, "admin")
 
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
@@ -476,7 +500,7 @@ func TestEditDoctor(t *testing.T) {
 		updatedDoctor.ID = 1
 		updatedDoctor.CreatedBy = adminMaster.ID
 		updatedDoctor.UpdatedBy = adminMaster.ID
-		err := doctorBusiness.EditDoctor(updatedDoctor)
+		err := doctorBusiness.EditDoctor(updatedDoctor, "admin")
 
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
@@ -501,7 +525,7 @@ func TestEditDoctor(t *testing.T) {
 		updatedDoctor.ID = 1
 		updatedDoctor.CreatedBy = adminMaster.ID
 		updatedDoctor.UpdatedBy = adminMaster.ID
-		err := doctorBusiness.EditDoctor(updatedDoctor)
+		err := doctorBusiness.EditDoctor(updatedDoctor, "admin")
 
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
@@ -531,7 +555,7 @@ func TestEditDoctor(t *testing.T) {
 		updatedDoctor.ID = 1
 		updatedDoctor.CreatedBy = adminMaster.ID
 		updatedDoctor.UpdatedBy = adminMaster.ID
-		err := doctorBusiness.EditDoctor(updatedDoctor)
+		err := doctorBusiness.EditDoctor(updatedDoctor, "admin")
 
 		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
 	})
@@ -561,7 +585,7 @@ func TestEditDoctor(t *testing.T) {
 		updatedDoctor.ID = 1
 		updatedDoctor.CreatedBy = adminMaster.ID
 		updatedDoctor.UpdatedBy = adminMaster.ID
-		err := doctorBusiness.EditDoctor(updatedDoctor)
+		err := doctorBusiness.EditDoctor(updatedDoctor, "admin")
 
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
@@ -596,7 +620,7 @@ func TestEditDoctor(t *testing.T) {
 		updatedDoctor.ID = 1
 		updatedDoctor.CreatedBy = adminMaster.ID
 		updatedDoctor.UpdatedBy = adminMaster.ID
-		err := doctorBusiness.EditDoctor(updatedDoctor)
+		err := doctorBusiness.EditDoctor(updatedDoctor, "admin")
 
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
@@ -605,11 +629,6 @@ func TestEditDoctor(t *testing.T) {
 func TestEditDoctorPassword(t *testing.T) {
 	t.Run("valid - when eveerything is fine", func(t *testing.T) {
 		doctorHanRecord := doctorHan
-		hashed, err := hash.Generate(doctorHan.Password)
-		if err != nil {
-			panic(err)
-		}
-		doctorHanRecord.Password = hashed
 
 		adminBusiness.
 			On("FindAdminById", mock.AnythingOfType("int")).
@@ -621,23 +640,23 @@ func TestEditDoctorPassword(t *testing.T) {
 			Return(doctorHanRecord, nil).
 			Once()
 
+		accountBusiness.
+			On("EditAccountPassword", doctorHan.ID, "doctor", doctorHan.Password, "new password").
+			Return(nil).
+			Once()
+
 		doctorData.
 			On("UpdateDoctor", mock.AnythingOfType("doctors.DoctorCore")).
 			Return(nil).
 			Once()
 
-		err = doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, doctorHan.Password, "new password")
+		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, "admin", doctorHan.Password, "new password")
 
 		assert.Nil(t, err)
 	})
 
 	t.Run("valid - when UpdateDoctor error", func(t *testing.T) {
 		doctorHanRecord := doctorHan
-		hashed, err := hash.Generate(doctorHan.Password)
-		if err != nil {
-			panic(err)
-		}
-		doctorHanRecord.Password = hashed
 
 		adminBusiness.
 			On("FindAdminById", mock.AnythingOfType("int")).
@@ -649,12 +668,17 @@ func TestEditDoctorPassword(t *testing.T) {
 			Return(doctorHanRecord, nil).
 			Once()
 
+		accountBusiness.
+			On("EditAccountPassword", doctorHan.ID, "doctor", doctorHan.Password, "new password").
+			Return(nil).
+			Once()
+
 		doctorData.
 			On("UpdateDoctor", mock.AnythingOfType("doctors.DoctorCore")).
 			Return(errServer).
 			Once()
 
-		err = doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, doctorHan.Password, "new password")
+		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, "admin", doctorHan.Password, "new password")
 
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
@@ -665,7 +689,7 @@ func TestEditDoctorPassword(t *testing.T) {
 			Return(admins.AdminCore{}, errNotFound).
 			Once()
 
-		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, doctorHan.Password, "new password")
+		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, "admin", doctorHan.Password, "new password")
 
 		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
 	})
@@ -677,7 +701,7 @@ func TestEditDoctorPassword(t *testing.T) {
 			Return(admins.AdminCore{}, errServer).
 			Once()
 
-		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, doctorHan.Password, "new password")
+		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, "admin", doctorHan.Password, "new password")
 
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
@@ -693,7 +717,7 @@ func TestEditDoctorPassword(t *testing.T) {
 			Return(doctors.DoctorCore{}, errNotFound).
 			Once()
 
-		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, doctorHan.Password, "new password")
+		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, "admin", doctorHan.Password, "new password")
 
 		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
 	})
@@ -709,18 +733,13 @@ func TestEditDoctorPassword(t *testing.T) {
 			Return(doctors.DoctorCore{}, errServer).
 			Once()
 
-		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, doctorHan.Password, "new password")
+		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, "admin", doctorHan.Password, "new password")
 
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
 
 	t.Run("valid - when old password does not match", func(t *testing.T) {
 		doctorHanRecord := doctorHan
-		hashed, err := hash.Generate(doctorHan.Password)
-		if err != nil {
-			panic(err)
-		}
-		doctorHanRecord.Password = hashed
 
 		adminBusiness.
 			On("FindAdminById", mock.AnythingOfType("int")).
@@ -732,12 +751,12 @@ func TestEditDoctorPassword(t *testing.T) {
 			Return(doctorHanRecord, nil).
 			Once()
 
-		doctorData.
-			On("UpdateDoctor", mock.AnythingOfType("doctors.DoctorCore")).
-			Return(nil).
+		accountBusiness.
+			On("EditAccountPassword", doctorHan.ID, "doctor", "wrong old password", "new password").
+			Return(errUnprocessable).
 			Once()
 
-		err = doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, "wrong old password", "new password")
+		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, adminMaster.ID, "admin", "wrong old password", "new password")
 
 		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
@@ -760,7 +779,7 @@ func TestEditDoctorImageProfile(t *testing.T) {
 			Return(nil).
 			Once()
 
-		err := doctorBusiness.EditDoctorImageProfile(doctorHan)
+		err := doctorBusiness.EditDoctorImageProfile(doctorHan, "admin")
 		assert.Nil(t, err)
 	})
 
@@ -770,7 +789,7 @@ func TestEditDoctorImageProfile(t *testing.T) {
 			Return(admins.AdminCore{}, errServer).
 			Once()
 
-		err := doctorBusiness.EditDoctorImageProfile(doctorHan)
+		err := doctorBusiness.EditDoctorImageProfile(doctorHan, "admin")
 		assert.Error(t, err)
 	})
 
@@ -785,12 +804,101 @@ func TestEditDoctorImageProfile(t *testing.T) {
 			Return(doctors.DoctorCore{}, errServer).
 			Once()
 
-		err := doctorBusiness.EditDoctorImageProfile(doctorHan)
+		err := doctorBusiness.EditDoctorImageProfile(doctorHan, "admin")
 		assert.Error(t, err)
 	})
 
 }
 
+func TestEditDoctorByThemself(t *testing.T) {
+	t.Run("valid - when doctor edits own profile", func(t *testing.T) {
+		doctorData.
+			On("SelectDoctorById", doctorHan.ID).
+			Return(doctorHan, nil).
+			Once()
+
+		doctorData.
+			On("SelectSpecialityById", speciality1.ID).
+			Return(speciality1, nil).
+			Once()
+
+		doctorData.
+			On("SelectRoomById", room1.ID).
+			Return(room1, nil).
+			Once()
+
+		doctorData.
+			On("UpdateDoctor", mock.MatchedBy(func(doctor doctors.DoctorCore) bool {
+				return doctor.Phone == "0812345678" && doctor.UpdatedBy == doctorHan.UpdatedBy
+			})).
+			Return(nil).
+			Once()
+
+		edited := doctorHan
+		edited.UpdatedBy = doctorHan.ID
+		edited.Phone = "0812345678"
+		err := doctorBusiness.EditDoctor(edited, "doctor")
+		assert.Nil(t, err)
+	})
+
+	t.Run("error - when doctor edits another doctor", func(t *testing.T) {
+		doctorData.
+			On("SelectDoctorById", doctorHan.ID).
+			Return(doctorHan, nil).
+			Once()
+
+		doctorData.
+			On("SelectSpecialityById", speciality1.ID).
+			Return(speciality1, nil).
+			Once()
+
+		doctorData.
+			On("SelectRoomById", room1.ID).
+			Return(room1, nil).
+			Once()
+
+		edited := doctorHan
+		edited.UpdatedBy = doctorHan.ID + 1
+		err := doctorBusiness.EditDoctor(edited, "doctor")
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when doctor changes own password", func(t *testing.T) {
+		doctorData.
+			On("SelectDoctorById", doctorHan.ID).
+			Return(doctorHan, nil).
+			Once()
+
+		accountBusiness.
+			On("EditAccountPassword", doctorHan.ID, "doctor", "12345678", "87654321").
+			Return(nil).
+			Once()
+
+		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, doctorHan.ID, "doctor", "12345678", "87654321")
+		assert.Nil(t, err)
+	})
+
+	t.Run("error - when doctor changes another doctor password", func(t *testing.T) {
+		err := doctorBusiness.EditDoctorPassword(doctorHan.ID, doctorHan.ID+1, "doctor", "12345678", "87654321")
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when doctor changes own image", func(t *testing.T) {
+		doctorData.
+			On("SelectDoctorById", doctorHan.ID).
+			Return(doctorHan, nil).
+			Once()
+
+		doctorData.
+			On("UpdateDoctor", mock.AnythingOfType("doctors.DoctorCore")).
+			Return(nil).
+			Once()
+
+		err := doctorBusiness.EditDoctorImageProfile(doctors.DoctorCore{ID: doctorHan.ID, UpdatedBy: doctorHan.ID, ImageUrl: "new.jpg"}, "doctor")
+		assert.Nil(t, err)
+	})
+}
+
 func TestRemoveDoctorById(t *testing.T) {
 	t.Run("valid - when everything is fine", func(t *testing.T) {
 		adminBusiness.
@@ -813,6 +921,11 @@ func TestRemoveDoctorById(t *testing.T) {
 			Return(nil).
 			Once()
 
+		accountBusiness.
+			On("RemoveAccountByUser", doctorHan.ID, "doctor").
+			Return(nil).
+			Once()
+
 		err := doctorBusiness.RemoveDoctorById(doctorHan.ID, adminMaster.ID)
 
 		assert.Nil(t, err)
@@ -963,10 +1076,10 @@ func TestCreateSpeciality(t *testing.T) {
 	t.Run("valid - when everything is fine", func(t *testing.T) {
 		doctorData.
 			On("InsertSpeciality", mock.AnythingOfType("doctors.SpecialityCore")).
-			Return(nil).
+			Return(1, nil).
 			Once()
 
-		err := doctorBusiness.CreateSpeciality(speciality1)
+		err := doctorBusiness.CreateSpeciality(speciality1, adminMaster.ID, "admin")
 
 		assert.Nil(t, err)
 	})
@@ -974,10 +1087,10 @@ func TestCreateSpeciality(t *testing.T) {
 	t.Run("valid - when InsertSpeciality error", func(t *testing.T) {
 		doctorData.
 			On("InsertSpeciality", mock.AnythingOfType("doctors.SpecialityCore")).
-			Return(errServer).
+			Return(0, errServer).
 			Once()
 
-		err := doctorBusiness.CreateSpeciality(speciality1)
+		err := doctorBusiness.CreateSpeciality(speciality1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
@@ -996,7 +1109,7 @@ func TestEditSpeciality(t *testing.T) {
 			Return(nil).
 			Once()
 
-		err := doctorBusiness.EditSpeciality(speciality1)
+		err := doctorBusiness.EditSpeciality(speciality1, adminMaster.ID, "admin")
 
 		assert.Nil(t, err)
 	})
@@ -1007,7 +1120,7 @@ func TestEditSpeciality(t *testing.T) {
 			Return(doctors.SpecialityCore{}, errNotFound).
 			Once()
 
-		err := doctorBusiness.EditSpeciality(speciality1)
+		err := doctorBusiness.EditSpeciality(speciality1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 	})
@@ -1023,7 +1136,7 @@ func TestEditSpeciality(t *testing.T) {
 			Return(errServer).
 			Once()
 
-		err := doctorBusiness.EditSpeciality(speciality1)
+		err := doctorBusiness.EditSpeciality(speciality1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 	})
@@ -1031,6 +1144,11 @@ func TestEditSpeciality(t *testing.T) {
 
 func TestRemoveSpeciality(t *testing.T) {
 	t.Run("valid - when everyhing is fine", func(t *testing.T) {
+		doctorData.
+			On("SelectSpecialityById", 1).
+			Return(speciality1, nil).
+			Once()
+
 		doctorData.
 			On("SelectDoctorsBySpecialityId", mock.AnythingOfType("int")).
 			Return([]doctors.DoctorCore{}, nil).
@@ -1041,34 +1159,61 @@ func TestRemoveSpeciality(t *testing.T) {
 			Return(nil).
 			Once()
 
-		err := doctorBusiness.RemoveSpeciality(1)
+		err := doctorBusiness.RemoveSpeciality(1, adminMaster.ID, "admin")
 
 		assert.Nil(t, err)
 	})
 
+	t.Run("valid - when speciality is not found", func(t *testing.T) {
+		doctorData.
+			On("SelectSpecialityById", 1).
+			Return(doctors.SpecialityCore{}, errNotFound).
+			Once()
+
+		err := doctorBusiness.RemoveSpeciality(1, adminMaster.ID, "admin")
+
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+
 	t.Run("valid - SelectDoctorsBySpecialityId return error", func(t *testing.T) {
+		doctorData.
+			On("SelectSpecialityById", 1).
+			Return(speciality1, nil).
+			Once()
+
 		doctorData.
 			On("SelectDoctorsBySpecialityId", mock.AnythingOfType("int")).
 			Return([]doctors.DoctorCore{}, errServer).
 			Once()
 
-		err := doctorBusiness.RemoveSpeciality(1)
+		err := doctorBusiness.RemoveSpeciality(1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 	})
 
 	t.Run("valid - when there exists doctor with that speciality", func(t *testing.T) {
+		doctorData.
+			On("SelectSpecialityById", 1).
+			Return(speciality1, nil).
+			Once()
+
 		doctorData.
 			On("SelectDoctorsBySpecialityId", mock.AnythingOfType("int")).
 			Return([]doctors.DoctorCore{doctorHan}, nil).
 			Once()
 
-		err := doctorBusiness.RemoveSpeciality(1)
+		err := doctorBusiness.RemoveSpeciality(1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 	})
 
 	t.Run("valid - when DeleteSpecialityId error", func(t *testing.T) {
+		doctorData.
+			On("SelectSpecialityById", 1).
+			Return(speciality1, nil).
+			Once()
+
 		doctorData.
 			On("SelectDoctorsBySpecialityId", mock.AnythingOfType("int")).
 			Return([]doctors.DoctorCore{}, nil).
@@ -1079,7 +1224,7 @@ func TestRemoveSpeciality(t *testing.T) {
 			Return(errServer).
 			Once()
 
-		err := doctorBusiness.RemoveSpeciality(1)
+		err := doctorBusiness.RemoveSpeciality(1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 	})
@@ -1121,12 +1266,15 @@ func TestCreateRoom(t *testing.T) {
 
 		doctorData.
 			On("InsertRoom", mock.AnythingOfType("doctors.RoomCore")).
-			Return(nil).
+			Return(1, nil).
 			Once()
 
-		err := doctorBusiness.CreateRoom(room1)
+		err := doctorBusiness.CreateRoom(room1, adminMaster.ID, "admin")
 
 		assert.Nil(t, err)
+		auditBusiness.AssertCalled(t, "Record", mock.MatchedBy(func(log audits.AuditLogCore) bool {
+			return log.Operation == "doctors.business.CreateRoom" && log.Entity == audits.EntityRoom && log.ActorID == adminMaster.ID
+		}))
 	})
 
 	t.Run("valid - when found a room with the same code", func(t *testing.T) {
@@ -1135,7 +1283,7 @@ func TestCreateRoom(t *testing.T) {
 			Return(doctors.RoomCore{ID: 2, Code: room1.Code}, nil).
 			Once()
 
-		err := doctorBusiness.CreateRoom(room1)
+		err := doctorBusiness.CreateRoom(room1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
@@ -1147,7 +1295,7 @@ func TestCreateRoom(t *testing.T) {
 			Return(doctors.RoomCore{}, errServer).
 			Once()
 
-		err := doctorBusiness.CreateRoom(room1)
+		err := doctorBusiness.CreateRoom(room1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
@@ -1161,10 +1309,10 @@ func TestCreateRoom(t *testing.T) {
 
 		doctorData.
 			On("InsertRoom", mock.AnythingOfType("doctors.RoomCore")).
-			Return(errServer).
+			Return(0, errServer).
 			Once()
 
-		err := doctorBusiness.CreateRoom(room1)
+		err := doctorBusiness.CreateRoom(room1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 	})
@@ -1187,7 +1335,7 @@ func TestEditRoom(t *testing.T) {
 			Return(nil).
 			Once()
 
-		err := doctorBusiness.EditRoom(room1)
+		err := doctorBusiness.EditRoom(room1, adminMaster.ID, "admin")
 
 		assert.Nil(t, err)
 	})
@@ -1198,7 +1346,7 @@ func TestEditRoom(t *testing.T) {
 			Return(doctors.RoomCore{}, errServer).
 			Once()
 
-		err := doctorBusiness.EditRoom(room1)
+		err := doctorBusiness.EditRoom(room1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 	})
@@ -1214,7 +1362,7 @@ func TestEditRoom(t *testing.T) {
 			Return(doctors.RoomCore{ID: 2, Code: room1.Code}, nil).
 			Once()
 
-		err := doctorBusiness.EditRoom(room1)
+		err := doctorBusiness.EditRoom(room1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 	})
@@ -1230,7 +1378,7 @@ func TestEditRoom(t *testing.T) {
 			Return(doctors.RoomCore{}, errServer).
 			Once()
 
-		err := doctorBusiness.EditRoom(room1)
+		err := doctorBusiness.EditRoom(room1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 	})
@@ -1251,7 +1399,7 @@ func TestEditRoom(t *testing.T) {
 			Return(errServer).
 			Once()
 
-		err := doctorBusiness.EditRoom(room1)
+		err := doctorBusiness.EditRoom(room1, adminMaster.ID, "admin")
 
 		assert.Error(t, err)
 	})
@@ -1260,6 +1408,11 @@ func TestEditRoom(t *testing.T) {
 
 func TestDeleteRoom(t *testing.T) {
 	t.Run("valid - when everything is fine", func(t *testing.T) {
+		doctorData.
+			On("SelectRoomById", 1).
+			Return(room1, nil).
+			Once()
+
 		doctorData.
 			On("SelectDoctorsByRoomId", mock.AnythingOfType("int")).
 			Return([]doctors.DoctorCore{}, nil).
@@ -1270,31 +1423,57 @@ func TestDeleteRoom(t *testing.T) {
 			Return(nil).
 			Once()
 
-		err := doctorBusiness.RemoveRoomById(1)
+		err := doctorBusiness.RemoveRoomById(1, adminMaster.ID, "admin")
 		assert.Nil(t, err)
 	})
 
+	t.Run("valid - when room is not found", func(t *testing.T) {
+		doctorData.
+			On("SelectRoomById", 1).
+			Return(doctors.RoomCore{}, errNotFound).
+			Once()
+
+		err := doctorBusiness.RemoveRoomById(1, adminMaster.ID, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+
 	t.Run("valid - when SelectDoctorsByRoomId return error", func(t *testing.T) {
+		doctorData.
+			On("SelectRoomById", 1).
+			Return(room1, nil).
+			Once()
+
 		doctorData.
 			On("SelectDoctorsByRoomId", mock.AnythingOfType("int")).
 			Return([]doctors.DoctorCore{}, errServer).
 			Once()
 
-		err := doctorBusiness.RemoveRoomById(1)
+		err := doctorBusiness.RemoveRoomById(1, adminMaster.ID, "admin")
 		assert.Error(t, err)
 	})
 
 	t.Run("valid - when there are still doctor using the room", func(t *testing.T) {
+		doctorData.
+			On("SelectRoomById", 1).
+			Return(room1, nil).
+			Once()
+
 		doctorData.
 			On("SelectDoctorsByRoomId", mock.AnythingOfType("int")).
 			Return([]doctors.DoctorCore{doctorHan}, nil).
 			Once()
 
-		err := doctorBusiness.RemoveRoomById(1)
+		err := doctorBusiness.RemoveRoomById(1, adminMaster.ID, "admin")
 		assert.Error(t, err)
 	})
 
 	t.Run("valid - when DeleteRoomById return error", func(t *testing.T) {
+		doctorData.
+			On("SelectRoomById", 1).
+			Return(room1, nil).
+			Once()
+
 		doctorData.
 			On("SelectDoctorsByRoomId", mock.AnythingOfType("int")).
 			Return([]doctors.DoctorCore{}, nil).
@@ -1305,7 +1484,7 @@ func TestDeleteRoom(t *testing.T) {
 			Return(errServer).
 			Once()
 
-		err := doctorBusiness.RemoveRoomById(1)
+		err := doctorBusiness.RemoveRoomById(1, adminMaster.ID, "admin")
 		assert.Error(t, err)
 	})
 
@@ -100,7 +100,7 @@ func (r *mySQLRepo) SelectDoctorByEmail(email string) (doctors.DoctorCore, error
 	}
 	return doctorRecord.ToDoctorCore(), nil
 }
-func (r *mySQLRepo) InsertDoctor(doctor doctors.DoctorCore) error {
+func (r *mySQLRepo) InsertDoctor(doctor doctors.DoctorCore) (int, error) {
 	const op errors.Op = "doctors.data.InsertDoctor"
 	var errMessage errors.ErrClientMessage = "Something went wrong"
 
@@ -111,7 +111,6 @@ func (r *mySQLRepo) InsertDoctor(doctor doctors.DoctorCore) error {
 
 		Name:      doctor.Name,
 		Email:     doctor.Email,
-		Password:  doctor.Password,
 		Phone:     doctor.Phone,
 		Gender:    doctor.Gender,
 		BirthDate: doctor.BirthDate,
@@ -120,9 +119,9 @@ func (r *mySQLRepo) InsertDoctor(doctor doctors.DoctorCore) error {
 	}
 	err := r.db.Create(&doctorRecord).Error
 	if err != nil {
-		return errors.E(err, op, errMessage, errors.KindServerError)
+		return 0, errors.E(err, op, errMessage, errors.KindServerError)
 	}
-	return nil
+	return int(doctorRecord.ID), nil
 }
 func (r *mySQLRepo) UpdateDoctor(doctor doctors.DoctorCore) error {
 	const op errors.Op = "doctors.data.UpdateDoctor"
@@ -140,7 +139,6 @@ func (r *mySQLRepo) UpdateDoctor(doctor doctors.DoctorCore) error {
 
 		Name:      doctor.Name,
 		Email:     doctor.Email,
-		Password:  doctor.Password,
 		Phone:     doctor.Phone,
 		Gender:    doctor.Gender,
 		BirthDate: doctor.BirthDate,
@@ -199,7 +197,7 @@ func (r *mySQLRepo) SelectSpecialityById(id int) (doctors.SpecialityCore, error)
 	}
 	return specialityRecord.ToSpecialityCore(), nil
 }
-func (r *mySQLRepo) InsertSpeciality(speciality doctors.SpecialityCore) error {
+func (r *mySQLRepo) InsertSpeciality(speciality doctors.SpecialityCore) (int, error) {
 	const op errors.Op = "doctors.data.InsertSpeciality"
 	var errMessage errors.ErrClientMessage = "Something went wrong"
 
@@ -208,9 +206,9 @@ func (r *mySQLRepo) InsertSpeciality(speciality doctors.SpecialityCore) error {
 	}
 	err := r.db.Create(&specialityRecord).Error
 	if err != nil {
-		return errors.E(err, op, errMessage, errors.KindServerError)
+		return 0, errors.E(err, op, errMessage, errors.KindServerError)
 	}
-	return nil
+	return int(specialityRecord.ID), nil
 }
 func (r *mySQLRepo) UpdateSpeciality(speciality doctors.SpecialityCore) error {
 	const op errors.Op = "doctors.data.UpdateSpeciality"
@@ -288,7 +286,7 @@ func (r *mySQLRepo) SelectRoomByCode(code string) (doctors.RoomCore, error) {
 	}
 	return roomRecord.ToRoomCore(), nil
 }
-func (r *mySQLRepo) InsertRoom(room doctors.RoomCore) error {
+func (r *mySQLRepo) InsertRoom(room doctors.RoomCore) (int, error) {
 	const op errors.Op = "doctors.data.InsertRoom"
 	var errMessage errors.ErrClientMessage = "Something went wrong"
 
@@ -298,9 +296,9 @@ func (r *mySQLRepo) InsertRoom(room doctors.RoomCore) error {
 	}
 	err := r.db.Create(&roomRecord).Error
 	if err != nil {
-		return errors.E(err, op, errMessage, errors.KindServerError)
+		return 0, errors.E(err, op, errMessage, errors.KindServerError)
 	}
-	return nil
+	return int(roomRecord.ID), nil
 }
 func (r *mySQLRepo) UpdateRoom(room doctors.RoomCore) error {
 	const op errors.Op = "doctors.data.UpdateRoom"
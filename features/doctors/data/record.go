@@ -18,7 +18,6 @@ type Doctor struct {
 	Room         Room
 
 	Email     string `gorm:"type:varchar(64);unique;not null"`
-	Password  string `gorm:"type:varchar(128);not null"`
 	Name      string `gorm:"type:varchar(64);not null"`
 	Phone     string `gorm:"type:varchar(14)"`
 	Gender    string `gorm:"type:varchar(1);not null"`
@@ -49,7 +48,6 @@ func (d Doctor) ToDoctorCore() doctors.DoctorCore {
 		Room:       d.Room.ToRoomCore(),
 
 		Email:     d.Email,
-		Password:  d.Password,
 		Name:      d.Name,
 		BirthDate: strings.Split(d.BirthDate, "T")[0],
 		ImageUrl:  d.ImageUrl,
@@ -10,7 +10,7 @@ type DoctorCore struct {
 	Room       RoomCore
 
 	Email     string
-	Password  string
+	Password  string // only filled on create, stored in accounts
 	Name      string
 	BirthDate string
 	ImageUrl  string
@@ -40,23 +40,24 @@ type IBusiness interface {
 	FindDoctors() ([]DoctorCore, error)
 	FindDoctorsByIds(ids []int) ([]DoctorCore, error)
 	FindDoctorById(id int) (DoctorCore, error)
+	FindDoctorsByRoomId(roomId int) ([]DoctorCore, error) // used by schedules to find doctors sharing a room
 	FindDoctorByEmail(email string) (DoctorCore, error)
 	CreateDoctor(doctor DoctorCore) error
-	EditDoctor(doctor DoctorCore) error
-	EditDoctorImageProfile(doctor DoctorCore) error
-	EditDoctorPassword(id int, updatedBy int, oldPassword string, newPassword string) error
+	EditDoctor(doctor DoctorCore, role string) error // role is the editor's, an admin or the doctor themself
+	EditDoctorImageProfile(doctor DoctorCore, role string) error
+	EditDoctorPassword(id int, updatedBy int, role string, oldPassword string, newPassword string) error
 	RemoveDoctorById(id int, updatedBy int) error
 
 	FindSpecialities() ([]SpecialityCore, error)
 	FindSpecialityById(id int) (SpecialityCore, error)
-	CreateSpeciality(speciality SpecialityCore) error
-	EditSpeciality(speciality SpecialityCore) error
-	RemoveSpeciality(id int) error
+	CreateSpeciality(speciality SpecialityCore, userId int, role string) error
+	EditSpeciality(speciality SpecialityCore, userId int, role string) error
+	RemoveSpeciality(id int, userId int, role string) error
 
 	FindRooms() ([]RoomCore, error)
-	CreateRoom(room RoomCore) error
-	EditRoom(room RoomCore) error
-	RemoveRoomById(id int) error
+	CreateRoom(room RoomCore, userId int, role string) error
+	EditRoom(room RoomCore, userId int, role string) error
+	RemoveRoomById(id int, userId int, role string) error
 }
 
 type IData interface {
@@ -68,20 +69,20 @@ type IData interface {
 	SelectDoctorById(id int) (DoctorCore, error)
 
 	SelectDoctorByEmail(email string) (DoctorCore, error)
-	InsertDoctor(doctor DoctorCore) error
+	InsertDoctor(doctor DoctorCore) (int, error)
 	UpdateDoctor(doctor DoctorCore) error
 	DeleteDoctorById(id int, updatedBy int) error
 
 	SelectSpecialities() ([]SpecialityCore, error)
 	SelectSpecialityById(id int) (SpecialityCore, error)
-	InsertSpeciality(speciality SpecialityCore) error
+	InsertSpeciality(speciality SpecialityCore) (int, error)
 	UpdateSpeciality(speciality SpecialityCore) error
 	DeleteSpecialityId(id int) error
 
 	SelectRooms() ([]RoomCore, error)
 	SelectRoomById(id int) (RoomCore, error)
 	SelectRoomByCode(code string) (RoomCore, error)
-	InsertRoom(room RoomCore) error
+	InsertRoom(room RoomCore) (int, error)
 	UpdateRoom(room RoomCore) error
 	DeleteRoomById(id int) error
 }
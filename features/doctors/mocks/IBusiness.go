@@ -26,13 +26,13 @@ func (_m *IBusiness) CreateDoctor(doctor doctors.DoctorCore) error {
 	return r0
 }
 
-// CreateRoom provides a mock function with given fields: room
-func (_m *IBusiness) CreateRoom(room doctors.RoomCore) error {
-	ret := _m.Called(room)
+// CreateRoom provides a mock function with given fields: room, userId, role
+func (_m *IBusiness) CreateRoom(room doctors.RoomCore, userId int, role string) error {
+	ret := _m.Called(room, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(doctors.RoomCore) error); ok {
-		r0 = rf(room)
+	if rf, ok := ret.Get(0).(func(doctors.RoomCore, int, string) error); ok {
+		r0 = rf(room, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -40,13 +40,13 @@ func (_m *IBusiness) CreateRoom(room doctors.RoomCore) error {
 	return r0
 }
 
-// CreateSpeciality provides a mock function with given fields: speciality
-func (_m *IBusiness) CreateSpeciality(speciality doctors.SpecialityCore) error {
-	ret := _m.Called(speciality)
+// CreateSpeciality provides a mock function with given fields: speciality, userId, role
+func (_m *IBusiness) CreateSpeciality(speciality doctors.SpecialityCore, userId int, role string) error {
+	ret := _m.Called(speciality, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(doctors.SpecialityCore) error); ok {
-		r0 = rf(speciality)
+	if rf, ok := ret.Get(0).(func(doctors.SpecialityCore, int, string) error); ok {
+		r0 = rf(speciality, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -54,13 +54,13 @@ func (_m *IBusiness) CreateSpeciality(speciality doctors.SpecialityCore) error {
 	return r0
 }
 
-// EditDoctor provides a mock function with given fields: doctor
-func (_m *IBusiness) EditDoctor(doctor doctors.DoctorCore) error {
-	ret := _m.Called(doctor)
+// EditDoctor provides a mock function with given fields: doctor, role
+func (_m *IBusiness) EditDoctor(doctor doctors.DoctorCore, role string) error {
+	ret := _m.Called(doctor, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(doctors.DoctorCore) error); ok {
-		r0 = rf(doctor)
+	if rf, ok := ret.Get(0).(func(doctors.DoctorCore, string) error); ok {
+		r0 = rf(doctor, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -68,13 +68,13 @@ func (_m *IBusiness) EditDoctor(doctor doctors.DoctorCore) error {
 	return r0
 }
 
-// EditDoctorImageProfile provides a mock function with given fields: doctor
-func (_m *IBusiness) EditDoctorImageProfile(doctor doctors.DoctorCore) error {
-	ret := _m.Called(doctor)
+// EditDoctorImageProfile provides a mock function with given fields: doctor, role
+func (_m *IBusiness) EditDoctorImageProfile(doctor doctors.DoctorCore, role string) error {
+	ret := _m.Called(doctor, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(doctors.DoctorCore) error); ok {
-		r0 = rf(doctor)
+	if rf, ok := ret.Get(0).(func(doctors.DoctorCore, string) error); ok {
+		r0 = rf(doctor, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -82,13 +82,13 @@ func (_m *IBusiness) EditDoctorImageProfile(doctor doctors.DoctorCore) error {
 	return r0
 }
 
-// EditDoctorPassword provides a mock function with given fields: id, updatedBy, oldPassword, newPassword
-func (_m *IBusiness) EditDoctorPassword(id int, updatedBy int, oldPassword string, newPassword string) error {
-	ret := _m.Called(id, updatedBy, oldPassword, newPassword)
+// EditDoctorPassword provides a mock function with given fields: id, updatedBy, role, oldPassword, newPassword
+func (_m *IBusiness) EditDoctorPassword(id int, updatedBy int, role string, oldPassword string, newPassword string) error {
+	ret := _m.Called(id, updatedBy, role, oldPassword, newPassword)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, int, string, string) error); ok {
-		r0 = rf(id, updatedBy, oldPassword, newPassword)
+	if rf, ok := ret.Get(0).(func(int, int, string, string, string) error); ok {
+		r0 = rf(id, updatedBy, role, oldPassword, newPassword)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -96,13 +96,13 @@ func (_m *IBusiness) EditDoctorPassword(id int, updatedBy int, oldPassword strin
 	return r0
 }
 
-// EditRoom provides a mock function with given fields: room
-func (_m *IBusiness) EditRoom(room doctors.RoomCore) error {
-	ret := _m.Called(room)
+// EditRoom provides a mock function with given fields: room, userId, role
+func (_m *IBusiness) EditRoom(room doctors.RoomCore, userId int, role string) error {
+	ret := _m.Called(room, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(doctors.RoomCore) error); ok {
-		r0 = rf(room)
+	if rf, ok := ret.Get(0).(func(doctors.RoomCore, int, string) error); ok {
+		r0 = rf(room, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -110,13 +110,13 @@ func (_m *IBusiness) EditRoom(room doctors.RoomCore) error {
 	return r0
 }
 
-// EditSpeciality provides a mock function with given fields: speciality
-func (_m *IBusiness) EditSpeciality(speciality doctors.SpecialityCore) error {
-	ret := _m.Called(speciality)
+// EditSpeciality provides a mock function with given fields: speciality, userId, role
+func (_m *IBusiness) EditSpeciality(speciality doctors.SpecialityCore, userId int, role string) error {
+	ret := _m.Called(speciality, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(doctors.SpecialityCore) error); ok {
-		r0 = rf(speciality)
+	if rf, ok := ret.Get(0).(func(doctors.SpecialityCore, int, string) error); ok {
+		r0 = rf(speciality, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -212,6 +212,29 @@ func (_m *IBusiness) FindDoctorsByIds(ids []int) ([]doctors.DoctorCore, error) {
 	return r0, r1
 }
 
+// FindDoctorsByRoomId provides a mock function with given fields: roomId
+func (_m *IBusiness) FindDoctorsByRoomId(roomId int) ([]doctors.DoctorCore, error) {
+	ret := _m.Called(roomId)
+
+	var r0 []doctors.DoctorCore
+	if rf, ok := ret.Get(0).(func(int) []doctors.DoctorCore); ok {
+		r0 = rf(roomId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]doctors.DoctorCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(roomId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindRooms provides a mock function with given fields:
 func (_m *IBusiness) FindRooms() ([]doctors.RoomCore, error) {
 	ret := _m.Called()
@@ -293,13 +316,13 @@ func (_m *IBusiness) RemoveDoctorById(id int, updatedBy int) error {
 	return r0
 }
 
-// RemoveRoomById provides a mock function with given fields: id
-func (_m *IBusiness) RemoveRoomById(id int) error {
-	ret := _m.Called(id)
+// RemoveRoomById provides a mock function with given fields: id, userId, role
+func (_m *IBusiness) RemoveRoomById(id int, userId int, role string) error {
+	ret := _m.Called(id, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(int, int, string) error); ok {
+		r0 = rf(id, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -307,13 +330,13 @@ func (_m *IBusiness) RemoveRoomById(id int) error {
 	return r0
 }
 
-// RemoveSpeciality provides a mock function with given fields: id
-func (_m *IBusiness) RemoveSpeciality(id int) error {
-	ret := _m.Called(id)
+// RemoveSpeciality provides a mock function with given fields: id, userId, role
+func (_m *IBusiness) RemoveSpeciality(id int, userId int, role string) error {
+	ret := _m.Called(id, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(int, int, string) error); ok {
+		r0 = rf(id, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -55,45 +55,66 @@ func (_m *IData) DeleteSpecialityId(id int) error {
 }
 
 // InsertDoctor provides a mock function with given fields: doctor
-func (_m *IData) InsertDoctor(doctor doctors.DoctorCore) error {
+func (_m *IData) InsertDoctor(doctor doctors.DoctorCore) (int, error) {
 	ret := _m.Called(doctor)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(doctors.DoctorCore) error); ok {
+	var r0 int
+	if rf, ok := ret.Get(0).(func(doctors.DoctorCore) int); ok {
 		r0 = rf(doctor)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(doctors.DoctorCore) error); ok {
+		r1 = rf(doctor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // InsertRoom provides a mock function with given fields: room
-func (_m *IData) InsertRoom(room doctors.RoomCore) error {
+func (_m *IData) InsertRoom(room doctors.RoomCore) (int, error) {
 	ret := _m.Called(room)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(doctors.RoomCore) error); ok {
+	var r0 int
+	if rf, ok := ret.Get(0).(func(doctors.RoomCore) int); ok {
 		r0 = rf(room)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(doctors.RoomCore) error); ok {
+		r1 = rf(room)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // InsertSpeciality provides a mock function with given fields: speciality
-func (_m *IData) InsertSpeciality(speciality doctors.SpecialityCore) error {
+func (_m *IData) InsertSpeciality(speciality doctors.SpecialityCore) (int, error) {
 	ret := _m.Called(speciality)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(doctors.SpecialityCore) error); ok {
+	var r0 int
+	if rf, ok := ret.Get(0).(func(doctors.SpecialityCore) int); ok {
 		r0 = rf(speciality)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(doctors.SpecialityCore) error); ok {
+		r1 = rf(speciality)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // SelectDoctorByEmail provides a mock function with given fields: email
@@ -129,7 +129,7 @@ func (dp DoctorPresentation) PutEditDoctor(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
 	}
 
-	err = dp.business.EditDoctor(doctor.ToDoctorCore())
+	err = dp.business.EditDoctor(doctor.ToDoctorCore(), c.Get("role").(string))
 	if err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
@@ -164,7 +164,7 @@ func (dp DoctorPresentation) PutEditDoctorPassword(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
 	}
 
-	err = dp.business.EditDoctorPassword(doctor.ID, updatedBy, doctor.OldPassword, doctor.NewPassword)
+	err = dp.business.EditDoctorPassword(doctor.ID, updatedBy, c.Get("role").(string), doctor.OldPassword, doctor.NewPassword)
 	if err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
@@ -192,7 +192,7 @@ func (ap *DoctorPresentation) PutEditImageProfile(c echo.Context) error {
 	}
 
 	updatedDoctor := doctors.DoctorCore{ID: doctorID, UpdatedBy: updatedBy, ImageUrl: filename}
-	err = ap.business.EditDoctorImageProfile(updatedDoctor)
+	err = ap.business.EditDoctorImageProfile(updatedDoctor, c.Get("role").(string))
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -214,7 +214,7 @@ func (ap *DoctorPresentation) DeleteImageProfile(c echo.Context) error {
 	}
 
 	updatedDoctor := doctors.DoctorCore{ID: doctorID, UpdatedBy: updatedBy, ImageUrl: ""}
-	err = ap.business.EditDoctorImageProfile(updatedDoctor)
+	err = ap.business.EditDoctorImageProfile(updatedDoctor, c.Get("role").(string))
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -248,6 +248,123 @@ func (dp *DoctorPresentation) DeleteDoctor(c echo.Context) error {
 	return response.Success(c, status, message, nil)
 }
 
+func (dp *DoctorPresentation) GetOwnProfile(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving own profile"
+	const op errors.Op = "doctors.presentation.GetOwnProfile"
+
+	doctorId := c.Get("userId").(int)
+
+	doctor, err := dp.business.FindDoctorById(doctorId)
+	if err != nil {
+		return response.Error(c, errors.E(op, err))
+	}
+	return response.Success(c, status, message, response.DetailDoctor(doctor))
+}
+
+func (dp *DoctorPresentation) PutEditOwnProfile(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success updating own profile"
+	const op errors.Op = "doctors.presentation.PutEditOwnProfile"
+	var errMessage errors.ErrClientMessage
+
+	doctorId := c.Get("userId").(int)
+
+	current, err := dp.business.FindDoctorById(doctorId)
+	if err != nil {
+		return response.Error(c, errors.E(op, err))
+	}
+
+	var doctor request.UpdateOwnDoctorRequest
+
+	err = c.Bind(&doctor)
+	if err != nil {
+		errMessage = "Invalid doctor payload request"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = dp.valitate.Struct(doctor)
+	if err != nil {
+		errMessage = "Invalid payload request. Makesure all field is filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	err = dp.business.EditDoctor(doctor.ToDoctorCore(current), c.Get("role").(string))
+	if err != nil {
+		return response.Error(c, errors.E(op, err))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
+func (dp *DoctorPresentation) PutEditOwnPassword(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success updating own password"
+	const op errors.Op = "doctors.presentation.PutEditOwnPassword"
+	var errMessage errors.ErrClientMessage
+
+	doctorId := c.Get("userId").(int)
+
+	var req request.UpdateOwnPasswordRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		errMessage = "Invalid doctor payload request"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = dp.valitate.Struct(req)
+	if err != nil {
+		errMessage = "Invalid. Makesure all field is filled & new password is 8 character long"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	err = dp.business.EditDoctorPassword(doctorId, doctorId, c.Get("role").(string), req.OldPassword, req.NewPassword)
+	if err != nil {
+		return response.Error(c, errors.E(op, err))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
+func (ap *DoctorPresentation) PutEditOwnImageProfile(c echo.Context) error {
+	status := http.StatusOK
+	message := "Image profile updated"
+	const op errors.Op = "doctors.presentation.PutEditOwnImageProfile"
+
+	doctorId := c.Get("userId").(int)
+
+	destDirectory := path.Join(project.GetMainDir(), "files")
+	filename, err := ap.allocateFile(c, destDirectory)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	updatedDoctor := doctors.DoctorCore{ID: doctorId, UpdatedBy: doctorId, ImageUrl: filename}
+	err = ap.business.EditDoctorImageProfile(updatedDoctor, c.Get("role").(string))
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
+func (ap *DoctorPresentation) DeleteOwnImageProfile(c echo.Context) error {
+	status := http.StatusOK
+	message := "Image profile deleted"
+	const op errors.Op = "doctors.presentation.DeleteOwnImageProfile"
+
+	doctorId := c.Get("userId").(int)
+
+	updatedDoctor := doctors.DoctorCore{ID: doctorId, UpdatedBy: doctorId, ImageUrl: ""}
+	err := ap.business.EditDoctorImageProfile(updatedDoctor, c.Get("role").(string))
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
 func (dp *DoctorPresentation) GetSpecialities(c echo.Context) error {
 	status := http.StatusOK
 	message := "Success retrieving specialities"
@@ -300,7 +417,9 @@ func (dp *DoctorPresentation) PostSpeciality(c echo.Context) error {
 		return response.Error(c, errors.E(op, err, errMessage, errors.KindUnprocessable))
 	}
 
-	err = dp.business.CreateSpeciality(speciality.ToSpecialityCore())
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = dp.business.CreateSpeciality(speciality.ToSpecialityCore(), userId, role)
 	if err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
@@ -327,7 +446,9 @@ func (dp *DoctorPresentation) PutEditSpeciality(c echo.Context) error {
 		return response.Error(c, errors.E(op, err, errMessage, errors.KindUnprocessable))
 	}
 
-	err = dp.business.EditSpeciality(speciality.ToSpecialityCore())
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = dp.business.EditSpeciality(speciality.ToSpecialityCore(), userId, role)
 	if err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
@@ -347,7 +468,9 @@ func (dp *DoctorPresentation) DeleteSpeciality(c echo.Context) error {
 		return response.Error(c, errors.E(op, err, errMessage, errors.KindBadRequest))
 	}
 
-	err = dp.business.RemoveSpeciality(specialityId)
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = dp.business.RemoveSpeciality(specialityId, userId, role)
 	if err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
@@ -387,7 +510,9 @@ func (dp *DoctorPresentation) PostRoom(c echo.Context) error {
 		return response.Error(c, errors.E(op, err, errMessage, errors.KindUnprocessable))
 	}
 
-	err = dp.business.CreateRoom(room.ToRoomCore())
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = dp.business.CreateRoom(room.ToRoomCore(), userId, role)
 	if err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
@@ -413,7 +538,9 @@ func (dp *DoctorPresentation) PutEditRoom(c echo.Context) error {
 		return response.Error(c, errors.E(op, err, errMessage, errors.KindUnprocessable))
 	}
 
-	err = dp.business.EditRoom(room.ToRoomCore())
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = dp.business.EditRoom(room.ToRoomCore(), userId, role)
 	if err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
@@ -433,7 +560,9 @@ func (dp *DoctorPresentation) DeleteRoom(c echo.Context) error {
 		return response.Error(c, errors.E(op, err, errMessage, errors.KindBadRequest))
 	}
 
-	err = dp.business.RemoveRoomById(roomId)
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = dp.business.RemoveRoomById(roomId, userId, role)
 	if err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
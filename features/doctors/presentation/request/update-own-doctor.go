@@ -0,0 +1,22 @@
+package request
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/doctors"
+
+// Doctor can only change their own contact, the rest is managed by admin
+type UpdateOwnDoctorRequest struct {
+	Phone   string `json:"phone"`
+	Address string `json:"address"`
+}
+
+// ToDoctorCore keeps the rest of the current profile untouched
+func (d UpdateOwnDoctorRequest) ToDoctorCore(current doctors.DoctorCore) doctors.DoctorCore {
+	current.UpdatedBy = current.ID
+	current.Phone = d.Phone
+	current.Address = d.Address
+	return current
+}
+
+type UpdateOwnPasswordRequest struct {
+	OldPassword string `json:"oldPassword" validate:"required"`
+	NewPassword string `json:"newPassword" validate:"required,min=8"`
+}
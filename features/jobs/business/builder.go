@@ -0,0 +1,45 @@
+package business
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/jobs"
+	"github.com/google/uuid"
+)
+
+type jobBusinessBuilder struct {
+	data          jobs.IData
+	auditBusiness audits.IBusiness
+}
+
+func NewJobBusinessBuilder() *jobBusinessBuilder {
+	return &jobBusinessBuilder{}
+}
+
+func (b *jobBusinessBuilder) SetData(data jobs.IData) *jobBusinessBuilder {
+	b.data = data
+	return b
+}
+
+func (b *jobBusinessBuilder) SetAuditBusiness(ab audits.IBusiness) *jobBusinessBuilder {
+	b.auditBusiness = ab
+	return b
+}
+
+func (b *jobBusinessBuilder) Build() jobs.IBusiness {
+	hostname, _ := os.Hostname()
+
+	jobBusiness := &jobBusiness{
+		data:          b.data,
+		auditBusiness: b.auditBusiness,
+		instance:      fmt.Sprintf("%s-%s", hostname, uuid.New().String()[:8]),
+		jobs:          make(map[string]*scheduledJob),
+	}
+
+	b.data = nil
+	b.auditBusiness = nil
+
+	return jobBusiness
+}
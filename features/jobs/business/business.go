@@ -0,0 +1,279 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/jobs"
+	"github.com/final-project-alterra/hospital-management-system-api/utils/cron"
+)
+
+type jobBusiness struct {
+	data          jobs.IData
+	auditBusiness audits.IBusiness
+	instance      string // holder of the locks taken by this instance
+
+	mu   sync.RWMutex
+	jobs map[string]*scheduledJob
+}
+
+type scheduledJob struct {
+	job      jobs.JobCore
+	schedule cron.Schedule
+	next     time.Time
+}
+
+func (j *jobBusiness) Register(job jobs.JobCore) error {
+	const op errors.Op = "jobs.business.Register"
+	var errMessage errors.ErrClientMessage
+
+	schedule, err := cron.Parse(job.Schedule)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, ok := j.jobs[job.Name]; ok {
+		errMessage = "Job is already registered"
+		return errors.E(errors.New(string(errMessage)), op, errMessage, errors.KindUnprocessable)
+	}
+
+	j.jobs[job.Name] = &scheduledJob{
+		job:      job,
+		schedule: schedule,
+		next:     schedule.Next(time.Now().In(config.GetTimeLoc())),
+	}
+	return nil
+}
+
+func (j *jobBusiness) Start() {
+	go func() {
+		for {
+			now := time.Now().In(config.GetTimeLoc())
+			time.Sleep(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+			j.runDueJobs(time.Now().In(config.GetTimeLoc()))
+		}
+	}()
+}
+
+func (j *jobBusiness) FindJobs() ([]jobs.JobCore, error) {
+	const op errors.Op = "jobs.business.FindJobs"
+
+	j.mu.RLock()
+	result := make([]jobs.JobCore, 0, len(j.jobs))
+	for _, sj := range j.jobs {
+		job := sj.job
+		job.NextRunAt = sj.next
+		result = append(result, job)
+	}
+	j.mu.RUnlock()
+
+	sort.Slice(result, func(a, b int) bool { return result[a].Name < result[b].Name })
+
+	names := make([]string, len(result))
+	for i := range result {
+		names[i] = result[i].Name
+	}
+
+	lastRuns, err := j.data.SelectLatestJobRuns(names)
+	if err != nil {
+		return []jobs.JobCore{}, errors.E(err, op)
+	}
+
+	for i := range result {
+		result[i].LastRun = lastRuns[result[i].Name]
+	}
+	return result, nil
+}
+
+func (j *jobBusiness) FindJobRuns(q jobs.JobRunQuery) ([]jobs.JobRunCore, error) {
+	const op errors.Op = "jobs.business.FindJobRuns"
+
+	if q.Job != "" {
+		if _, err := j.findJob(q.Job); err != nil {
+			return []jobs.JobRunCore{}, errors.E(err, op)
+		}
+	}
+
+	if q.Limit < 1 {
+		q.Limit = jobs.DEFAULT_LIMIT
+	}
+	if q.Limit > jobs.MAX_LIMIT {
+		q.Limit = jobs.MAX_LIMIT
+	}
+
+	runs, err := j.data.SelectJobRuns(q)
+	if err != nil {
+		return []jobs.JobRunCore{}, errors.E(err, op)
+	}
+	return runs, nil
+}
+
+func (j *jobBusiness) TriggerJob(name string, userId int, role string) (jobs.JobRunCore, error) {
+	const op errors.Op = "jobs.business.TriggerJob"
+	var errMessage errors.ErrClientMessage
+
+	job, err := j.findJob(name)
+	if err != nil {
+		return jobs.JobRunCore{}, errors.E(err, op)
+	}
+
+	now := time.Now().In(config.GetTimeLoc())
+	acquired, err := j.data.AcquireLock(name, j.instance, time.Time{}, now.Add(jobs.LOCK_LEASE))
+	if err != nil {
+		return jobs.JobRunCore{}, errors.E(err, op)
+	}
+
+	if !acquired {
+		errMessage = "Job is already running"
+		return jobs.JobRunCore{}, errors.E(errors.New(string(errMessage)), op, errMessage, errors.KindUnprocessable)
+	}
+
+	run := jobs.JobRunCore{
+		Job:         name,
+		Status:      jobs.StatusRunning,
+		Trigger:     jobs.TriggerManual,
+		TriggeredBy: userId,
+		Instance:    j.instance,
+		StartedAt:   now,
+	}
+
+	run.ID, err = j.data.InsertJobRun(run)
+	if err != nil {
+		j.releaseLock(name)
+		return jobs.JobRunCore{}, errors.E(err, op)
+	}
+
+	j.auditBusiness.Record(audits.AuditLogCore{
+		ActorID:   userId,
+		ActorRole: role,
+		Operation: string(op),
+		Entity:    audits.EntityJob,
+		EntityID:  run.ID,
+		After:     run,
+	})
+
+	go j.execute(job, run)
+	return run, nil
+}
+
+// Private methods
+
+func (j *jobBusiness) findJob(name string) (jobs.JobCore, error) {
+	const op errors.Op = "jobs.business.findJob"
+	var errMessage errors.ErrClientMessage = "Job not found"
+
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	sj, ok := j.jobs[name]
+	if !ok {
+		return jobs.JobCore{}, errors.E(errors.New(string(errMessage)), op, errMessage, errors.KindNotFound)
+	}
+	return sj.job, nil
+}
+
+// runDueJobs starts the jobs whose time has come, a missed occurrence (e.g. the instance
+// was down) is not caught up
+func (j *jobBusiness) runDueJobs(now time.Time) {
+	j.mu.Lock()
+	due := map[string]time.Time{}
+	for name, sj := range j.jobs {
+		if sj.next.IsZero() || now.Before(sj.next) {
+			continue
+		}
+		due[name] = sj.next
+		sj.next = sj.schedule.Next(now)
+	}
+	j.mu.Unlock()
+
+	for name, scheduledAt := range due {
+		go j.runScheduled(name, scheduledAt)
+	}
+}
+
+// runScheduled runs the occurrence at scheduledAt unless another instance already took it
+func (j *jobBusiness) runScheduled(name string, scheduledAt time.Time) {
+	const op errors.Op = "jobs.business.runScheduled"
+
+	job, err := j.findJob(name)
+	if err != nil {
+		errors.Log(errors.E(err, op))
+		return
+	}
+
+	now := time.Now().In(config.GetTimeLoc())
+	acquired, err := j.data.AcquireLock(name, j.instance, scheduledAt, now.Add(jobs.LOCK_LEASE))
+	if err != nil {
+		errors.Log(errors.E(err, op))
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	run := jobs.JobRunCore{
+		Job:       name,
+		Status:    jobs.StatusRunning,
+		Trigger:   jobs.TriggerSchedule,
+		Instance:  j.instance,
+		StartedAt: now,
+	}
+
+	run.ID, err = j.data.InsertJobRun(run)
+	if err != nil {
+		j.releaseLock(name)
+		errors.Log(errors.E(err, op))
+		return
+	}
+
+	j.execute(job, run)
+}
+
+// execute runs the job and keeps its outcome, a panic fails the run instead of the API
+func (j *jobBusiness) execute(job jobs.JobCore, run jobs.JobRunCore) {
+	const op errors.Op = "jobs.business.execute"
+
+	defer j.releaseLock(job.Name)
+	defer func() {
+		if r := recover(); r != nil {
+			run.Status = jobs.StatusFailed
+			run.Error = fmt.Sprintf("panic: %v", r)
+			j.finish(run)
+		}
+	}()
+
+	result, err := job.Func()
+	run.Result = result
+	run.Status = jobs.StatusSucceeded
+	if err != nil {
+		run.Status = jobs.StatusFailed
+		run.Error = err.Error()
+		errors.Log(errors.E(err, op))
+	}
+	j.finish(run)
+}
+
+func (j *jobBusiness) finish(run jobs.JobRunCore) {
+	const op errors.Op = "jobs.business.finish"
+
+	run.FinishedAt = time.Now().In(config.GetTimeLoc())
+	if err := j.data.UpdateJobRun(run); err != nil {
+		errors.Log(errors.E(err, op))
+	}
+}
+
+func (j *jobBusiness) releaseLock(name string) {
+	const op errors.Op = "jobs.business.releaseLock"
+
+	if err := j.data.ReleaseLock(name, j.instance); err != nil {
+		errors.Log(errors.E(err, op))
+	}
+}
@@ -0,0 +1,288 @@
+package business_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	aum "github.com/final-project-alterra/hospital-management-system-api/features/audits/mocks"
+	jb "github.com/final-project-alterra/hospital-management-system-api/features/jobs/business"
+	jm "github.com/final-project-alterra/hospital-management-system-api/features/jobs/mocks"
+)
+
+var (
+	repo     jm.IData
+	business jobs.IBusiness
+
+	auditBusiness aum.IBusiness
+
+	// what the registered job does when it runs
+	work func() (string, error)
+
+	anyString interface{}
+	anyTime   interface{}
+
+	errServer error
+)
+
+func TestMain(m *testing.M) {
+	config.InitTimeLoc("Asia/Jakarta")
+
+	business = jb.NewJobBusinessBuilder().
+		SetData(&repo).
+		SetAuditBusiness(&auditBusiness).
+		Build()
+
+	auditBusiness.On("Record", mock.AnythingOfType("audits.AuditLogCore")).Return()
+
+	err := business.Register(jobs.JobCore{
+		Name:        "cleanup",
+		Description: "Cleans up",
+		Schedule:    "*/5 * * * *",
+		Func:        func() (string, error) { return work() },
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	anyString = mock.AnythingOfType("string")
+	anyTime = mock.AnythingOfType("time.Time")
+
+	errServer = errors.E(errors.New("server"), errors.KindServerError)
+
+	os.Exit(m.Run())
+}
+
+// expectRun waits for the background run and returns how it was finished
+func expectRun(t *testing.T) func() jobs.JobRunCore {
+	finished := make(chan jobs.JobRunCore, 1)
+	released := make(chan bool, 1)
+
+	repo.
+		On("UpdateJobRun", mock.AnythingOfType("jobs.JobRunCore")).
+		Run(func(args mock.Arguments) { finished <- args.Get(0).(jobs.JobRunCore) }).
+		Return(nil).
+		Once()
+
+	repo.
+		On("ReleaseLock", "cleanup", anyString).
+		Run(func(args mock.Arguments) { released <- true }).
+		Return(nil).
+		Once()
+
+	return func() jobs.JobRunCore {
+		var run jobs.JobRunCore
+		select {
+		case run = <-finished:
+		case <-time.After(time.Second):
+			t.Fatal("job run was not finished")
+		}
+		select {
+		case <-released:
+		case <-time.After(time.Second):
+			t.Fatal("job lock was not released")
+		}
+		return run
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("valid - when schedule is invalid", func(t *testing.T) {
+		err := business.Register(jobs.JobCore{Name: "invalid", Schedule: "every minute"})
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
+	})
+
+	t.Run("valid - when name is already registered", func(t *testing.T) {
+		err := business.Register(jobs.JobCore{Name: "cleanup", Schedule: "@daily"})
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+}
+
+func TestFindJobs(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		lastRun := jobs.JobRunCore{ID: 3, Job: "cleanup", Status: jobs.StatusSucceeded}
+
+		repo.
+			On("SelectLatestJobRuns", []string{"cleanup"}).
+			Return(map[string]jobs.JobRunCore{"cleanup": lastRun}, nil).
+			Once()
+
+		result, err := business.FindJobs()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, "*/5 * * * *", result[0].Schedule)
+		assert.Equal(t, lastRun, result[0].LastRun)
+		assert.True(t, result[0].NextRunAt.After(time.Now()))
+		assert.Equal(t, 0, result[0].NextRunAt.Minute()%5)
+	})
+
+	t.Run("valid - SelectLatestJobRuns error", func(t *testing.T) {
+		repo.
+			On("SelectLatestJobRuns", []string{"cleanup"}).
+			Return(map[string]jobs.JobRunCore{}, errServer).
+			Once()
+
+		_, err := business.FindJobs()
+		assert.Error(t, err)
+	})
+}
+
+func TestFindJobRuns(t *testing.T) {
+	t.Run("valid - when limit is left out", func(t *testing.T) {
+		repo.
+			On("SelectJobRuns", jobs.JobRunQuery{Job: "cleanup", Limit: jobs.DEFAULT_LIMIT}).
+			Return([]jobs.JobRunCore{{ID: 1}}, nil).
+			Once()
+
+		result, err := business.FindJobRuns(jobs.JobRunQuery{Job: "cleanup"})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+	})
+
+	t.Run("valid - when limit is too high", func(t *testing.T) {
+		repo.
+			On("SelectJobRuns", jobs.JobRunQuery{Job: "cleanup", Limit: jobs.MAX_LIMIT}).
+			Return([]jobs.JobRunCore{}, nil).
+			Once()
+
+		_, err := business.FindJobRuns(jobs.JobRunQuery{Job: "cleanup", Limit: 100000})
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when job is unknown", func(t *testing.T) {
+		_, err := business.FindJobRuns(jobs.JobRunQuery{Job: "unknown"})
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+
+	t.Run("valid - SelectJobRuns error", func(t *testing.T) {
+		repo.
+			On("SelectJobRuns", jobs.JobRunQuery{Job: "cleanup", Limit: jobs.DEFAULT_LIMIT}).
+			Return([]jobs.JobRunCore{}, errServer).
+			Once()
+
+		_, err := business.FindJobRuns(jobs.JobRunQuery{Job: "cleanup"})
+		assert.Error(t, err)
+	})
+}
+
+func TestTriggerJob(t *testing.T) {
+	t.Run("valid - when job succeeds", func(t *testing.T) {
+		work = func() (string, error) { return "3 rows", nil }
+
+		repo.
+			On("AcquireLock", "cleanup", anyString, time.Time{}, anyTime).
+			Return(true, nil).
+			Once()
+
+		repo.
+			On("InsertJobRun", mock.MatchedBy(func(r jobs.JobRunCore) bool {
+				return r.Trigger == jobs.TriggerManual && r.TriggeredBy == 1 && r.Status == jobs.StatusRunning
+			})).
+			Return(7, nil).
+			Once()
+
+		wait := expectRun(t)
+
+		run, err := business.TriggerJob("cleanup", 1, "admin")
+		assert.Nil(t, err)
+		assert.Equal(t, 7, run.ID)
+
+		finished := wait()
+		assert.Equal(t, 7, finished.ID)
+		assert.Equal(t, jobs.StatusSucceeded, finished.Status)
+		assert.Equal(t, "3 rows", finished.Result)
+		assert.False(t, finished.FinishedAt.IsZero())
+	})
+
+	t.Run("valid - when job fails", func(t *testing.T) {
+		work = func() (string, error) { return "", errServer }
+
+		repo.
+			On("AcquireLock", "cleanup", anyString, time.Time{}, anyTime).
+			Return(true, nil).
+			Once()
+
+		repo.
+			On("InsertJobRun", mock.AnythingOfType("jobs.JobRunCore")).
+			Return(8, nil).
+			Once()
+
+		wait := expectRun(t)
+
+		_, err := business.TriggerJob("cleanup", 1, "admin")
+		assert.Nil(t, err)
+
+		finished := wait()
+		assert.Equal(t, jobs.StatusFailed, finished.Status)
+		assert.Equal(t, errServer.Error(), finished.Error)
+	})
+
+	t.Run("valid - when job panics", func(t *testing.T) {
+		work = func() (string, error) { panic("boom") }
+
+		repo.
+			On("AcquireLock", "cleanup", anyString, time.Time{}, anyTime).
+			Return(true, nil).
+			Once()
+
+		repo.
+			On("InsertJobRun", mock.AnythingOfType("jobs.JobRunCore")).
+			Return(9, nil).
+			Once()
+
+		wait := expectRun(t)
+
+		_, err := business.TriggerJob("cleanup", 1, "admin")
+		assert.Nil(t, err)
+
+		finished := wait()
+		assert.Equal(t, jobs.StatusFailed, finished.Status)
+		assert.Equal(t, "panic: boom", finished.Error)
+	})
+
+	t.Run("valid - when job is already running", func(t *testing.T) {
+		repo.
+			On("AcquireLock", "cleanup", anyString, time.Time{}, anyTime).
+			Return(false, nil).
+			Once()
+
+		_, err := business.TriggerJob("cleanup", 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when job is unknown", func(t *testing.T) {
+		_, err := business.TriggerJob("unknown", 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+
+	t.Run("valid - InsertJobRun error releases the lock", func(t *testing.T) {
+		repo.
+			On("AcquireLock", "cleanup", anyString, time.Time{}, anyTime).
+			Return(true, nil).
+			Once()
+
+		repo.
+			On("InsertJobRun", mock.AnythingOfType("jobs.JobRunCore")).
+			Return(0, errServer).
+			Once()
+
+		repo.
+			On("ReleaseLock", "cleanup", anyString).
+			Return(nil).
+			Once()
+
+		_, err := business.TriggerJob("cleanup", 1, "admin")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import "time"
+
+const (
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+const (
+	// LOCK_LEASE is the longest a run holds its lock, the lock of an instance that died
+	// mid run is free again afterwards
+	LOCK_LEASE = 30 * time.Minute
+
+	DEFAULT_LIMIT = 50
+	MAX_LIMIT     = 500
+)
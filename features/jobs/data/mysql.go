@@ -0,0 +1,136 @@
+package data
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/jobs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// neverLocked is the lease and occurrence of a lock row that was just created
+var neverLocked = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type mySQLRepo struct {
+	db *gorm.DB
+}
+
+func NewMySQLRepo(db *gorm.DB) *mySQLRepo {
+	return &mySQLRepo{db: db}
+}
+
+func (r *mySQLRepo) AcquireLock(job string, holder string, scheduledAt time.Time, until time.Time) (bool, error) {
+	const op errors.Op = "jobs.data.AcquireLock"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	lock := JobLock{Job: job, LockedUntil: neverLocked, ScheduledAt: neverLocked}
+	err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&lock).Error
+	if err != nil {
+		return false, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+
+	// a single conditional update, only one instance can win it
+	updates := map[string]interface{}{"holder": holder, "locked_until": until}
+	tx := r.db.Model(&JobLock{}).Where("job = ? AND locked_until < ?", job, time.Now())
+	if !scheduledAt.IsZero() {
+		tx = tx.Where("scheduled_at < ?", scheduledAt)
+		updates["scheduled_at"] = scheduledAt
+	}
+
+	result := tx.Updates(updates)
+	if result.Error != nil {
+		return false, errors.E(result.Error, op, errMessage, errors.KindServerError)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *mySQLRepo) ReleaseLock(job string, holder string) error {
+	const op errors.Op = "jobs.data.ReleaseLock"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.
+		Model(&JobLock{}).
+		Where("job = ? AND holder = ?", job, holder).
+		Update("locked_until", neverLocked).
+		Error
+
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) SelectJobRuns(q jobs.JobRunQuery) ([]jobs.JobRunCore, error) {
+	const op errors.Op = "jobs.data.SelectJobRuns"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	tx := r.db.Order("id DESC").Limit(q.Limit)
+	if q.Job != "" {
+		tx = tx.Where("job = ?", q.Job)
+	}
+
+	runs := []JobRun{}
+	err := tx.Find(&runs).Error
+	if err != nil {
+		return []jobs.JobRunCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return toSliceJobRunCore(runs), nil
+}
+
+func (r *mySQLRepo) SelectLatestJobRuns(jobNames []string) (map[string]jobs.JobRunCore, error) {
+	const op errors.Op = "jobs.data.SelectLatestJobRuns"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	latest := r.db.
+		Model(&JobRun{}).
+		Select("MAX(id)").
+		Where("job IN ?", jobNames).
+		Group("job")
+
+	runs := []JobRun{}
+	err := r.db.Where("id IN (?)", latest).Find(&runs).Error
+	if err != nil {
+		return map[string]jobs.JobRunCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+
+	result := make(map[string]jobs.JobRunCore)
+	for _, run := range runs {
+		result[run.Job] = run.toJobRunCore()
+	}
+	return result, nil
+}
+
+func (r *mySQLRepo) InsertJobRun(run jobs.JobRunCore) (int, error) {
+	const op errors.Op = "jobs.data.InsertJobRun"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	record := toJobRunRecord(run)
+	err := r.db.Create(&record).Error
+	if err != nil {
+		return 0, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return int(record.ID), nil
+}
+
+func (r *mySQLRepo) UpdateJobRun(run jobs.JobRunCore) error {
+	const op errors.Op = "jobs.data.UpdateJobRun"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	record := toJobRunRecord(run)
+	err := r.db.
+		Model(&JobRun{}).
+		Where("id = ?", run.ID).
+		Updates(map[string]interface{}{
+			"status":      record.Status,
+			"result":      record.Result,
+			"error":       record.Error,
+			"finished_at": record.FinishedAt,
+		}).
+		Error
+
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package data
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/jobs"
+	"gorm.io/gorm"
+)
+
+// JobLock is held by the instance running a job, see jobs.IData.AcquireLock
+type JobLock struct {
+	Job         string    `gorm:"type:varchar(100);primaryKey"`
+	Holder      string    `gorm:"type:varchar(100);not null"`
+	LockedUntil time.Time `gorm:"not null"`
+	ScheduledAt time.Time `gorm:"not null"` // last scheduled occurrence taken
+}
+
+type JobRun struct {
+	gorm.Model
+	Job         string `gorm:"type:varchar(100);not null;index"`
+	Status      string `gorm:"type:varchar(20);not null"`
+	TriggerType string `gorm:"type:varchar(20);not null"` // trigger is a reserved word in MySQL
+	TriggeredBy int
+	Instance    string `gorm:"type:varchar(100);not null"`
+	Result      string `gorm:"type:text"`
+	Error       string `gorm:"type:text"`
+	StartedAt   time.Time
+	FinishedAt  *time.Time
+}
+
+func (r JobRun) toJobRunCore() jobs.JobRunCore {
+	run := jobs.JobRunCore{
+		ID:          int(r.ID),
+		Job:         r.Job,
+		Status:      r.Status,
+		Trigger:     r.TriggerType,
+		TriggeredBy: r.TriggeredBy,
+		Instance:    r.Instance,
+		Result:      r.Result,
+		Error:       r.Error,
+		StartedAt:   r.StartedAt,
+	}
+	if r.FinishedAt != nil {
+		run.FinishedAt = *r.FinishedAt
+	}
+	return run
+}
+
+func toSliceJobRunCore(r []JobRun) []jobs.JobRunCore {
+	result := make([]jobs.JobRunCore, len(r))
+	for i := range r {
+		result[i] = r[i].toJobRunCore()
+	}
+	return result
+}
+
+func toJobRunRecord(r jobs.JobRunCore) JobRun {
+	record := JobRun{
+		Model:       gorm.Model{ID: uint(r.ID)},
+		Job:         r.Job,
+		Status:      r.Status,
+		TriggerType: r.Trigger,
+		TriggeredBy: r.TriggeredBy,
+		Instance:    r.Instance,
+		Result:      r.Result,
+		Error:       r.Error,
+		StartedAt:   r.StartedAt,
+	}
+	if !r.FinishedAt.IsZero() {
+		record.FinishedAt = &r.FinishedAt
+	}
+	return record
+}
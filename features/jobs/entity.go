@@ -0,0 +1,57 @@
+package jobs
+
+import "time"
+
+// Func is the work of a job, the summary it returns is kept in the run history
+type Func func() (string, error)
+
+// JobCore is a job registered in code, only its runs are stored
+type JobCore struct {
+	Name        string
+	Description string
+	Schedule    string // cron expression, see utils/cron
+	Func        Func
+	NextRunAt   time.Time
+	LastRun     JobRunCore // zero when it never ran
+}
+
+// JobRunCore is one run of a job, on its schedule or triggered by an admin
+type JobRunCore struct {
+	ID          int
+	Job         string
+	Status      string
+	Trigger     string
+	TriggeredBy int    // admin of a manual run
+	Instance    string // API instance that ran it
+	Result      string
+	Error       string
+	StartedAt   time.Time
+	FinishedAt  time.Time // zero while running
+}
+
+// JobRunQuery filters runs, the latest come first
+type JobRunQuery struct {
+	Job   string
+	Limit int
+}
+
+type IBusiness interface {
+	Register(job JobCore) error // before Start, the schedule is checked
+	Start()                     // runs the jobs on their schedule until the process exits
+	FindJobs() ([]JobCore, error)
+	FindJobRuns(q JobRunQuery) ([]JobRunCore, error)
+	TriggerJob(name string, userId int, role string) (JobRunCore, error) // runs in the background
+}
+
+type IData interface {
+	// AcquireLock takes the lock of job until the lease ends. A scheduled run also needs
+	// scheduledAt to be later than the last one, so only one instance runs each occurrence.
+	// scheduledAt is zero for a manual run.
+	AcquireLock(job string, holder string, scheduledAt time.Time, until time.Time) (bool, error)
+	ReleaseLock(job string, holder string) error
+
+	SelectJobRuns(q JobRunQuery) ([]JobRunCore, error)
+	SelectLatestJobRuns(jobNames []string) (map[string]JobRunCore, error)
+	InsertJobRun(run JobRunCore) (int, error)
+	UpdateJobRun(run JobRunCore) error
+}
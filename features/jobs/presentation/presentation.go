@@ -0,0 +1,74 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/jobs"
+	"github.com/final-project-alterra/hospital-management-system-api/features/jobs/presentation/request"
+	"github.com/final-project-alterra/hospital-management-system-api/features/jobs/presentation/response"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type JobPresentation struct {
+	business jobs.IBusiness
+	validate *validator.Validate
+}
+
+func NewJobPresentation(business jobs.IBusiness) *JobPresentation {
+	return &JobPresentation{
+		business: business,
+		validate: validator.New(),
+	}
+}
+
+func (p *JobPresentation) GetJobs(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving jobs"
+	const op errors.Op = "jobs.presentation.GetJobs"
+
+	data, err := p.business.FindJobs()
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListJobs(data))
+}
+
+func (p *JobPresentation) GetJobRuns(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving job runs"
+	const op errors.Op = "jobs.presentation.GetJobRuns"
+	var errMessage errors.ErrClientMessage
+
+	var req request.QueryParamsRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	runs, err := p.business.FindJobRuns(req.ToJobRunQuery(c.Param("name")))
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListJobRuns(runs))
+}
+
+func (p *JobPresentation) PostRunJob(c echo.Context) error {
+	status := http.StatusAccepted
+	message := "Success triggering job"
+	const op errors.Op = "jobs.presentation.PostRunJob"
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	run, err := p.business.TriggerJob(c.Param("name"), userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.JobRun(run))
+}
@@ -0,0 +1,14 @@
+package request
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/jobs"
+
+type QueryParamsRequest struct {
+	Limit int `query:"limit" validate:"gte=0"`
+}
+
+func (q QueryParamsRequest) ToJobRunQuery(job string) jobs.JobRunQuery {
+	return jobs.JobRunQuery{
+		Job:   job,
+		Limit: q.Limit,
+	}
+}
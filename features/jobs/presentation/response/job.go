@@ -0,0 +1,78 @@
+package response
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/jobs"
+)
+
+type JobResponse struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Schedule    string          `json:"schedule"`
+	NextRunAt   *time.Time      `json:"nextRunAt"`
+	LastRun     *JobRunResponse `json:"lastRun"`
+}
+
+type JobRunResponse struct {
+	ID          int        `json:"id"`
+	Job         string     `json:"job"`
+	Status      string     `json:"status"`
+	Trigger     string     `json:"trigger"`
+	TriggeredBy int        `json:"triggeredBy"`
+	Instance    string     `json:"instance"`
+	Result      string     `json:"result"`
+	Error       string     `json:"error"`
+	StartedAt   time.Time  `json:"startedAt"`
+	FinishedAt  *time.Time `json:"finishedAt"`
+}
+
+func Job(j jobs.JobCore) JobResponse {
+	job := JobResponse{
+		Name:        j.Name,
+		Description: j.Description,
+		Schedule:    j.Schedule,
+	}
+	if !j.NextRunAt.IsZero() {
+		job.NextRunAt = &j.NextRunAt
+	}
+	if j.LastRun.ID != 0 {
+		lastRun := JobRun(j.LastRun)
+		job.LastRun = &lastRun
+	}
+	return job
+}
+
+func ListJobs(j []jobs.JobCore) []JobResponse {
+	result := make([]JobResponse, len(j))
+	for i := range j {
+		result[i] = Job(j[i])
+	}
+	return result
+}
+
+func JobRun(r jobs.JobRunCore) JobRunResponse {
+	run := JobRunResponse{
+		ID:          r.ID,
+		Job:         r.Job,
+		Status:      r.Status,
+		Trigger:     r.Trigger,
+		TriggeredBy: r.TriggeredBy,
+		Instance:    r.Instance,
+		Result:      r.Result,
+		Error:       r.Error,
+		StartedAt:   r.StartedAt,
+	}
+	if !r.FinishedAt.IsZero() {
+		run.FinishedAt = &r.FinishedAt
+	}
+	return run
+}
+
+func ListJobRuns(r []jobs.JobRunCore) []JobRunResponse {
+	result := make([]JobRunResponse, len(r))
+	for i := range r {
+		result[i] = JobRun(r[i])
+	}
+	return result
+}
@@ -0,0 +1,46 @@
+package business
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/leaves"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+type leaveBusinessBuilder struct {
+	data             leaves.IData
+	scheduleBusiness schedules.IBusiness
+	auditBusiness    audits.IBusiness
+}
+
+func NewLeaveBusinessBuilder() *leaveBusinessBuilder {
+	return &leaveBusinessBuilder{}
+}
+
+func (b *leaveBusinessBuilder) SetData(data leaves.IData) *leaveBusinessBuilder {
+	b.data = data
+	return b
+}
+
+func (b *leaveBusinessBuilder) SetScheduleBusiness(sb schedules.IBusiness) *leaveBusinessBuilder {
+	b.scheduleBusiness = sb
+	return b
+}
+
+func (b *leaveBusinessBuilder) SetAuditBusiness(ab audits.IBusiness) *leaveBusinessBuilder {
+	b.auditBusiness = ab
+	return b
+}
+
+func (b *leaveBusinessBuilder) Build() leaves.IBusiness {
+	leaveBusiness := &leaveBusiness{
+		data:             b.data,
+		scheduleBusiness: b.scheduleBusiness,
+		auditBusiness:    b.auditBusiness,
+	}
+
+	b.data = nil
+	b.scheduleBusiness = nil
+	b.auditBusiness = nil
+
+	return leaveBusiness
+}
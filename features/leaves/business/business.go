@@ -0,0 +1,283 @@
+package business
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/leaves"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+const DATE_LAYOUT = "2006-01-02"
+
+type leaveBusiness struct {
+	data             leaves.IData
+	scheduleBusiness schedules.IBusiness
+	auditBusiness    audits.IBusiness
+}
+
+func (lb *leaveBusiness) FindLeaves(q leaves.LeaveQuery) ([]leaves.LeaveCore, error) {
+	const op errors.Op = "leaves.business.FindLeaves"
+
+	data, err := lb.data.SelectLeaves(q)
+	if err != nil {
+		return []leaves.LeaveCore{}, errors.E(err, op)
+	}
+	return data, nil
+}
+
+func (lb *leaveBusiness) FindLeaveById(id int) (leaves.LeaveCore, error) {
+	const op errors.Op = "leaves.business.FindLeaveById"
+
+	leave, err := lb.data.SelectLeaveById(id)
+	if err != nil {
+		return leaves.LeaveCore{}, errors.E(err, op)
+	}
+	return leave, nil
+}
+
+func (lb *leaveBusiness) FindLeaveWorkSchedules(id int) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "leaves.business.FindLeaveWorkSchedules"
+
+	leave, err := lb.data.SelectLeaveById(id)
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+
+	ws, err := lb.workSchedulesDuring(leave)
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+	return ws, nil
+}
+
+func (lb *leaveBusiness) CreateLeave(leave leaves.LeaveCore, userId int, role string) error {
+	const op errors.Op = "leaves.business.CreateLeave"
+	var errMessage errors.ErrClientMessage = "Only doctors and nurses can request leave"
+
+	if role != permissions.RoleDoctor && role != permissions.RoleNurse {
+		err := errors.New("Unknown staff role")
+		return errors.E(err, op, errMessage, errors.KindUnauthorized)
+	}
+
+	start, errStart := time.Parse(DATE_LAYOUT, leave.StartDate)
+	end, errEnd := time.Parse(DATE_LAYOUT, leave.EndDate)
+	if errStart != nil || errEnd != nil || start.After(end) {
+		err := errors.New("Invalid date range")
+		errMessage = "Start date must not be after end date"
+		return errors.E(err, op, errMessage, errors.KindBadRequest)
+	}
+	if leave.StartDate < today() {
+		err := errors.New("Leave starts in the past")
+		errMessage = "Leave can not start in the past"
+		return errors.E(err, op, errMessage, errors.KindUnprocessable)
+	}
+	if int(end.Sub(start).Hours()/24)+1 > leaves.MAX_LEAVE_DAYS {
+		err := errors.New("Leave is too long")
+		errMessage = "Leave can not be longer than 90 days"
+		return errors.E(err, op, errMessage, errors.KindUnprocessable)
+	}
+
+	overlapping, err := lb.data.SelectOverlappingLeaves(role, userId, leave.StartDate, leave.EndDate)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	if len(overlapping) > 0 {
+		ids := make([]int, len(overlapping))
+		for i := range overlapping {
+			ids[i] = overlapping[i].ID
+		}
+		errMessage = "Leave overlaps with another pending or approved leave"
+		payload := errors.ErrPayload{Data: map[string]interface{}{"leaveIds": ids}}
+		return errors.E(errors.New(string(errMessage)), op, errMessage, payload, errors.KindUnprocessable)
+	}
+
+	leave.StaffID = userId
+	leave.StaffRole = role
+	leave.Status = leaves.StatusPending
+
+	leave.ID, err = lb.data.InsertLeave(leave)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	lb.audit(op, userId, role, leave.ID, nil, leave)
+	return nil
+}
+
+func (lb *leaveBusiness) CancelLeave(id int, userId int, role string) error {
+	const op errors.Op = "leaves.business.CancelLeave"
+	var errMessage errors.ErrClientMessage = "You can only cancel your own leave"
+
+	leave, err := lb.data.SelectLeaveById(id)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	if leave.StaffID != userId || leave.StaffRole != role {
+		return errors.E(errors.New(string(errMessage)), op, errMessage, errors.KindUnauthorized)
+	}
+
+	err = lb.review(op, leave, leaves.StatusCancelled, 0, "", userId, role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (lb *leaveBusiness) ApproveLeave(id int, replacementId int, note string, userId int, role string) (leaves.ApprovalCore, error) {
+	const op errors.Op = "leaves.business.ApproveLeave"
+	var errMessage errors.ErrClientMessage = "Replacement must be someone else"
+
+	leave, err := lb.data.SelectLeaveById(id)
+	if err != nil {
+		return leaves.ApprovalCore{}, errors.E(err, op)
+	}
+
+	if leave.Status != leaves.StatusPending {
+		errMessage = "Only pending leaves can be reviewed"
+		return leaves.ApprovalCore{}, errors.E(errors.New(string(errMessage)), op, errMessage, errors.KindUnprocessable)
+	}
+	if replacementId == leave.StaffID {
+		return leaves.ApprovalCore{}, errors.E(errors.New(string(errMessage)), op, errMessage, errors.KindUnprocessable)
+	}
+
+	ws, err := lb.workSchedulesDuring(leave)
+	if err != nil {
+		return leaves.ApprovalCore{}, errors.E(err, op)
+	}
+
+	if replacementId != 0 {
+		err = lb.reassign(leave, ws, replacementId, userId, role)
+	} else {
+		err = lb.scheduleBusiness.FlagWorkSchedulesOnLeave(ws, leave.ID, userId, role)
+	}
+	if err != nil {
+		return leaves.ApprovalCore{}, errors.E(err, op)
+	}
+
+	err = lb.review(op, leave, leaves.StatusApproved, userId, note, userId, role)
+	if err != nil {
+		return leaves.ApprovalCore{}, errors.E(err, op)
+	}
+
+	leave.Status = leaves.StatusApproved
+	leave.ReviewedBy = userId
+	leave.ReviewNote = note
+	return leaves.ApprovalCore{Leave: leave, ReplacementID: replacementId, WorkSchedules: ws}, nil
+}
+
+func (lb *leaveBusiness) RejectLeave(id int, note string, userId int, role string) error {
+	const op errors.Op = "leaves.business.RejectLeave"
+
+	leave, err := lb.data.SelectLeaveById(id)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = lb.review(op, leave, leaves.StatusRejected, userId, note, userId, role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+// Private methods
+
+// review moves a pending leave to its final status, the change is audited as caller
+func (lb *leaveBusiness) review(caller errors.Op, leave leaves.LeaveCore, status string, reviewedBy int, note string, userId int, role string) error {
+	const op errors.Op = "leaves.business.review"
+	var errMessage errors.ErrClientMessage = "Only pending leaves can be reviewed"
+
+	if leave.Status != leaves.StatusPending {
+		return errors.E(errors.New(string(errMessage)), op, errMessage, errors.KindUnprocessable)
+	}
+
+	before := leave
+	leave.Status = status
+	leave.ReviewedBy = reviewedBy
+	leave.ReviewNote = note
+
+	err := lb.data.UpdateLeave(leave)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	lb.audit(caller, userId, role, leave.ID, before, leave)
+	return nil
+}
+
+// reassign moves the work schedules to the replacement, who must not be on approved leave
+func (lb *leaveBusiness) reassign(leave leaves.LeaveCore, ws []schedules.WorkScheduleCore, replacementId int, userId int, role string) error {
+	const op errors.Op = "leaves.business.reassign"
+	var errMessage errors.ErrClientMessage = "Replacement is on leave during this leave"
+
+	if len(ws) == 0 {
+		return nil
+	}
+
+	replacementLeaves, err := lb.data.SelectOverlappingLeaves(leave.StaffRole, replacementId, leave.StartDate, leave.EndDate)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	for _, l := range replacementLeaves {
+		if l.Status == leaves.StatusApproved {
+			payload := errors.ErrPayload{Data: map[string]interface{}{"leaveIds": []int{l.ID}}}
+			return errors.E(errors.New(string(errMessage)), op, errMessage, payload, errors.KindUnprocessable)
+		}
+	}
+
+	doctorId, nurseId := replacementId, 0
+	if leave.StaffRole == permissions.RoleNurse {
+		doctorId, nurseId = 0, replacementId
+	}
+
+	err = lb.scheduleBusiness.ReassignWorkSchedules(ws, doctorId, nurseId, userId, role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+// workSchedulesDuring lists the staff's work schedules from the later of the leave's start
+// and today, past shifts are left as they were
+func (lb *leaveBusiness) workSchedulesDuring(leave leaves.LeaveCore) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "leaves.business.workSchedulesDuring"
+
+	start := leave.StartDate
+	if now := today(); start < now {
+		start = now
+	}
+	if start > leave.EndDate {
+		return []schedules.WorkScheduleCore{}, nil
+	}
+
+	ws, err := lb.scheduleBusiness.FindStaffWorkSchedules(leave.StaffRole, leave.StaffID, schedules.ScheduleQuery{
+		StartDate: start,
+		EndDate:   leave.EndDate,
+		Limit:     10000,
+	})
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+	return ws, nil
+}
+
+func (lb *leaveBusiness) audit(op errors.Op, actorId int, actorRole string, entityId int, before interface{}, after interface{}) {
+	lb.auditBusiness.Record(audits.AuditLogCore{
+		ActorID:   actorId,
+		ActorRole: actorRole,
+		Operation: string(op),
+		Entity:    audits.EntityLeave,
+		EntityID:  entityId,
+		Before:    before,
+		After:     after,
+	})
+}
+
+func today() string {
+	return time.Now().In(config.GetTimeLoc()).Format(DATE_LAYOUT)
+}
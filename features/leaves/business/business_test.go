@@ -0,0 +1,424 @@
+package business_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/leaves"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	aum "github.com/final-project-alterra/hospital-management-system-api/features/audits/mocks"
+	lb "github.com/final-project-alterra/hospital-management-system-api/features/leaves/business"
+	lm "github.com/final-project-alterra/hospital-management-system-api/features/leaves/mocks"
+	sm "github.com/final-project-alterra/hospital-management-system-api/features/schedules/mocks"
+)
+
+var (
+	repo     lm.IData
+	business leaves.IBusiness
+
+	scheduleBusiness sm.IBusiness
+	auditBusiness    aum.IBusiness
+
+	leave1        leaves.LeaveCore
+	workSchedule1 schedules.WorkScheduleCore
+
+	any string
+
+	errNotFound error
+	errServer   error
+)
+
+func TestMain(m *testing.M) {
+	config.InitTimeLoc("Asia/Jakarta")
+
+	business = lb.NewLeaveBusinessBuilder().
+		SetData(&repo).
+		SetScheduleBusiness(&scheduleBusiness).
+		SetAuditBusiness(&auditBusiness).
+		Build()
+
+	auditBusiness.On("Record", mock.AnythingOfType("audits.AuditLogCore")).Return()
+
+	leave1 = leaves.LeaveCore{
+		ID:        1,
+		StaffID:   1,
+		StaffRole: "doctor",
+		StartDate: "2100-01-01",
+		EndDate:   "2100-01-07",
+		Status:    leaves.StatusPending,
+	}
+	workSchedule1 = schedules.WorkScheduleCore{
+		ID:   1,
+		Date: "2100-01-02",
+		Outpatients: []schedules.OutpatientCore{
+			{ID: 1, Status: schedules.StatusWaiting, Patient: schedules.PatientCore{ID: 1}},
+		},
+	}
+
+	any = mock.Anything
+
+	errNotFound = errors.E(errors.New("not found"), errors.KindNotFound)
+	errServer = errors.E(errors.New("server"), errors.KindServerError)
+
+	os.Exit(m.Run())
+}
+
+func TestFindLeaves(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectLeaves", leaves.LeaveQuery{Status: leaves.StatusPending}).
+			Return([]leaves.LeaveCore{leave1}, nil).
+			Once()
+
+		result, err := business.FindLeaves(leaves.LeaveQuery{Status: leaves.StatusPending})
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("valid - SelectLeaves error", func(t *testing.T) {
+		repo.
+			On("SelectLeaves", any).
+			Return(nil, errServer).
+			Once()
+
+		_, err := business.FindLeaves(leaves.LeaveQuery{})
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestFindLeaveWorkSchedules(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(leave1, nil).
+			Once()
+
+		scheduleBusiness.
+			On("FindStaffWorkSchedules", "doctor", leave1.StaffID, mock.MatchedBy(func(q schedules.ScheduleQuery) bool {
+				return q.StartDate == leave1.StartDate && q.EndDate == leave1.EndDate
+			})).
+			Return([]schedules.WorkScheduleCore{workSchedule1}, nil).
+			Once()
+
+		result, err := business.FindLeaveWorkSchedules(leave1.ID)
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("valid - when leave is over", func(t *testing.T) {
+		past := leave1
+		past.StartDate, past.EndDate = "2000-01-01", "2000-01-07"
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(past, nil).
+			Once()
+
+		result, err := business.FindLeaveWorkSchedules(leave1.ID)
+		assert.Nil(t, err)
+		assert.Len(t, result, 0)
+	})
+
+	t.Run("valid - SelectLeaveById error", func(t *testing.T) {
+		repo.
+			On("SelectLeaveById", 99).
+			Return(leaves.LeaveCore{}, errNotFound).
+			Once()
+
+		_, err := business.FindLeaveWorkSchedules(99)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+}
+
+func TestCreateLeave(t *testing.T) {
+	newLeave := leaves.LeaveCore{StartDate: "2100-01-01", EndDate: "2100-01-07", Reason: "Conference"}
+
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOverlappingLeaves", "nurse", 2, newLeave.StartDate, newLeave.EndDate).
+			Return([]leaves.LeaveCore{}, nil).
+			Once()
+
+		repo.
+			On("InsertLeave", mock.MatchedBy(func(l leaves.LeaveCore) bool {
+				return l.StaffID == 2 && l.StaffRole == "nurse" && l.Status == leaves.StatusPending
+			})).
+			Return(2, nil).
+			Once()
+
+		err := business.CreateLeave(newLeave, 2, "nurse")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when role can not request leave", func(t *testing.T) {
+		err := business.CreateLeave(newLeave, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when dates are invalid", func(t *testing.T) {
+		invalidLeaves := []leaves.LeaveCore{
+			{StartDate: "2100-01-07", EndDate: "2100-01-01"},
+			{StartDate: "01-01-2100", EndDate: "2100-01-07"},
+		}
+		for _, l := range invalidLeaves {
+			err := business.CreateLeave(l, 1, "doctor")
+			assert.Error(t, err)
+			assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
+		}
+	})
+
+	t.Run("valid - when leave starts in the past or is too long", func(t *testing.T) {
+		invalidLeaves := []leaves.LeaveCore{
+			{StartDate: "2000-01-01", EndDate: "2100-01-07"},
+			{StartDate: "2100-01-01", EndDate: "2100-04-01"},
+		}
+		for _, l := range invalidLeaves {
+			err := business.CreateLeave(l, 1, "doctor")
+			assert.Error(t, err)
+			assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+		}
+	})
+
+	t.Run("valid - when leave overlaps with another leave", func(t *testing.T) {
+		repo.
+			On("SelectOverlappingLeaves", "doctor", 1, newLeave.StartDate, newLeave.EndDate).
+			Return([]leaves.LeaveCore{leave1}, nil).
+			Once()
+
+		err := business.CreateLeave(newLeave, 1, "doctor")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+		assert.Equal(t, []int{leave1.ID}, errors.Payload(err).Data.(map[string]interface{})["leaveIds"])
+	})
+
+	t.Run("valid - InsertLeave error", func(t *testing.T) {
+		repo.
+			On("SelectOverlappingLeaves", "doctor", 1, newLeave.StartDate, newLeave.EndDate).
+			Return([]leaves.LeaveCore{}, nil).
+			Once()
+
+		repo.
+			On("InsertLeave", any).
+			Return(0, errServer).
+			Once()
+
+		err := business.CreateLeave(newLeave, 1, "doctor")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestCancelLeave(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(leave1, nil).
+			Once()
+
+		repo.
+			On("UpdateLeave", mock.MatchedBy(func(l leaves.LeaveCore) bool {
+				return l.Status == leaves.StatusCancelled && l.ReviewedBy == 0
+			})).
+			Return(nil).
+			Once()
+
+		err := business.CancelLeave(leave1.ID, leave1.StaffID, leave1.StaffRole)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when leave belongs to someone else", func(t *testing.T) {
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(leave1, nil).
+			Once()
+
+		err := business.CancelLeave(leave1.ID, leave1.StaffID, "nurse")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when leave is already reviewed", func(t *testing.T) {
+		approved := leave1
+		approved.Status = leaves.StatusApproved
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(approved, nil).
+			Once()
+
+		err := business.CancelLeave(leave1.ID, leave1.StaffID, leave1.StaffRole)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+}
+
+func TestApproveLeave(t *testing.T) {
+	staffWorkSchedules := func() {
+		scheduleBusiness.
+			On("FindStaffWorkSchedules", "doctor", leave1.StaffID, any).
+			Return([]schedules.WorkScheduleCore{workSchedule1}, nil).
+			Once()
+	}
+
+	t.Run("valid - flag work schedules without replacement", func(t *testing.T) {
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(leave1, nil).
+			Once()
+
+		staffWorkSchedules()
+
+		scheduleBusiness.
+			On("FlagWorkSchedulesOnLeave", []schedules.WorkScheduleCore{workSchedule1}, leave1.ID, 9, "admin").
+			Return(nil).
+			Once()
+
+		repo.
+			On("UpdateLeave", mock.MatchedBy(func(l leaves.LeaveCore) bool {
+				return l.Status == leaves.StatusApproved && l.ReviewedBy == 9 && l.ReviewNote == "ok"
+			})).
+			Return(nil).
+			Once()
+
+		result, err := business.ApproveLeave(leave1.ID, 0, "ok", 9, "admin")
+		assert.Nil(t, err)
+		assert.Equal(t, leaves.StatusApproved, result.Leave.Status)
+		assert.Len(t, result.WorkSchedules, 1)
+		assert.Len(t, result.WorkSchedules[0].Outpatients, 1)
+	})
+
+	t.Run("valid - reassign work schedules to replacement", func(t *testing.T) {
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(leave1, nil).
+			Once()
+
+		staffWorkSchedules()
+
+		repo.
+			On("SelectOverlappingLeaves", "doctor", 2, leave1.StartDate, leave1.EndDate).
+			Return([]leaves.LeaveCore{{ID: 3, StaffID: 2, Status: leaves.StatusPending}}, nil).
+			Once()
+
+		scheduleBusiness.
+			On("ReassignWorkSchedules", []schedules.WorkScheduleCore{workSchedule1}, 2, 0, 9, "admin").
+			Return(nil).
+			Once()
+
+		repo.
+			On("UpdateLeave", any).
+			Return(nil).
+			Once()
+
+		result, err := business.ApproveLeave(leave1.ID, 2, "", 9, "admin")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, result.ReplacementID)
+	})
+
+	t.Run("valid - when replacement is on approved leave", func(t *testing.T) {
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(leave1, nil).
+			Once()
+
+		staffWorkSchedules()
+
+		repo.
+			On("SelectOverlappingLeaves", "doctor", 2, leave1.StartDate, leave1.EndDate).
+			Return([]leaves.LeaveCore{{ID: 3, StaffID: 2, Status: leaves.StatusApproved}}, nil).
+			Once()
+
+		_, err := business.ApproveLeave(leave1.ID, 2, "", 9, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when replacement is the staff on leave", func(t *testing.T) {
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(leave1, nil).
+			Once()
+
+		_, err := business.ApproveLeave(leave1.ID, leave1.StaffID, "", 9, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when leave is not pending", func(t *testing.T) {
+		cancelled := leave1
+		cancelled.Status = leaves.StatusCancelled
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(cancelled, nil).
+			Once()
+
+		_, err := business.ApproveLeave(leave1.ID, 0, "", 9, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - ReassignWorkSchedules error", func(t *testing.T) {
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(leave1, nil).
+			Once()
+
+		staffWorkSchedules()
+
+		repo.
+			On("SelectOverlappingLeaves", "doctor", 2, leave1.StartDate, leave1.EndDate).
+			Return([]leaves.LeaveCore{}, nil).
+			Once()
+
+		errConflict := errors.E(errors.New("conflict"), errors.KindUnprocessable)
+		scheduleBusiness.
+			On("ReassignWorkSchedules", any, 2, 0, 9, "admin").
+			Return(errConflict).
+			Once()
+
+		_, err := business.ApproveLeave(leave1.ID, 2, "", 9, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+}
+
+func TestRejectLeave(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(leave1, nil).
+			Once()
+
+		repo.
+			On("UpdateLeave", mock.MatchedBy(func(l leaves.LeaveCore) bool {
+				return l.Status == leaves.StatusRejected && l.ReviewNote == "short staffed"
+			})).
+			Return(nil).
+			Once()
+
+		err := business.RejectLeave(leave1.ID, "short staffed", 9, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - UpdateLeave error", func(t *testing.T) {
+		repo.
+			On("SelectLeaveById", leave1.ID).
+			Return(leave1, nil).
+			Once()
+
+		repo.
+			On("UpdateLeave", any).
+			Return(errServer).
+			Once()
+
+		err := business.RejectLeave(leave1.ID, "", 9, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
@@ -0,0 +1,10 @@
+package leaves
+
+const (
+	StatusPending   = "pending"
+	StatusApproved  = "approved"
+	StatusRejected  = "rejected"
+	StatusCancelled = "cancelled"
+)
+
+const MAX_LEAVE_DAYS = 90
@@ -0,0 +1,98 @@
+package data
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/leaves"
+	"gorm.io/gorm"
+)
+
+type mySQLRepo struct {
+	db *gorm.DB
+}
+
+func NewMySQLRepo(db *gorm.DB) *mySQLRepo {
+	return &mySQLRepo{db: db}
+}
+
+func (r *mySQLRepo) SelectLeaves(q leaves.LeaveQuery) ([]leaves.LeaveCore, error) {
+	const op errors.Op = "leaves.data.SelectLeaves"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	tx := r.db.Order("start_date DESC")
+	if q.StaffID != 0 {
+		tx = tx.Where("staff_id = ?", q.StaffID)
+	}
+	if q.StaffRole != "" {
+		tx = tx.Where("staff_role = ?", q.StaffRole)
+	}
+	if q.Status != "" {
+		tx = tx.Where("status = ?", q.Status)
+	}
+
+	leaveRecords := []Leave{}
+	err := tx.Find(&leaveRecords).Error
+	if err != nil {
+		return []leaves.LeaveCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return toSliceLeaveCore(leaveRecords), nil
+}
+
+func (r *mySQLRepo) SelectLeaveById(id int) (leaves.LeaveCore, error) {
+	const op errors.Op = "leaves.data.SelectLeaveById"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	leaveRecord := Leave{}
+	err := r.db.First(&leaveRecord, id).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMessage = "Leave not found"
+			return leaves.LeaveCore{}, errors.E(err, op, errMessage, errors.KindNotFound)
+		default:
+			return leaves.LeaveCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+	return leaveRecord.toLeaveCore(), nil
+}
+
+func (r *mySQLRepo) SelectOverlappingLeaves(staffRole string, staffId int, startDate string, endDate string) ([]leaves.LeaveCore, error) {
+	const op errors.Op = "leaves.data.SelectOverlappingLeaves"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	leaveRecords := []Leave{}
+	err := r.db.
+		Where("staff_role = ? AND staff_id = ?", staffRole, staffId).
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate).
+		Where("status IN ?", []string{leaves.StatusPending, leaves.StatusApproved}).
+		Find(&leaveRecords).
+		Error
+
+	if err != nil {
+		return []leaves.LeaveCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return toSliceLeaveCore(leaveRecords), nil
+}
+
+func (r *mySQLRepo) InsertLeave(leave leaves.LeaveCore) (int, error) {
+	const op errors.Op = "leaves.data.InsertLeave"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	leaveRecord := toLeaveRecord(leave)
+	err := r.db.Create(&leaveRecord).Error
+	if err != nil {
+		return 0, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return int(leaveRecord.ID), nil
+}
+
+func (r *mySQLRepo) UpdateLeave(leave leaves.LeaveCore) error {
+	const op errors.Op = "leaves.data.UpdateLeave"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	leaveRecord := toLeaveRecord(leave)
+	err := r.db.Save(&leaveRecord).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package data
+
+import (
+	"strings"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/leaves"
+	"gorm.io/gorm"
+)
+
+type Leave struct {
+	gorm.Model
+	StaffID    int    `gorm:"not null;index:idx_leaves_staff"`
+	StaffRole  string `gorm:"type:varchar(16);not null;index:idx_leaves_staff"`
+	StartDate  string `gorm:"type:date;not null"`
+	EndDate    string `gorm:"type:date;not null"`
+	Reason     string `gorm:"type:varchar(255);not null"`
+	Status     string `gorm:"type:varchar(16);not null;index"`
+	ReviewedBy int
+	ReviewNote string `gorm:"type:varchar(255)"`
+}
+
+func (l Leave) toLeaveCore() leaves.LeaveCore {
+	return leaves.LeaveCore{
+		ID:         int(l.ID),
+		StaffID:    l.StaffID,
+		StaffRole:  l.StaffRole,
+		StartDate:  strings.Split(l.StartDate, "T")[0],
+		EndDate:    strings.Split(l.EndDate, "T")[0],
+		Reason:     l.Reason,
+		Status:     l.Status,
+		ReviewedBy: l.ReviewedBy,
+		ReviewNote: l.ReviewNote,
+		CreatedAt:  l.CreatedAt,
+		UpdatedAt:  l.UpdatedAt,
+	}
+}
+
+func toSliceLeaveCore(l []Leave) []leaves.LeaveCore {
+	result := make([]leaves.LeaveCore, len(l))
+	for i := range l {
+		result[i] = l[i].toLeaveCore()
+	}
+	return result
+}
+
+func toLeaveRecord(l leaves.LeaveCore) Leave {
+	return Leave{
+		Model:      gorm.Model{ID: uint(l.ID), CreatedAt: l.CreatedAt},
+		StaffID:    l.StaffID,
+		StaffRole:  l.StaffRole,
+		StartDate:  l.StartDate,
+		EndDate:    l.EndDate,
+		Reason:     l.Reason,
+		Status:     l.Status,
+		ReviewedBy: l.ReviewedBy,
+		ReviewNote: l.ReviewNote,
+	}
+}
@@ -0,0 +1,55 @@
+package leaves
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+// LeaveCore is a leave request of a doctor or a nurse, dates are inclusive
+type LeaveCore struct {
+	ID         int
+	StaffID    int
+	StaffRole  string // doctor or nurse
+	StartDate  string
+	EndDate    string
+	Reason     string
+	Status     string
+	ReviewedBy int
+	ReviewNote string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// LeaveQuery filters leaves, zero value fields are not filtered
+type LeaveQuery struct {
+	StaffID   int
+	StaffRole string
+	Status    string
+}
+
+// ApprovalCore lists work schedules during an approved leave with their waiting
+// outpatients, they are reassigned to ReplacementID or flagged with the leave
+type ApprovalCore struct {
+	Leave         LeaveCore
+	ReplacementID int
+	WorkSchedules []schedules.WorkScheduleCore
+}
+
+type IBusiness interface {
+	FindLeaves(q LeaveQuery) ([]LeaveCore, error)
+	FindLeaveById(id int) (LeaveCore, error)
+	FindLeaveWorkSchedules(id int) ([]schedules.WorkScheduleCore, error) // work schedules approval would reassign or flag
+	CreateLeave(leave LeaveCore, userId int, role string) error          // staff request their own leave
+	CancelLeave(id int, userId int, role string) error                   // only pending leaves of the staff
+	ApproveLeave(id int, replacementId int, note string, userId int, role string) (ApprovalCore, error)
+	RejectLeave(id int, note string, userId int, role string) error
+}
+
+type IData interface {
+	SelectLeaves(q LeaveQuery) ([]LeaveCore, error)
+	SelectLeaveById(id int) (LeaveCore, error)
+	SelectOverlappingLeaves(staffRole string, staffId int, startDate string, endDate string) ([]LeaveCore, error) // pending or approved
+	InsertLeave(leave LeaveCore) (int, error)
+	UpdateLeave(leave LeaveCore) error
+}
@@ -0,0 +1,237 @@
+package presentation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/leaves"
+	"github.com/final-project-alterra/hospital-management-system-api/features/leaves/presentation/request"
+	"github.com/final-project-alterra/hospital-management-system-api/features/leaves/presentation/response"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type LeavePresentation struct {
+	business leaves.IBusiness
+	validate *validator.Validate
+}
+
+func NewLeavePresentation(business leaves.IBusiness) *LeavePresentation {
+	return &LeavePresentation{
+		business: business,
+		validate: validator.New(),
+	}
+}
+
+func (lp *LeavePresentation) GetLeaves(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving leaves"
+	const op errors.Op = "leaves.presentation.GetLeaves"
+	var errMessage errors.ErrClientMessage
+
+	var req request.QueryParamsRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := lp.validate.Struct(req); err != nil {
+		errMessage = "Invalid query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	data, err := lp.business.FindLeaves(req.ToLeaveQuery())
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListLeaves(data))
+}
+
+// GetOwnLeaves lists leaves of the logged in doctor or nurse, only status is filtered
+func (lp *LeavePresentation) GetOwnLeaves(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving leaves"
+	const op errors.Op = "leaves.presentation.GetOwnLeaves"
+	var errMessage errors.ErrClientMessage
+
+	var req request.QueryParamsRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := lp.validate.Struct(req); err != nil {
+		errMessage = "Invalid query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	q := req.ToLeaveQuery()
+	q.StaffID = c.Get("userId").(int)
+	q.StaffRole = c.Get("role").(string)
+
+	data, err := lp.business.FindLeaves(q)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListLeaves(data))
+}
+
+func (lp *LeavePresentation) GetDetailLeave(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving leave"
+	const op errors.Op = "leaves.presentation.GetDetailLeave"
+
+	leaveId, err := lp.leaveId(c)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	leave, err := lp.business.FindLeaveById(leaveId)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.DetailLeave(leave))
+}
+
+func (lp *LeavePresentation) GetLeaveWorkSchedules(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving work schedules during leave"
+	const op errors.Op = "leaves.presentation.GetLeaveWorkSchedules"
+
+	leaveId, err := lp.leaveId(c)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	ws, err := lp.business.FindLeaveWorkSchedules(leaveId)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListLeaveWorkSchedules(ws))
+}
+
+func (lp *LeavePresentation) PostLeave(c echo.Context) error {
+	status := http.StatusCreated
+	message := "Success requesting leave"
+	const op errors.Op = "leaves.presentation.PostLeave"
+	var errMessage errors.ErrClientMessage
+
+	leave := request.CreateLeaveRequest{}
+	err := c.Bind(&leave)
+	if err != nil {
+		errMessage = "Unable to parse payload request"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = lp.validate.Struct(leave)
+	if err != nil {
+		errMessage = "Invalid. Makesure all field is filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = lp.business.CreateLeave(leave.ToLeaveCore(), userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (lp *LeavePresentation) PutCancelLeave(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success cancelling leave"
+	const op errors.Op = "leaves.presentation.PutCancelLeave"
+
+	leaveId, err := lp.leaveId(c)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = lp.business.CancelLeave(leaveId, userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (lp *LeavePresentation) PutApproveLeave(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success approving leave"
+	const op errors.Op = "leaves.presentation.PutApproveLeave"
+	var errMessage errors.ErrClientMessage
+
+	leaveId, err := lp.leaveId(c)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	approval := request.ApproveLeaveRequest{}
+	err = c.Bind(&approval)
+	if err != nil {
+		errMessage = "Unable to parse payload request"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = lp.validate.Struct(approval)
+	if err != nil {
+		errMessage = "Invalid. Makesure all field is filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	result, err := lp.business.ApproveLeave(leaveId, approval.ReplacementID, approval.Note, userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.Approval(result))
+}
+
+func (lp *LeavePresentation) PutRejectLeave(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success rejecting leave"
+	const op errors.Op = "leaves.presentation.PutRejectLeave"
+	var errMessage errors.ErrClientMessage
+
+	leaveId, err := lp.leaveId(c)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	rejection := request.RejectLeaveRequest{}
+	err = c.Bind(&rejection)
+	if err != nil {
+		errMessage = "Unable to parse payload request"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = lp.validate.Struct(rejection)
+	if err != nil {
+		errMessage = "Invalid. Makesure all field is filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = lp.business.RejectLeave(leaveId, rejection.Note, userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+// Private methods
+func (lp *LeavePresentation) leaveId(c echo.Context) (int, error) {
+	const op errors.Op = "leaves.presentation.leaveId"
+	var errMessage errors.ErrClientMessage = "Invalid leave id"
+
+	leaveId, err := strconv.Atoi(c.Param("leaveId"))
+	if err != nil || leaveId < 1 {
+		return 0, errors.E(err, op, errMessage, errors.KindBadRequest)
+	}
+	return leaveId, nil
+}
@@ -0,0 +1,32 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/leaves"
+)
+
+type CreateLeaveRequest struct {
+	StartDate string `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate   string `json:"endDate" validate:"required,datetime=2006-01-02"`
+	Reason    string `json:"reason" validate:"required,max=255"`
+}
+
+func (r CreateLeaveRequest) ToLeaveCore() leaves.LeaveCore {
+	return leaves.LeaveCore{
+		StartDate: r.StartDate,
+		EndDate:   r.EndDate,
+		Reason:    strings.TrimSpace(r.Reason),
+	}
+}
+
+// ApproveLeaveRequest reassigns the work schedules to ReplacementID (a doctor for a
+// doctor's leave, a nurse for a nurse's), zero flags them instead
+type ApproveLeaveRequest struct {
+	ReplacementID int    `json:"replacementId" validate:"gte=0"`
+	Note          string `json:"note" validate:"max=255"`
+}
+
+type RejectLeaveRequest struct {
+	Note string `json:"note" validate:"max=255"`
+}
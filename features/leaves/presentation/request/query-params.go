@@ -0,0 +1,17 @@
+package request
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/leaves"
+
+type QueryParamsRequest struct {
+	StaffID   int    `query:"staffId" validate:"gte=0"`
+	StaffRole string `query:"staffRole" validate:"omitempty,oneof=doctor nurse"`
+	Status    string `query:"status" validate:"omitempty,oneof=pending approved rejected cancelled"`
+}
+
+func (q QueryParamsRequest) ToLeaveQuery() leaves.LeaveQuery {
+	return leaves.LeaveQuery{
+		StaffID:   q.StaffID,
+		StaffRole: q.StaffRole,
+		Status:    q.Status,
+	}
+}
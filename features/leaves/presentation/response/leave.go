@@ -0,0 +1,108 @@
+package response
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/leaves"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+type LeaveResponse struct {
+	ID         int       `json:"id"`
+	StaffID    int       `json:"staffId"`
+	StaffRole  string    `json:"staffRole"`
+	StartDate  string    `json:"startDate"`
+	EndDate    string    `json:"endDate"`
+	Reason     string    `json:"reason"`
+	Status     string    `json:"status"`
+	ReviewedBy int       `json:"reviewedBy"`
+	ReviewNote string    `json:"reviewNote"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func DetailLeave(l leaves.LeaveCore) LeaveResponse {
+	return LeaveResponse{
+		ID:         l.ID,
+		StaffID:    l.StaffID,
+		StaffRole:  l.StaffRole,
+		StartDate:  l.StartDate,
+		EndDate:    l.EndDate,
+		Reason:     l.Reason,
+		Status:     l.Status,
+		ReviewedBy: l.ReviewedBy,
+		ReviewNote: l.ReviewNote,
+		CreatedAt:  l.CreatedAt,
+		UpdatedAt:  l.UpdatedAt,
+	}
+}
+
+func ListLeaves(l []leaves.LeaveCore) []LeaveResponse {
+	result := make([]LeaveResponse, len(l))
+	for i := range l {
+		result[i] = DetailLeave(l[i])
+	}
+	return result
+}
+
+type WaitingOutpatientResponse struct {
+	ID        int `json:"id"`
+	PatientID int `json:"patientId"`
+}
+
+type LeaveWorkScheduleResponse struct {
+	ID                 int                         `json:"id"`
+	Group              string                      `json:"group"`
+	Date               string                      `json:"date"`
+	StartTime          string                      `json:"startTime"`
+	EndTime            string                      `json:"endTime"`
+	DoctorID           int                         `json:"doctorId"`
+	NurseID            int                         `json:"nurseId"`
+	TotalWaiting       int                         `json:"totalWaiting"`
+	WaitingOutpatients []WaitingOutpatientResponse `json:"waitingOutpatients"`
+}
+
+func LeaveWorkSchedule(ws schedules.WorkScheduleCore) LeaveWorkScheduleResponse {
+	outpatients := make([]WaitingOutpatientResponse, len(ws.Outpatients))
+	for i, o := range ws.Outpatients {
+		outpatients[i] = WaitingOutpatientResponse{ID: o.ID, PatientID: o.Patient.ID}
+	}
+
+	return LeaveWorkScheduleResponse{
+		ID:                 ws.ID,
+		Group:              ws.Group,
+		Date:               ws.Date,
+		StartTime:          ws.StartTime,
+		EndTime:            ws.EndTime,
+		DoctorID:           ws.Doctor.ID,
+		NurseID:            ws.Nurse.ID,
+		TotalWaiting:       ws.TotalWaiting,
+		WaitingOutpatients: outpatients,
+	}
+}
+
+func ListLeaveWorkSchedules(ws []schedules.WorkScheduleCore) []LeaveWorkScheduleResponse {
+	result := make([]LeaveWorkScheduleResponse, len(ws))
+	for i := range ws {
+		result[i] = LeaveWorkSchedule(ws[i])
+	}
+	return result
+}
+
+// ApprovalResponse lists work schedules as they were before approval, reassigned to
+// replacementId when it is set, flagged with the leave otherwise
+type ApprovalResponse struct {
+	Leave         LeaveResponse               `json:"leave"`
+	ReplacementID int                         `json:"replacementId"`
+	Reassigned    bool                        `json:"reassigned"`
+	WorkSchedules []LeaveWorkScheduleResponse `json:"workSchedules"`
+}
+
+func Approval(a leaves.ApprovalCore) ApprovalResponse {
+	return ApprovalResponse{
+		Leave:         DetailLeave(a.Leave),
+		ReplacementID: a.ReplacementID,
+		Reassigned:    a.ReplacementID != 0,
+		WorkSchedules: ListLeaveWorkSchedules(a.WorkSchedules),
+	}
+}
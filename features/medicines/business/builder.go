@@ -0,0 +1,37 @@
+package business
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
+)
+
+type medicineBusinessBuilder struct {
+	data          medicines.IData
+	auditBusiness audits.IBusiness
+}
+
+func NewMedicineBusinessBuilder() *medicineBusinessBuilder {
+	return &medicineBusinessBuilder{}
+}
+
+func (b *medicineBusinessBuilder) SetData(data medicines.IData) *medicineBusinessBuilder {
+	b.data = data
+	return b
+}
+
+func (b *medicineBusinessBuilder) SetAuditBusiness(ab audits.IBusiness) *medicineBusinessBuilder {
+	b.auditBusiness = ab
+	return b
+}
+
+func (b *medicineBusinessBuilder) Build() medicines.IBusiness {
+	medicineBusiness := &medicineBusiness{
+		data:          b.data,
+		auditBusiness: b.auditBusiness,
+	}
+
+	b.data = nil
+	b.auditBusiness = nil
+
+	return medicineBusiness
+}
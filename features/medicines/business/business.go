@@ -0,0 +1,345 @@
+package business
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
+)
+
+const DATE_LAYOUT = "2006-01-02"
+
+type medicineBusiness struct {
+	data          medicines.IData
+	auditBusiness audits.IBusiness
+}
+
+func (m *medicineBusiness) FindMedicines(q medicines.MedicineQuery) ([]medicines.MedicineCore, error) {
+	const op errors.Op = "medicines.business.FindMedicines"
+
+	medicineList, err := m.data.SelectMedicines(q)
+	if err != nil {
+		return []medicines.MedicineCore{}, errors.E(err, op)
+	}
+
+	medicineList, err = m.withStock(medicineList)
+	if err != nil {
+		return []medicines.MedicineCore{}, errors.E(err, op)
+	}
+
+	if !q.LowStock {
+		return medicineList, nil
+	}
+
+	low := []medicines.MedicineCore{}
+	for _, medicine := range medicineList {
+		if medicine.Stock <= medicine.MinimumStock {
+			low = append(low, medicine)
+		}
+	}
+	return low, nil
+}
+
+func (m *medicineBusiness) FindMedicineById(id int) (medicines.MedicineCore, error) {
+	const op errors.Op = "medicines.business.FindMedicineById"
+
+	medicine, err := m.data.SelectMedicineById(id)
+	if err != nil {
+		return medicines.MedicineCore{}, errors.E(err, op)
+	}
+
+	medicineList, err := m.withStock([]medicines.MedicineCore{medicine})
+	if err != nil {
+		return medicines.MedicineCore{}, errors.E(err, op)
+	}
+	return medicineList[0], nil
+}
+
+func (m *medicineBusiness) FindMedicinesByIds(ids []int) ([]medicines.MedicineCore, error) {
+	const op errors.Op = "medicines.business.FindMedicinesByIds"
+
+	if len(ids) == 0 {
+		return []medicines.MedicineCore{}, nil
+	}
+
+	medicineList, err := m.data.SelectMedicinesByIds(ids)
+	if err != nil {
+		return []medicines.MedicineCore{}, errors.E(err, op)
+	}
+
+	medicineList, err = m.withStock(medicineList)
+	if err != nil {
+		return []medicines.MedicineCore{}, errors.E(err, op)
+	}
+	return medicineList, nil
+}
+
+func (m *medicineBusiness) CreateMedicine(medicine medicines.MedicineCore, userId int, role string) error {
+	const op errors.Op = "medicines.business.CreateMedicine"
+	var errMsg errors.ErrClientMessage
+
+	_, err := m.data.SelectMedicineByName(medicine.Name)
+	if err == nil {
+		errMsg = "Medicine with this name already exists"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+	if errors.Kind(err) != errors.KindNotFound {
+		return errors.E(err, op)
+	}
+
+	medicine.CreatedBy = userId
+	medicine.UpdatedBy = userId
+	medicine.ID, err = m.data.InsertMedicine(medicine)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	m.audit(op, userId, role, audits.EntityMedicine, medicine.ID, nil, medicine)
+	return nil
+}
+
+func (m *medicineBusiness) EditMedicine(medicine medicines.MedicineCore, userId int, role string) error {
+	const op errors.Op = "medicines.business.EditMedicine"
+	var errMsg errors.ErrClientMessage
+
+	existing, err := m.data.SelectMedicineById(medicine.ID)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	other, err := m.data.SelectMedicineByName(medicine.Name)
+	if err == nil && other.ID != medicine.ID {
+		errMsg = "Another medicine is using this name"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+	if err != nil && errors.Kind(err) != errors.KindNotFound {
+		return errors.E(err, op)
+	}
+
+	before := existing
+	existing.UpdatedBy = userId
+	existing.Name = medicine.Name
+	existing.Form = medicine.Form
+	existing.Unit = medicine.Unit
+	existing.MinimumStock = medicine.MinimumStock
+
+	err = m.data.UpdateMedicine(existing)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	m.audit(op, userId, role, audits.EntityMedicine, existing.ID, before, existing)
+	return nil
+}
+
+func (m *medicineBusiness) RemoveMedicineById(id int, userId int, role string) error {
+	const op errors.Op = "medicines.business.RemoveMedicineById"
+	var errMsg errors.ErrClientMessage = "Can't delete medicine that still has stock on hand"
+
+	existing, err := m.data.SelectMedicineById(id)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	batches, err := m.data.SelectBatchesByMedicineId(id)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	if len(batches) > 0 {
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	err = m.data.DeleteMedicineById(id, userId)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	m.audit(op, userId, role, audits.EntityMedicine, id, existing, nil)
+	return nil
+}
+
+func (m *medicineBusiness) FindBatchesByMedicineId(medicineId int) ([]medicines.BatchCore, error) {
+	const op errors.Op = "medicines.business.FindBatchesByMedicineId"
+
+	_, err := m.data.SelectMedicineById(medicineId)
+	if err != nil {
+		return []medicines.BatchCore{}, errors.E(err, op)
+	}
+
+	batches, err := m.data.SelectBatchesByMedicineId(medicineId)
+	if err != nil {
+		return []medicines.BatchCore{}, errors.E(err, op)
+	}
+	return batches, nil
+}
+
+func (m *medicineBusiness) CreateBatch(batch medicines.BatchCore, userId int, role string) error {
+	const op errors.Op = "medicines.business.CreateBatch"
+	var errMsg errors.ErrClientMessage = "Batch has already expired"
+
+	_, err := m.data.SelectMedicineById(batch.MedicineID)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	if batch.ExpiryDate <= today() {
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	batch.ReceivedBy = userId
+	batch.Remaining = batch.Quantity
+	batch.Reserved = 0
+	batch.ID, err = m.data.InsertBatch(batch)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	m.audit(op, userId, role, audits.EntityBatch, batch.ID, nil, batch)
+	return nil
+}
+
+func (m *medicineBusiness) AdjustBatch(batchId int, remaining int, reason string, userId int, role string) error {
+	const op errors.Op = "medicines.business.AdjustBatch"
+	var errMsg errors.ErrClientMessage
+
+	existing, err := m.data.SelectBatchById(batchId)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	if remaining > existing.Quantity {
+		errMsg = "Units on hand cannot be more than the units received"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+	if remaining < existing.Reserved {
+		errMsg = "Units on hand cannot be less than the reserved units"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	err = m.data.UpdateBatchRemaining(batchId, remaining)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	adjusted := struct {
+		medicines.BatchCore
+		Reason string
+	}{existing, reason}
+	adjusted.Remaining = remaining
+
+	m.audit(op, userId, role, audits.EntityBatch, batchId, existing, adjusted)
+	return nil
+}
+
+func (m *medicineBusiness) ReserveStock(requests []medicines.ReservationCore) ([]medicines.ReservationCore, error) {
+	const op errors.Op = "medicines.business.ReserveStock"
+
+	requests = stockRequests(requests)
+	if len(requests) == 0 {
+		return []medicines.ReservationCore{}, nil
+	}
+
+	reserved, err := m.data.ReserveStock(requests, today())
+	if err != nil {
+		return []medicines.ReservationCore{}, errors.E(err, op)
+	}
+	return reserved, nil
+}
+
+func (m *medicineBusiness) ReleaseStock(prescriptionIds []int) error {
+	const op errors.Op = "medicines.business.ReleaseStock"
+
+	if len(prescriptionIds) == 0 {
+		return nil
+	}
+
+	err := m.data.ReleaseStock(prescriptionIds)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (m *medicineBusiness) DispenseStock(requests []medicines.ReservationCore, userId int) error {
+	const op errors.Op = "medicines.business.DispenseStock"
+
+	requests = stockRequests(requests)
+	if len(requests) == 0 {
+		return nil
+	}
+
+	err := m.data.DispenseStock(requests, userId, today())
+	if err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+func (m *medicineBusiness) FindReservationsByPrescriptionIds(prescriptionIds []int) ([]medicines.ReservationCore, error) {
+	const op errors.Op = "medicines.business.FindReservationsByPrescriptionIds"
+
+	if len(prescriptionIds) == 0 {
+		return []medicines.ReservationCore{}, nil
+	}
+
+	reservations, err := m.data.SelectReservationsByPrescriptionIds(prescriptionIds)
+	if err != nil {
+		return []medicines.ReservationCore{}, errors.E(err, op)
+	}
+	return reservations, nil
+}
+
+// Private methods
+
+func (m *medicineBusiness) withStock(medicineList []medicines.MedicineCore) ([]medicines.MedicineCore, error) {
+	const op errors.Op = "medicines.business.withStock"
+
+	if len(medicineList) == 0 {
+		return medicineList, nil
+	}
+
+	ids := make([]int, len(medicineList))
+	for i := range medicineList {
+		ids[i] = medicineList[i].ID
+	}
+
+	stock, err := m.data.SelectAvailableStock(ids, today())
+	if err != nil {
+		return []medicines.MedicineCore{}, errors.E(err, op)
+	}
+
+	for i := range medicineList {
+		medicineList[i].Stock = stock[medicineList[i].ID]
+	}
+	return medicineList, nil
+}
+
+// audit records a change on medicines or their batches
+func (m *medicineBusiness) audit(op errors.Op, actorId int, actorRole string, entity string, entityId int, before interface{}, after interface{}) {
+	m.auditBusiness.Record(audits.AuditLogCore{
+		ActorID:   actorId,
+		ActorRole: actorRole,
+		Operation: string(op),
+		Entity:    entity,
+		EntityID:  entityId,
+		Before:    before,
+		After:     after,
+	})
+}
+
+// stockRequests leaves out medicines outside the catalogue and empty quantities
+func stockRequests(requests []medicines.ReservationCore) []medicines.ReservationCore {
+	result := []medicines.ReservationCore{}
+	for _, r := range requests {
+		if r.MedicineID > 0 && r.Quantity > 0 {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func today() string {
+	return time.Now().In(config.GetTimeLoc()).Format(DATE_LAYOUT)
+}
@@ -0,0 +1,392 @@
+package business_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	aum "github.com/final-project-alterra/hospital-management-system-api/features/audits/mocks"
+	mb "github.com/final-project-alterra/hospital-management-system-api/features/medicines/business"
+	mm "github.com/final-project-alterra/hospital-management-system-api/features/medicines/mocks"
+)
+
+var (
+	repo     mm.IData
+	business medicines.IBusiness
+
+	auditBusiness aum.IBusiness
+
+	medicine1 medicines.MedicineCore
+	medicine2 medicines.MedicineCore
+	batch1    medicines.BatchCore
+
+	any    string
+	anyInt mock.AnythingOfTypeArgument
+
+	errNotFound error
+	errServer   error
+)
+
+func TestMain(m *testing.M) {
+	config.InitTimeLoc("Asia/Jakarta")
+
+	business = mb.NewMedicineBusinessBuilder().
+		SetData(&repo).
+		SetAuditBusiness(&auditBusiness).
+		Build()
+
+	auditBusiness.On("Record", mock.AnythingOfType("audits.AuditLogCore")).Return()
+
+	medicine1 = medicines.MedicineCore{ID: 1, Name: "Paracetamol 500 mg", Form: medicines.FormTablet, Unit: "tablet", MinimumStock: 100}
+	medicine2 = medicines.MedicineCore{ID: 2, Name: "Amoxicillin 500 mg", Form: medicines.FormCapsule, Unit: "capsule", MinimumStock: 50}
+	batch1 = medicines.BatchCore{ID: 1, MedicineID: 1, BatchNumber: "PCM-001", ExpiryDate: "2100-01-01", Quantity: 200, Remaining: 150, Reserved: 30}
+
+	any = mock.Anything
+	anyInt = mock.AnythingOfType("int")
+
+	errNotFound = errors.E(errors.New("not found"), errors.KindNotFound)
+	errServer = errors.E(errors.New("server"), errors.KindServerError)
+
+	os.Exit(m.Run())
+}
+
+func TestFindMedicines(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectMedicines", medicines.MedicineQuery{}).
+			Return([]medicines.MedicineCore{medicine1, medicine2}, nil).
+			Once()
+
+		repo.
+			On("SelectAvailableStock", []int{1, 2}, any).
+			Return(map[int]int{1: 120}, nil).
+			Once()
+
+		result, err := business.FindMedicines(medicines.MedicineQuery{})
+		assert.Nil(t, err)
+		assert.Equal(t, 120, result[0].Stock)
+		assert.Equal(t, 0, result[1].Stock)
+	})
+
+	t.Run("valid - only low stock", func(t *testing.T) {
+		repo.
+			On("SelectMedicines", medicines.MedicineQuery{LowStock: true}).
+			Return([]medicines.MedicineCore{medicine1, medicine2}, nil).
+			Once()
+
+		repo.
+			On("SelectAvailableStock", []int{1, 2}, any).
+			Return(map[int]int{1: 120, 2: 50}, nil).
+			Once()
+
+		result, err := business.FindMedicines(medicines.MedicineQuery{LowStock: true})
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, medicine2.ID, result[0].ID)
+	})
+
+	t.Run("valid - SelectAvailableStock error", func(t *testing.T) {
+		repo.
+			On("SelectMedicines", any).
+			Return([]medicines.MedicineCore{medicine1}, nil).
+			Once()
+
+		repo.
+			On("SelectAvailableStock", any, any).
+			Return(map[int]int{}, errServer).
+			Once()
+
+		_, err := business.FindMedicines(medicines.MedicineQuery{})
+		assert.Error(t, err)
+	})
+}
+
+func TestFindMedicinesByIds(t *testing.T) {
+	t.Run("valid - when there is no id", func(t *testing.T) {
+		result, err := business.FindMedicinesByIds([]int{})
+		assert.Nil(t, err)
+		assert.Len(t, result, 0)
+	})
+
+	t.Run("valid - SelectMedicinesByIds error", func(t *testing.T) {
+		repo.
+			On("SelectMedicinesByIds", []int{1}).
+			Return([]medicines.MedicineCore{}, errServer).
+			Once()
+
+		_, err := business.FindMedicinesByIds([]int{1})
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateMedicine(t *testing.T) {
+	newMedicine := medicines.MedicineCore{Name: "Ibuprofen 400 mg", Form: medicines.FormTablet, Unit: "tablet"}
+
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectMedicineByName", newMedicine.Name).
+			Return(medicines.MedicineCore{}, errNotFound).
+			Once()
+
+		repo.
+			On("InsertMedicine", mock.MatchedBy(func(m medicines.MedicineCore) bool {
+				return m.Name == newMedicine.Name && m.CreatedBy == 1 && m.UpdatedBy == 1
+			})).
+			Return(3, nil).
+			Once()
+
+		err := business.CreateMedicine(newMedicine, 1, "pharmacist")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when the name is taken", func(t *testing.T) {
+		repo.
+			On("SelectMedicineByName", newMedicine.Name).
+			Return(medicine1, nil).
+			Once()
+
+		err := business.CreateMedicine(newMedicine, 1, "pharmacist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - SelectMedicineByName error", func(t *testing.T) {
+		repo.
+			On("SelectMedicineByName", newMedicine.Name).
+			Return(medicines.MedicineCore{}, errServer).
+			Once()
+
+		err := business.CreateMedicine(newMedicine, 1, "pharmacist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestEditMedicine(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		edited := medicine1
+		edited.MinimumStock = 200
+
+		repo.
+			On("SelectMedicineById", medicine1.ID).
+			Return(medicine1, nil).
+			Once()
+
+		repo.
+			On("SelectMedicineByName", medicine1.Name).
+			Return(medicine1, nil).
+			Once()
+
+		repo.
+			On("UpdateMedicine", mock.MatchedBy(func(m medicines.MedicineCore) bool {
+				return m.MinimumStock == 200 && m.UpdatedBy == 2
+			})).
+			Return(nil).
+			Once()
+
+		err := business.EditMedicine(edited, 2, "pharmacist")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when another medicine has the name", func(t *testing.T) {
+		edited := medicine1
+		edited.Name = medicine2.Name
+
+		repo.
+			On("SelectMedicineById", medicine1.ID).
+			Return(medicine1, nil).
+			Once()
+
+		repo.
+			On("SelectMedicineByName", medicine2.Name).
+			Return(medicine2, nil).
+			Once()
+
+		err := business.EditMedicine(edited, 2, "pharmacist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+}
+
+func TestRemoveMedicineById(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectMedicineById", medicine2.ID).
+			Return(medicine2, nil).
+			Once()
+
+		repo.
+			On("SelectBatchesByMedicineId", medicine2.ID).
+			Return([]medicines.BatchCore{}, nil).
+			Once()
+
+		repo.
+			On("DeleteMedicineById", medicine2.ID, 1).
+			Return(nil).
+			Once()
+
+		err := business.RemoveMedicineById(medicine2.ID, 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when there is stock on hand", func(t *testing.T) {
+		repo.
+			On("SelectMedicineById", medicine1.ID).
+			Return(medicine1, nil).
+			Once()
+
+		repo.
+			On("SelectBatchesByMedicineId", medicine1.ID).
+			Return([]medicines.BatchCore{batch1}, nil).
+			Once()
+
+		err := business.RemoveMedicineById(medicine1.ID, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+}
+
+func TestCreateBatch(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectMedicineById", medicine1.ID).
+			Return(medicine1, nil).
+			Once()
+
+		repo.
+			On("InsertBatch", mock.MatchedBy(func(b medicines.BatchCore) bool {
+				return b.Remaining == 100 && b.Reserved == 0 && b.ReceivedBy == 4
+			})).
+			Return(2, nil).
+			Once()
+
+		err := business.CreateBatch(medicines.BatchCore{MedicineID: 1, BatchNumber: "PCM-002", ExpiryDate: "2100-06-01", Quantity: 100}, 4, "pharmacist")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when the batch has expired", func(t *testing.T) {
+		repo.
+			On("SelectMedicineById", medicine1.ID).
+			Return(medicine1, nil).
+			Once()
+
+		err := business.CreateBatch(medicines.BatchCore{MedicineID: 1, BatchNumber: "PCM-000", ExpiryDate: "2000-01-01", Quantity: 100}, 4, "pharmacist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - SelectMedicineById error", func(t *testing.T) {
+		repo.
+			On("SelectMedicineById", 9).
+			Return(medicines.MedicineCore{}, errNotFound).
+			Once()
+
+		err := business.CreateBatch(medicines.BatchCore{MedicineID: 9, ExpiryDate: "2100-06-01", Quantity: 100}, 4, "pharmacist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+}
+
+func TestAdjustBatch(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectBatchById", batch1.ID).
+			Return(batch1, nil).
+			Once()
+
+		repo.
+			On("UpdateBatchRemaining", batch1.ID, 140).
+			Return(nil).
+			Once()
+
+		err := business.AdjustBatch(batch1.ID, 140, "Broken during transport", 4, "pharmacist")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when below the reserved units", func(t *testing.T) {
+		repo.
+			On("SelectBatchById", batch1.ID).
+			Return(batch1, nil).
+			Once()
+
+		err := business.AdjustBatch(batch1.ID, 20, "Stock count", 4, "pharmacist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when above the received units", func(t *testing.T) {
+		repo.
+			On("SelectBatchById", batch1.ID).
+			Return(batch1, nil).
+			Once()
+
+		err := business.AdjustBatch(batch1.ID, 201, "Stock count", 4, "pharmacist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+}
+
+func TestReserveStock(t *testing.T) {
+	t.Run("valid - medicines outside the catalogue are left out", func(t *testing.T) {
+		reserved := []medicines.ReservationCore{{ID: 1, PrescriptionID: 1, MedicineID: 1, BatchID: 1, Quantity: 10}}
+
+		repo.
+			On("ReserveStock", []medicines.ReservationCore{{PrescriptionID: 1, MedicineID: 1, Quantity: 10}}, any).
+			Return(reserved, nil).
+			Once()
+
+		result, err := business.ReserveStock([]medicines.ReservationCore{
+			{PrescriptionID: 1, MedicineID: 1, Quantity: 10},
+			{PrescriptionID: 2, Quantity: 5},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, reserved, result)
+	})
+
+	t.Run("valid - when nothing is from the catalogue", func(t *testing.T) {
+		result, err := business.ReserveStock([]medicines.ReservationCore{{PrescriptionID: 2, Quantity: 5}})
+		assert.Nil(t, err)
+		assert.Len(t, result, 0)
+	})
+
+	t.Run("valid - ReserveStock error", func(t *testing.T) {
+		repo.
+			On("ReserveStock", any, any).
+			Return([]medicines.ReservationCore{}, errServer).
+			Once()
+
+		_, err := business.ReserveStock([]medicines.ReservationCore{{PrescriptionID: 1, MedicineID: 1, Quantity: 10}})
+		assert.Error(t, err)
+	})
+}
+
+func TestDispenseStock(t *testing.T) {
+	requests := []medicines.ReservationCore{{PrescriptionID: 1, MedicineID: 1, Quantity: 10}}
+
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("DispenseStock", requests, 4, any).
+			Return(nil).
+			Once()
+
+		err := business.DispenseStock(requests, 4)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when the stock is short", func(t *testing.T) {
+		errShort := errors.E(errors.New("short"), errors.KindUnprocessable)
+		repo.
+			On("DispenseStock", requests, anyInt, any).
+			Return(errShort).
+			Once()
+
+		err := business.DispenseStock(requests, 4)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+}
@@ -0,0 +1,17 @@
+package medicines
+
+const (
+	ReservationReserved  = "reserved"
+	ReservationDispensed = "dispensed"
+	ReservationReleased  = "released" // the prescription was amended, voided or removed
+
+	FormTablet      = "tablet"
+	FormCapsule     = "capsule"
+	FormSyrup       = "syrup"
+	FormInjection   = "injection"
+	FormCream       = "cream"
+	FormDrops       = "drops"
+	FormInhaler     = "inhaler"
+	FormSuppository = "suppository"
+	FormOther       = "other"
+)
@@ -0,0 +1,436 @@
+package data
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mySQLRepo struct {
+	db *gorm.DB
+}
+
+func NewMySQLRepo(db *gorm.DB) *mySQLRepo {
+	return &mySQLRepo{db}
+}
+
+// LowStock is not filtered here, it needs the stock from SelectAvailableStock
+func (r *mySQLRepo) SelectMedicines(q medicines.MedicineQuery) ([]medicines.MedicineCore, error) {
+	const op errors.Op = "medicines.data.SelectMedicines"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	tx := r.db.Order("name")
+	if q.Name != "" {
+		tx = tx.Where("name LIKE ?", "%"+q.Name+"%")
+	}
+
+	records := []Medicine{}
+	err := tx.Find(&records).Error
+	if err != nil {
+		return []medicines.MedicineCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return toSliceMedicineCore(records), nil
+}
+
+func (r *mySQLRepo) SelectMedicineById(id int) (medicines.MedicineCore, error) {
+	const op errors.Op = "medicines.data.SelectMedicineById"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	record := Medicine{}
+	err := r.db.First(&record, id).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMsg = "Medicine not found"
+			return medicines.MedicineCore{}, errors.E(err, op, errMsg, errors.KindNotFound)
+
+		default:
+			return medicines.MedicineCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+		}
+	}
+	return record.toMedicineCore(), nil
+}
+
+func (r *mySQLRepo) SelectMedicinesByIds(ids []int) ([]medicines.MedicineCore, error) {
+	const op errors.Op = "medicines.data.SelectMedicinesByIds"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	records := []Medicine{}
+	err := r.db.Where("id IN ?", ids).Find(&records).Error
+	if err != nil {
+		return []medicines.MedicineCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return toSliceMedicineCore(records), nil
+}
+
+func (r *mySQLRepo) SelectMedicineByName(name string) (medicines.MedicineCore, error) {
+	const op errors.Op = "medicines.data.SelectMedicineByName"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	record := Medicine{}
+	err := r.db.Where("name = ?", name).First(&record).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMsg = "Medicine not found"
+			return medicines.MedicineCore{}, errors.E(err, op, errMsg, errors.KindNotFound)
+
+		default:
+			return medicines.MedicineCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+		}
+	}
+	return record.toMedicineCore(), nil
+}
+
+func (r *mySQLRepo) SelectAvailableStock(medicineIds []int, today string) (map[int]int, error) {
+	const op errors.Op = "medicines.data.SelectAvailableStock"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	totals := []AvailableStock{}
+	query := `
+		SELECT b.medicine_id, SUM(b.remaining - b.reserved) AS total FROM batches b
+		WHERE b.deleted_at IS NULL AND b.expiry_date > ? AND b.medicine_id IN (?)
+		GROUP BY b.medicine_id
+	`
+
+	err := r.db.Raw(query, today, medicineIds).Scan(&totals).Error
+	if err != nil {
+		return map[int]int{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	result := make(map[int]int)
+	for _, t := range totals {
+		result[t.MedicineID] = t.Total
+	}
+	return result, nil
+}
+
+func (r *mySQLRepo) InsertMedicine(medicine medicines.MedicineCore) (int, error) {
+	const op errors.Op = "medicines.data.InsertMedicine"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	record := toMedicineRecord(medicine)
+	err := r.db.Create(&record).Error
+	if err != nil {
+		return 0, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return int(record.ID), nil
+}
+
+func (r *mySQLRepo) UpdateMedicine(medicine medicines.MedicineCore) error {
+	const op errors.Op = "medicines.data.UpdateMedicine"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	record := toMedicineRecord(medicine)
+	err := r.db.Save(&record).Error
+	if err != nil {
+		return errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) DeleteMedicineById(id int, updatedBy int) error {
+	const op errors.Op = "medicines.data.DeleteMedicineById"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	now := time.Now().In(config.GetTimeLoc())
+	err := r.db.
+		Exec("UPDATE medicines SET updated_by = ?, deleted_at = ? WHERE id = ?", updatedBy, now, id).
+		Error
+
+	if err != nil {
+		return errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) SelectBatchesByMedicineId(medicineId int) ([]medicines.BatchCore, error) {
+	const op errors.Op = "medicines.data.SelectBatchesByMedicineId"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	records := []Batch{}
+	err := r.db.
+		Where("medicine_id = ? AND remaining > 0", medicineId).
+		Order("expiry_date, id").
+		Find(&records).
+		Error
+
+	if err != nil {
+		return []medicines.BatchCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return toSliceBatchCore(records), nil
+}
+
+func (r *mySQLRepo) SelectBatchById(id int) (medicines.BatchCore, error) {
+	const op errors.Op = "medicines.data.SelectBatchById"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	record := Batch{}
+	err := r.db.First(&record, id).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMsg = "Batch not found"
+			return medicines.BatchCore{}, errors.E(err, op, errMsg, errors.KindNotFound)
+
+		default:
+			return medicines.BatchCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+		}
+	}
+	return record.toBatchCore(), nil
+}
+
+func (r *mySQLRepo) InsertBatch(batch medicines.BatchCore) (int, error) {
+	const op errors.Op = "medicines.data.InsertBatch"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	record := toBatchRecord(batch)
+	err := r.db.Create(&record).Error
+	if err != nil {
+		return 0, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return int(record.ID), nil
+}
+
+func (r *mySQLRepo) UpdateBatchRemaining(batchId int, remaining int) error {
+	const op errors.Op = "medicines.data.UpdateBatchRemaining"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	result := r.db.
+		Model(&Batch{}).
+		Where("id = ? AND reserved <= ?", batchId, remaining).
+		Update("remaining", remaining)
+
+	if result.Error != nil {
+		return errors.E(result.Error, op, errMsg, errors.KindServerError)
+	}
+	if result.RowsAffected == 0 {
+		errMsg = "Units on hand cannot be less than the reserved units"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) ReserveStock(requests []medicines.ReservationCore, today string) ([]medicines.ReservationCore, error) {
+	const op errors.Op = "medicines.data.ReserveStock"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	reserved := []Reservation{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, request := range requests {
+			rs, _, err := reserve(tx, request.PrescriptionID, request.MedicineID, request.Quantity, today)
+			if err != nil {
+				return err
+			}
+			reserved = append(reserved, rs...)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return []medicines.ReservationCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return toSliceReservationCore(reserved), nil
+}
+
+func (r *mySQLRepo) ReleaseStock(prescriptionIds []int) error {
+	const op errors.Op = "medicines.data.ReleaseStock"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		held := []Reservation{}
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("prescription_id IN ? AND status = ?", prescriptionIds, medicines.ReservationReserved).
+			Find(&held).
+			Error
+		if err != nil {
+			return err
+		}
+
+		for _, h := range held {
+			if err := release(tx, h); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return nil
+}
+
+// DispenseStock takes the reserved units off their batches. Units reserved on batches
+// that expired since are released and, like a short reservation, topped up from the
+// stock first.
+func (r *mySQLRepo) DispenseStock(requests []medicines.ReservationCore, dispensedBy int, today string) error {
+	const op errors.Op = "medicines.data.DispenseStock"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	now := time.Now().In(config.GetTimeLoc())
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, request := range requests {
+			held := []Reservation{}
+			err := tx.
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("prescription_id = ? AND status = ?", request.PrescriptionID, medicines.ReservationReserved).
+				Find(&held).
+				Error
+			if err != nil {
+				return err
+			}
+
+			valid := []Reservation{}
+			missing := request.Quantity
+			for _, h := range held {
+				batch := Batch{}
+				err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&batch, h.BatchID).Error
+				if err != nil {
+					return err
+				}
+
+				if batch.toBatchCore().ExpiryDate > today {
+					valid = append(valid, h)
+					missing -= h.Quantity
+				} else if err = release(tx, h); err != nil {
+					return err
+				}
+			}
+
+			if missing > 0 {
+				rs, left, err := reserve(tx, request.PrescriptionID, request.MedicineID, missing, today)
+				if err != nil {
+					return err
+				}
+				if left > 0 {
+					errMsg = "Not enough stock to dispense the prescriptions"
+					return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+				}
+				valid = append(valid, rs...)
+			}
+
+			for _, v := range valid {
+				err = tx.
+					Model(&Batch{}).
+					Where("id = ?", v.BatchID).
+					Updates(map[string]interface{}{
+						"remaining": gorm.Expr("remaining - ?", v.Quantity),
+						"reserved":  gorm.Expr("reserved - ?", v.Quantity),
+					}).
+					Error
+				if err != nil {
+					return err
+				}
+
+				err = tx.
+					Model(&Reservation{}).
+					Where("id = ?", v.ID).
+					Updates(map[string]interface{}{
+						"status":       medicines.ReservationDispensed,
+						"dispensed_by": dispensedBy,
+						"dispensed_at": now,
+					}).
+					Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		if errors.Kind(err) == errors.KindUnprocessable {
+			return errors.E(err, op)
+		}
+		return errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) SelectReservationsByPrescriptionIds(prescriptionIds []int) ([]medicines.ReservationCore, error) {
+	const op errors.Op = "medicines.data.SelectReservationsByPrescriptionIds"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	records := []Reservation{}
+	err := r.db.
+		Where("prescription_id IN ? AND status <> ?", prescriptionIds, medicines.ReservationReleased).
+		Order("id").
+		Find(&records).
+		Error
+
+	if err != nil {
+		return []medicines.ReservationCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return toSliceReservationCore(records), nil
+}
+
+// reserve holds up to quantity units for the prescription from the earliest expiring
+// batches, it returns the units that could not be reserved
+func reserve(tx *gorm.DB, prescriptionId int, medicineId int, quantity int, today string) ([]Reservation, int, error) {
+	batches := []Batch{}
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("medicine_id = ? AND expiry_date > ? AND remaining > reserved", medicineId, today).
+		Order("expiry_date, id").
+		Find(&batches).
+		Error
+	if err != nil {
+		return nil, quantity, err
+	}
+
+	reserved := []Reservation{}
+	for _, b := range batches {
+		if quantity == 0 {
+			break
+		}
+
+		take := b.Remaining - b.Reserved
+		if take > quantity {
+			take = quantity
+		}
+
+		reservation := Reservation{
+			PrescriptionID: prescriptionId,
+			MedicineID:     b.MedicineID,
+			BatchID:        b.ID,
+			Quantity:       take,
+			Status:         medicines.ReservationReserved,
+		}
+		if err = tx.Create(&reservation).Error; err != nil {
+			return nil, quantity, err
+		}
+
+		err = tx.Model(&Batch{}).Where("id = ?", b.ID).Update("reserved", gorm.Expr("reserved + ?", take)).Error
+		if err != nil {
+			return nil, quantity, err
+		}
+
+		reserved = append(reserved, reservation)
+		quantity -= take
+	}
+	return reserved, quantity, nil
+}
+
+func release(tx *gorm.DB, reservation Reservation) error {
+	err := tx.
+		Model(&Batch{}).
+		Where("id = ?", reservation.BatchID).
+		Update("reserved", gorm.Expr("reserved - ?", reservation.Quantity)).
+		Error
+	if err != nil {
+		return err
+	}
+
+	return tx.
+		Model(&Reservation{}).
+		Where("id = ?", reservation.ID).
+		Update("status", medicines.ReservationReleased).
+		Error
+}
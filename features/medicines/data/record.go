@@ -0,0 +1,145 @@
+package data
+
+import (
+	"strings"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
+	"gorm.io/gorm"
+)
+
+type Medicine struct {
+	gorm.Model
+	CreatedBy int
+	UpdatedBy int
+
+	Name         string `gorm:"type:varchar(64);not null;index"`
+	Form         string `gorm:"type:varchar(16);not null"`
+	Unit         string `gorm:"type:varchar(16);not null"`
+	MinimumStock int    `gorm:"not null;default:0"`
+	Batches      []Batch
+}
+
+type Batch struct {
+	gorm.Model
+	MedicineID  uint   `gorm:"not null;index"`
+	ReceivedBy  int    `gorm:"not null"`
+	BatchNumber string `gorm:"type:varchar(64);not null"`
+	ExpiryDate  string `gorm:"type:date;not null;index"`
+	Quantity    int    `gorm:"not null"`
+	Remaining   int    `gorm:"not null"`
+	Reserved    int    `gorm:"not null;default:0"`
+}
+
+type Reservation struct {
+	gorm.Model
+	PrescriptionID int    `gorm:"not null;index"`
+	MedicineID     uint   `gorm:"not null"`
+	BatchID        uint   `gorm:"not null"`
+	Quantity       int    `gorm:"not null"`
+	Status         string `gorm:"type:varchar(16);not null;index"`
+	DispensedBy    int
+	DispensedAt    *time.Time
+}
+
+// AvailableStock is a row of SelectAvailableStock
+type AvailableStock struct {
+	MedicineID int
+	Total      int
+}
+
+func (m Medicine) toMedicineCore() medicines.MedicineCore {
+	return medicines.MedicineCore{
+		ID:           int(m.ID),
+		CreatedBy:    m.CreatedBy,
+		UpdatedBy:    m.UpdatedBy,
+		Name:         m.Name,
+		Form:         m.Form,
+		Unit:         m.Unit,
+		MinimumStock: m.MinimumStock,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func toSliceMedicineCore(m []Medicine) []medicines.MedicineCore {
+	result := make([]medicines.MedicineCore, len(m))
+	for i := range m {
+		result[i] = m[i].toMedicineCore()
+	}
+	return result
+}
+
+func toMedicineRecord(m medicines.MedicineCore) Medicine {
+	return Medicine{
+		Model:        gorm.Model{ID: uint(m.ID), CreatedAt: m.CreatedAt},
+		CreatedBy:    m.CreatedBy,
+		UpdatedBy:    m.UpdatedBy,
+		Name:         m.Name,
+		Form:         m.Form,
+		Unit:         m.Unit,
+		MinimumStock: m.MinimumStock,
+	}
+}
+
+func (b Batch) toBatchCore() medicines.BatchCore {
+	return medicines.BatchCore{
+		ID:          int(b.ID),
+		MedicineID:  int(b.MedicineID),
+		ReceivedBy:  b.ReceivedBy,
+		BatchNumber: b.BatchNumber,
+		ExpiryDate:  strings.Split(b.ExpiryDate, "T")[0],
+		Quantity:    b.Quantity,
+		Remaining:   b.Remaining,
+		Reserved:    b.Reserved,
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+	}
+}
+
+func toSliceBatchCore(b []Batch) []medicines.BatchCore {
+	result := make([]medicines.BatchCore, len(b))
+	for i := range b {
+		result[i] = b[i].toBatchCore()
+	}
+	return result
+}
+
+func toBatchRecord(b medicines.BatchCore) Batch {
+	return Batch{
+		Model:       gorm.Model{ID: uint(b.ID), CreatedAt: b.CreatedAt},
+		MedicineID:  uint(b.MedicineID),
+		ReceivedBy:  b.ReceivedBy,
+		BatchNumber: b.BatchNumber,
+		ExpiryDate:  b.ExpiryDate,
+		Quantity:    b.Quantity,
+		Remaining:   b.Remaining,
+		Reserved:    b.Reserved,
+	}
+}
+
+func (r Reservation) toReservationCore() medicines.ReservationCore {
+	reservation := medicines.ReservationCore{
+		ID:             int(r.ID),
+		PrescriptionID: r.PrescriptionID,
+		MedicineID:     int(r.MedicineID),
+		BatchID:        int(r.BatchID),
+		Quantity:       r.Quantity,
+		Status:         r.Status,
+		DispensedBy:    r.DispensedBy,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+	if r.DispensedAt != nil {
+		reservation.DispensedAt = *r.DispensedAt
+	}
+	return reservation
+}
+
+func toSliceReservationCore(r []Reservation) []medicines.ReservationCore {
+	result := make([]medicines.ReservationCore, len(r))
+	for i := range r {
+		result[i] = r[i].toReservationCore()
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package medicines
+
+import "time"
+
+// MedicineCore is an entry of the catalogue, its stock is kept in batches
+type MedicineCore struct {
+	ID           int
+	CreatedBy    int
+	UpdatedBy    int
+	Name         string // unique, includes the strength, e.g. Paracetamol 500 mg
+	Form         string
+	Unit         string // what is counted when handed out, e.g. tablet or bottle
+	MinimumStock int    // stock at or below it is reported as low
+	Stock        int    // available in unexpired batches, reserved units are not counted
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// MedicineQuery filters the catalogue, Name matches part of the name
+type MedicineQuery struct {
+	Name     string
+	LowStock bool
+}
+
+// BatchCore is stock received at once, handed out first expiry first
+type BatchCore struct {
+	ID          int
+	MedicineID  int
+	ReceivedBy  int
+	BatchNumber string
+	ExpiryDate  string // not handed out from this date on
+	Quantity    int    // received
+	Remaining   int    // on hand, including reserved units
+	Reserved    int    // held for prescriptions that are not dispensed yet
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ReservationCore holds units of a batch for a prescription. As a request to the stock
+// only PrescriptionID, MedicineID and Quantity are filled.
+type ReservationCore struct {
+	ID             int
+	PrescriptionID int
+	MedicineID     int
+	BatchID        int
+	Quantity       int
+	Status         string
+	DispensedBy    int
+	DispensedAt    time.Time // zero unless dispensed
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type IBusiness interface {
+	FindMedicines(q MedicineQuery) ([]MedicineCore, error)
+	FindMedicineById(id int) (MedicineCore, error)
+	FindMedicinesByIds(ids []int) ([]MedicineCore, error) // used by schedules to check prescriptions
+	CreateMedicine(medicine MedicineCore, userId int, role string) error
+	EditMedicine(medicine MedicineCore, userId int, role string) error
+	RemoveMedicineById(id int, userId int, role string) error // only without stock on hand
+
+	FindBatchesByMedicineId(medicineId int) ([]BatchCore, error) // first expiry first, empty batches excluded
+	CreateBatch(batch BatchCore, userId int, role string) error
+	// AdjustBatch corrects the units on hand after a count or writes off expired and
+	// damaged units, reserved units cannot be adjusted away
+	AdjustBatch(batchId int, remaining int, reason string, userId int, role string) error
+
+	// Used by schedules. Reservations are best effort, the part that could not be
+	// reserved is taken from the stock when dispensing.
+	ReserveStock(requests []ReservationCore) ([]ReservationCore, error)
+	ReleaseStock(prescriptionIds []int) error
+	DispenseStock(requests []ReservationCore, userId int) error // all or nothing, fails when stock is short
+	FindReservationsByPrescriptionIds(prescriptionIds []int) ([]ReservationCore, error)
+}
+
+type IData interface {
+	SelectMedicines(q MedicineQuery) ([]MedicineCore, error)
+	SelectMedicineById(id int) (MedicineCore, error)
+	SelectMedicinesByIds(ids []int) ([]MedicineCore, error)
+	SelectMedicineByName(name string) (MedicineCore, error)
+	SelectAvailableStock(medicineIds []int, today string) (map[int]int, error) // by medicine id
+	InsertMedicine(medicine MedicineCore) (int, error)
+	UpdateMedicine(medicine MedicineCore) error
+	DeleteMedicineById(id int, updatedBy int) error
+
+	SelectBatchesByMedicineId(medicineId int) ([]BatchCore, error)
+	SelectBatchById(id int) (BatchCore, error)
+	InsertBatch(batch BatchCore) (int, error)
+	UpdateBatchRemaining(batchId int, remaining int) error // fails when below the reserved units
+
+	// Batches are locked while the units are counted, expired ones are skipped
+	ReserveStock(requests []ReservationCore, today string) ([]ReservationCore, error)
+	ReleaseStock(prescriptionIds []int) error
+	DispenseStock(requests []ReservationCore, dispensedBy int, today string) error
+	SelectReservationsByPrescriptionIds(prescriptionIds []int) ([]ReservationCore, error)
+}
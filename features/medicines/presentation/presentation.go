@@ -0,0 +1,220 @@
+package presentation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines/presentation/request"
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines/presentation/response"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type MedicinePresentation struct {
+	business medicines.IBusiness
+	validate *validator.Validate
+}
+
+func NewMedicinePresentation(business medicines.IBusiness) *MedicinePresentation {
+	return &MedicinePresentation{
+		business: business,
+		validate: validator.New(),
+	}
+}
+
+func (mp *MedicinePresentation) GetMedicines(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving medicines"
+	const op errors.Op = "medicines.presentation.GetMedicines"
+	var errMessage errors.ErrClientMessage
+
+	var req request.QueryParamsRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := mp.validate.Struct(req); err != nil {
+		errMessage = "Invalid query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	data, err := mp.business.FindMedicines(req.ToMedicineQuery())
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListMedicines(data))
+}
+
+func (mp *MedicinePresentation) GetDetailMedicine(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving medicine"
+	const op errors.Op = "medicines.presentation.GetDetailMedicine"
+	var errMessage errors.ErrClientMessage
+
+	medicineId, err := strconv.Atoi(c.Param("medicineId"))
+	if err != nil || medicineId < 1 {
+		errMessage = "Invalid medicine id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	medicine, err := mp.business.FindMedicineById(medicineId)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.DetailMedicine(medicine))
+}
+
+func (mp *MedicinePresentation) PostMedicine(c echo.Context) error {
+	status := http.StatusCreated
+	message := "Success creating medicine"
+	const op errors.Op = "medicines.presentation.PostMedicine"
+	var errMessage errors.ErrClientMessage
+
+	medicine := request.CreateMedicineRequest{}
+	err := c.Bind(&medicine)
+	if err != nil {
+		errMessage = "Unable to parse payload request"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = mp.validate.Struct(medicine)
+	if err != nil {
+		errMessage = "Invalid. Makesure all field is filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = mp.business.CreateMedicine(medicine.ToMedicineCore(), userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (mp *MedicinePresentation) PutEditMedicine(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success updating medicine"
+	const op errors.Op = "medicines.presentation.PutEditMedicine"
+	var errMessage errors.ErrClientMessage
+
+	medicine := request.UpdateMedicineRequest{}
+	err := c.Bind(&medicine)
+	if err != nil {
+		errMessage = "Unable to parse payload request"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = mp.validate.Struct(medicine)
+	if err != nil {
+		errMessage = "Invalid. Makesure all field is filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = mp.business.EditMedicine(medicine.ToMedicineCore(), userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (mp *MedicinePresentation) DeleteMedicine(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success deleting medicine"
+	const op errors.Op = "medicines.presentation.DeleteMedicine"
+	var errMessage errors.ErrClientMessage
+
+	medicineId, err := strconv.Atoi(c.Param("medicineId"))
+	if err != nil || medicineId < 1 {
+		errMessage = "Invalid medicine id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = mp.business.RemoveMedicineById(medicineId, userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (mp *MedicinePresentation) GetMedicineBatches(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving medicine batches"
+	const op errors.Op = "medicines.presentation.GetMedicineBatches"
+	var errMessage errors.ErrClientMessage
+
+	medicineId, err := strconv.Atoi(c.Param("medicineId"))
+	if err != nil || medicineId < 1 {
+		errMessage = "Invalid medicine id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	batches, err := mp.business.FindBatchesByMedicineId(medicineId)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListBatches(batches))
+}
+
+func (mp *MedicinePresentation) PostBatch(c echo.Context) error {
+	status := http.StatusCreated
+	message := "Success receiving medicine batch"
+	const op errors.Op = "medicines.presentation.PostBatch"
+	var errMessage errors.ErrClientMessage
+
+	batch := request.CreateBatchRequest{}
+	err := c.Bind(&batch)
+	if err != nil {
+		errMessage = "Unable to parse payload request"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = mp.validate.Struct(batch)
+	if err != nil {
+		errMessage = "Invalid. Makesure all field is filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = mp.business.CreateBatch(batch.ToBatchCore(), userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
+
+func (mp *MedicinePresentation) PutAdjustBatch(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success adjusting medicine batch"
+	const op errors.Op = "medicines.presentation.PutAdjustBatch"
+	var errMessage errors.ErrClientMessage
+
+	adjustment := request.AdjustBatchRequest{}
+	err := c.Bind(&adjustment)
+	if err != nil {
+		errMessage = "Unable to parse payload request"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = mp.validate.Struct(adjustment)
+	if err != nil {
+		errMessage = "Invalid. Makesure all field is filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = mp.business.AdjustBatch(adjustment.ID, adjustment.Remaining, adjustment.Reason, userId, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, nil)
+}
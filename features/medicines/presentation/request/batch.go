@@ -0,0 +1,29 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
+)
+
+type CreateBatchRequest struct {
+	MedicineID  int    `json:"medicineId" validate:"required,gt=0"`
+	BatchNumber string `json:"batchNumber" validate:"required,max=64"`
+	ExpiryDate  string `json:"expiryDate" validate:"required,datetime=2006-01-02"`
+	Quantity    int    `json:"quantity" validate:"required,gt=0"`
+}
+
+func (r CreateBatchRequest) ToBatchCore() medicines.BatchCore {
+	return medicines.BatchCore{
+		MedicineID:  r.MedicineID,
+		BatchNumber: strings.TrimSpace(r.BatchNumber),
+		ExpiryDate:  r.ExpiryDate,
+		Quantity:    r.Quantity,
+	}
+}
+
+type AdjustBatchRequest struct {
+	ID        int    `json:"id" validate:"required,gt=0"`
+	Remaining int    `json:"remaining" validate:"gte=0"`
+	Reason    string `json:"reason" validate:"required,max=255"`
+}
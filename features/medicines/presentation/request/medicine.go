@@ -0,0 +1,41 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
+)
+
+type CreateMedicineRequest struct {
+	Name         string `json:"name" validate:"required,max=64"`
+	Form         string `json:"form" validate:"required,oneof=tablet capsule syrup injection cream drops inhaler suppository other"`
+	Unit         string `json:"unit" validate:"required,max=16"`
+	MinimumStock int    `json:"minimumStock" validate:"gte=0"`
+}
+
+func (r CreateMedicineRequest) ToMedicineCore() medicines.MedicineCore {
+	return medicines.MedicineCore{
+		Name:         strings.TrimSpace(r.Name),
+		Form:         r.Form,
+		Unit:         strings.TrimSpace(r.Unit),
+		MinimumStock: r.MinimumStock,
+	}
+}
+
+type UpdateMedicineRequest struct {
+	ID           int    `json:"id" validate:"required,gt=0"`
+	Name         string `json:"name" validate:"required,max=64"`
+	Form         string `json:"form" validate:"required,oneof=tablet capsule syrup injection cream drops inhaler suppository other"`
+	Unit         string `json:"unit" validate:"required,max=16"`
+	MinimumStock int    `json:"minimumStock" validate:"gte=0"`
+}
+
+func (r UpdateMedicineRequest) ToMedicineCore() medicines.MedicineCore {
+	return medicines.MedicineCore{
+		ID:           r.ID,
+		Name:         strings.TrimSpace(r.Name),
+		Form:         r.Form,
+		Unit:         strings.TrimSpace(r.Unit),
+		MinimumStock: r.MinimumStock,
+	}
+}
@@ -0,0 +1,19 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
+)
+
+type QueryParamsRequest struct {
+	Name     string `query:"name" validate:"max=64"`
+	LowStock bool   `query:"lowStock"`
+}
+
+func (q QueryParamsRequest) ToMedicineQuery() medicines.MedicineQuery {
+	return medicines.MedicineQuery{
+		Name:     strings.TrimSpace(q.Name),
+		LowStock: q.LowStock,
+	}
+}
@@ -0,0 +1,77 @@
+package response
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
+)
+
+type MedicineResponse struct {
+	ID           int       `json:"id"`
+	CreatedBy    int       `json:"createdBy"`
+	UpdatedBy    int       `json:"updatedBy"`
+	Name         string    `json:"name"`
+	Form         string    `json:"form"`
+	Unit         string    `json:"unit"`
+	MinimumStock int       `json:"minimumStock"`
+	Stock        int       `json:"stock"`
+	LowStock     bool      `json:"lowStock"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func DetailMedicine(m medicines.MedicineCore) MedicineResponse {
+	return MedicineResponse{
+		ID:           m.ID,
+		CreatedBy:    m.CreatedBy,
+		UpdatedBy:    m.UpdatedBy,
+		Name:         m.Name,
+		Form:         m.Form,
+		Unit:         m.Unit,
+		MinimumStock: m.MinimumStock,
+		Stock:        m.Stock,
+		LowStock:     m.Stock <= m.MinimumStock,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func ListMedicines(m []medicines.MedicineCore) []MedicineResponse {
+	result := make([]MedicineResponse, len(m))
+	for i := range m {
+		result[i] = DetailMedicine(m[i])
+	}
+	return result
+}
+
+type BatchResponse struct {
+	ID          int       `json:"id"`
+	MedicineID  int       `json:"medicineId"`
+	ReceivedBy  int       `json:"receivedBy"`
+	BatchNumber string    `json:"batchNumber"`
+	ExpiryDate  string    `json:"expiryDate"`
+	Quantity    int       `json:"quantity"`
+	Remaining   int       `json:"remaining"`
+	Reserved    int       `json:"reserved"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func ListBatches(b []medicines.BatchCore) []BatchResponse {
+	result := make([]BatchResponse, len(b))
+	for i, v := range b {
+		result[i] = BatchResponse{
+			ID:          v.ID,
+			MedicineID:  v.MedicineID,
+			ReceivedBy:  v.ReceivedBy,
+			BatchNumber: v.BatchNumber,
+			ExpiryDate:  v.ExpiryDate,
+			Quantity:    v.Quantity,
+			Remaining:   v.Remaining,
+			Reserved:    v.Reserved,
+			CreatedAt:   v.CreatedAt,
+			UpdatedAt:   v.UpdatedAt,
+		}
+	}
+	return result
+}
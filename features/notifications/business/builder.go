@@ -0,0 +1,63 @@
+package business
+
+import (
+	"text/template"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications"
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+)
+
+type notificationBusinessBuilder struct {
+	data            notifications.IData
+	channel         notifications.IChannel
+	patientBusiness patients.IBusiness
+	doctorBusiness  doctors.IBusiness
+}
+
+func NewNotificationBusinessBuilder() *notificationBusinessBuilder {
+	return &notificationBusinessBuilder{}
+}
+
+func (b *notificationBusinessBuilder) SetData(data notifications.IData) *notificationBusinessBuilder {
+	b.data = data
+	return b
+}
+
+func (b *notificationBusinessBuilder) SetChannel(channel notifications.IChannel) *notificationBusinessBuilder {
+	b.channel = channel
+	return b
+}
+
+func (b *notificationBusinessBuilder) SetPatientBusiness(p patients.IBusiness) *notificationBusinessBuilder {
+	b.patientBusiness = p
+	return b
+}
+
+func (b *notificationBusinessBuilder) SetDoctorBusiness(d doctors.IBusiness) *notificationBusinessBuilder {
+	b.doctorBusiness = d
+	return b
+}
+
+// Build panics on an invalid template, they are fixed in notifications.Templates
+func (b *notificationBusinessBuilder) Build() notifications.IBusiness {
+	templates := make(map[string]*template.Template)
+	for event, text := range notifications.Templates {
+		templates[event] = template.Must(template.New(event).Parse(text))
+	}
+
+	notificationBusiness := &notificationBusiness{
+		data:            b.data,
+		channel:         b.channel,
+		patientBusiness: b.patientBusiness,
+		doctorBusiness:  b.doctorBusiness,
+		templates:       templates,
+	}
+
+	b.data = nil
+	b.channel = nil
+	b.patientBusiness = nil
+	b.doctorBusiness = nil
+
+	return notificationBusiness
+}
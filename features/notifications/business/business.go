@@ -0,0 +1,216 @@
+package business
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications"
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+type notificationBusiness struct {
+	data            notifications.IData
+	channel         notifications.IChannel
+	patientBusiness patients.IBusiness
+	doctorBusiness  doctors.IBusiness
+	templates       map[string]*template.Template
+}
+
+// MessageData fills notifications.Templates
+type MessageData struct {
+	PatientName string
+	DoctorName  string
+	Room        string
+	Date        string
+	Time        string // start of the slot or of the work schedule
+	QueueNumber int
+}
+
+func (n *notificationBusiness) NotifyOutpatients(event string, outpatients []schedules.OutpatientCore) {
+	go func() {
+		if err := n.notifyOutpatients(event, outpatients); err != nil {
+			errors.Log(err)
+		}
+	}()
+}
+
+func (n *notificationBusiness) FindNotifications(q notifications.NotificationQuery) ([]notifications.NotificationCore, error) {
+	const op errors.Op = "notifications.business.FindNotifications"
+
+	if q.Limit < 1 {
+		q.Limit = notifications.DEFAULT_LIMIT
+	}
+	if q.Limit > notifications.MAX_LIMIT {
+		q.Limit = notifications.MAX_LIMIT
+	}
+
+	result, err := n.data.SelectNotifications(q)
+	if err != nil {
+		return []notifications.NotificationCore{}, errors.E(err, op)
+	}
+	return result, nil
+}
+
+// Private methods
+
+// notifyOutpatients sends the message of event to the patient of each outpatient and logs
+// every delivery, a failed one doesn't stop the others
+func (n *notificationBusiness) notifyOutpatients(event string, outpatients []schedules.OutpatientCore) error {
+	const op errors.Op = "notifications.business.notifyOutpatients"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	tmpl, ok := n.templates[event]
+	if !ok {
+		return errors.E(fmt.Errorf("no template for event %q", event), op, errMsg, errors.KindServerError)
+	}
+
+	if notifications.OnceEvents[event] {
+		ids := make([]int, len(outpatients))
+		for i := range outpatients {
+			ids[i] = outpatients[i].ID
+		}
+
+		notified, err := n.data.SelectNotifiedOutpatientIds(event, ids)
+		if err != nil {
+			return errors.E(err, op)
+		}
+
+		pending := []schedules.OutpatientCore{}
+		for _, o := range outpatients {
+			if !notified[o.ID] {
+				pending = append(pending, o)
+			}
+		}
+		outpatients = pending
+	}
+
+	if len(outpatients) == 0 {
+		return nil
+	}
+
+	patientsById, err := n.findPatients(outpatients)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	doctorsById, err := n.findDoctors(outpatients)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	for _, o := range outpatients {
+		patient, ok := patientsById[o.Patient.ID]
+		if !ok {
+			continue // removed since
+		}
+
+		message, err := render(tmpl, o, patient, doctorsById[o.WorkSchedule.Doctor.ID])
+		if err != nil {
+			errors.Log(errors.E(err, op, errMsg, errors.KindServerError))
+			continue
+		}
+
+		notification := notifications.NotificationCore{
+			Event:        event,
+			Channel:      n.channel.Name(),
+			PatientID:    patient.ID,
+			OutpatientID: o.ID,
+			Recipient:    n.channel.Recipient(patient),
+			Message:      message,
+			Status:       notifications.StatusSent,
+		}
+
+		if notification.Recipient == "" {
+			notification.Status = notifications.StatusFailed
+			notification.Error = "Patient cannot be reached on this channel"
+		} else if err := n.channel.Send(notification.Recipient, message); err != nil {
+			notification.Status = notifications.StatusFailed
+			notification.Error = err.Error()
+		}
+
+		if _, err := n.data.InsertNotification(notification); err != nil {
+			errors.Log(errors.E(err, op))
+		}
+	}
+	return nil
+}
+
+func (n *notificationBusiness) findPatients(outpatients []schedules.OutpatientCore) (map[int]patients.PatientCore, error) {
+	const op errors.Op = "notifications.business.findPatients"
+
+	ids := []int{}
+	seen := map[int]bool{}
+	for _, o := range outpatients {
+		if !seen[o.Patient.ID] {
+			seen[o.Patient.ID] = true
+			ids = append(ids, o.Patient.ID)
+		}
+	}
+
+	found, err := n.patientBusiness.FindPatientsByIds(ids)
+	if err != nil {
+		return nil, errors.E(err, op)
+	}
+
+	result := make(map[int]patients.PatientCore)
+	for _, p := range found {
+		result[p.ID] = p
+	}
+	return result, nil
+}
+
+func (n *notificationBusiness) findDoctors(outpatients []schedules.OutpatientCore) (map[int]doctors.DoctorCore, error) {
+	const op errors.Op = "notifications.business.findDoctors"
+
+	ids := []int{}
+	seen := map[int]bool{}
+	for _, o := range outpatients {
+		if !seen[o.WorkSchedule.Doctor.ID] {
+			seen[o.WorkSchedule.Doctor.ID] = true
+			ids = append(ids, o.WorkSchedule.Doctor.ID)
+		}
+	}
+
+	found, err := n.doctorBusiness.FindDoctorsByIds(ids)
+	if err != nil {
+		return nil, errors.E(err, op)
+	}
+
+	result := make(map[int]doctors.DoctorCore)
+	for _, d := range found {
+		result[d.ID] = d
+	}
+	return result, nil
+}
+
+func render(tmpl *template.Template, o schedules.OutpatientCore, patient patients.PatientCore, doctor doctors.DoctorCore) (string, error) {
+	data := MessageData{
+		PatientName: patient.Name,
+		DoctorName:  doctor.Name,
+		Room:        doctor.Room.Code,
+		Date:        o.WorkSchedule.Date,
+		Time:        o.WorkSchedule.StartTime,
+		QueueNumber: o.QueueNumber,
+	}
+	if o.SlotTime != "" {
+		data.Time = o.SlotTime
+	}
+
+	if date, err := time.Parse("2006-01-02", data.Date); err == nil {
+		data.Date = date.Format("Mon, 02 Jan 2006")
+	}
+	if t, err := time.Parse("15:04:05", data.Time); err == nil {
+		data.Time = t.Format("15:04")
+	}
+
+	var message bytes.Buffer
+	if err := tmpl.Execute(&message, data); err != nil {
+		return "", err
+	}
+	return message.String(), nil
+}
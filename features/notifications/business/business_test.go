@@ -0,0 +1,217 @@
+package business_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications"
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	dm "github.com/final-project-alterra/hospital-management-system-api/features/doctors/mocks"
+	nb "github.com/final-project-alterra/hospital-management-system-api/features/notifications/business"
+	nm "github.com/final-project-alterra/hospital-management-system-api/features/notifications/mocks"
+	pm "github.com/final-project-alterra/hospital-management-system-api/features/patients/mocks"
+)
+
+var (
+	repo     nm.IData
+	channel  nm.IChannel
+	business notifications.IBusiness
+
+	patientBusiness pm.IBusiness
+	doctorBusiness  dm.IBusiness
+
+	patient1    patients.PatientCore
+	patient2    patients.PatientCore
+	doctor1     doctors.DoctorCore
+	outpatient1 schedules.OutpatientCore
+	outpatient2 schedules.OutpatientCore
+
+	errServer error
+)
+
+func TestMain(m *testing.M) {
+	business = nb.NewNotificationBusinessBuilder().
+		SetData(&repo).
+		SetChannel(&channel).
+		SetPatientBusiness(&patientBusiness).
+		SetDoctorBusiness(&doctorBusiness).
+		Build()
+
+	channel.On("Name").Return("sms")
+
+	patient1 = patients.PatientCore{ID: 1, Name: "Budi", Phone: "0811"}
+	patient2 = patients.PatientCore{ID: 2, Name: "Sari"}
+	doctor1 = doctors.DoctorCore{ID: 1, Name: "dr. Ani", Room: doctors.RoomCore{ID: 1, Code: "A01"}}
+
+	workSchedule := schedules.WorkScheduleCore{
+		ID:        1,
+		Date:      "2100-01-01",
+		StartTime: "08:00:00",
+		EndTime:   "12:00:00",
+		Doctor:    schedules.DoctorCore{ID: doctor1.ID},
+	}
+	outpatient1 = schedules.OutpatientCore{
+		ID:           1,
+		QueueNumber:  3,
+		SlotTime:     "08:30:00",
+		Patient:      schedules.PatientCore{ID: patient1.ID},
+		WorkSchedule: workSchedule,
+	}
+	outpatient2 = schedules.OutpatientCore{
+		ID:           2,
+		QueueNumber:  4,
+		Patient:      schedules.PatientCore{ID: patient2.ID},
+		WorkSchedule: workSchedule,
+	}
+
+	errServer = errors.E(errors.New("server"), errors.KindServerError)
+
+	os.Exit(m.Run())
+}
+
+// expectInserts waits for the background delivery to log n notifications
+func expectInserts(t *testing.T, n int) func() []notifications.NotificationCore {
+	inserted := make(chan notifications.NotificationCore, n)
+
+	repo.
+		On("InsertNotification", mock.AnythingOfType("notifications.NotificationCore")).
+		Run(func(args mock.Arguments) { inserted <- args.Get(0).(notifications.NotificationCore) }).
+		Return(1, nil).
+		Times(n)
+
+	return func() []notifications.NotificationCore {
+		result := []notifications.NotificationCore{}
+		for i := 0; i < n; i++ {
+			select {
+			case notification := <-inserted:
+				result = append(result, notification)
+			case <-time.After(time.Second):
+				t.Fatal("notification was not logged")
+			}
+		}
+		return result
+	}
+}
+
+func TestNotifyOutpatients(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		patientBusiness.
+			On("FindPatientsByIds", []int{patient1.ID, patient2.ID}).
+			Return([]patients.PatientCore{patient1, patient2}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", []int{doctor1.ID}).
+			Return([]doctors.DoctorCore{doctor1}, nil).
+			Once()
+
+		channel.On("Recipient", patient1).Return("+62811").Once()
+		channel.On("Recipient", patient2).Return("").Once()
+
+		message := "Hi Budi, your visit to dr. Ani on Fri, 01 Jan 2100 at 08:30 has been canceled."
+		channel.On("Send", "+62811", message).Return(nil).Once()
+
+		wait := expectInserts(t, 2)
+		business.NotifyOutpatients(schedules.NotifyCanceled, []schedules.OutpatientCore{outpatient1, outpatient2})
+		result := wait()
+
+		assert.Equal(t, notifications.StatusSent, result[0].Status)
+		assert.Equal(t, "sms", result[0].Channel)
+		assert.Equal(t, message, result[0].Message)
+		assert.Equal(t, outpatient1.ID, result[0].OutpatientID)
+
+		assert.Equal(t, notifications.StatusFailed, result[1].Status)
+		assert.Equal(t, patient2.ID, result[1].PatientID)
+		assert.NotEmpty(t, result[1].Error)
+	})
+
+	t.Run("valid - when channel fails to send", func(t *testing.T) {
+		patientBusiness.
+			On("FindPatientsByIds", []int{patient1.ID}).
+			Return([]patients.PatientCore{patient1}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", []int{doctor1.ID}).
+			Return([]doctors.DoctorCore{doctor1}, nil).
+			Once()
+
+		channel.On("Recipient", patient1).Return("+62811").Once()
+		channel.On("Send", "+62811", mock.AnythingOfType("string")).Return(errors.New("gateway is down")).Once()
+
+		wait := expectInserts(t, 1)
+		business.NotifyOutpatients(schedules.NotifyRescheduled, []schedules.OutpatientCore{outpatient1})
+		result := wait()
+
+		assert.Equal(t, notifications.StatusFailed, result[0].Status)
+		assert.Equal(t, "gateway is down", result[0].Error)
+	})
+
+	t.Run("valid - once events skip outpatients already notified", func(t *testing.T) {
+		repo.
+			On("SelectNotifiedOutpatientIds", schedules.NotifyNext, []int{outpatient1.ID, outpatient2.ID}).
+			Return(map[int]bool{outpatient2.ID: true}, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientsByIds", []int{patient1.ID}).
+			Return([]patients.PatientCore{patient1}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", []int{doctor1.ID}).
+			Return([]doctors.DoctorCore{doctor1}, nil).
+			Once()
+
+		message := "Hi Budi, you're next for dr. Ani in room A01. Your queue number is 3."
+		channel.On("Recipient", patient1).Return("+62811").Once()
+		channel.On("Send", "+62811", message).Return(nil).Once()
+
+		wait := expectInserts(t, 1)
+		business.NotifyOutpatients(schedules.NotifyNext, []schedules.OutpatientCore{outpatient1, outpatient2})
+		result := wait()
+
+		assert.Equal(t, outpatient1.ID, result[0].OutpatientID)
+		assert.Equal(t, schedules.NotifyNext, result[0].Event)
+	})
+}
+
+func TestFindNotifications(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectNotifications", notifications.NotificationQuery{PatientID: 1, Limit: notifications.DEFAULT_LIMIT}).
+			Return([]notifications.NotificationCore{{ID: 1}}, nil).
+			Once()
+
+		result, err := business.FindNotifications(notifications.NotificationQuery{PatientID: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+	})
+
+	t.Run("valid - limit is capped", func(t *testing.T) {
+		repo.
+			On("SelectNotifications", notifications.NotificationQuery{Limit: notifications.MAX_LIMIT}).
+			Return([]notifications.NotificationCore{}, nil).
+			Once()
+
+		_, err := business.FindNotifications(notifications.NotificationQuery{Limit: 10000})
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - SelectNotifications error", func(t *testing.T) {
+		repo.
+			On("SelectNotifications", notifications.NotificationQuery{Limit: notifications.DEFAULT_LIMIT}).
+			Return([]notifications.NotificationCore{}, errServer).
+			Once()
+
+		_, err := business.FindNotifications(notifications.NotificationQuery{})
+		assert.Error(t, err)
+	})
+}
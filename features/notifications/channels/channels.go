@@ -0,0 +1,54 @@
+package channels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications"
+)
+
+const (
+	ChannelLog      = "log"
+	ChannelFile     = "file"
+	ChannelSMS      = "sms"
+	ChannelWhatsApp = "whatsapp"
+	ChannelEmail    = "email"
+)
+
+// New returns the channel named by NOTIFICATION_CHANNEL. The log and file channels keep
+// messages, login codes included, in plain text on the server, so they must be opted into
+// with APP_ENV=development.
+func New() (notifications.IChannel, error) {
+	switch config.ENV.NOTIFICATION_CHANNEL {
+	case ChannelLog, ChannelFile:
+		if config.ENV.APP_ENV != "development" {
+			return nil, fmt.Errorf("notification channel %q is only allowed with APP_ENV=development", config.ENV.NOTIFICATION_CHANNEL)
+		}
+		if config.ENV.NOTIFICATION_CHANNEL == ChannelLog {
+			return NewLogChannel(), nil
+		}
+		return NewFileChannel(config.ENV.NOTIFICATION_FILE), nil
+	case "":
+		return nil, fmt.Errorf("NOTIFICATION_CHANNEL is not set")
+	case ChannelSMS:
+		return NewSMSChannel(config.ENV.NOTIFICATION_URL, config.ENV.NOTIFICATION_TOKEN), nil
+	case ChannelWhatsApp:
+		return NewWhatsAppChannel(config.ENV.NOTIFICATION_URL, config.ENV.NOTIFICATION_TOKEN), nil
+	case ChannelEmail:
+		// patients are registered without an email address, email only reaches staff
+		return nil, fmt.Errorf("notification channel %q can not reach patients, use sms or whatsapp", ChannelEmail)
+	default:
+		return nil, fmt.Errorf("unknown notification channel %q", config.ENV.NOTIFICATION_CHANNEL)
+	}
+}
+
+// internationalPhone turns a local number (08xx) into 628xx, separators are dropped
+func internationalPhone(phone string) string {
+	phone = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(phone)
+	phone = strings.TrimPrefix(phone, "+")
+	if strings.HasPrefix(phone, "0") {
+		phone = "62" + phone[1:]
+	}
+	return phone
+}
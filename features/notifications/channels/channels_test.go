@@ -0,0 +1,122 @@
+package channels_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications/channels"
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatewayChannel(t *testing.T) {
+	var body map[string]interface{}
+	var auth string
+	status := http.StatusOK
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	patient := patients.PatientCore{Phone: "0811-2233"}
+
+	t.Run("valid - sms", func(t *testing.T) {
+		sms := channels.NewSMSChannel(server.URL, "secret")
+		to := sms.Recipient(patient)
+		assert.Equal(t, "+628112233", to)
+
+		err := sms.Send(to, "hello")
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer secret", auth)
+		assert.Equal(t, map[string]interface{}{"to": "+628112233", "message": "hello"}, body)
+	})
+
+	t.Run("valid - whatsapp", func(t *testing.T) {
+		whatsapp := channels.NewWhatsAppChannel(server.URL, "secret")
+		to := whatsapp.Recipient(patient)
+		assert.Equal(t, "628112233", to)
+
+		err := whatsapp.Send(to, "hello")
+		assert.Nil(t, err)
+		assert.Equal(t, "628112233", body["to"])
+		assert.Equal(t, map[string]interface{}{"body": "hello"}, body["text"])
+	})
+
+	t.Run("valid - when patient has no phone", func(t *testing.T) {
+		sms := channels.NewSMSChannel(server.URL, "secret")
+		assert.Equal(t, "", sms.Recipient(patients.PatientCore{}))
+	})
+
+	t.Run("valid - when gateway rejects the message", func(t *testing.T) {
+		status = http.StatusBadRequest
+		sms := channels.NewSMSChannel(server.URL, "secret")
+		err := sms.Send("+628112233", "hello")
+		assert.Error(t, err)
+	})
+}
+
+func TestFileChannel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.log")
+	file := channels.NewFileChannel(path)
+
+	assert.Nil(t, file.Send("0811", "first"))
+	assert.Nil(t, file.Send("0812", "second"))
+
+	content, err := os.ReadFile(path)
+	assert.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Equal(t, 2, len(lines))
+	assert.Contains(t, lines[1], `"to":"0812"`)
+	assert.Contains(t, lines[1], `"message":"second"`)
+}
+
+func TestNew(t *testing.T) {
+	defer func() {
+		config.ENV.NOTIFICATION_CHANNEL = ""
+		config.ENV.APP_ENV = ""
+	}()
+
+	t.Run("valid - sms", func(t *testing.T) {
+		config.ENV.NOTIFICATION_CHANNEL = channels.ChannelSMS
+		channel, err := channels.New()
+		assert.Nil(t, err)
+		assert.Equal(t, channels.ChannelSMS, channel.Name())
+	})
+
+	t.Run("valid - log in development", func(t *testing.T) {
+		config.ENV.NOTIFICATION_CHANNEL = channels.ChannelLog
+		config.ENV.APP_ENV = "development"
+		channel, err := channels.New()
+		assert.Nil(t, err)
+		assert.Equal(t, channels.ChannelLog, channel.Name())
+	})
+
+	t.Run("error - log outside development", func(t *testing.T) {
+		config.ENV.NOTIFICATION_CHANNEL = channels.ChannelLog
+		config.ENV.APP_ENV = ""
+		_, err := channels.New()
+		assert.Error(t, err)
+	})
+
+	t.Run("error - email can not reach patients", func(t *testing.T) {
+		config.ENV.NOTIFICATION_CHANNEL = channels.ChannelEmail
+		_, err := channels.New()
+		assert.Error(t, err)
+	})
+
+	t.Run("error - when no channel is set", func(t *testing.T) {
+		config.ENV.NOTIFICATION_CHANNEL = ""
+		_, err := channels.New()
+		assert.Error(t, err)
+	})
+}
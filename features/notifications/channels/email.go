@@ -0,0 +1,50 @@
+package channels
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+)
+
+// emailChannel sends plain text mails over SMTP to staff, such as password reset tokens.
+// It is not selectable as NOTIFICATION_CHANNEL, see New.
+type emailChannel struct {
+	addr    string
+	auth    smtp.Auth
+	from    string
+	subject string
+}
+
+func NewEmailChannel(host string, port string, username string, password string, from string) *emailChannel {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &emailChannel{addr: net.JoinHostPort(host, port), auth: auth, from: from, subject: "Your hospital visit"}
+}
+
+// WithSubject returns a copy of the channel that sends its mails with another subject
+func (e *emailChannel) WithSubject(subject string) *emailChannel {
+	copied := *e
+	copied.subject = subject
+	return &copied
+}
+
+func (e *emailChannel) Name() string {
+	return ChannelEmail
+}
+
+// Recipient is always empty, patients are registered without an email address
+func (e *emailChannel) Recipient(patient patients.PatientCore) string {
+	return ""
+}
+
+func (e *emailChannel) Send(recipient string, message string) error {
+	mail := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		e.from, recipient, e.subject, message,
+	)
+	return smtp.SendMail(e.addr, e.auth, e.from, []string{recipient}, []byte(mail))
+}
@@ -0,0 +1,56 @@
+package channels
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+)
+
+// fileChannel appends messages to a file as JSON lines, meant for local testing
+type fileChannel struct {
+	path string
+	mu   sync.Mutex
+}
+
+type fileMessage struct {
+	At      time.Time `json:"at"`
+	To      string    `json:"to"`
+	Message string    `json:"message"`
+}
+
+func NewFileChannel(path string) *fileChannel {
+	if path == "" {
+		path = "notifications.log"
+	}
+	return &fileChannel{path: path}
+}
+
+func (f *fileChannel) Name() string {
+	return ChannelFile
+}
+
+func (f *fileChannel) Recipient(patient patients.PatientCore) string {
+	return patient.Phone
+}
+
+func (f *fileChannel) Send(recipient string, message string) error {
+	line, err := json.Marshal(fileMessage{At: time.Now(), To: recipient, Message: message})
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
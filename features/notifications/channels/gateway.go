@@ -0,0 +1,98 @@
+package channels
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+)
+
+const GATEWAY_TIMEOUT = 10 * time.Second
+
+// gatewayChannel posts messages as JSON to the HTTP API of an SMS or WhatsApp provider,
+// authenticated with a bearer token
+type gatewayChannel struct {
+	name      string
+	url       string
+	token     string
+	client    *http.Client
+	recipient func(phone string) string
+	payload   func(to string, message string) interface{}
+}
+
+// NewSMSChannel posts {"to": "+628...", "message": "..."}
+func NewSMSChannel(url string, token string) *gatewayChannel {
+	return &gatewayChannel{
+		name:   ChannelSMS,
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: GATEWAY_TIMEOUT},
+		recipient: func(phone string) string {
+			return "+" + internationalPhone(phone)
+		},
+		payload: func(to string, message string) interface{} {
+			return map[string]string{"to": to, "message": message}
+		},
+	}
+}
+
+// NewWhatsAppChannel posts a text message of the WhatsApp Cloud API, url is the messages
+// endpoint of the sending phone number
+func NewWhatsAppChannel(url string, token string) *gatewayChannel {
+	return &gatewayChannel{
+		name:      ChannelWhatsApp,
+		url:       url,
+		token:     token,
+		client:    &http.Client{Timeout: GATEWAY_TIMEOUT},
+		recipient: internationalPhone,
+		payload: func(to string, message string) interface{} {
+			return map[string]interface{}{
+				"messaging_product": "whatsapp",
+				"to":                to,
+				"type":              "text",
+				"text":              map[string]string{"body": message},
+			}
+		},
+	}
+}
+
+func (g *gatewayChannel) Name() string {
+	return g.name
+}
+
+func (g *gatewayChannel) Recipient(patient patients.PatientCore) string {
+	if patient.Phone == "" {
+		return ""
+	}
+	return g.recipient(patient.Phone)
+}
+
+func (g *gatewayChannel) Send(recipient string, message string) error {
+	body, err := json.Marshal(g.payload(recipient, message))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, g.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+g.token)
+
+	res, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("%s gateway responded %d: %s", g.name, res.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package channels
+
+import (
+	"log"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+)
+
+// logChannel only writes messages to the server log, meant for local development
+type logChannel struct{}
+
+func NewLogChannel() *logChannel {
+	return &logChannel{}
+}
+
+func (l *logChannel) Name() string {
+	return ChannelLog
+}
+
+func (l *logChannel) Recipient(patient patients.PatientCore) string {
+	return patient.Phone
+}
+
+func (l *logChannel) Send(recipient string, message string) error {
+	log.Printf("Notification to %s: %s\n", recipient, message)
+	return nil
+}
@@ -0,0 +1,26 @@
+package notifications
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+
+const (
+	StatusSent   = "sent"
+	StatusFailed = "failed"
+
+	DEFAULT_LIMIT = 50
+	MAX_LIMIT     = 500
+)
+
+// Templates of the messages by event, filled with business.MessageData (text/template)
+var Templates = map[string]string{
+	schedules.NotifyReminder:    "Hi {{.PatientName}}, this is a reminder of your visit to {{.DoctorName}} on {{.Date}} at {{.Time}}. Your queue number is {{.QueueNumber}}.",
+	schedules.NotifyCanceled:    "Hi {{.PatientName}}, your visit to {{.DoctorName}} on {{.Date}} at {{.Time}} has been canceled.",
+	schedules.NotifyRescheduled: "Hi {{.PatientName}}, your visit has been moved to {{.DoctorName}} on {{.Date}} at {{.Time}}.",
+	schedules.NotifyRemoved:     "Hi {{.PatientName}}, the schedule of {{.DoctorName}} on {{.Date}} at {{.Time}} has been canceled. Please book another visit.",
+	schedules.NotifyNext:        "Hi {{.PatientName}}, you're next for {{.DoctorName}}{{if .Room}} in room {{.Room}}{{end}}. Your queue number is {{.QueueNumber}}.",
+}
+
+// OnceEvents are sent at most once per outpatient, e.g. when the reminder job is rerun
+var OnceEvents = map[string]bool{
+	schedules.NotifyReminder: true,
+	schedules.NotifyNext:     true,
+}
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications"
+	"gorm.io/gorm"
+)
+
+type mySQLRepo struct {
+	db *gorm.DB
+}
+
+func NewMySQLRepo(db *gorm.DB) *mySQLRepo {
+	return &mySQLRepo{db: db}
+}
+
+func (r *mySQLRepo) SelectNotifications(q notifications.NotificationQuery) ([]notifications.NotificationCore, error) {
+	const op errors.Op = "notifications.data.SelectNotifications"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	tx := r.db.Order("id DESC").Limit(q.Limit)
+	if q.PatientID != 0 {
+		tx = tx.Where("patient_id = ?", q.PatientID)
+	}
+	if q.OutpatientID != 0 {
+		tx = tx.Where("outpatient_id = ?", q.OutpatientID)
+	}
+	if q.Event != "" {
+		tx = tx.Where("event = ?", q.Event)
+	}
+	if q.Status != "" {
+		tx = tx.Where("status = ?", q.Status)
+	}
+
+	result := []Notification{}
+	err := tx.Find(&result).Error
+	if err != nil {
+		return []notifications.NotificationCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return toSliceNotificationCore(result), nil
+}
+
+func (r *mySQLRepo) SelectNotifiedOutpatientIds(event string, outpatientIds []int) (map[int]bool, error) {
+	const op errors.Op = "notifications.data.SelectNotifiedOutpatientIds"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	result := make(map[int]bool)
+	if len(outpatientIds) == 0 {
+		return result, nil
+	}
+
+	ids := []int{}
+	err := r.db.
+		Model(&Notification{}).
+		Distinct("outpatient_id").
+		Where("event = ? AND status = ? AND outpatient_id IN ?", event, notifications.StatusSent, outpatientIds).
+		Pluck("outpatient_id", &ids).
+		Error
+
+	if err != nil {
+		return map[int]bool{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+
+	for _, id := range ids {
+		result[id] = true
+	}
+	return result, nil
+}
+
+func (r *mySQLRepo) InsertNotification(notification notifications.NotificationCore) (int, error) {
+	const op errors.Op = "notifications.data.InsertNotification"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	record := toNotificationRecord(notification)
+	err := r.db.Create(&record).Error
+	if err != nil {
+		return 0, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return int(record.ID), nil
+}
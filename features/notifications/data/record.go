@@ -0,0 +1,55 @@
+package data
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications"
+	"gorm.io/gorm"
+)
+
+type Notification struct {
+	gorm.Model
+	Event        string `gorm:"type:varchar(20);not null;index:idx_notifications_outpatient"`
+	Channel      string `gorm:"type:varchar(20);not null"`
+	PatientID    int    `gorm:"not null;index"`
+	OutpatientID int    `gorm:"not null;index:idx_notifications_outpatient"`
+	Recipient    string `gorm:"type:varchar(255)"`
+	Message      string `gorm:"type:text;not null"`
+	Status       string `gorm:"type:varchar(20);not null"`
+	Error        string `gorm:"type:text"`
+}
+
+func (n Notification) toNotificationCore() notifications.NotificationCore {
+	return notifications.NotificationCore{
+		ID:           int(n.ID),
+		Event:        n.Event,
+		Channel:      n.Channel,
+		PatientID:    n.PatientID,
+		OutpatientID: n.OutpatientID,
+		Recipient:    n.Recipient,
+		Message:      n.Message,
+		Status:       n.Status,
+		Error:        n.Error,
+		CreatedAt:    n.CreatedAt,
+	}
+}
+
+func toSliceNotificationCore(n []Notification) []notifications.NotificationCore {
+	result := make([]notifications.NotificationCore, len(n))
+	for i := range n {
+		result[i] = n[i].toNotificationCore()
+	}
+	return result
+}
+
+func toNotificationRecord(n notifications.NotificationCore) Notification {
+	return Notification{
+		Model:        gorm.Model{ID: uint(n.ID)},
+		Event:        n.Event,
+		Channel:      n.Channel,
+		PatientID:    n.PatientID,
+		OutpatientID: n.OutpatientID,
+		Recipient:    n.Recipient,
+		Message:      n.Message,
+		Status:       n.Status,
+		Error:        n.Error,
+	}
+}
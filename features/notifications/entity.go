@@ -0,0 +1,49 @@
+package notifications
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+// NotificationCore is one message to a patient and how its delivery went
+type NotificationCore struct {
+	ID           int
+	Event        string // one of schedules.Notify*
+	Channel      string
+	PatientID    int
+	OutpatientID int
+	Recipient    string // empty when the patient cannot be reached on the channel
+	Message      string
+	Status       string
+	Error        string
+	CreatedAt    time.Time
+}
+
+// NotificationQuery filters notifications, the latest come first
+type NotificationQuery struct {
+	PatientID    int
+	OutpatientID int
+	Event        string
+	Status       string
+	Limit        int
+}
+
+// IChannel delivers messages, e.g. SMS, email or WhatsApp. See notifications/channels.
+type IChannel interface {
+	Name() string
+	Recipient(patient patients.PatientCore) string // address of the patient, empty when there is none
+	Send(recipient string, message string) error
+}
+
+type IBusiness interface {
+	NotifyOutpatients(event string, outpatients []schedules.OutpatientCore) // schedules.INotifier
+	FindNotifications(q NotificationQuery) ([]NotificationCore, error)
+}
+
+type IData interface {
+	SelectNotifications(q NotificationQuery) ([]NotificationCore, error)
+	SelectNotifiedOutpatientIds(event string, outpatientIds []int) (map[int]bool, error) // only sent ones
+	InsertNotification(notification NotificationCore) (int, error)
+}
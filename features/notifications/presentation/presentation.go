@@ -0,0 +1,48 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications/presentation/request"
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications/presentation/response"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationPresentation struct {
+	business notifications.IBusiness
+	validate *validator.Validate
+}
+
+func NewNotificationPresentation(business notifications.IBusiness) *NotificationPresentation {
+	return &NotificationPresentation{
+		business: business,
+		validate: validator.New(),
+	}
+}
+
+func (p *NotificationPresentation) GetNotifications(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving notifications"
+	const op errors.Op = "notifications.presentation.GetNotifications"
+	var errMessage errors.ErrClientMessage
+
+	var req request.QueryParamsRequest
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(req); err != nil {
+		errMessage = "Invalid query params"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	data, err := p.business.FindNotifications(req.ToNotificationQuery())
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListNotifications(data))
+}
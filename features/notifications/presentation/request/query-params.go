@@ -0,0 +1,21 @@
+package request
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/notifications"
+
+type QueryParamsRequest struct {
+	PatientID    int    `query:"patientId" validate:"gte=0"`
+	OutpatientID int    `query:"outpatientId" validate:"gte=0"`
+	Event        string `query:"event"`
+	Status       string `query:"status" validate:"omitempty,oneof=sent failed"`
+	Limit        int    `query:"limit" validate:"gte=0"`
+}
+
+func (q QueryParamsRequest) ToNotificationQuery() notifications.NotificationQuery {
+	return notifications.NotificationQuery{
+		PatientID:    q.PatientID,
+		OutpatientID: q.OutpatientID,
+		Event:        q.Event,
+		Status:       q.Status,
+		Limit:        q.Limit,
+	}
+}
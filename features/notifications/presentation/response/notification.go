@@ -0,0 +1,43 @@
+package response
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/notifications"
+)
+
+type NotificationResponse struct {
+	ID           int       `json:"id"`
+	Event        string    `json:"event"`
+	Channel      string    `json:"channel"`
+	PatientID    int       `json:"patientId"`
+	OutpatientID int       `json:"outpatientId"`
+	Recipient    string    `json:"recipient"`
+	Message      string    `json:"message"`
+	Status       string    `json:"status"`
+	Error        string    `json:"error"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func Notification(n notifications.NotificationCore) NotificationResponse {
+	return NotificationResponse{
+		ID:           n.ID,
+		Event:        n.Event,
+		Channel:      n.Channel,
+		PatientID:    n.PatientID,
+		OutpatientID: n.OutpatientID,
+		Recipient:    n.Recipient,
+		Message:      n.Message,
+		Status:       n.Status,
+		Error:        n.Error,
+		CreatedAt:    n.CreatedAt,
+	}
+}
+
+func ListNotifications(n []notifications.NotificationCore) []NotificationResponse {
+	result := make([]NotificationResponse, len(n))
+	for i := range n {
+		result[i] = Notification(n[i])
+	}
+	return result
+}
@@ -1,8 +1,9 @@
 package business
 
 import (
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
 	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
-	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
 	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
 )
@@ -10,8 +11,9 @@ import (
 type nurseBusinessBuilder struct {
 	nurseRepo        nurses.IData
 	adminBusiness    admins.IBusiness
-	doctorBusiness   doctors.IBusiness
+	accountBusiness  accounts.IBusiness
 	scheduleBusiness schedules.IBusiness
+	auditBusiness    audits.IBusiness
 }
 
 func NewNurseBusinessBuilder() *nurseBusinessBuilder {
@@ -22,14 +24,16 @@ func (n *nurseBusinessBuilder) Build() nurses.IBusiness {
 	nurseBusiness := &nurseBusiness{
 		data:             n.nurseRepo,
 		adminBusiness:    n.adminBusiness,
-		doctorBusiness:   n.doctorBusiness,
+		accountBusiness:  n.accountBusiness,
 		scheduleBusiness: n.scheduleBusiness,
+		auditBusiness:    n.auditBusiness,
 	}
 
 	n.nurseRepo = nil
 	n.adminBusiness = nil
-	n.doctorBusiness = nil
+	n.accountBusiness = nil
 	n.scheduleBusiness = nil
+	n.auditBusiness = nil
 
 	return nurseBusiness
 }
@@ -44,8 +48,8 @@ func (n *nurseBusinessBuilder) SetAdminBusiness(adminBusiness admins.IBusiness)
 	return n
 }
 
-func (n *nurseBusinessBuilder) SetDoctorBusiness(doctorBusiness doctors.IBusiness) *nurseBusinessBuilder {
-	n.doctorBusiness = doctorBusiness
+func (n *nurseBusinessBuilder) SetAccountBusiness(accountBusiness accounts.IBusiness) *nurseBusinessBuilder {
+	n.accountBusiness = accountBusiness
 	return n
 }
 
@@ -53,3 +57,8 @@ func (n *nurseBusinessBuilder) SetScheduleBusiness(scheduleBusiness schedules.IB
 	n.scheduleBusiness = scheduleBusiness
 	return n
 }
+
+func (n *nurseBusinessBuilder) SetAuditBusiness(auditBusiness audits.IBusiness) *nurseBusinessBuilder {
+	n.auditBusiness = auditBusiness
+	return n
+}
@@ -5,19 +5,21 @@ import (
 	"path"
 
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
 	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
-	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
 	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
-	"github.com/final-project-alterra/hospital-management-system-api/utils/hash"
 	"github.com/final-project-alterra/hospital-management-system-api/utils/project"
 )
 
 type nurseBusiness struct {
 	data             nurses.IData
 	adminBusiness    admins.IBusiness
-	doctorBusiness   doctors.IBusiness
+	accountBusiness  accounts.IBusiness
 	scheduleBusiness schedules.IBusiness
+	auditBusiness    audits.IBusiness
 }
 
 func (n *nurseBusiness) FindNurses() ([]nurses.NurseCore, error) {
@@ -76,26 +78,57 @@ func (n *nurseBusiness) CreateNurse(nurse nurses.NurseCore) error {
 		}
 	}
 
-	if err = n.checkEmail(nurse.Email); err != nil {
+	if err = n.accountBusiness.CheckEmail(nurse.Email); err != nil {
 		return errors.E(err, op)
 	}
 
-	nurse.Password, err = hash.Generate(nurse.Password)
+	nurseId, err := n.data.InsertNurse(nurse)
 	if err != nil {
-		return errors.E(err, op, errMessage, errors.KindServerError)
+		return errors.E(op, err)
 	}
 
-	err = n.data.InsertNurse(nurse)
+	account := accounts.AccountCore{
+		Email:    nurse.Email,
+		Password: nurse.Password,
+		Role:     permissions.RoleNurse,
+		UserID:   nurseId,
+	}
+	err = n.accountBusiness.CreateAccount(account)
 	if err != nil {
-		return errors.E(op, err)
+		// Nurse without account can not login, so roll it back
+		_ = n.data.DeleteNurseById(nurseId, nurse.CreatedBy)
+		return errors.E(err, op)
+	}
+
+	nurse.ID = nurseId
+	nurse.Password = ""
+	n.audit(op, nurse.CreatedBy, permissions.RoleAdmin, nurseId, nil, nurse)
+	return nil
+}
+
+// checkEditor lets an admin edit any nurse, while a nurse may only edit their own record
+func (n *nurseBusiness) checkEditor(nurseId int, updatedBy int, role string) error {
+	const op errors.Op = "nurses.business.checkEditor"
+
+	if role == permissions.RoleNurse && updatedBy == nurseId {
+		return nil
+	}
+	if role != permissions.RoleAdmin {
+		var errMessage errors.ErrClientMessage = "Nurse can only edit their own profile"
+		return errors.E(errors.New("editing another nurse"), op, errMessage, errors.KindUnauthorized)
+	}
+
+	_, err := n.adminBusiness.FindAdminById(updatedBy)
+	if err != nil {
+		return errors.E(err, op)
 	}
 	return nil
 }
 
-func (n *nurseBusiness) EditNurse(nurse nurses.NurseCore) error {
+func (n *nurseBusiness) EditNurse(nurse nurses.NurseCore, role string) error {
 	const op errors.Op = "nurses.business.EditNurse"
 
-	_, err := n.adminBusiness.FindAdminById(nurse.UpdatedBy)
+	err := n.checkEditor(nurse.ID, nurse.UpdatedBy, role)
 	if err != nil {
 		return errors.E(err, op)
 	}
@@ -105,6 +138,7 @@ func (n *nurseBusiness) EditNurse(nurse nurses.NurseCore) error {
 		return errors.E(op, err)
 	}
 
+	before := existingNurse
 	existingNurse.Name = nurse.Name
 	existingNurse.BirthDate = nurse.BirthDate
 	existingNurse.Phone = nurse.Phone
@@ -115,15 +149,17 @@ func (n *nurseBusiness) EditNurse(nurse nurses.NurseCore) error {
 	if err != nil {
 		return errors.E(op, err)
 	}
+
+	n.audit(op, nurse.UpdatedBy, role, existingNurse.ID, before, existingNurse)
 	return nil
 }
 
-func (nb *nurseBusiness) EditNurseImageProfile(nurse nurses.NurseCore) error {
+func (nb *nurseBusiness) EditNurseImageProfile(nurse nurses.NurseCore, role string) error {
 	const op errors.Op = "nurses.business.EditNurseImageProfile"
 
 	newImage := path.Join(project.GetMainDir(), "files", nurse.ImageUrl)
 
-	_, err := nb.adminBusiness.FindAdminById(nurse.UpdatedBy)
+	err := nb.checkEditor(nurse.ID, nurse.UpdatedBy, role)
 	if err != nil {
 		go os.Remove(newImage)
 		return errors.E(err, op)
@@ -136,8 +172,12 @@ func (nb *nurseBusiness) EditNurseImageProfile(nurse nurses.NurseCore) error {
 	}
 	oldImage := path.Join(project.GetMainDir(), "files", existingNurse.ImageUrl)
 
+	before := existingNurse
 	existingNurse.ImageUrl = nurse.ImageUrl
-	existingNurse.UpdatedBy = nurse.UpdatedBy
+	// UpdatedBy refers to an admin, changes made by the nurse are only in the audit log
+	if role == permissions.RoleAdmin {
+		existingNurse.UpdatedBy = nurse.UpdatedBy
+	}
 
 	err = nb.data.UpdateNurse(existingNurse)
 	if err != nil {
@@ -145,42 +185,33 @@ func (nb *nurseBusiness) EditNurseImageProfile(nurse nurses.NurseCore) error {
 		return errors.E(err, op)
 	}
 
+	nb.audit(op, nurse.UpdatedBy, role, existingNurse.ID, before, existingNurse)
+
 	go os.Remove(oldImage)
 
 	return nil
 }
 
-func (n *nurseBusiness) EditNursePassword(id int, updatedBy int, oldPassword string, newPassword string) error {
+func (n *nurseBusiness) EditNursePassword(id int, updatedBy int, role string, oldPassword string, newPassword string) error {
 	const op errors.Op = "nurses.business.EditNursePassword"
-	var errMessage errors.ErrClientMessage = "Something went wrong"
 
-	_, err := n.adminBusiness.FindAdminById(updatedBy)
+	err := n.checkEditor(id, updatedBy, role)
 	if err != nil {
 		return errors.E(err, op)
 	}
 
-	existingNurse, err := n.data.SelectNurseById(id)
+	_, err = n.data.SelectNurseById(id)
 	if err != nil {
 		return errors.E(op, err)
 	}
 
-	doesMatch := hash.Validate(existingNurse.Password, oldPassword)
-	if !doesMatch {
-		err = errors.New("Wrong old password")
-		errMessage = "Wrong old password"
-
-		return errors.E(err, op, errMessage, errors.KindUnprocessable)
-	}
-
-	existingNurse.Password, err = hash.Generate(newPassword)
-	if err != nil {
-		return errors.E(err, op, errMessage, errors.KindServerError)
-	}
-
-	err = n.data.UpdateNurse(existingNurse)
+	err = n.accountBusiness.EditAccountPassword(id, permissions.RoleNurse, oldPassword, newPassword)
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	// Password itself is never part of the audit log
+	n.audit(op, updatedBy, role, id, nil, nil)
 	return nil
 }
 
@@ -208,69 +239,28 @@ func (n *nurseBusiness) RemoveNurseById(id int, updatedBy int) error {
 		return errors.E(err, op)
 	}
 
+	err = n.accountBusiness.RemoveAccountByUser(id, permissions.RoleNurse)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	n.audit(op, updatedBy, permissions.RoleAdmin, id, existingNurse, nil)
 	go os.Remove(existingImage)
 
 	return nil
 }
 
 // Private methods
-func (nb *nurseBusiness) checkEmail(email string) error {
-	const op errors.Op = "admins.business.checkEmail"
-	var errMsg errors.ErrClientMessage = "Email already exist"
-
-	adminCh := make(chan error)
-	doctorCh := make(chan error)
-	nurseCh := make(chan error)
-
-	go func() {
-		_, err := nb.data.SelectNurseByEmail(email)
-		if err != nil {
-			if errors.Kind(err) == errors.KindNotFound {
-				err = nil
-			}
-			nurseCh <- err
-			return
-		}
-		nurseCh <- errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
-	}()
-
-	go func() {
-		_, err := nb.adminBusiness.FindAdminByEmail(email)
-		if err != nil {
-			if errors.Kind(err) == errors.KindNotFound {
-				err = nil
-			}
-			adminCh <- err
-			return
-		}
-		adminCh <- errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
-	}()
-
-	go func() {
-		_, err := nb.doctorBusiness.FindDoctorByEmail(email)
-		if err != nil {
-			if errors.Kind(err) == errors.KindNotFound {
-				err = nil
-			}
-			doctorCh <- err
-			return
-		}
-		doctorCh <- errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
-	}()
-
-	adminErr := <-adminCh
-	doctorErr := <-doctorCh
-	nurseErr := <-nurseCh
 
-	if adminErr == nil && doctorErr == nil && nurseErr == nil {
-		return nil
-	}
-
-	if adminErr != nil {
-		return adminErr
-	}
-	if doctorErr != nil {
-		return doctorErr
-	}
-	return nurseErr
+// audit records a change on a nurse, done either by an admin or by the nurse themself
+func (n *nurseBusiness) audit(op errors.Op, actorId int, actorRole string, nurseId int, before interface{}, after interface{}) {
+	n.auditBusiness.Record(audits.AuditLogCore{
+		ActorID:   actorId,
+		ActorRole: actorRole,
+		Operation: string(op),
+		Entity:    audits.EntityNurse,
+		EntityID:  nurseId,
+		Before:    before,
+		After:     after,
+	})
 }
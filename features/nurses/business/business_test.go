@@ -5,16 +5,15 @@ import (
 	"testing"
 
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	acm "github.com/final-project-alterra/hospital-management-system-api/features/accounts/mocks"
 	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
 	am "github.com/final-project-alterra/hospital-management-system-api/features/admins/mocks"
-	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
-	dm "github.com/final-project-alterra/hospital-management-system-api/features/doctors/mocks"
+	aum "github.com/final-project-alterra/hospital-management-system-api/features/audits/mocks"
 	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
 	nb "github.com/final-project-alterra/hospital-management-system-api/features/nurses/business"
 	nm "github.com/final-project-alterra/hospital-management-system-api/features/nurses/mocks"
 	sm "github.com/final-project-alterra/hospital-management-system-api/features/schedules/mocks"
 	"github.com/final-project-alterra/hospital-management-system-api/utils/files"
-	"github.com/final-project-alterra/hospital-management-system-api/utils/hash"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -24,8 +23,9 @@ var (
 
 	business         nurses.IBusiness
 	adminBusiness    am.IBusiness
-	doctorBusiness   dm.IBusiness
+	accountBusiness  acm.IBusiness
 	scheduleBusiness sm.IBusiness
+	auditBusiness    aum.IBusiness
 
 	admin1 admins.AdminCore
 	nurse1 nurses.NurseCore
@@ -38,10 +38,13 @@ func TestMain(t *testing.M) {
 	business = nb.NewNurseBusinessBuilder().
 		SetData(&repo).
 		SetAdminBusiness(&adminBusiness).
-		SetDoctorBusiness(&doctorBusiness).
+		SetAccountBusiness(&accountBusiness).
 		SetScheduleBusiness(&scheduleBusiness).
+		SetAuditBusiness(&auditBusiness).
 		Build()
 
+	auditBusiness.On("Record", mock.AnythingOfType("audits.AuditLogCore")).Return()
+
 	admin1 = admins.AdminCore{
 		ID:   1,
 		Name: "admin1",
@@ -175,23 +178,18 @@ func TestCreateNurse(t *testing.T) {
 			Return(admin1, nil).
 			Once()
 
-		repo.
-			On("SelectNurseByEmail", mock.AnythingOfType("string")).
-			Return(nurses.NurseCore{}, errNotFound).
-			Once()
-
-		adminBusiness.
-			On("FindAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
-			Once()
-
-		doctorBusiness.
-			On("FindDoctorByEmail", mock.AnythingOfType("string")).
-			Return(doctors.DoctorCore{}, errNotFound).
+		accountBusiness.
+			On("CheckEmail", nurse1.Email).
+			Return(nil).
 			Once()
 
 		repo.
 			On("InsertNurse", mock.AnythingOfType("nurses.NurseCore")).
+			Return(2, nil).
+			Once()
+
+		accountBusiness.
+			On("CreateAccount", mock.AnythingOfType("accounts.AccountCore")).
 			Return(nil).
 			Once()
 
@@ -225,61 +223,16 @@ func TestCreateNurse(t *testing.T) {
 		adminBusiness.
 			On("FindAdminById", mock.AnythingOfType("int")).
 			Return(admin1, nil).
-			Times(3)
-
-		for i := 0; i < 3; i++ {
-			switch i {
-			case 0:
-				repo.
-					On("SelectNurseByEmail", mock.AnythingOfType("string")).
-					Return(nurses.NurseCore{ID: 2, Email: nurse1.Email}, nil).
-					Once()
-
-				adminBusiness.
-					On("FindAdminByEmail", mock.AnythingOfType("string")).
-					Return(admins.AdminCore{}, errNotFound).
-					Once()
-
-				doctorBusiness.
-					On("FindDoctorByEmail", mock.AnythingOfType("string")).
-					Return(doctors.DoctorCore{}, errNotFound).
-					Once()
-			case 1:
-				repo.
-					On("SelectNurseByEmail", mock.AnythingOfType("string")).
-					Return(nurses.NurseCore{}, errNotFound).
-					Once()
-
-				adminBusiness.
-					On("FindAdminByEmail", mock.AnythingOfType("string")).
-					Return(admins.AdminCore{ID: 1}, nil).
-					Once()
-
-				doctorBusiness.
-					On("FindDoctorByEmail", mock.AnythingOfType("string")).
-					Return(doctors.DoctorCore{}, errNotFound).
-					Once()
-			case 2:
-				repo.
-					On("SelectNurseByEmail", mock.AnythingOfType("string")).
-					Return(nurses.NurseCore{}, errNotFound).
-					Once()
-
-				adminBusiness.
-					On("FindAdminByEmail", mock.AnythingOfType("string")).
-					Return(admins.AdminCore{}, errNotFound).
-					Once()
-
-				doctorBusiness.
-					On("FindDoctorByEmail", mock.AnythingOfType("string")).
-					Return(doctors.DoctorCore{ID: 1}, nil).
-					Once()
-			}
-
-			err := business.CreateNurse(nurse1)
-			assert.Error(t, err)
-		}
+			Once()
+
+		accountBusiness.
+			On("CheckEmail", nurse1.Email).
+			Return(errors.E(errors.New("email already exist"), errors.KindUnprocessable)).
+			Once()
 
+		err := business.CreateNurse(nurse1)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 
 	t.Run("valid - when InsertNurse return error", func(t *testing.T) {
@@ -288,30 +241,50 @@ func TestCreateNurse(t *testing.T) {
 			Return(admin1, nil).
 			Once()
 
+		accountBusiness.
+			On("CheckEmail", nurse1.Email).
+			Return(nil).
+			Once()
+
 		repo.
-			On("SelectNurseByEmail", mock.AnythingOfType("string")).
-			Return(nurses.NurseCore{}, errNotFound).
+			On("InsertNurse", mock.AnythingOfType("nurses.NurseCore")).
+			Return(0, errServer).
 			Once()
 
+		err := business.CreateNurse(nurse1)
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when CreateAccount return error", func(t *testing.T) {
 		adminBusiness.
-			On("FindAdminByEmail", mock.AnythingOfType("string")).
-			Return(admins.AdminCore{}, errNotFound).
+			On("FindAdminById", mock.AnythingOfType("int")).
+			Return(admin1, nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorByEmail", mock.AnythingOfType("string")).
-			Return(doctors.DoctorCore{}, errNotFound).
+		accountBusiness.
+			On("CheckEmail", nurse1.Email).
+			Return(nil).
 			Once()
 
 		repo.
 			On("InsertNurse", mock.AnythingOfType("nurses.NurseCore")).
+			Return(2, nil).
+			Once()
+
+		accountBusiness.
+			On("CreateAccount", mock.AnythingOfType("accounts.AccountCore")).
 			Return(errServer).
 			Once()
 
+		repo.
+			On("DeleteNurseById", 2, nurse1.CreatedBy).
+			Return(nil).
+			Once()
+
 		err := business.CreateNurse(nurse1)
 		assert.Error(t, err)
+		repo.AssertCalled(t, "DeleteNurseById", 2, nurse1.CreatedBy)
 	})
-
 }
 
 func TestEditNurse(t *testing.T) {
@@ -331,7 +304,7 @@ func TestEditNurse(t *testing.T) {
 			Return(nil).
 			Once()
 
-		err := business.EditNurse(nurse1)
+		err := business.EditNurse(nurse1, "admin")
 		assert.Nil(t, err)
 	})
 
@@ -341,7 +314,7 @@ func TestEditNurse(t *testing.T) {
 			Return(admins.AdminCore{}, errServer).
 			Once()
 
-		err := business.EditNurse(nurse1)
+		err := business.EditNurse(nurse1, "admin")
 		assert.Error(t, err)
 	})
 
@@ -356,7 +329,7 @@ func TestEditNurse(t *testing.T) {
 			Return(nurses.NurseCore{}, errServer).
 			Once()
 
-		err := business.EditNurse(nurse1)
+		err := business.EditNurse(nurse1, "admin")
 		assert.Error(t, err)
 	})
 
@@ -376,7 +349,7 @@ func TestEditNurse(t *testing.T) {
 			Return(errServer).
 			Once()
 
-		err := business.EditNurse(nurse1)
+		err := business.EditNurse(nurse1, "admin")
 		assert.Error(t, err)
 	})
 }
@@ -388,24 +361,17 @@ func TestEditNursePassword(t *testing.T) {
 			Return(admin1, nil).
 			Once()
 
-		record := nurse1
-		hashed, err := hash.Generate(nurse1.Password)
-		if err != nil {
-			panic(err)
-		}
-		record.Password = hashed
-
 		repo.
 			On("SelectNurseById", mock.AnythingOfType("int")).
-			Return(record, nil).
+			Return(nurse1, nil).
 			Once()
 
-		repo.
-			On("UpdateNurse", mock.AnythingOfType("nurses.NurseCore")).
+		accountBusiness.
+			On("EditAccountPassword", 1, "nurse", nurse1.Password, "new password").
 			Return(nil).
 			Once()
 
-		err = business.EditNursePassword(1, 2, nurse1.Password, "new password")
+		err := business.EditNursePassword(1, 2, "admin", nurse1.Password, "new password")
 		assert.Nil(t, err)
 	})
 
@@ -415,7 +381,7 @@ func TestEditNursePassword(t *testing.T) {
 			Return(admins.AdminCore{}, errServer).
 			Once()
 
-		err := business.EditNursePassword(1, 2, nurse1.Password, "new password")
+		err := business.EditNursePassword(1, 2, "admin", nurse1.Password, "new password")
 		assert.Error(t, err)
 	})
 
@@ -430,57 +396,29 @@ func TestEditNursePassword(t *testing.T) {
 			Return(nurses.NurseCore{}, errNotFound).
 			Once()
 
-		err := business.EditNursePassword(1, 2, nurse1.Password, "new password")
+		err := business.EditNursePassword(1, 2, "admin", nurse1.Password, "new password")
 		assert.Error(t, err)
 	})
 
-	t.Run("valid - when old password does not match", func(t *testing.T) {
+	t.Run("valid - when EditAccountPassword return error", func(t *testing.T) {
 		adminBusiness.
 			On("FindAdminById", mock.AnythingOfType("int")).
 			Return(admin1, nil).
 			Once()
 
-		record := nurse1
-		hashed, err := hash.Generate(nurse1.Password)
-		if err != nil {
-			panic(err)
-		}
-		record.Password = hashed
-
 		repo.
 			On("SelectNurseById", mock.AnythingOfType("int")).
-			Return(record, nil).
-			Once()
-
-		err = business.EditNursePassword(1, 2, "wrong old password", "new password")
-		assert.Error(t, err)
-	})
-
-	t.Run("valid - when UpdateNurse return error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admin1, nil).
+			Return(nurse1, nil).
 			Once()
 
-		record := nurse1
-		hashed, err := hash.Generate(nurse1.Password)
-		if err != nil {
-			panic(err)
-		}
-		record.Password = hashed
-
-		repo.
-			On("SelectNurseById", mock.AnythingOfType("int")).
-			Return(record, nil).
+		accountBusiness.
+			On("EditAccountPassword", 1, "nurse", "wrong old password", "new password").
+			Return(errors.E(errors.New("wrong old password"), errors.KindUnprocessable)).
 			Once()
 
-		repo.
-			On("UpdateNurse", mock.AnythingOfType("nurses.NurseCore")).
-			Return(errServer).
-			Once()
-
-		err = business.EditNursePassword(1, 2, nurse1.Password, "new password")
+		err := business.EditNursePassword(1, 2, "admin", "wrong old password", "new password")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 }
 
@@ -501,7 +439,7 @@ func TestEditNurseImageProfile(t *testing.T) {
 			Return(nil).
 			Once()
 
-		err := business.EditNurseImageProfile(nurse1)
+		err := business.EditNurseImageProfile(nurse1, "admin")
 		assert.Nil(t, err)
 	})
 
@@ -511,7 +449,7 @@ func TestEditNurseImageProfile(t *testing.T) {
 			Return(admin1, errServer).
 			Once()
 
-		err := business.EditNurseImageProfile(nurse1)
+		err := business.EditNurseImageProfile(nurse1, "admin")
 		assert.Error(t, err)
 	})
 
@@ -526,12 +464,76 @@ func TestEditNurseImageProfile(t *testing.T) {
 			Return(nurse1, errServer).
 			Once()
 
-		err := business.EditNurseImageProfile(nurse1)
+		err := business.EditNurseImageProfile(nurse1, "admin")
 		assert.Error(t, err)
 	})
 
 }
 
+func TestEditNurseByThemself(t *testing.T) {
+	t.Run("valid - when nurse edits own profile", func(t *testing.T) {
+		repo.
+			On("SelectNurseById", nurse1.ID).
+			Return(nurse1, nil).
+			Once()
+
+		repo.
+			On("UpdateNurse", mock.MatchedBy(func(nurse nurses.NurseCore) bool {
+				return nurse.Phone == "0812345678" && nurse.Name == nurse1.Name
+			})).
+			Return(nil).
+			Once()
+
+		edited := nurse1
+		edited.UpdatedBy = nurse1.ID
+		edited.Phone = "0812345678"
+		err := business.EditNurse(edited, "nurse")
+		assert.Nil(t, err)
+	})
+
+	t.Run("error - when nurse edits another nurse", func(t *testing.T) {
+		edited := nurse1
+		edited.UpdatedBy = nurse1.ID + 1
+		err := business.EditNurse(edited, "nurse")
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when nurse changes own password", func(t *testing.T) {
+		repo.
+			On("SelectNurseById", nurse1.ID).
+			Return(nurse1, nil).
+			Once()
+
+		accountBusiness.
+			On("EditAccountPassword", nurse1.ID, "nurse", "password", "new password").
+			Return(nil).
+			Once()
+
+		err := business.EditNursePassword(nurse1.ID, nurse1.ID, "nurse", "password", "new password")
+		assert.Nil(t, err)
+	})
+
+	t.Run("error - when nurse changes another nurse password", func(t *testing.T) {
+		err := business.EditNursePassword(nurse1.ID, nurse1.ID+1, "nurse", "password", "new password")
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - when nurse changes own image", func(t *testing.T) {
+		repo.
+			On("SelectNurseById", nurse1.ID).
+			Return(nurse1, nil).
+			Once()
+
+		repo.
+			On("UpdateNurse", mock.AnythingOfType("nurses.NurseCore")).
+			Return(nil).
+			Once()
+
+		err := business.EditNurseImageProfile(nurses.NurseCore{ID: nurse1.ID, UpdatedBy: nurse1.ID, ImageUrl: "new.jpg"}, "nurse")
+		assert.Nil(t, err)
+	})
+}
+
 func TestRemoveNurseById(t *testing.T) {
 	t.Run("valid - when everything is fine", func(t *testing.T) {
 		adminBusiness.
@@ -554,6 +556,11 @@ func TestRemoveNurseById(t *testing.T) {
 			Return(nil).
 			Once()
 
+		accountBusiness.
+			On("RemoveAccountByUser", 1, "nurse").
+			Return(nil).
+			Once()
+
 		err := business.RemoveNurseById(1, 2)
 		assert.Nil(t, err)
 	})
@@ -84,14 +84,13 @@ func (r *mySQLRepo) SelectNurseByEmail(email string) (nurses.NurseCore, error) {
 	return nurseRecord.ToNurseCore(), err
 }
 
-func (r *mySQLRepo) InsertNurse(nurse nurses.NurseCore) error {
+func (r *mySQLRepo) InsertNurse(nurse nurses.NurseCore) (int, error) {
 	const op errors.Op = "nurses.data.InsertNurse"
 	var errMessage errors.ErrClientMessage = "Something went wrong"
 
 	newNurse := Nurse{
 		CreatedBy: nurse.CreatedBy,
 		Email:     nurse.Email,
-		Password:  nurse.Password,
 		Name:      nurse.Name,
 		BirthDate: nurse.BirthDate,
 		ImageUrl:  nurse.ImageUrl,
@@ -102,9 +101,9 @@ func (r *mySQLRepo) InsertNurse(nurse nurses.NurseCore) error {
 
 	err := r.db.Create(&newNurse).Error
 	if err != nil {
-		return errors.E(err, op, errMessage, errors.KindServerError)
+		return 0, errors.E(err, op, errMessage, errors.KindServerError)
 	}
-	return nil
+	return int(newNurse.ID), nil
 }
 
 func (r *mySQLRepo) UpdateNurse(nurse nurses.NurseCore) error {
@@ -125,7 +124,6 @@ func (r *mySQLRepo) UpdateNurse(nurse nurses.NurseCore) error {
 		ImageUrl:  nurse.ImageUrl,
 		Phone:     nurse.Phone,
 		Address:   nurse.Address,
-		Password:  nurse.Password,
 		Gender:    nurse.Gender,
 	}
 
@@ -14,7 +14,6 @@ type Nurse struct {
 	UpdatedBy int
 
 	Email     string `gorm:"type:varchar(64);unique;not null"`
-	Password  string `gorm:"type:varchar(128);not null"`
 	Name      string `gorm:"type:varchar(64);not null"`
 	BirthDate string `gorm:"type:date;not null"`
 	ImageUrl  string
@@ -33,7 +32,6 @@ func (n Nurse) ToNurseCore() nurses.NurseCore {
 		UpdatedBy: n.UpdatedBy,
 
 		Email:     n.Email,
-		Password:  n.Password,
 		Name:      n.Name,
 		BirthDate: strings.Split(n.BirthDate, "T")[0],
 		ImageUrl:  n.ImageUrl,
@@ -7,7 +7,7 @@ type NurseCore struct {
 	CreatedBy int
 	UpdatedBy int
 	Email     string
-	Password  string
+	Password  string // only filled on create, stored in accounts
 	Name      string
 	BirthDate string
 	ImageUrl  string
@@ -24,9 +24,9 @@ type IBusiness interface {
 	FindNurseById(id int) (NurseCore, error)
 	FindNurseByEmail(email string) (NurseCore, error)
 	CreateNurse(nurse NurseCore) error
-	EditNurse(nurse NurseCore) error
-	EditNurseImageProfile(nurse NurseCore) error
-	EditNursePassword(id int, updatedBy int, oldPassword string, newPassword string) error
+	EditNurse(nurse NurseCore, role string) error // role is the editor's, an admin or the nurse themself
+	EditNurseImageProfile(nurse NurseCore, role string) error
+	EditNursePassword(id int, updatedBy int, role string, oldPassword string, newPassword string) error
 	RemoveNurseById(id int, updatedBy int) error
 }
 
@@ -35,7 +35,7 @@ type IData interface {
 	SelectNursesByIds(ids []int) ([]NurseCore, error)
 	SelectNurseById(id int) (NurseCore, error)
 	SelectNurseByEmail(email string) (NurseCore, error)
-	InsertNurse(nurse NurseCore) error
+	InsertNurse(nurse NurseCore) (int, error)
 	UpdateNurse(nurse NurseCore) error
 	DeleteNurseById(id int, updatedBy int) error
 }
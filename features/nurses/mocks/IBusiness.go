@@ -26,13 +26,13 @@ func (_m *IBusiness) CreateNurse(nurse nurses.NurseCore) error {
 	return r0
 }
 
-// EditNurse provides a mock function with given fields: nurse
-func (_m *IBusiness) EditNurse(nurse nurses.NurseCore) error {
-	ret := _m.Called(nurse)
+// EditNurse provides a mock function with given fields: nurse, role
+func (_m *IBusiness) EditNurse(nurse nurses.NurseCore, role string) error {
+	ret := _m.Called(nurse, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(nurses.NurseCore) error); ok {
-		r0 = rf(nurse)
+	if rf, ok := ret.Get(0).(func(nurses.NurseCore, string) error); ok {
+		r0 = rf(nurse, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -40,13 +40,13 @@ func (_m *IBusiness) EditNurse(nurse nurses.NurseCore) error {
 	return r0
 }
 
-// EditNurseImageProfile provides a mock function with given fields: nurse
-func (_m *IBusiness) EditNurseImageProfile(nurse nurses.NurseCore) error {
-	ret := _m.Called(nurse)
+// EditNurseImageProfile provides a mock function with given fields: nurse, role
+func (_m *IBusiness) EditNurseImageProfile(nurse nurses.NurseCore, role string) error {
+	ret := _m.Called(nurse, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(nurses.NurseCore) error); ok {
-		r0 = rf(nurse)
+	if rf, ok := ret.Get(0).(func(nurses.NurseCore, string) error); ok {
+		r0 = rf(nurse, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -54,13 +54,13 @@ func (_m *IBusiness) EditNurseImageProfile(nurse nurses.NurseCore) error {
 	return r0
 }
 
-// EditNursePassword provides a mock function with given fields: id, updatedBy, oldPassword, newPassword
-func (_m *IBusiness) EditNursePassword(id int, updatedBy int, oldPassword string, newPassword string) error {
-	ret := _m.Called(id, updatedBy, oldPassword, newPassword)
+// EditNursePassword provides a mock function with given fields: id, updatedBy, role, oldPassword, newPassword
+func (_m *IBusiness) EditNursePassword(id int, updatedBy int, role string, oldPassword string, newPassword string) error {
+	ret := _m.Called(id, updatedBy, role, oldPassword, newPassword)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, int, string, string) error); ok {
-		r0 = rf(id, updatedBy, oldPassword, newPassword)
+	if rf, ok := ret.Get(0).(func(int, int, string, string, string) error); ok {
+		r0 = rf(id, updatedBy, role, oldPassword, newPassword)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,17 +27,24 @@ func (_m *IData) DeleteNurseById(id int, updatedBy int) error {
 }
 
 // InsertNurse provides a mock function with given fields: nurse
-func (_m *IData) InsertNurse(nurse nurses.NurseCore) error {
+func (_m *IData) InsertNurse(nurse nurses.NurseCore) (int, error) {
 	ret := _m.Called(nurse)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(nurses.NurseCore) error); ok {
+	var r0 int
+	if rf, ok := ret.Get(0).(func(nurses.NurseCore) int); ok {
 		r0 = rf(nurse)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(nurses.NurseCore) error); ok {
+		r1 = rf(nurse)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // SelectNurseByEmail provides a mock function with given fields: email
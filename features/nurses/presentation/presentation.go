@@ -120,7 +120,7 @@ func (np *NursePresentation) PutEditNurse(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
 	}
 
-	if err := np.business.EditNurse(nurse.ToCore()); err != nil {
+	if err := np.business.EditNurse(nurse.ToCore(), c.Get("role").(string)); err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
 
@@ -151,7 +151,7 @@ func (np *NursePresentation) PutEditNursePassword(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
 	}
 
-	err := np.business.EditNursePassword(nurse.ID, updatedBy, nurse.OldPassword, nurse.NewPassword)
+	err := np.business.EditNursePassword(nurse.ID, updatedBy, c.Get("role").(string), nurse.OldPassword, nurse.NewPassword)
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -179,7 +179,7 @@ func (ap *NursePresentation) PutEditImageProfile(c echo.Context) error {
 	}
 
 	updatedNurse := nurses.NurseCore{ID: nuserID, UpdatedBy: updatedBy, ImageUrl: filename}
-	err = ap.business.EditNurseImageProfile(updatedNurse)
+	err = ap.business.EditNurseImageProfile(updatedNurse, c.Get("role").(string))
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -201,7 +201,7 @@ func (ap *NursePresentation) DeleteImageProfile(c echo.Context) error {
 	}
 
 	updatedNurse := nurses.NurseCore{ID: nurseID, UpdatedBy: updatedBy, ImageUrl: ""}
-	err = ap.business.EditNurseImageProfile(updatedNurse)
+	err = ap.business.EditNurseImageProfile(updatedNurse, c.Get("role").(string))
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -237,6 +237,116 @@ func (np *NursePresentation) DeleteNurse(c echo.Context) error {
 	return response.Success(c, status, message, nil)
 }
 
+func (np *NursePresentation) GetOwnProfile(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving own profile"
+	const op errors.Op = "presentation.nurses.GetOwnProfile"
+
+	nurseId := c.Get("userId").(int)
+
+	nurseData, err := np.business.FindNurseById(nurseId)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.DetailNurse(nurseData))
+}
+
+func (np *NursePresentation) PutEditOwnProfile(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success updating own profile"
+	const op errors.Op = "presentation.nurses.PutEditOwnProfile"
+	var errMessage errors.ErrClientMessage
+
+	nurseId := c.Get("userId").(int)
+
+	current, err := np.business.FindNurseById(nurseId)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	nurse := request.UpdateOwnNurseRequest{}
+	if err := c.Bind(&nurse); err != nil {
+		errMessage = "Unable to parse nurse request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := np.validate.Struct(nurse); err != nil {
+		errMessage = "Invalid nurse data. Makesure all required fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	if err := np.business.EditNurse(nurse.ToCore(current), c.Get("role").(string)); err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
+func (np *NursePresentation) PutEditOwnPassword(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success editing own password"
+	const op errors.Op = "presentation.nurses.PutEditOwnPassword"
+	var errMessage errors.ErrClientMessage
+
+	nurseId := c.Get("userId").(int)
+
+	req := request.UpdateOwnPasswordRequest{}
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse nurse request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := np.validate.Struct(req); err != nil {
+		errMessage = "Invalid nurse data. Makesure all fields are filled correctly and new password is min 8 characters"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	err := np.business.EditNursePassword(nurseId, nurseId, c.Get("role").(string), req.OldPassword, req.NewPassword)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
+func (ap *NursePresentation) PutEditOwnImageProfile(c echo.Context) error {
+	status := http.StatusOK
+	message := "Image profile updated"
+	const op errors.Op = "nurses.presentation.PutEditOwnImageProfile"
+
+	nurseId := c.Get("userId").(int)
+
+	destDirectory := path.Join(project.GetMainDir(), "files")
+	filename, err := ap.allocateFile(c, destDirectory)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	updatedNurse := nurses.NurseCore{ID: nurseId, UpdatedBy: nurseId, ImageUrl: filename}
+	err = ap.business.EditNurseImageProfile(updatedNurse, c.Get("role").(string))
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
+func (ap *NursePresentation) DeleteOwnImageProfile(c echo.Context) error {
+	status := http.StatusOK
+	message := "Image profile deleted"
+	const op errors.Op = "nurses.presentation.DeleteOwnImageProfile"
+
+	nurseId := c.Get("userId").(int)
+
+	updatedNurse := nurses.NurseCore{ID: nurseId, UpdatedBy: nurseId, ImageUrl: ""}
+	err := ap.business.EditNurseImageProfile(updatedNurse, c.Get("role").(string))
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
 // Private methods
 func (ap *NursePresentation) allocateFile(c echo.Context, destDirectory string) (string, error) {
 	const op errors.Op = "nurses.presentation.allocateFile"
@@ -0,0 +1,22 @@
+package request
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/nurses"
+
+// Nurse can only change their own contact, the rest is managed by admin
+type UpdateOwnNurseRequest struct {
+	Phone   string `json:"phone"`
+	Address string `json:"address"`
+}
+
+// ToCore keeps the rest of the current profile untouched
+func (c UpdateOwnNurseRequest) ToCore(current nurses.NurseCore) nurses.NurseCore {
+	current.UpdatedBy = current.ID
+	current.Phone = c.Phone
+	current.Address = c.Address
+	return current
+}
+
+type UpdateOwnPasswordRequest struct {
+	OldPassword string `json:"oldPassword" validate:"required"`
+	NewPassword string `json:"newPassword" validate:"required,min=8"`
+}
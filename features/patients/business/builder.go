@@ -1,15 +1,17 @@
 package business
 
 import (
-	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
 	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
 )
 
 type patientBusinessBuilder struct {
 	repo              patients.IData
-	adminBusiness     admins.IBusiness
+	accountBusiness   accounts.IBusiness
 	schedulesBusiness schedules.IBusiness
+	auditBusiness     audits.IBusiness
 }
 
 func NewPatientBusinessBuilder() *patientBusinessBuilder {
@@ -19,13 +21,15 @@ func NewPatientBusinessBuilder() *patientBusinessBuilder {
 func (p *patientBusinessBuilder) Build() *patientBusiness {
 	business := &patientBusiness{
 		data:              p.repo,
-		adminBusiness:     p.adminBusiness,
+		accountBusiness:   p.accountBusiness,
 		schedulesBusiness: p.schedulesBusiness,
+		auditBusiness:     p.auditBusiness,
 	}
 
 	p.repo = nil
-	p.adminBusiness = nil
+	p.accountBusiness = nil
 	p.schedulesBusiness = nil
+	p.auditBusiness = nil
 
 	return business
 }
@@ -35,8 +39,8 @@ func (p *patientBusinessBuilder) SetData(data patients.IData) *patientBusinessBu
 	return p
 }
 
-func (p *patientBusinessBuilder) SetAdminBusiness(b admins.IBusiness) *patientBusinessBuilder {
-	p.adminBusiness = b
+func (p *patientBusinessBuilder) SetAccountBusiness(b accounts.IBusiness) *patientBusinessBuilder {
+	p.accountBusiness = b
 	return p
 }
 
@@ -44,3 +48,8 @@ func (p *patientBusinessBuilder) SetScheduleBusiness(s schedules.IBusiness) *pat
 	p.schedulesBusiness = s
 	return p
 }
+
+func (p *patientBusinessBuilder) SetAuditBusiness(a audits.IBusiness) *patientBusinessBuilder {
+	p.auditBusiness = a
+	return p
+}
@@ -2,24 +2,45 @@ package business
 
 import (
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
 	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
 )
 
 type patientBusiness struct {
 	data              patients.IData
-	adminBusiness     admins.IBusiness
+	accountBusiness   accounts.IBusiness
 	schedulesBusiness schedules.IBusiness
+	auditBusiness     audits.IBusiness
 }
 
-func (p *patientBusiness) FindPatients() ([]patients.PatientCore, error) {
+func (p *patientBusiness) FindPatients(userId int, role string) ([]patients.PatientCore, error) {
 	const op errors.Op = "patients.business.FindPatients"
 
 	patientsData, err := p.data.SelectPatients()
 	if err != nil {
 		return []patients.PatientCore{}, errors.E(err, op)
 	}
+
+	patientIds := make([]int, len(patientsData))
+	for i := range patientsData {
+		patientIds[i] = patientsData[i].ID
+	}
+
+	noShows, err := p.schedulesBusiness.FindNoShowCounts(patientIds)
+	if err != nil {
+		return []patients.PatientCore{}, errors.E(err, op)
+	}
+
+	for i := range patientsData {
+		patientsData[i].NoShows = noShows[patientsData[i].ID]
+	}
+
+	err = p.recordAccess(op, userId, role, patientIds...)
+	if err != nil {
+		return []patients.PatientCore{}, errors.E(err, op)
+	}
 	return patientsData, nil
 }
 
@@ -43,11 +64,42 @@ func (p *patientBusiness) FindPatientById(id int) (patients.PatientCore, error)
 	return patientData, nil
 }
 
-func (p *patientBusiness) CreatePatient(patient patients.PatientCore) error {
+func (p *patientBusiness) FindPatientByNIK(nik string) (patients.PatientCore, error) {
+	const op errors.Op = "patients.business.FindPatientByNIK"
+
+	patientData, err := p.data.SelectPatientByNIK(nik)
+	if err != nil {
+		return patients.PatientCore{}, errors.E(err, op)
+	}
+	return patientData, nil
+}
+
+func (p *patientBusiness) ViewPatientById(id int, userId int, role string) (patients.PatientCore, error) {
+	const op errors.Op = "patients.business.ViewPatientById"
+
+	patientData, err := p.data.SelectPatientById(id)
+	if err != nil {
+		return patients.PatientCore{}, errors.E(err, op)
+	}
+
+	noShows, err := p.schedulesBusiness.FindNoShowCounts([]int{patientData.ID})
+	if err != nil {
+		return patients.PatientCore{}, errors.E(err, op)
+	}
+	patientData.NoShows = noShows[patientData.ID]
+
+	err = p.recordAccess(op, userId, role, patientData.ID)
+	if err != nil {
+		return patients.PatientCore{}, errors.E(err, op)
+	}
+	return patientData, nil
+}
+
+func (p *patientBusiness) CreatePatient(patient patients.PatientCore, role string) error {
 	const op errors.Op = "patients.business.CreatePatient"
 	var errMessage errors.ErrClientMessage
 
-	_, err := p.adminBusiness.FindAdminById(patient.CreatedBy)
+	_, err := p.accountBusiness.FindAccountByUser(patient.CreatedBy, role)
 	if err != nil {
 		return errors.E(err, op)
 	}
@@ -62,18 +114,20 @@ func (p *patientBusiness) CreatePatient(patient patients.PatientCore) error {
 		return errors.E(err, op)
 	}
 
-	err = p.data.InsertPatient(patient)
+	patientId, err := p.data.InsertPatient(patient)
 	if err != nil {
 		return errors.E(err, op)
 	}
 
+	patient.ID = patientId
+	p.audit(op, patient.CreatedBy, role, patientId, nil, patient)
 	return nil
 }
 
-func (p *patientBusiness) EditPatient(patient patients.PatientCore) error {
+func (p *patientBusiness) EditPatient(patient patients.PatientCore, role string) error {
 	const op errors.Op = "patients.business.EditPatient"
 
-	_, err := p.adminBusiness.FindAdminById(patient.UpdatedBy)
+	_, err := p.accountBusiness.FindAccountByUser(patient.UpdatedBy, role)
 	if err != nil {
 		return errors.E(err, op)
 	}
@@ -83,6 +137,7 @@ func (p *patientBusiness) EditPatient(patient patients.PatientCore) error {
 		return errors.E(err, op)
 	}
 
+	before := existingPatient
 	existingPatient.UpdatedBy = patient.UpdatedBy
 	existingPatient.Name = patient.Name
 	existingPatient.BirthDate = patient.BirthDate
@@ -95,13 +150,19 @@ func (p *patientBusiness) EditPatient(patient patients.PatientCore) error {
 		return errors.E(err, op)
 	}
 
+	p.audit(op, patient.UpdatedBy, role, existingPatient.ID, before, existingPatient)
 	return nil
 }
 
-func (p *patientBusiness) RemovePatientById(id int, updatedBy int) error {
-	const op errors.Op = "patients.business.EditPatient"
+func (p *patientBusiness) RemovePatientById(id int, updatedBy int, role string) error {
+	const op errors.Op = "patients.business.RemovePatientById"
+
+	_, err := p.accountBusiness.FindAccountByUser(updatedBy, role)
+	if err != nil {
+		return errors.E(err, op)
+	}
 
-	_, err := p.adminBusiness.FindAdminById(updatedBy)
+	existingPatient, err := p.data.SelectPatientById(id)
 	if err != nil {
 		return errors.E(err, op)
 	}
@@ -116,5 +177,35 @@ func (p *patientBusiness) RemovePatientById(id int, updatedBy int) error {
 		return errors.E(err, op)
 	}
 
+	p.audit(op, updatedBy, role, id, existingPatient, nil)
 	return nil
 }
+
+// Private methods
+
+// recordAccess logs a read of patient data, the read fails when it can not be logged
+func (p *patientBusiness) recordAccess(op errors.Op, userId int, role string, patientIds ...int) error {
+	logs := make([]audits.AccessLogCore, len(patientIds))
+	for i := range patientIds {
+		logs[i] = audits.AccessLogCore{
+			ReaderID:   userId,
+			ReaderRole: role,
+			PatientID:  patientIds[i],
+			Operation:  string(op),
+		}
+	}
+	return p.auditBusiness.RecordAccess(logs)
+}
+
+// audit records a change on a patient, done by an admin or a receptionist
+func (p *patientBusiness) audit(op errors.Op, actorId int, actorRole string, patientId int, before interface{}, after interface{}) {
+	p.auditBusiness.Record(audits.AuditLogCore{
+		ActorID:   actorId,
+		ActorRole: actorRole,
+		Operation: string(op),
+		Entity:    audits.EntityPatient,
+		EntityID:  patientId,
+		Before:    before,
+		After:     after,
+	})
+}
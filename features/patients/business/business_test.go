@@ -5,11 +5,14 @@ import (
 	"testing"
 
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
-	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
-	amocks "github.com/final-project-alterra/hospital-management-system-api/features/admins/mocks"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	acmocks "github.com/final-project-alterra/hospital-management-system-api/features/accounts/mocks"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	aumocks "github.com/final-project-alterra/hospital-management-system-api/features/audits/mocks"
 	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
 	pb "github.com/final-project-alterra/hospital-management-system-api/features/patients/business"
 	pmocks "github.com/final-project-alterra/hospital-management-system-api/features/patients/mocks"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	smocks "github.com/final-project-alterra/hospital-management-system-api/features/schedules/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -19,11 +22,12 @@ var (
 	repo pmocks.IData
 
 	schedulesBusiness smocks.IBusiness
-	adminBusiness     amocks.IBusiness
+	accountBusiness   acmocks.IBusiness
+	auditBusiness     aumocks.IBusiness
 	business          patients.IBusiness
 
 	patient patients.PatientCore
-	admin   admins.AdminCore
+	account accounts.AccountCore
 
 	errNotFound error
 	errServer   error
@@ -32,19 +36,23 @@ var (
 func TestMain(m *testing.M) {
 	business = pb.NewPatientBusinessBuilder().
 		SetData(&repo).
-		SetAdminBusiness(&adminBusiness).
+		SetAccountBusiness(&accountBusiness).
 		SetScheduleBusiness(&schedulesBusiness).
+		SetAuditBusiness(&auditBusiness).
 		Build()
 
+	auditBusiness.On("Record", mock.AnythingOfType("audits.AuditLogCore")).Return()
+
 	patient = patients.PatientCore{
 		ID:   1,
 		NIK:  "123456789",
 		Name: "John Doe",
 	}
-	admin = admins.AdminCore{
-		ID:    1,
-		Email: "admin@mail.com",
-		Name:  "admin",
+	account = accounts.AccountCore{
+		ID:     1,
+		Email:  "admin@mail.com",
+		Role:   permissions.RoleAdmin,
+		UserID: 1,
 	}
 
 	errNotFound = errors.E(errors.New("not found"), errors.KindNotFound)
@@ -54,16 +62,34 @@ func TestMain(m *testing.M) {
 }
 
 func TestFindPatients(t *testing.T) {
+	accessLogs := []audits.AccessLogCore{{
+		ReaderID:   2,
+		ReaderRole: "doctor",
+		PatientID:  patient.ID,
+		Operation:  "patients.business.FindPatients",
+	}}
+
 	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
 			On("SelectPatients").
 			Return([]patients.PatientCore{patient}, nil).
 			Once()
 
-		result, err := business.FindPatients()
+		schedulesBusiness.
+			On("FindNoShowCounts", []int{patient.ID}).
+			Return(map[int]int{patient.ID: 2}, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", accessLogs).
+			Return(nil).
+			Once()
+
+		result, err := business.FindPatients(2, "doctor")
 
 		assert.Nil(t, err)
 		assert.Equal(t, 1, len(result))
+		assert.Equal(t, 2, result[0].NoShows)
 	})
 
 	t.Run("valid - when SelectPatients return error", func(t *testing.T) {
@@ -72,7 +98,46 @@ func TestFindPatients(t *testing.T) {
 			Return([]patients.PatientCore{}, errServer).
 			Once()
 
-		result, err := business.FindPatients()
+		result, err := business.FindPatients(2, "doctor")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, len(result))
+	})
+
+	t.Run("valid - when FindNoShowCounts return error", func(t *testing.T) {
+		repo.
+			On("SelectPatients").
+			Return([]patients.PatientCore{patient}, nil).
+			Once()
+
+		schedulesBusiness.
+			On("FindNoShowCounts", []int{patient.ID}).
+			Return(map[int]int{}, errServer).
+			Once()
+
+		result, err := business.FindPatients(2, "doctor")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, len(result))
+	})
+
+	t.Run("valid - when access can not be recorded", func(t *testing.T) {
+		repo.
+			On("SelectPatients").
+			Return([]patients.PatientCore{patient}, nil).
+			Once()
+
+		schedulesBusiness.
+			On("FindNoShowCounts", []int{patient.ID}).
+			Return(map[int]int{patient.ID: 2}, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", accessLogs).
+			Return(errServer).
+			Once()
+
+		result, err := business.FindPatients(2, "doctor")
 
 		assert.Error(t, err)
 		assert.Equal(t, 0, len(result))
@@ -129,11 +194,97 @@ func TestFindPatientById(t *testing.T) {
 	})
 }
 
+func TestFindPatientByNIK(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectPatientByNIK", patient.NIK).
+			Return(patient, nil).
+			Once()
+
+		result, err := business.FindPatientByNIK(patient.NIK)
+		assert.NoError(t, err)
+		assert.Equal(t, patient, result)
+	})
+
+	t.Run("valid - when SelectPatientByNIK return error", func(t *testing.T) {
+		repo.
+			On("SelectPatientByNIK", mock.AnythingOfType("string")).
+			Return(patients.PatientCore{}, errNotFound).
+			Once()
+
+		_, err := business.FindPatientByNIK("3201010101010001")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+}
+
+func TestViewPatientById(t *testing.T) {
+	accessLogs := []audits.AccessLogCore{{
+		ReaderID:   2,
+		ReaderRole: "nurse",
+		PatientID:  patient.ID,
+		Operation:  "patients.business.ViewPatientById",
+	}}
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectPatientById", patient.ID).
+			Return(patient, nil).
+			Once()
+
+		schedulesBusiness.
+			On("FindNoShowCounts", []int{patient.ID}).
+			Return(map[int]int{}, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", accessLogs).
+			Return(nil).
+			Once()
+
+		result, err := business.ViewPatientById(patient.ID, 2, "nurse")
+		assert.NoError(t, err)
+		assert.Equal(t, patient, result)
+	})
+
+	t.Run("valid - when patient is not found", func(t *testing.T) {
+		repo.
+			On("SelectPatientById", patient.ID).
+			Return(patients.PatientCore{}, errNotFound).
+			Once()
+
+		_, err := business.ViewPatientById(patient.ID, 2, "nurse")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+
+	t.Run("valid - when access can not be recorded", func(t *testing.T) {
+		repo.
+			On("SelectPatientById", patient.ID).
+			Return(patient, nil).
+			Once()
+
+		schedulesBusiness.
+			On("FindNoShowCounts", []int{patient.ID}).
+			Return(map[int]int{}, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", accessLogs).
+			Return(errServer).
+			Once()
+
+		result, err := business.ViewPatientById(patient.ID, 2, "nurse")
+		assert.Error(t, err)
+		assert.Equal(t, patients.PatientCore{}, result)
+	})
+}
+
 func TestCreatePatient(t *testing.T) {
 	t.Run("valid - when everything is fine", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admin, nil).
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(account, nil).
 			Once()
 
 		repo.
@@ -143,28 +294,53 @@ func TestCreatePatient(t *testing.T) {
 
 		repo.
 			On("InsertPatient", mock.AnythingOfType("patients.PatientCore")).
-			Return(nil).
+			Return(1, nil).
 			Once()
 
-		err := business.CreatePatient(patient)
+		err := business.CreatePatient(patient, permissions.RoleAdmin)
 		assert.NoError(t, err)
 	})
 
-	t.Run("valid - when FindAdminById return error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admins.AdminCore{}, errServer).
+	t.Run("valid - when receptionist creates the patient", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByUser", 7, permissions.RoleReceptionist).
+			Return(accounts.AccountCore{ID: 2, Role: permissions.RoleReceptionist, UserID: 7}, nil).
 			Once()
 
-		err := business.CreatePatient(patient)
+		repo.
+			On("SelectPatientByNIK", mock.AnythingOfType("string")).
+			Return(patients.PatientCore{}, errNotFound).
+			Once()
+
+		repo.
+			On("InsertPatient", mock.AnythingOfType("patients.PatientCore")).
+			Return(2, nil).
+			Once()
+
+		created := patient
+		created.CreatedBy = 7
+		err := business.CreatePatient(created, permissions.RoleReceptionist)
+		assert.NoError(t, err)
+		auditBusiness.AssertCalled(t, "Record", mock.MatchedBy(func(log audits.AuditLogCore) bool {
+			return log.Operation == "patients.business.CreatePatient" && log.ActorRole == permissions.RoleReceptionist
+		}))
+	})
+
+	t.Run("valid - when FindAccountByUser return error", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(accounts.AccountCore{}, errServer).
+			Once()
+
+		err := business.CreatePatient(patient, permissions.RoleAdmin)
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
 
 	t.Run("valid - when duplicate NIK", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admin, nil).
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(account, nil).
 			Once()
 
 		repo.
@@ -172,15 +348,15 @@ func TestCreatePatient(t *testing.T) {
 			Return(patients.PatientCore{NIK: patient.NIK}, nil).
 			Once()
 
-		err := business.CreatePatient(patient)
+		err := business.CreatePatient(patient, permissions.RoleAdmin)
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 
 	t.Run("valid - when SelectPatientByNIK return error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admin, nil).
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(account, nil).
 			Once()
 
 		repo.
@@ -188,15 +364,15 @@ func TestCreatePatient(t *testing.T) {
 			Return(patients.PatientCore{}, errServer).
 			Once()
 
-		err := business.CreatePatient(patient)
+		err := business.CreatePatient(patient, permissions.RoleAdmin)
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
 
 	t.Run("valid - when InsertPatient return error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admin, nil).
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(account, nil).
 			Once()
 
 		repo.
@@ -206,10 +382,10 @@ func TestCreatePatient(t *testing.T) {
 
 		repo.
 			On("InsertPatient", mock.AnythingOfType("patients.PatientCore")).
-			Return(errServer).
+			Return(0, errServer).
 			Once()
 
-		err := business.CreatePatient(patient)
+		err := business.CreatePatient(patient, permissions.RoleAdmin)
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
@@ -217,9 +393,9 @@ func TestCreatePatient(t *testing.T) {
 
 func TestEditPatient(t *testing.T) {
 	t.Run("valid - when everything is fine", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admin, nil).
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(account, nil).
 			Once()
 
 		repo.
@@ -232,25 +408,25 @@ func TestEditPatient(t *testing.T) {
 			Return(nil).
 			Once()
 
-		err := business.EditPatient(patient)
+		err := business.EditPatient(patient, permissions.RoleAdmin)
 		assert.NoError(t, err)
 	})
 
-	t.Run("valid - when FindAdminById return error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admins.AdminCore{}, errServer).
+	t.Run("valid - when FindAccountByUser return error", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(accounts.AccountCore{}, errServer).
 			Once()
 
-		err := business.EditPatient(patient)
+		err := business.EditPatient(patient, permissions.RoleAdmin)
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
 
 	t.Run("valid - when SelectPatientById return error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admin, nil).
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(account, nil).
 			Once()
 
 		repo.
@@ -258,15 +434,15 @@ func TestEditPatient(t *testing.T) {
 			Return(patients.PatientCore{}, errNotFound).
 			Once()
 
-		err := business.EditPatient(patient)
+		err := business.EditPatient(patient, permissions.RoleAdmin)
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
 	})
 
 	t.Run("valid - UpdatePatient return error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admin, nil).
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(account, nil).
 			Once()
 
 		repo.
@@ -279,7 +455,7 @@ func TestEditPatient(t *testing.T) {
 			Return(errServer).
 			Once()
 
-		err := business.EditPatient(patient)
+		err := business.EditPatient(patient, permissions.RoleAdmin)
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
@@ -287,9 +463,14 @@ func TestEditPatient(t *testing.T) {
 
 func TestRemovePatientById(t *testing.T) {
 	t.Run("valid - when everything is fine", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admin, nil).
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(account, nil).
+			Once()
+
+		repo.
+			On("SelectPatientById", patient.ID).
+			Return(patient, nil).
 			Once()
 
 		schedulesBusiness.
@@ -302,25 +483,49 @@ func TestRemovePatientById(t *testing.T) {
 			Return(nil).
 			Once()
 
-		err := business.RemovePatientById(patient.ID, admin.ID)
+		err := business.RemovePatientById(patient.ID, account.UserID, permissions.RoleAdmin)
 		assert.NoError(t, err)
+		auditBusiness.AssertCalled(t, "Record", mock.MatchedBy(func(log audits.AuditLogCore) bool {
+			return log.Operation == "patients.business.RemovePatientById" && log.EntityID == patient.ID && log.After == nil
+		}))
 	})
 
-	t.Run("valid - when FindAdminById return error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admins.AdminCore{}, errNotFound).
+	t.Run("valid - when FindAccountByUser return error", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(accounts.AccountCore{}, errNotFound).
 			Once()
 
-		err := business.RemovePatientById(patient.ID, admin.ID)
+		err := business.RemovePatientById(patient.ID, account.UserID, permissions.RoleAdmin)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+
+	t.Run("valid - when patient is not found", func(t *testing.T) {
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(account, nil).
+			Once()
+
+		repo.
+			On("SelectPatientById", patient.ID).
+			Return(patients.PatientCore{}, errNotFound).
+			Once()
+
+		err := business.RemovePatientById(patient.ID, account.UserID, permissions.RoleAdmin)
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
 	})
 
 	t.Run("valid - when RemovePatientWaitingOutpatients return error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admin, nil).
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(account, nil).
+			Once()
+
+		repo.
+			On("SelectPatientById", patient.ID).
+			Return(patient, nil).
 			Once()
 
 		schedulesBusiness.
@@ -328,14 +533,19 @@ func TestRemovePatientById(t *testing.T) {
 			Return(errServer).
 			Once()
 
-		err := business.RemovePatientById(patient.ID, admin.ID)
+		err := business.RemovePatientById(patient.ID, account.UserID, permissions.RoleAdmin)
 		assert.Error(t, err)
 	})
 
 	t.Run("valid - when DeletePatientById return error", func(t *testing.T) {
-		adminBusiness.
-			On("FindAdminById", mock.AnythingOfType("int")).
-			Return(admin, nil).
+		accountBusiness.
+			On("FindAccountByUser", mock.AnythingOfType("int"), permissions.RoleAdmin).
+			Return(account, nil).
+			Once()
+
+		repo.
+			On("SelectPatientById", patient.ID).
+			Return(patient, nil).
 			Once()
 
 		schedulesBusiness.
@@ -348,7 +558,7 @@ func TestRemovePatientById(t *testing.T) {
 			Return(errServer).
 			Once()
 
-		err := business.RemovePatientById(patient.ID, admin.ID)
+		err := business.RemovePatientById(patient.ID, account.UserID, permissions.RoleAdmin)
 		assert.Error(t, err)
 		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
@@ -76,8 +76,8 @@ func (r *mySQLRepo) SelectPatientByNIK(nik string) (patients.PatientCore, error)
 	return patientRecord.toPatientCore(), nil
 }
 
-func (r *mySQLRepo) InsertPatient(patient patients.PatientCore) error {
-	const op errors.Op = "patients.data.SelectPatientByNIK"
+func (r *mySQLRepo) InsertPatient(patient patients.PatientCore) (int, error) {
+	const op errors.Op = "patients.data.InsertPatient"
 	var errMessage errors.ErrClientMessage = "Something went wrong"
 
 	newPatientRecord := Patient{
@@ -92,9 +92,9 @@ func (r *mySQLRepo) InsertPatient(patient patients.PatientCore) error {
 
 	err := r.db.Create(&newPatientRecord).Error
 	if err != nil {
-		return errors.E(err, op, errMessage, errors.KindServerError)
+		return 0, errors.E(err, op, errMessage, errors.KindServerError)
 	}
-	return nil
+	return int(newPatientRecord.ID), nil
 }
 
 func (r *mySQLRepo) UpdatePatient(patient patients.PatientCore) error {
@@ -12,17 +12,20 @@ type PatientCore struct {
 	Phone     string
 	Address   string
 	Gender    string
+	NoShows   int // outpatient visits missed, only filled when viewed by staff
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
 type IBusiness interface {
-	FindPatients() ([]PatientCore, error)
+	FindPatients(userId int, role string) ([]PatientCore, error) // logged as access of every patient
 	FindPatientsByIds(ids []int) ([]PatientCore, error)
-	FindPatientById(id int) (PatientCore, error)
-	CreatePatient(patient PatientCore) error
-	EditPatient(patient PatientCore) error
-	RemovePatientById(id int, updatedBy int) error
+	FindPatientById(id int) (PatientCore, error)                          // not logged, used by other features
+	FindPatientByNIK(nik string) (PatientCore, error)                     // not logged, used to sign patients in
+	ViewPatientById(id int, userId int, role string) (PatientCore, error) // logged as access
+	CreatePatient(patient PatientCore, role string) error                 // role of the staff member creating it
+	EditPatient(patient PatientCore, role string) error
+	RemovePatientById(id int, updatedBy int, role string) error
 }
 
 type IData interface {
@@ -30,7 +33,7 @@ type IData interface {
 	SelectPatientsByIds(ids []int) ([]PatientCore, error)
 	SelectPatientById(id int) (PatientCore, error)
 	SelectPatientByNIK(nik string) (PatientCore, error)
-	InsertPatient(patient PatientCore) error
+	InsertPatient(patient PatientCore) (int, error)
 	UpdatePatient(patient PatientCore) error
 	DeletePatientById(id int, updatedBy int) error
 }
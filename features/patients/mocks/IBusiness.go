@@ -12,13 +12,13 @@ type IBusiness struct {
 	mock.Mock
 }
 
-// CreatePatient provides a mock function with given fields: patient
-func (_m *IBusiness) CreatePatient(patient patients.PatientCore) error {
-	ret := _m.Called(patient)
+// CreatePatient provides a mock function with given fields: patient, role
+func (_m *IBusiness) CreatePatient(patient patients.PatientCore, role string) error {
+	ret := _m.Called(patient, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(patients.PatientCore) error); ok {
-		r0 = rf(patient)
+	if rf, ok := ret.Get(0).(func(patients.PatientCore, string) error); ok {
+		r0 = rf(patient, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -26,13 +26,13 @@ func (_m *IBusiness) CreatePatient(patient patients.PatientCore) error {
 	return r0
 }
 
-// EditPatient provides a mock function with given fields: patient
-func (_m *IBusiness) EditPatient(patient patients.PatientCore) error {
-	ret := _m.Called(patient)
+// EditPatient provides a mock function with given fields: patient, role
+func (_m *IBusiness) EditPatient(patient patients.PatientCore, role string) error {
+	ret := _m.Called(patient, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(patients.PatientCore) error); ok {
-		r0 = rf(patient)
+	if rf, ok := ret.Get(0).(func(patients.PatientCore, string) error); ok {
+		r0 = rf(patient, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -61,13 +61,34 @@ func (_m *IBusiness) FindPatientById(id int) (patients.PatientCore, error) {
 	return r0, r1
 }
 
-// FindPatients provides a mock function with given fields:
-func (_m *IBusiness) FindPatients() ([]patients.PatientCore, error) {
-	ret := _m.Called()
+// FindPatientByNIK provides a mock function with given fields: nik
+func (_m *IBusiness) FindPatientByNIK(nik string) (patients.PatientCore, error) {
+	ret := _m.Called(nik)
+
+	var r0 patients.PatientCore
+	if rf, ok := ret.Get(0).(func(string) patients.PatientCore); ok {
+		r0 = rf(nik)
+	} else {
+		r0 = ret.Get(0).(patients.PatientCore)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(nik)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPatients provides a mock function with given fields: userId, role
+func (_m *IBusiness) FindPatients(userId int, role string) ([]patients.PatientCore, error) {
+	ret := _m.Called(userId, role)
 
 	var r0 []patients.PatientCore
-	if rf, ok := ret.Get(0).(func() []patients.PatientCore); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(int, string) []patients.PatientCore); ok {
+		r0 = rf(userId, role)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]patients.PatientCore)
@@ -75,8 +96,8 @@ func (_m *IBusiness) FindPatients() ([]patients.PatientCore, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(userId, role)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -107,16 +128,37 @@ func (_m *IBusiness) FindPatientsByIds(ids []int) ([]patients.PatientCore, error
 	return r0, r1
 }
 
-// RemovePatientById provides a mock function with given fields: id, updatedBy
-func (_m *IBusiness) RemovePatientById(id int, updatedBy int) error {
-	ret := _m.Called(id, updatedBy)
+// RemovePatientById provides a mock function with given fields: id, updatedBy, role
+func (_m *IBusiness) RemovePatientById(id int, updatedBy int, role string) error {
+	ret := _m.Called(id, updatedBy, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, int) error); ok {
-		r0 = rf(id, updatedBy)
+	if rf, ok := ret.Get(0).(func(int, int, string) error); ok {
+		r0 = rf(id, updatedBy, role)
 	} else {
 		r0 = ret.Error(0)
 	}
 
 	return r0
 }
+
+// ViewPatientById provides a mock function with given fields: id, userId, role
+func (_m *IBusiness) ViewPatientById(id int, userId int, role string) (patients.PatientCore, error) {
+	ret := _m.Called(id, userId, role)
+
+	var r0 patients.PatientCore
+	if rf, ok := ret.Get(0).(func(int, int, string) patients.PatientCore); ok {
+		r0 = rf(id, userId, role)
+	} else {
+		r0 = ret.Get(0).(patients.PatientCore)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int, string) error); ok {
+		r1 = rf(id, userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -27,17 +27,24 @@ func (_m *IData) DeletePatientById(id int, updatedBy int) error {
 }
 
 // InsertPatient provides a mock function with given fields: patient
-func (_m *IData) InsertPatient(patient patients.PatientCore) error {
+func (_m *IData) InsertPatient(patient patients.PatientCore) (int, error) {
 	ret := _m.Called(patient)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(patients.PatientCore) error); ok {
+	var r0 int
+	if rf, ok := ret.Get(0).(func(patients.PatientCore) int); ok {
 		r0 = rf(patient)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(patients.PatientCore) error); ok {
+		r1 = rf(patient)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // SelectPatientById provides a mock function with given fields: id
@@ -32,7 +32,9 @@ func (p *PatientPresentation) GetPatients(c echo.Context) error {
 	message := "Success retrieving patients"
 	const op errors.Op = "patients.presentation.GetPatients"
 
-	patientsData, err := p.business.FindPatients()
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	patientsData, err := p.business.FindPatients(userId, role)
 	if err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
@@ -51,7 +53,9 @@ func (p *PatientPresentation) GetDetailPatient(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
 	}
 
-	patientData, err := p.business.FindPatientById(patientId)
+	userId := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	patientData, err := p.business.ViewPatientById(patientId, userId, role)
 	if err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
@@ -66,8 +70,8 @@ func (p *PatientPresentation) PostPatient(c echo.Context) error {
 
 	createdBy, ok := c.Get("userId").(int)
 	if !ok {
-		err := errors.New("Invalid admin id")
-		errMessage = "Invalid admin id"
+		err := errors.New("Invalid user id")
+		errMessage = "Invalid user id"
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
 	}
 
@@ -82,7 +86,8 @@ func (p *PatientPresentation) PostPatient(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
 	}
 
-	if err := p.business.CreatePatient(patient.ToPatientCore()); err != nil {
+	role := c.Get("role").(string)
+	if err := p.business.CreatePatient(patient.ToPatientCore(), role); err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
 	return response.Success(c, status, message, nil)
@@ -96,8 +101,8 @@ func (p *PatientPresentation) PutEditPatient(c echo.Context) error {
 
 	updatedBy, ok := c.Get("userId").(int)
 	if !ok {
-		err := errors.New("Invalid admin id")
-		errMessage = "Invalid admin id"
+		err := errors.New("Invalid user id")
+		errMessage = "Invalid user id"
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
 	}
 
@@ -112,7 +117,8 @@ func (p *PatientPresentation) PutEditPatient(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
 	}
 
-	if err := p.business.EditPatient(patient.ToPatientCore()); err != nil {
+	role := c.Get("role").(string)
+	if err := p.business.EditPatient(patient.ToPatientCore(), role); err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
 
@@ -127,8 +133,8 @@ func (p *PatientPresentation) DeletePatient(c echo.Context) error {
 
 	updatedBy, ok := c.Get("userId").(int)
 	if !ok {
-		err := errors.New("Invalid admin id")
-		errMessage = "Invalid admin id"
+		err := errors.New("Invalid user id")
+		errMessage = "Invalid user id"
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
 	}
 
@@ -138,7 +144,8 @@ func (p *PatientPresentation) DeletePatient(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
 	}
 
-	if err := p.business.RemovePatientById(patientId, updatedBy); err != nil {
+	role := c.Get("role").(string)
+	if err := p.business.RemovePatientById(patientId, updatedBy, role); err != nil {
 		return response.Error(c, errors.E(op, err))
 	}
 
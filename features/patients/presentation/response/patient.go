@@ -14,6 +14,7 @@ type PatientResponse struct {
 	Phone     string    `json:"phone"`
 	Address   string    `json:"address"`
 	Gender    string    `json:"gender"`
+	NoShows   int       `json:"noShows"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -27,6 +28,7 @@ func DetailPatient(p patients.PatientCore) PatientResponse {
 		Phone:     p.Phone,
 		Address:   p.Address,
 		Gender:    p.Gender,
+		NoShows:   p.NoShows,
 		CreatedAt: p.CreatedAt,
 		UpdatedAt: p.UpdatedAt,
 	}
@@ -0,0 +1,21 @@
+package business
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+
+type permissionBusinessBuilder struct {
+	matrix map[string]map[string]permissions.Scope
+}
+
+func NewPermissionBusinessBuilder() *permissionBusinessBuilder {
+	return &permissionBusinessBuilder{matrix: matrix}
+}
+
+func (b *permissionBusinessBuilder) Build() permissions.IBusiness {
+	permissionBusiness := &permissionBusiness{
+		matrix: b.matrix,
+	}
+
+	b.matrix = nil
+
+	return permissionBusiness
+}
@@ -0,0 +1,50 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+)
+
+type permissionBusiness struct {
+	matrix map[string]map[string]permissions.Scope
+}
+
+func (pb *permissionBusiness) FindMatrix() permissions.MatrixCore {
+	result := permissions.MatrixCore{
+		Actions: append([]string{}, actions...),
+		Roles:   make([]permissions.RoleCore, len(roles)),
+	}
+
+	for i, role := range roles {
+		result.Roles[i] = permissions.RoleCore{
+			Role:        role,
+			Permissions: []permissions.PermissionCore{},
+		}
+
+		for _, action := range actions {
+			scope := pb.matrix[role][action]
+			if scope == permissions.ScopeNone {
+				continue
+			}
+			result.Roles[i].Permissions = append(result.Roles[i].Permissions, permissions.PermissionCore{
+				Action: action,
+				Scope:  scope,
+			})
+		}
+	}
+	return result
+}
+
+func (pb *permissionBusiness) Authorize(role string, action string) (permissions.Scope, error) {
+	const op errors.Op = "permissions.business.Authorize"
+	var errMessage errors.ErrClientMessage = "You are not allowed to perform this action"
+
+	scope := pb.matrix[role][action]
+	if scope == permissions.ScopeNone {
+		err := fmt.Errorf("role %q is not granted %q", role, action)
+		return permissions.ScopeNone, errors.E(err, op, errMessage, errors.KindUnauthorized)
+	}
+	return scope, nil
+}
@@ -0,0 +1,98 @@
+package business_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/final-project-alterra/hospital-management-system-api/features/permissions/business"
+)
+
+var business permissions.IBusiness
+
+func TestMain(m *testing.M) {
+	business = pb.NewPermissionBusinessBuilder().Build()
+
+	os.Exit(m.Run())
+}
+
+func TestFindMatrix(t *testing.T) {
+	t.Run("valid - matrix lists every role", func(t *testing.T) {
+		matrix := business.FindMatrix()
+
+		roles := []string{}
+		for _, role := range matrix.Roles {
+			roles = append(roles, role.Role)
+		}
+
+		assert.NotEmpty(t, matrix.Actions)
+		assert.Contains(t, roles, permissions.RoleAdmin)
+		assert.Contains(t, roles, permissions.RoleDoctor)
+		assert.Contains(t, roles, permissions.RoleNurse)
+		assert.Contains(t, roles, permissions.RoleReceptionist)
+		assert.Contains(t, roles, permissions.RolePharmacist)
+		assert.Contains(t, roles, permissions.RolePatient)
+	})
+
+	t.Run("valid - matrix only lists granted permissions", func(t *testing.T) {
+		matrix := business.FindMatrix()
+
+		for _, role := range matrix.Roles {
+			for _, permission := range role.Permissions {
+				assert.NotEqual(t, permissions.ScopeNone, permission.Scope)
+			}
+		}
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Run("valid - admin can manage doctors", func(t *testing.T) {
+		scope, err := business.Authorize(permissions.RoleAdmin, permissions.ActionManageDoctors)
+		assert.Nil(t, err)
+		assert.Equal(t, permissions.ScopeAll, scope)
+	})
+
+	t.Run("valid - doctor can only examine their own outpatients", func(t *testing.T) {
+		scope, err := business.Authorize(permissions.RoleDoctor, permissions.ActionExamineOutpatients)
+		assert.Nil(t, err)
+		assert.Equal(t, permissions.ScopeOwn, scope)
+	})
+
+	t.Run("valid - nurse cannot finish outpatient", func(t *testing.T) {
+		scope, err := business.Authorize(permissions.RoleNurse, permissions.ActionFinishOutpatients)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+		assert.Equal(t, permissions.ScopeNone, scope)
+	})
+
+	t.Run("valid - receptionist can manage patients", func(t *testing.T) {
+		scope, err := business.Authorize(permissions.RoleReceptionist, permissions.ActionManagePatients)
+		assert.Nil(t, err)
+		assert.Equal(t, permissions.ScopeAll, scope)
+	})
+
+	t.Run("valid - patient cannot view other outpatients", func(t *testing.T) {
+		scope, err := business.Authorize(permissions.RolePatient, permissions.ActionViewOutpatients)
+		assert.Error(t, err)
+		assert.Equal(t, permissions.ScopeNone, scope)
+
+		scope, err = business.Authorize(permissions.RolePatient, permissions.ActionViewOwnVisits)
+		assert.Nil(t, err)
+		assert.Equal(t, permissions.ScopeOwn, scope)
+	})
+
+	t.Run("valid - unknown role is not granted anything", func(t *testing.T) {
+		_, err := business.Authorize("unknown", permissions.ActionViewDoctors)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+
+	t.Run("valid - unknown action is not granted", func(t *testing.T) {
+		_, err := business.Authorize(permissions.RoleAdmin, "unknown.action")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
+}
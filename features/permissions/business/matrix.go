@@ -0,0 +1,170 @@
+package business
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+
+// Order of roles and actions when the matrix is listed
+var roles = []string{
+	permissions.RoleAdmin,
+	permissions.RoleDoctor,
+	permissions.RoleNurse,
+	permissions.RoleReceptionist,
+	permissions.RolePharmacist,
+	permissions.RolePatient,
+}
+
+var actions = []string{
+	permissions.ActionViewAdmins,
+	permissions.ActionManageAdmins,
+	permissions.ActionViewDoctors,
+	permissions.ActionManageDoctors,
+	permissions.ActionManageOwnDoctor,
+	permissions.ActionViewNurses,
+	permissions.ActionManageNurses,
+	permissions.ActionManageOwnNurse,
+	permissions.ActionViewStaffs,
+	permissions.ActionManageStaffs,
+	permissions.ActionManageOwnStaff,
+	permissions.ActionViewPatients,
+	permissions.ActionManagePatients,
+	permissions.ActionViewRooms,
+	permissions.ActionManageRooms,
+	permissions.ActionViewSpecialities,
+	permissions.ActionManageSpecialities,
+	permissions.ActionViewWorkSchedules,
+	permissions.ActionManageWorkSchedules,
+	permissions.ActionViewClosures,
+	permissions.ActionManageClosures,
+	permissions.ActionRequestLeave,
+	permissions.ActionViewLeaves,
+	permissions.ActionReviewLeaves,
+	permissions.ActionViewOutpatients,
+	permissions.ActionManageOutpatients,
+	permissions.ActionExamineOutpatients,
+	permissions.ActionFinishOutpatients,
+	permissions.ActionCancelOutpatients,
+	permissions.ActionManagePrescriptions,
+	permissions.ActionDispensePrescriptions,
+	permissions.ActionViewMedicines,
+	permissions.ActionManageMedicines,
+	permissions.ActionManageStock,
+	permissions.ActionViewOwnVisits,
+	permissions.ActionBookOwnVisits,
+	permissions.ActionViewClinicalData,
+	permissions.ActionBreakGlass,
+	permissions.ActionRevokeSessions,
+	permissions.ActionUnlockAccounts,
+	permissions.ActionManageOwnTwoFactor,
+	permissions.ActionViewPermissions,
+	permissions.ActionViewAuditLogs,
+	permissions.ActionViewAccessLogs,
+	permissions.ActionViewJobs,
+	permissions.ActionRunJobs,
+	permissions.ActionViewNotifications,
+}
+
+// Actions that are not listed for a role are not granted (ScopeNone)
+var matrix = map[string]map[string]permissions.Scope{
+	permissions.RoleAdmin: {
+		permissions.ActionViewAdmins:          permissions.ScopeAll,
+		permissions.ActionManageAdmins:        permissions.ScopeAll,
+		permissions.ActionViewDoctors:         permissions.ScopeAll,
+		permissions.ActionManageDoctors:       permissions.ScopeAll,
+		permissions.ActionViewNurses:          permissions.ScopeAll,
+		permissions.ActionManageNurses:        permissions.ScopeAll,
+		permissions.ActionViewStaffs:          permissions.ScopeAll,
+		permissions.ActionManageStaffs:        permissions.ScopeAll,
+		permissions.ActionViewPatients:        permissions.ScopeAll,
+		permissions.ActionManagePatients:      permissions.ScopeAll,
+		permissions.ActionViewRooms:           permissions.ScopeAll,
+		permissions.ActionManageRooms:         permissions.ScopeAll,
+		permissions.ActionViewSpecialities:    permissions.ScopeAll,
+		permissions.ActionManageSpecialities:  permissions.ScopeAll,
+		permissions.ActionViewWorkSchedules:   permissions.ScopeAll,
+		permissions.ActionManageWorkSchedules: permissions.ScopeAll,
+		permissions.ActionViewClosures:        permissions.ScopeAll,
+		permissions.ActionManageClosures:      permissions.ScopeAll,
+		permissions.ActionViewLeaves:          permissions.ScopeAll,
+		permissions.ActionReviewLeaves:        permissions.ScopeAll,
+		permissions.ActionViewOutpatients:     permissions.ScopeAll,
+		permissions.ActionManageOutpatients:   permissions.ScopeAll,
+		permissions.ActionCancelOutpatients:   permissions.ScopeAll,
+		permissions.ActionViewMedicines:       permissions.ScopeAll,
+		permissions.ActionManageMedicines:     permissions.ScopeAll,
+		permissions.ActionManageStock:         permissions.ScopeAll,
+		permissions.ActionRevokeSessions:      permissions.ScopeAll,
+		permissions.ActionUnlockAccounts:      permissions.ScopeAll,
+		permissions.ActionManageOwnTwoFactor:  permissions.ScopeOwn,
+		permissions.ActionViewPermissions:     permissions.ScopeAll,
+		permissions.ActionViewAuditLogs:       permissions.ScopeAll,
+		permissions.ActionViewAccessLogs:      permissions.ScopeAll,
+		permissions.ActionViewJobs:            permissions.ScopeAll,
+		permissions.ActionRunJobs:             permissions.ScopeAll,
+		permissions.ActionViewNotifications:   permissions.ScopeAll,
+	},
+	permissions.RoleDoctor: {
+		permissions.ActionViewDoctors:         permissions.ScopeAll,
+		permissions.ActionManageOwnDoctor:     permissions.ScopeOwn,
+		permissions.ActionViewNurses:          permissions.ScopeAll,
+		permissions.ActionViewPatients:        permissions.ScopeAll,
+		permissions.ActionViewRooms:           permissions.ScopeAll,
+		permissions.ActionViewSpecialities:    permissions.ScopeAll,
+		permissions.ActionViewWorkSchedules:   permissions.ScopeAll,
+		permissions.ActionViewClosures:        permissions.ScopeAll,
+		permissions.ActionRequestLeave:        permissions.ScopeOwn,
+		permissions.ActionViewOutpatients:     permissions.ScopeAll,
+		permissions.ActionExamineOutpatients:  permissions.ScopeOwn,
+		permissions.ActionFinishOutpatients:   permissions.ScopeOwn,
+		permissions.ActionCancelOutpatients:   permissions.ScopeOwn,
+		permissions.ActionManagePrescriptions: permissions.ScopeOwn,
+		permissions.ActionViewMedicines:       permissions.ScopeAll,
+		permissions.ActionViewClinicalData:    permissions.ScopeOwn,
+		permissions.ActionBreakGlass:          permissions.ScopeAll,
+	},
+	permissions.RoleNurse: {
+		permissions.ActionViewDoctors:        permissions.ScopeAll,
+		permissions.ActionViewNurses:         permissions.ScopeAll,
+		permissions.ActionManageOwnNurse:     permissions.ScopeOwn,
+		permissions.ActionViewPatients:       permissions.ScopeAll,
+		permissions.ActionViewRooms:          permissions.ScopeAll,
+		permissions.ActionViewSpecialities:   permissions.ScopeAll,
+		permissions.ActionViewWorkSchedules:  permissions.ScopeAll,
+		permissions.ActionViewClosures:       permissions.ScopeAll,
+		permissions.ActionRequestLeave:       permissions.ScopeOwn,
+		permissions.ActionViewOutpatients:    permissions.ScopeAll,
+		permissions.ActionExamineOutpatients: permissions.ScopeOwn,
+		permissions.ActionCancelOutpatients:  permissions.ScopeOwn,
+		permissions.ActionViewMedicines:      permissions.ScopeAll,
+		permissions.ActionViewClinicalData:   permissions.ScopeOwn,
+		permissions.ActionBreakGlass:         permissions.ScopeAll,
+	},
+	permissions.RoleReceptionist: {
+		permissions.ActionViewDoctors:       permissions.ScopeAll,
+		permissions.ActionViewNurses:        permissions.ScopeAll,
+		permissions.ActionManageOwnStaff:    permissions.ScopeOwn,
+		permissions.ActionViewPatients:      permissions.ScopeAll,
+		permissions.ActionManagePatients:    permissions.ScopeAll,
+		permissions.ActionViewRooms:         permissions.ScopeAll,
+		permissions.ActionViewSpecialities:  permissions.ScopeAll,
+		permissions.ActionViewWorkSchedules: permissions.ScopeAll,
+		permissions.ActionViewClosures:      permissions.ScopeAll,
+		permissions.ActionViewOutpatients:   permissions.ScopeAll,
+		permissions.ActionManageOutpatients: permissions.ScopeAll,
+		permissions.ActionCancelOutpatients: permissions.ScopeAll,
+		permissions.ActionViewNotifications: permissions.ScopeAll,
+	},
+	permissions.RolePharmacist: {
+		permissions.ActionViewDoctors:           permissions.ScopeAll,
+		permissions.ActionManageOwnStaff:        permissions.ScopeOwn,
+		permissions.ActionViewPatients:          permissions.ScopeAll,
+		permissions.ActionViewOutpatients:       permissions.ScopeAll,
+		permissions.ActionDispensePrescriptions: permissions.ScopeAll,
+		permissions.ActionViewMedicines:         permissions.ScopeAll,
+		permissions.ActionManageMedicines:       permissions.ScopeAll,
+		permissions.ActionManageStock:           permissions.ScopeAll,
+	},
+	permissions.RolePatient: {
+		permissions.ActionViewSpecialities: permissions.ScopeAll,
+		permissions.ActionViewOwnVisits:    permissions.ScopeOwn,
+		permissions.ActionBookOwnVisits:    permissions.ScopeOwn,
+	},
+}
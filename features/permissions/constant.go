@@ -0,0 +1,101 @@
+package permissions
+
+const (
+	RoleAdmin        = "admin"
+	RoleDoctor       = "doctor"
+	RoleNurse        = "nurse"
+	RoleReceptionist = "receptionist"
+	RolePharmacist   = "pharmacist"
+
+	// Patients sign in to the portal with their NIK and a one-time code, they only
+	// reach their own visits
+	RolePatient = "patient"
+)
+
+// Scope tells how far a granted action reaches
+type Scope int
+
+const (
+	ScopeNone Scope = iota
+	ScopeOwn        // only records the user is assigned to, e.g. outpatients of their work schedule
+	ScopeAll
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeOwn:
+		return "own"
+	case ScopeAll:
+		return "all"
+	default:
+		return "none"
+	}
+}
+
+const (
+	ActionViewAdmins   = "admins.view"
+	ActionManageAdmins = "admins.manage"
+
+	ActionViewDoctors     = "doctors.view"
+	ActionManageDoctors   = "doctors.manage"
+	ActionManageOwnDoctor = "doctors.manage-own"
+
+	ActionViewNurses     = "nurses.view"
+	ActionManageNurses   = "nurses.manage"
+	ActionManageOwnNurse = "nurses.manage-own"
+
+	// Receptionists and pharmacists
+	ActionViewStaffs     = "staffs.view"
+	ActionManageStaffs   = "staffs.manage"
+	ActionManageOwnStaff = "staffs.manage-own"
+
+	ActionViewPatients   = "patients.view"
+	ActionManagePatients = "patients.manage"
+
+	ActionViewRooms   = "rooms.view"
+	ActionManageRooms = "rooms.manage"
+
+	ActionViewSpecialities   = "specialities.view"
+	ActionManageSpecialities = "specialities.manage"
+
+	ActionViewWorkSchedules   = "work-schedules.view"
+	ActionManageWorkSchedules = "work-schedules.manage"
+
+	ActionViewClosures   = "closures.view"
+	ActionManageClosures = "closures.manage"
+
+	ActionRequestLeave = "leaves.request" // doctors and nurses, only their own leaves
+	ActionViewLeaves   = "leaves.view"
+	ActionReviewLeaves = "leaves.review"
+
+	ActionViewOutpatients    = "outpatients.view"
+	ActionManageOutpatients  = "outpatients.manage"
+	ActionExamineOutpatients = "outpatients.examine"
+	ActionFinishOutpatients  = "outpatients.finish"
+	ActionCancelOutpatients  = "outpatients.cancel"
+
+	ActionManagePrescriptions   = "prescriptions.manage" // amending and voiding prescriptions of finished outpatients
+	ActionDispensePrescriptions = "prescriptions.dispense"
+
+	ActionViewMedicines   = "medicines.view"
+	ActionManageMedicines = "medicines.manage"
+	ActionManageStock     = "medicine-stock.manage" // receiving and adjusting batches
+
+	ActionViewOwnVisits = "visits.view-own" // patients, their own outpatient visits
+	ActionBookOwnVisits = "visits.book-own" // patients, booking and canceling their own visits
+
+	// Complaint, diagnosis and prescriptions of an outpatient. ScopeOwn is limited to
+	// the patient's care team, break glass lifts that limit and is always logged.
+	ActionViewClinicalData = "clinical-data.view"
+	ActionBreakGlass       = "clinical-data.break-glass"
+
+	ActionRevokeSessions     = "sessions.revoke"
+	ActionUnlockAccounts     = "accounts.unlock"
+	ActionManageOwnTwoFactor = "two-factor.manage-own"
+	ActionViewPermissions    = "permissions.view"
+	ActionViewAuditLogs      = "audit-logs.view"
+	ActionViewAccessLogs     = "access-logs.view"
+	ActionViewJobs           = "jobs.view"
+	ActionRunJobs            = "jobs.run"
+	ActionViewNotifications  = "notifications.view"
+)
@@ -0,0 +1,21 @@
+package permissions
+
+type PermissionCore struct {
+	Action string
+	Scope  Scope
+}
+
+type RoleCore struct {
+	Role        string
+	Permissions []PermissionCore
+}
+
+type MatrixCore struct {
+	Actions []string
+	Roles   []RoleCore
+}
+
+type IBusiness interface {
+	FindMatrix() MatrixCore
+	Authorize(role string, action string) (Scope, error)
+}
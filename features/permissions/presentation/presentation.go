@@ -0,0 +1,27 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions/presentation/response"
+	"github.com/labstack/echo/v4"
+)
+
+type PermissionPresentation struct {
+	business permissions.IBusiness
+}
+
+func NewPermissionPresentation(business permissions.IBusiness) *PermissionPresentation {
+	return &PermissionPresentation{
+		business: business,
+	}
+}
+
+func (p *PermissionPresentation) GetPermissions(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving permission matrix"
+
+	matrix := p.business.FindMatrix()
+	return response.Success(c, status, message, response.Matrix(matrix))
+}
@@ -0,0 +1,39 @@
+package response
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+
+type PermissionResponse struct {
+	Action string `json:"action"`
+	Scope  string `json:"scope"`
+}
+
+type RoleResponse struct {
+	Role        string               `json:"role"`
+	Permissions []PermissionResponse `json:"permissions"`
+}
+
+type MatrixResponse struct {
+	Actions []string       `json:"actions"`
+	Roles   []RoleResponse `json:"roles"`
+}
+
+func Matrix(m permissions.MatrixCore) MatrixResponse {
+	result := MatrixResponse{
+		Actions: m.Actions,
+		Roles:   make([]RoleResponse, len(m.Roles)),
+	}
+
+	for i, role := range m.Roles {
+		result.Roles[i] = RoleResponse{
+			Role:        role.Role,
+			Permissions: make([]PermissionResponse, len(role.Permissions)),
+		}
+		for j, permission := range role.Permissions {
+			result.Roles[i].Permissions[j] = PermissionResponse{
+				Action: permission.Action,
+				Scope:  permission.Scope.String(),
+			}
+		}
+	}
+	return result
+}
@@ -1,17 +1,28 @@
 package business
 
 import (
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures"
 	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
 	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
 	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
 )
 
 type scheduleBusinessBuilder struct {
-	repo            schedules.IData
-	doctorBusiness  doctors.IBusiness
-	nurseBusiness   nurses.IBusiness
-	patientBusiness patients.IBusiness
+	repo             schedules.IData
+	doctorBusiness   doctors.IBusiness
+	nurseBusiness    nurses.IBusiness
+	patientBusiness  patients.IBusiness
+	closureBusiness  closures.IBusiness
+	medicineBusiness medicines.IBusiness
+
+	permissionBusiness permissions.IBusiness
+	auditBusiness      audits.IBusiness
+	notifier           schedules.INotifier
+	queueHub           *queueHub
 }
 
 func NewScheduleBusinessBuilder() *scheduleBusinessBuilder {
@@ -38,17 +49,65 @@ func (b *scheduleBusinessBuilder) SetNurseBusiness(n nurses.IBusiness) *schedule
 	return b
 }
 
+func (b *scheduleBusinessBuilder) SetClosureBusiness(c closures.IBusiness) *scheduleBusinessBuilder {
+	b.closureBusiness = c
+	return b
+}
+
+func (b *scheduleBusinessBuilder) SetMedicineBusiness(m medicines.IBusiness) *scheduleBusinessBuilder {
+	b.medicineBusiness = m
+	return b
+}
+
+func (b *scheduleBusinessBuilder) SetPermissionBusiness(p permissions.IBusiness) *scheduleBusinessBuilder {
+	b.permissionBusiness = p
+	return b
+}
+
+func (b *scheduleBusinessBuilder) SetAuditBusiness(a audits.IBusiness) *scheduleBusinessBuilder {
+	b.auditBusiness = a
+	return b
+}
+
+func (b *scheduleBusinessBuilder) SetNotifier(n schedules.INotifier) *scheduleBusinessBuilder {
+	b.notifier = n
+	return b
+}
+
+func (b *scheduleBusinessBuilder) SetQueueHub(h *queueHub) *scheduleBusinessBuilder {
+	b.queueHub = h
+	return b
+}
+
 func (b *scheduleBusinessBuilder) Build() *scheduleBusiness {
+	if b.queueHub == nil {
+		b.queueHub = NewQueueHub()
+	}
+
 	business := &scheduleBusiness{
-		data:            b.repo,
-		patientBusiness: b.patientBusiness,
-		doctorBusiness:  b.doctorBusiness,
-		nurseBusiness:   b.nurseBusiness,
+		data:             b.repo,
+		patientBusiness:  b.patientBusiness,
+		doctorBusiness:   b.doctorBusiness,
+		nurseBusiness:    b.nurseBusiness,
+		closureBusiness:  b.closureBusiness,
+		medicineBusiness: b.medicineBusiness,
+
+		permissionBusiness: b.permissionBusiness,
+		auditBusiness:      b.auditBusiness,
+		notifier:           b.notifier,
+
+		queueHub: b.queueHub,
 	}
 	b.repo = nil
 	b.doctorBusiness = nil
 	b.nurseBusiness = nil
 	b.patientBusiness = nil
+	b.closureBusiness = nil
+	b.medicineBusiness = nil
+	b.permissionBusiness = nil
+	b.auditBusiness = nil
+	b.notifier = nil
+	b.queueHub = nil
 
 	return business
 }
@@ -6,18 +6,31 @@ import (
 
 	"github.com/final-project-alterra/hospital-management-system-api/config"
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/closures"
 	"github.com/final-project-alterra/hospital-management-system-api/features/doctors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
 	"github.com/final-project-alterra/hospital-management-system-api/features/nurses"
 	"github.com/final-project-alterra/hospital-management-system-api/features/patients"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+	"github.com/final-project-alterra/hospital-management-system-api/utils/recurrence"
 	"github.com/google/uuid"
 )
 
 type scheduleBusiness struct {
-	data            schedules.IData
-	doctorBusiness  doctors.IBusiness
-	nurseBusiness   nurses.IBusiness
-	patientBusiness patients.IBusiness
+	data             schedules.IData
+	doctorBusiness   doctors.IBusiness
+	nurseBusiness    nurses.IBusiness
+	patientBusiness  patients.IBusiness
+	closureBusiness  closures.IBusiness
+	medicineBusiness medicines.IBusiness
+
+	permissionBusiness permissions.IBusiness
+	auditBusiness      audits.IBusiness
+	notifier           schedules.INotifier
+
+	queueHub *queueHub
 }
 
 func (s *scheduleBusiness) FindWorkSchedules(q schedules.ScheduleQuery) ([]schedules.WorkScheduleCore, error) {
@@ -103,13 +116,12 @@ func (s *scheduleBusiness) FindNurseWorkSchedules(nurseId int, q schedules.Sched
 	return schedulesData, nil
 }
 
-func (s *scheduleBusiness) CreateWorkSchedule(workSchedule schedules.WorkScheduleCore, q schedules.ScheduleQuery) error { // GENERATE LIST
+func (s *scheduleBusiness) CreateWorkSchedule(workSchedule schedules.WorkScheduleCore, q schedules.ScheduleQuery, userId int, role string) error { // GENERATE LIST
 	const op errors.Op = "schedules.business.CreateWorkSchedule"
-	var errMesage errors.ErrClientMessage
 
 	var dates []string
 
-	_, err := s.doctorBusiness.FindDoctorById(workSchedule.Doctor.ID)
+	doctor, err := s.doctorBusiness.FindDoctorById(workSchedule.Doctor.ID)
 	if err != nil {
 		return errors.E(err, op)
 	}
@@ -119,20 +131,26 @@ func (s *scheduleBusiness) CreateWorkSchedule(workSchedule schedules.WorkSchedul
 		return errors.E(err, op)
 	}
 
-	switch q.Repeat {
-	case schedules.RepeatNoRepeat:
+	if q.Repeat == schedules.RepeatNoRepeat {
 		dates = []string{q.StartDate}
-	case schedules.RepeatDaily:
-		dates, err = s.repeatEveryDay(q.StartDate, q.EndDate)
-	case schedules.RepeatWeekly:
-		dates, err = s.repeatEveryWeek(q.StartDate, q.EndDate)
-	case schedules.RepeatMonthly:
-		dates, err = s.repeatEveryMonthSameDay(q.StartDate, q.EndDate)
-	default:
-		errMesage = "Invalid repeat type"
-		return errors.E(errors.New("Invalid repeat type"), op, errMesage, errors.KindBadRequest)
+	} else {
+		dates, err = s.generateDates(q)
+		if err != nil {
+			return errors.E(err, op)
+		}
+	}
+
+	dates, err = s.skipClosedDates(dates)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	workSchedule, err = s.checkSlotSettings(workSchedule)
+	if err != nil {
+		return errors.E(err, op)
 	}
 
+	err = s.checkConflicts(workSchedule, doctor, dates, nil)
 	if err != nil {
 		return errors.E(err, op)
 	}
@@ -149,15 +167,19 @@ func (s *scheduleBusiness) CreateWorkSchedule(workSchedule schedules.WorkSchedul
 		newSchedules[i] = newSchedule
 	}
 
-	err = s.data.InsertWorkSchedules(newSchedules)
+	ids, err := s.data.InsertWorkSchedules(newSchedules)
 	if err != nil {
 		return errors.E(err, op)
 	}
 
+	for i := range ids {
+		newSchedules[i].ID = ids[i]
+		s.audit(op, userId, role, audits.EntityWorkSchedule, ids[i], nil, newSchedules[i])
+	}
 	return nil
 }
 
-func (s *scheduleBusiness) EditWorkSchedule(workSchedule schedules.WorkScheduleCore) error {
+func (s *scheduleBusiness) EditWorkSchedule(workSchedule schedules.WorkScheduleCore, userId int, role string) error {
 	const op errors.Op = "schedules.business.EditWorkSchedule"
 
 	existingSchedules, err := s.data.SelectWorkScheduleById(workSchedule.ID)
@@ -165,7 +187,7 @@ func (s *scheduleBusiness) EditWorkSchedule(workSchedule schedules.WorkScheduleC
 		return errors.E(err, op)
 	}
 
-	_, err = s.doctorBusiness.FindDoctorById(workSchedule.Doctor.ID)
+	doctor, err := s.doctorBusiness.FindDoctorById(workSchedule.Doctor.ID)
 	if err != nil {
 		return errors.E(err, op)
 	}
@@ -175,28 +197,421 @@ func (s *scheduleBusiness) EditWorkSchedule(workSchedule schedules.WorkScheduleC
 		return errors.E(err, op)
 	}
 
+	before := existingSchedules
+	if existingSchedules.Doctor.ID != workSchedule.Doctor.ID || existingSchedules.Nurse.ID != workSchedule.Nurse.ID {
+		existingSchedules.LeaveID = 0 // staffed by someone else now
+	}
 	existingSchedules.Doctor.ID = workSchedule.Doctor.ID
 	existingSchedules.Nurse.ID = workSchedule.Nurse.ID
 	existingSchedules.Date = workSchedule.Date
 	existingSchedules.StartTime = workSchedule.StartTime
 	existingSchedules.EndTime = workSchedule.EndTime
+	existingSchedules.SlotMinutes = workSchedule.SlotMinutes
+	existingSchedules.SlotCapacity = workSchedule.SlotCapacity
+	existingSchedules.Capacity = workSchedule.Capacity
+	existingSchedules.Overbook = workSchedule.Overbook
+
+	existingSchedules, err = s.checkSlotSettings(existingSchedules)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = s.checkConflicts(existingSchedules, doctor, []string{existingSchedules.Date}, []int{existingSchedules.ID})
+	if err != nil {
+		return errors.E(err, op)
+	}
 
 	err = s.data.UpdateWorkSchedule(existingSchedules)
 	if err != nil {
 		return errors.E(err, op)
 	}
 
+	s.audit(op, userId, role, audits.EntityWorkSchedule, existingSchedules.ID, before, existingSchedules)
 	return nil
 }
 
-func (s *scheduleBusiness) RemoveWorkScheduleById(workScheduleId int) error {
+func (s *scheduleBusiness) RemoveWorkScheduleById(workScheduleId int, userId int, role string) error {
 	const op errors.Op = "schedules.business.RemoveWorkScheduleById"
 
-	err := s.data.DeleteWorkScheduleById(workScheduleId)
+	existingSchedule, err := s.data.SelectWorkScheduleById(workScheduleId)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	waiting, err := s.findWaitingToRemove([]schedules.WorkScheduleCore{existingSchedule})
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = s.data.DeleteWorkScheduleById(workScheduleId)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	s.audit(op, userId, role, audits.EntityWorkSchedule, workScheduleId, existingSchedule, nil)
+	s.announceRemoved(waiting)
+
+	return nil
+}
+
+func (s *scheduleBusiness) FindWorkSchedulesByGroup(group string) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.business.FindWorkSchedulesByGroup"
+	var errMsg errors.ErrClientMessage = "Work schedule series not found"
+
+	schedulesData, err := s.data.SelectWorkSchedulesByGroup(group)
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+	if len(schedulesData) == 0 {
+		return []schedules.WorkScheduleCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindNotFound)
+	}
+
+	scheduleIds := make([]int, len(schedulesData))
+	for i := range schedulesData {
+		scheduleIds[i] = schedulesData[i].ID
+	}
+
+	waitingMap, err := s.data.SelectCountWorkSchedulesWaitings(scheduleIds)
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+
+	doctorsMap, err := s.findDoctorsData(s.getUniqueDoctorIds(schedulesData))
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+
+	nursesMap, err := s.findNursesData(s.getUniqueNurseIds(schedulesData))
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+
+	for i := range schedulesData {
+		ID := schedulesData[i].ID
+		doctorID := schedulesData[i].Doctor.ID
+		nurseID := schedulesData[i].Nurse.ID
+
+		schedulesData[i].TotalWaiting = waitingMap[ID]
+		schedulesData[i].Doctor = doctorsMap[doctorID]
+		schedulesData[i].Nurse = nursesMap[nurseID]
+	}
+
+	return schedulesData, nil
+}
+
+func (s *scheduleBusiness) EditWorkScheduleSeries(group string, workScheduleId int, scope string, changes schedules.WorkScheduleCore, userId int, role string) (schedules.SeriesResultCore, error) {
+	const op errors.Op = "schedules.business.EditWorkScheduleSeries"
+
+	targets, err := s.selectSeries(group, workScheduleId, scope)
+	if err != nil {
+		return schedules.SeriesResultCore{}, errors.E(err, op)
+	}
+
+	editable, skippedIds, err := s.skipExamined(targets)
+	if err != nil {
+		return schedules.SeriesResultCore{}, errors.E(err, op)
+	}
+
+	// the doctor is looked up when conflicts are checked
+	if changes.Nurse.ID != 0 {
+		if _, err = s.nurseBusiness.FindNurseById(changes.Nurse.ID); err != nil {
+			return schedules.SeriesResultCore{}, errors.E(err, op)
+		}
+	}
+
+	if changes.Doctor.ID != 0 {
+		var errMsg errors.ErrClientMessage = "Replacement doctor must have the same speciality"
+		for _, doctorIdBefore := range s.getUniqueDoctorIds(editable) {
+			if err = s.checkSameSpeciality(doctorIdBefore, changes.Doctor.ID, errMsg); err != nil {
+				return schedules.SeriesResultCore{}, errors.E(err, op)
+			}
+		}
+	}
+
+	updated := make([]schedules.WorkScheduleCore, len(editable))
+	ids := make([]int, len(editable))
+	for i, ws := range editable {
+		if changes.Doctor.ID != 0 {
+			ws.Doctor.ID = changes.Doctor.ID
+			ws.LeaveID = 0
+		}
+		if changes.Nurse.ID != 0 {
+			ws.Nurse.ID = changes.Nurse.ID
+			ws.LeaveID = 0
+		}
+		if changes.StartTime != "" && changes.EndTime != "" {
+			ws.StartTime = changes.StartTime
+			ws.EndTime = changes.EndTime
+
+			// the slots must still fit the new time
+			if ws, err = s.checkSlotSettings(ws); err != nil {
+				return schedules.SeriesResultCore{}, errors.E(err, op)
+			}
+		}
+		updated[i] = ws
+		ids[i] = ws.ID
+	}
+
+	err = s.checkShiftConflicts(updated, ids)
+	if err != nil {
+		return schedules.SeriesResultCore{}, errors.E(err, op)
+	}
+
+	err = s.data.UpdateWorkSchedules(updated)
+	if err != nil {
+		return schedules.SeriesResultCore{}, errors.E(err, op)
+	}
+
+	for i := range updated {
+		s.audit(op, userId, role, audits.EntityWorkSchedule, updated[i].ID, editable[i], updated[i])
+	}
+	return schedules.SeriesResultCore{WorkScheduleIDs: ids, SkippedIDs: skippedIds}, nil
+}
+
+func (s *scheduleBusiness) RemoveWorkScheduleSeries(group string, workScheduleId int, scope string, userId int, role string) (schedules.SeriesResultCore, error) {
+	const op errors.Op = "schedules.business.RemoveWorkScheduleSeries"
+
+	targets, err := s.selectSeries(group, workScheduleId, scope)
+	if err != nil {
+		return schedules.SeriesResultCore{}, errors.E(err, op)
+	}
+
+	removable, skippedIds, err := s.skipExamined(targets)
+	if err != nil {
+		return schedules.SeriesResultCore{}, errors.E(err, op)
+	}
+
+	ids := make([]int, len(removable))
+	for i := range removable {
+		ids[i] = removable[i].ID
+	}
+
+	waiting, err := s.findWaitingToRemove(removable)
+	if err != nil {
+		return schedules.SeriesResultCore{}, errors.E(err, op)
+	}
+
+	err = s.data.DeleteWorkSchedulesByIds(ids)
+	if err != nil {
+		return schedules.SeriesResultCore{}, errors.E(err, op)
+	}
+
+	for i := range removable {
+		s.audit(op, userId, role, audits.EntityWorkSchedule, removable[i].ID, removable[i], nil)
+	}
+	s.announceRemoved(waiting)
+	return schedules.SeriesResultCore{WorkScheduleIDs: ids, SkippedIDs: skippedIds}, nil
+}
+
+func (s *scheduleBusiness) FindWorkSchedulesByDates(dates []string) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.business.FindWorkSchedulesByDates"
+
+	if len(dates) == 0 {
+		return []schedules.WorkScheduleCore{}, nil
+	}
+
+	schedulesData, err := s.data.SelectWorkSchedulesByDates(dates)
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+
+	for i := range schedulesData {
+		schedulesData[i].TotalWaiting = len(schedulesData[i].Outpatients)
+	}
+	return schedulesData, nil
+}
+
+func (s *scheduleBusiness) FindAvailability(specialityId int, q schedules.ScheduleQuery) ([]schedules.AvailabilityCore, error) {
+	const op errors.Op = "schedules.business.FindAvailability"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	doctorsData, err := s.doctorBusiness.FindDoctors()
+	if err != nil {
+		return []schedules.AvailabilityCore{}, errors.E(err, op)
+	}
+
+	doctorIds := []int{}
+	doctorsMap := make(map[int]schedules.DoctorCore)
+	for _, d := range doctorsData {
+		if d.Speciality.ID == specialityId {
+			doctorIds = append(doctorIds, d.ID)
+			doctorsMap[d.ID] = toDoctorCore(d)
+		}
+	}
+	if len(doctorIds) == 0 {
+		return []schedules.AvailabilityCore{}, nil
+	}
+
+	schedulesData, err := s.data.SelectWorkSchedulesByDoctorIds(doctorIds, q)
+	if err != nil {
+		return []schedules.AvailabilityCore{}, errors.E(err, op)
+	}
+
+	open := []schedules.WorkScheduleCore{}
+	for _, ws := range schedulesData {
+		if ws.LeaveID != 0 || s.checkNotEnded(ws, errMsg) != nil {
+			continue
+		}
+		open = append(open, ws)
+	}
+	if len(open) == 0 {
+		return []schedules.AvailabilityCore{}, nil
+	}
+
+	ids := make([]int, len(open))
+	for i := range open {
+		ids[i] = open[i].ID
+	}
+
+	bookings, err := s.data.SelectBookingCounts(ids)
+	if err != nil {
+		return []schedules.AvailabilityCore{}, errors.E(err, op)
+	}
+
+	now := time.Now().In(config.GetTimeLoc())
+	result := []schedules.AvailabilityCore{}
+	for _, ws := range open {
+		ws.Doctor = doctorsMap[ws.Doctor.ID]
+
+		availability, err := availabilityOf(ws, bookings[ws.ID], now)
+		if err != nil {
+			return []schedules.AvailabilityCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+		}
+		if availability.Remaining != 0 {
+			result = append(result, availability)
+		}
+	}
+	return result, nil
+}
+
+func (s *scheduleBusiness) FindStaffWorkSchedules(staffRole string, staffId int, q schedules.ScheduleQuery) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.business.FindStaffWorkSchedules"
+	var errMsg errors.ErrClientMessage = "Only doctors and nurses have work schedules"
+
+	var schedulesData []schedules.WorkScheduleCore
+	var err error
+	switch staffRole {
+	case permissions.RoleDoctor:
+		schedulesData, err = s.data.SelectWorkSchedulesByDoctorId(staffId, q)
+	case permissions.RoleNurse:
+		schedulesData, err = s.data.SelectWorkSchedulesByNurseId(staffId, q)
+	default:
+		err = errors.New("Unknown staff role")
+		return []schedules.WorkScheduleCore{}, errors.E(err, op, errMsg, errors.KindBadRequest)
+	}
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+	if len(schedulesData) == 0 {
+		return schedulesData, nil
+	}
+
+	ids := make([]int, len(schedulesData))
+	for i := range schedulesData {
+		ids[i] = schedulesData[i].ID
+	}
+
+	waiting, err := s.data.SelectWaitingOutpatientsByWorkScheduleIds(ids)
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+
+	waitingMap := make(map[int][]schedules.OutpatientCore)
+	for _, o := range waiting {
+		waitingMap[o.WorkSchedule.ID] = append(waitingMap[o.WorkSchedule.ID], o)
+	}
+	for i := range schedulesData {
+		schedulesData[i].Outpatients = waitingMap[schedulesData[i].ID]
+		schedulesData[i].TotalWaiting = len(schedulesData[i].Outpatients)
+	}
+	return schedulesData, nil
+}
+
+func (s *scheduleBusiness) ReassignWorkSchedules(workSchedules []schedules.WorkScheduleCore, doctorId int, nurseId int, userId int, role string) error {
+	const op errors.Op = "schedules.business.ReassignWorkSchedules"
+
+	if len(workSchedules) == 0 {
+		return nil
+	}
+
+	var errMsg errors.ErrClientMessage = "Replacement doctor must have the same speciality"
+
+	// the doctor is looked up when conflicts are checked
+	if nurseId != 0 {
+		if _, err := s.nurseBusiness.FindNurseById(nurseId); err != nil {
+			return errors.E(err, op)
+		}
+	}
+
+	workSchedules, err := s.skipStarted(workSchedules)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	if len(workSchedules) == 0 {
+		return nil
+	}
+
+	if doctorId != 0 {
+		for _, doctorIdBefore := range s.getUniqueDoctorIds(workSchedules) {
+			if err = s.checkSameSpeciality(doctorIdBefore, doctorId, errMsg); err != nil {
+				return errors.E(err, op)
+			}
+		}
+	}
+
+	updated := make([]schedules.WorkScheduleCore, len(workSchedules))
+	ids := make([]int, len(workSchedules))
+	for i, ws := range workSchedules {
+		if doctorId != 0 {
+			ws.Doctor.ID = doctorId
+		}
+		if nurseId != 0 {
+			ws.Nurse.ID = nurseId
+		}
+		ws.LeaveID = 0
+		ws.Outpatients = nil
+		updated[i] = ws
+		ids[i] = ws.ID
+	}
+
+	err = s.checkShiftConflicts(updated, ids)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = s.data.UpdateWorkSchedules(updated)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	for i := range updated {
+		s.audit(op, userId, role, audits.EntityWorkSchedule, updated[i].ID, workSchedules[i], updated[i])
+	}
+	return nil
+}
+
+func (s *scheduleBusiness) FlagWorkSchedulesOnLeave(workSchedules []schedules.WorkScheduleCore, leaveId int, userId int, role string) error {
+	const op errors.Op = "schedules.business.FlagWorkSchedulesOnLeave"
+
+	if len(workSchedules) == 0 {
+		return nil
+	}
+
+	flagged := make([]schedules.WorkScheduleCore, len(workSchedules))
+	for i, ws := range workSchedules {
+		ws.LeaveID = leaveId
+		ws.Outpatients = nil
+		flagged[i] = ws
+	}
+
+	err := s.data.UpdateWorkSchedules(flagged)
 	if err != nil {
 		return errors.E(err, op)
 	}
 
+	for i := range flagged {
+		s.audit(op, userId, role, audits.EntityWorkSchedule, flagged[i].ID, workSchedules[i], flagged[i])
+	}
 	return nil
 }
 
@@ -206,12 +621,13 @@ func (s *scheduleBusiness) RemoveDoctorFutureWorkSchedules(doctorId int) error {
 
 	yearFormat := "2006-01-02"
 	now := time.Now().In(config.GetTimeLoc())
-
-	ws, err := s.data.SelectWorkSchedulesByDoctorId(doctorId, schedules.ScheduleQuery{
+	q := schedules.ScheduleQuery{
 		StartDate: now.Format(yearFormat),
-		EndDate:   now.Format(yearFormat),
+		EndDate:   now.AddDate(100, 0, 0).Format(yearFormat),
 		Limit:     10000,
-	})
+	}
+
+	ws, err := s.data.SelectWorkSchedulesByDoctorId(doctorId, q)
 	if err != nil {
 		return errors.E(err, op)
 	}
@@ -225,13 +641,17 @@ func (s *scheduleBusiness) RemoveDoctorFutureWorkSchedules(doctorId int) error {
 		}
 	}
 
-	err = s.data.DeleteWorkSchedulesByDoctorId(doctorId, schedules.ScheduleQuery{
-		StartDate: now.Format(yearFormat),
-		EndDate:   now.AddDate(100, 0, 0).Format(yearFormat),
-	})
+	waiting, err := s.findWaitingToRemove(ws)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = s.data.DeleteWorkSchedulesByDoctorId(doctorId, q)
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	s.announceRemoved(waiting)
 	return nil
 }
 
@@ -249,7 +669,7 @@ func (s *scheduleBusiness) RemoveNurseFromNextWorkSchedules(nurseId int) error {
 	return nil
 }
 
-func (s *scheduleBusiness) FindOutpatients(q schedules.ScheduleQuery) ([]schedules.OutpatientCore, error) {
+func (s *scheduleBusiness) FindOutpatients(q schedules.ScheduleQuery, userId int, role string) ([]schedules.OutpatientCore, error) {
 	const op errors.Op = "schedules.business.FindOutpatients"
 
 	outpatientsData, err := s.data.SelectOutpatients(q)
@@ -284,10 +704,25 @@ func (s *scheduleBusiness) FindOutpatients(q schedules.ScheduleQuery) ([]schedul
 		outpatientsData[i].WorkSchedule.Nurse = nurseMap[nurseID]
 	}
 
+	// A listing only shows clinical data of the reader's own work schedules
+	for i := range outpatientsData {
+		visible, err := s.canViewClinicalData(outpatientsData[i].WorkSchedule, 0, userId, role)
+		if err != nil {
+			return []schedules.OutpatientCore{}, errors.E(err, op)
+		}
+		if !visible {
+			outpatientsData[i] = s.redactClinicalData(outpatientsData[i])
+		}
+	}
+
+	err = s.recordAccess(op, userId, role, "", s.getUniquePatientIds(outpatientsData)...)
+	if err != nil {
+		return []schedules.OutpatientCore{}, errors.E(err, op)
+	}
 	return outpatientsData, nil
 }
 
-func (s *scheduleBusiness) FindOutpatientsByWorkScheduleId(workScheduleId int) (schedules.WorkScheduleCore, error) {
+func (s *scheduleBusiness) FindOutpatientsByWorkScheduleId(workScheduleId int, userId int, role string) (schedules.WorkScheduleCore, error) {
 	const op errors.Op = "schedules.business.FindOutpatientsByWorkScheduleId"
 
 	workSchedule, err := s.data.SelectOutpatientsByWorkScheduleId(workScheduleId)
@@ -336,10 +771,24 @@ func (s *scheduleBusiness) FindOutpatientsByWorkScheduleId(workScheduleId int) (
 		workSchedule.Outpatients[i].Patient = patientsMap[patientID]
 	}
 
+	visible, err := s.canViewClinicalData(workSchedule, 0, userId, role)
+	if err != nil {
+		return schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+	if !visible {
+		for i := range workSchedule.Outpatients {
+			workSchedule.Outpatients[i] = s.redactClinicalData(workSchedule.Outpatients[i])
+		}
+	}
+
+	err = s.recordAccess(op, userId, role, "", s.getUniquePatientIds(workSchedule.Outpatients)...)
+	if err != nil {
+		return schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
 	return workSchedule, nil
 }
 
-func (s *scheduleBusiness) FindOutpatientsByPatientId(patientId int, q schedules.ScheduleQuery) ([]schedules.OutpatientCore, error) {
+func (s *scheduleBusiness) FindOutpatientsByPatientId(patientId int, q schedules.ScheduleQuery, userId int, role string, breakGlassReason string) ([]schedules.OutpatientCore, error) {
 	const op errors.Op = "schedules.business.FindOutpatientsByPatientId"
 
 	outpatientsData, err := s.data.SelectOutpatientsByPatientId(patientId, q)
@@ -367,10 +816,32 @@ func (s *scheduleBusiness) FindOutpatientsByPatientId(patientId int, q schedules
 		outpatientsData[i].WorkSchedule.Nurse = nurseMap[nurseID]
 	}
 
-	return outpatientsData, nil
-}
-
-func (s *scheduleBusiness) FindOutpatientById(outpatientId int) (schedules.OutpatientCore, error) {
+	visible, err := s.canViewClinicalData(schedules.WorkScheduleCore{}, patientId, userId, role)
+	if err != nil {
+		return []schedules.OutpatientCore{}, errors.E(err, op)
+	}
+
+	breakGlass, err := s.breakGlass(visible, role, breakGlassReason)
+	if err != nil {
+		return []schedules.OutpatientCore{}, errors.E(err, op)
+	}
+
+	if !visible && !breakGlass {
+		for i := range outpatientsData {
+			outpatientsData[i] = s.redactClinicalData(outpatientsData[i])
+		}
+		breakGlassReason = ""
+	}
+
+	// Even an empty history tells the reader something about the patient
+	err = s.recordAccess(op, userId, role, breakGlassReason, patientId)
+	if err != nil {
+		return []schedules.OutpatientCore{}, errors.E(err, op)
+	}
+	return outpatientsData, nil
+}
+
+func (s *scheduleBusiness) FindOutpatientById(outpatientId int, userId int, role string, breakGlassReason string) (schedules.OutpatientCore, error) {
 	const op errors.Op = "schedules.business.FindOutpatientById"
 
 	outpatientData, err := s.data.SelectOutpatientById(outpatientId)
@@ -424,10 +895,35 @@ func (s *scheduleBusiness) FindOutpatientById(outpatientId int) (schedules.Outpa
 	outpatientData.WorkSchedule.Doctor = doctor
 	outpatientData.WorkSchedule.Nurse = nurse
 
+	visible, err := s.canViewClinicalData(outpatientData.WorkSchedule, patient.ID, userId, role)
+	if err != nil {
+		return schedules.OutpatientCore{}, errors.E(err, op)
+	}
+
+	breakGlass, err := s.breakGlass(visible, role, breakGlassReason)
+	if err != nil {
+		return schedules.OutpatientCore{}, errors.E(err, op)
+	}
+
+	if !visible && !breakGlass {
+		prescriptions := outpatientData.Prescriptions
+		outpatientData = s.redactClinicalData(outpatientData)
+		breakGlassReason = ""
+
+		// the pharmacy hands out prescriptions without seeing complaint or diagnosis
+		if _, err := s.permissionBusiness.Authorize(role, permissions.ActionDispensePrescriptions); err == nil {
+			outpatientData.Prescriptions = prescriptions
+		}
+	}
+
+	err = s.recordAccess(op, userId, role, breakGlassReason, patient.ID)
+	if err != nil {
+		return schedules.OutpatientCore{}, errors.E(err, op)
+	}
 	return outpatientData, nil
 }
 
-func (s *scheduleBusiness) CreateOutpatient(outpatient schedules.OutpatientCore) error {
+func (s *scheduleBusiness) CreateOutpatient(outpatient schedules.OutpatientCore, userId int, role string) error {
 	const op errors.Op = "schedules.business.CreateOutpatient"
 	var errMsg errors.ErrClientMessage
 
@@ -441,35 +937,149 @@ func (s *scheduleBusiness) CreateOutpatient(outpatient schedules.OutpatientCore)
 		return errors.E(err, op)
 	}
 
-	currentTime := time.Now().In(config.GetTimeLoc())
+	errMsg = "Cannot add new outpatient to this workschedule, because it has ended"
+	if err = s.checkNotEnded(workSchedule, errMsg); err != nil {
+		return errors.E(err, op)
+	}
 
-	layout := "2006-01-02T15:04:05"
-	value := fmt.Sprintf("%sT%s", workSchedule.Date, workSchedule.EndTime)
+	if workSchedule.LeaveID != 0 {
+		errMsg = "Cannot add new outpatient to a work schedule whose staff is on leave"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
 
-	workScheduleTime, err := time.ParseInLocation(layout, value, config.GetTimeLoc())
+	outpatient, err = s.checkBooking(workSchedule, outpatient)
 	if err != nil {
-		errMsg = "Something went wrong"
-		return errors.E(err, op, errMsg, errors.KindServerError)
+		return errors.E(err, op)
 	}
 
-	// current time = 2021-10-10 19:30:00
-	// work schedule time = 2021-10-10 19:30:00
-	// it's considered that current time IS AFTER work schedule
-	if currentTime.After(workScheduleTime) {
-		errMsg = "Cannot add new outpatient to this workschedule, because it has ended"
+	outpatient.Status = schedules.StatusWaiting
+	outpatientId, err := s.data.InsertOutpatient(outpatient)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	outpatient.ID = outpatientId
+	s.audit(op, userId, role, audits.EntityOutpatient, outpatientId, nil, outpatient)
+	s.publishQueueEventById(schedules.QueueEventCreated, outpatientId)
+	return nil
+}
+
+func (s *scheduleBusiness) FindOutpatientQueue(outpatientId int, userId int, role string) (schedules.QueueCore, error) {
+	const op errors.Op = "schedules.business.FindOutpatientQueue"
+	var errMsg errors.ErrClientMessage
+
+	outpatient, err := s.data.SelectOutpatientById(outpatientId)
+	if err != nil {
+		return schedules.QueueCore{}, errors.E(err, op)
+	}
+
+	var queue schedules.QueueCore
+	switch outpatient.Status {
+	case schedules.StatusWaiting:
+		queue, err = s.estimateQueue(outpatient)
+		if err != nil {
+			return schedules.QueueCore{}, errors.E(err, op)
+		}
+	case schedules.StatusOnprogress:
+		// being examined, nobody is ahead
+		queue = schedules.QueueCore{Outpatient: outpatient, EstimatedTime: time.Now().In(config.GetTimeLoc())}
+	default:
+		errMsg = "Outpatient is no longer in the queue"
+		return schedules.QueueCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	err = s.recordAccess(op, userId, role, "", outpatient.Patient.ID)
+	if err != nil {
+		return schedules.QueueCore{}, errors.E(err, op)
+	}
+	return queue, nil
+}
+
+func (s *scheduleBusiness) SubscribeWorkScheduleQueue(workScheduleId int) (<-chan schedules.QueueEventCore, func(), error) {
+	const op errors.Op = "schedules.business.SubscribeWorkScheduleQueue"
+
+	_, err := s.data.SelectWorkScheduleById(workScheduleId)
+	if err != nil {
+		return nil, nil, errors.E(err, op)
+	}
+
+	events, cancel := s.queueHub.subscribe(workScheduleTopic(workScheduleId))
+	return events, cancel, nil
+}
+
+func (s *scheduleBusiness) SubscribeRoomQueue(roomId int) (<-chan schedules.QueueEventCore, func(), error) {
+	const op errors.Op = "schedules.business.SubscribeRoomQueue"
+	var errMsg errors.ErrClientMessage = "Invalid room id"
+
+	// rooms are owned by doctors, a room without doctors simply has no events
+	if roomId < 1 {
+		return nil, nil, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindBadRequest)
+	}
+
+	events, cancel := s.queueHub.subscribe(roomTopic(roomId))
+	return events, cancel, nil
+}
+
+func (s *scheduleBusiness) RescheduleOutpatient(outpatientId int, workScheduleId int, reason string, userId int, role string) error {
+	const op errors.Op = "schedules.business.RescheduleOutpatient"
+	var errMsg errors.ErrClientMessage
+
+	outpatient, err := s.data.SelectOutpatientById(outpatientId)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	if outpatient.Status != schedules.StatusWaiting {
+		errMsg = "Only waiting outpatients can be rescheduled"
 		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
 	}
 
-	outpatient.Status = schedules.StatusWaiting
-	err = s.data.InsertOutpatient(outpatient)
+	target, err := s.checkMoveTarget(outpatient.WorkSchedule, workScheduleId)
 	if err != nil {
 		return errors.E(err, op)
 	}
 
+	err = s.moveOutpatients(op, []schedules.OutpatientCore{outpatient}, target, reason, userId, role)
+	if err != nil {
+		return errors.E(err, op)
+	}
 	return nil
 }
 
-func (s *scheduleBusiness) EditOutpatient(outpatient schedules.OutpatientCore) error {
+func (s *scheduleBusiness) TransferOutpatients(fromWorkScheduleId int, toWorkScheduleId int, reason string, userId int, role string) ([]int, error) {
+	const op errors.Op = "schedules.business.TransferOutpatients"
+
+	source, err := s.data.SelectWorkScheduleById(fromWorkScheduleId)
+	if err != nil {
+		return []int{}, errors.E(err, op)
+	}
+
+	target, err := s.checkMoveTarget(source, toWorkScheduleId)
+	if err != nil {
+		return []int{}, errors.E(err, op)
+	}
+
+	waiting, err := s.data.SelectWaitingOutpatientsByWorkScheduleIds([]int{source.ID})
+	if err != nil {
+		return []int{}, errors.E(err, op)
+	}
+	for i := range waiting {
+		waiting[i].WorkSchedule = source
+	}
+
+	err = s.moveOutpatients(op, waiting, target, reason, userId, role)
+	if err != nil {
+		return []int{}, errors.E(err, op)
+	}
+
+	ids := make([]int, len(waiting))
+	for i := range waiting {
+		ids[i] = waiting[i].ID
+	}
+	return ids, nil
+}
+
+func (s *scheduleBusiness) EditOutpatient(outpatient schedules.OutpatientCore, userId int, role string) error {
 	// ONLY EDIT COMPLAINT
 	const op errors.Op = "schedules.business.EditOutpatient"
 
@@ -478,11 +1088,14 @@ func (s *scheduleBusiness) EditOutpatient(outpatient schedules.OutpatientCore) e
 		return errors.E(err, op)
 	}
 
+	before := existingOutpatient
 	existingOutpatient.Complaint = outpatient.Complaint
 	err = s.data.UpdateOutpatient(existingOutpatient)
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	s.audit(op, userId, role, audits.EntityOutpatient, existingOutpatient.ID, before, existingOutpatient)
 	return nil
 }
 
@@ -500,22 +1113,9 @@ func (s *scheduleBusiness) ExamineOutpatient(outpatientId int, userId int, role
 		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
 	}
 
-	switch role {
-	case "doctor":
-		if userId != existingOutpatient.WorkSchedule.Doctor.ID {
-			errMsg = "Only doctor of this outpatient work schedule can examine this outpatient"
-			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnauthorized)
-		}
-
-	case "nurse":
-		if userId != existingOutpatient.WorkSchedule.Nurse.ID {
-			errMsg = "Only nurse of this outpatient work schedule can examine this outpatient"
-			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnauthorized)
-		}
-
-	default:
-		errMsg = "Only doctor or nurse of this outpatient work schedule can examine this outpatient"
-		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnauthorized)
+	errMsg = "Only doctor or nurse of this outpatient work schedule can examine this outpatient"
+	if err = s.authorize(existingOutpatient.WorkSchedule, userId, role, permissions.ActionExamineOutpatients, errMsg); err != nil {
+		return errors.E(err, op)
 	}
 
 	workSchedule, err := s.data.SelectOutpatientsByWorkScheduleId(existingOutpatient.WorkSchedule.ID)
@@ -524,7 +1124,6 @@ func (s *scheduleBusiness) ExamineOutpatient(outpatientId int, userId int, role
 	}
 
 	const LAYOUT = "2006-01-02T15:04:05"
-	const OFFSET = 2 * time.Hour
 
 	date := fmt.Sprintf("%sT%s", workSchedule.Date, workSchedule.StartTime)
 	start, _ := time.ParseInLocation(LAYOUT, date, config.GetTimeLoc())
@@ -532,8 +1131,8 @@ func (s *scheduleBusiness) ExamineOutpatient(outpatientId int, userId int, role
 	date = fmt.Sprintf("%sT%s", workSchedule.Date, workSchedule.EndTime)
 	end, _ := time.ParseInLocation(LAYOUT, date, config.GetTimeLoc())
 
-	lowerLimit := start.Add(-OFFSET)
-	upperLimit := end.Add(OFFSET)
+	lowerLimit := start.Add(-schedules.EXAMINE_OFFSET)
+	upperLimit := end.Add(schedules.EXAMINE_OFFSET)
 
 	currentTime := time.Now().In(config.GetTimeLoc())
 	if currentTime.Before(lowerLimit) || currentTime.After(upperLimit) {
@@ -548,6 +1147,7 @@ func (s *scheduleBusiness) ExamineOutpatient(outpatientId int, userId int, role
 		}
 	}
 
+	before := existingOutpatient
 	existingOutpatient.Status = schedules.StatusOnprogress
 	existingOutpatient.StartTime = time.Now().In(config.GetTimeLoc()).Format("15:04:05")
 
@@ -555,6 +1155,24 @@ func (s *scheduleBusiness) ExamineOutpatient(outpatientId int, userId int, role
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	s.audit(op, userId, role, audits.EntityOutpatient, existingOutpatient.ID, before, existingOutpatient)
+	s.publishQueueEvent(schedules.QueueEventExamined, existingOutpatient)
+
+	// the lowest waiting queue number is up next
+	var next *schedules.OutpatientCore
+	for i, o := range workSchedule.Outpatients {
+		if o.Status != schedules.StatusWaiting || o.ID == existingOutpatient.ID {
+			continue
+		}
+		if next == nil || o.QueueNumber < next.QueueNumber {
+			next = &workSchedule.Outpatients[i]
+		}
+	}
+	if next != nil {
+		next.WorkSchedule = existingOutpatient.WorkSchedule
+		s.notify(schedules.NotifyNext, *next)
+	}
 	return nil
 }
 
@@ -572,27 +1190,39 @@ func (s *scheduleBusiness) FinishOutpatient(outpatient schedules.OutpatientCore,
 		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
 	}
 
-	switch role {
-	case "doctor":
-		if userId != existingOutpatient.WorkSchedule.Doctor.ID {
-			errMsg = "Only doctor of this outpatient work schedule can finish this outpatient"
-			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnauthorized)
-		}
+	errMsg = "Only doctor of this outpatient work schedule can finish this outpatient"
+	if err = s.authorize(existingOutpatient.WorkSchedule, userId, role, permissions.ActionFinishOutpatients, errMsg); err != nil {
+		return errors.E(err, op)
+	}
 
-	default:
-		errMsg = "Only doctor of this outpatient work schedule can finish this outpatient"
-		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnauthorized)
+	prescriptions, err := s.withCatalogue(outpatient.Prescriptions)
+	if err != nil {
+		return errors.E(err, op)
 	}
 
-	existingOutpatient.EndTime = time.Now().In(config.GetTimeLoc()).Format("15:04:05")
+	now := time.Now().In(config.GetTimeLoc())
+	before := existingOutpatient
+	existingOutpatient.EndTime = now.Format("15:04:05")
 	existingOutpatient.Status = schedules.StatusFinished
 	existingOutpatient.Diagnosis = outpatient.Diagnosis
-	existingOutpatient.Prescriptions = outpatient.Prescriptions
-
-	err = s.data.UpdateOutpatient(existingOutpatient)
+	existingOutpatient.Prescriptions = make([]schedules.PrescriptionCore, len(prescriptions))
+	for i, p := range prescriptions {
+		p.ID = 0
+		p.OutpatientID = existingOutpatient.ID
+		p.Version = 1
+		p.Status = schedules.PrescriptionActive
+		p.PrescribedBy = userId
+		existingOutpatient.Prescriptions[i] = p
+	}
+
+	// the finish fails when the catalogue medicines can't all be reserved with it
+	err = s.data.UpdateOutpatientReservingStock(existingOutpatient, now.Format("2006-01-02"))
 	if err != nil {
 		return errors.E(err, op)
 	}
+
+	s.audit(op, userId, role, audits.EntityOutpatient, existingOutpatient.ID, before, existingOutpatient)
+	s.publishQueueEvent(schedules.QueueEventFinished, existingOutpatient)
 	return nil
 }
 
@@ -610,212 +1240,1159 @@ func (s *scheduleBusiness) CancelOutpatient(outpatientId int, userId int, role s
 		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
 	}
 
-	switch role {
-	case "admin":
-		// just proceed to next step since admin can cancel any outpatient
-	case "doctor":
-		if userId != existingOutpatient.WorkSchedule.Doctor.ID {
-			errMsg = "Only doctor of this outpatient work schedule can cancel this outpatient"
-			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnauthorized)
-		}
-	case "nurse":
-		if userId != existingOutpatient.WorkSchedule.Nurse.ID {
-			errMsg = "Only nurse of this outpatient work schedule can cancel this outpatient"
-			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnauthorized)
-		}
-
-	default:
-		errMsg = "You are not authorized to cancel this outpatient"
-		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnauthorized)
+	errMsg = "You are not authorized to cancel this outpatient"
+	if err = s.authorize(existingOutpatient.WorkSchedule, userId, role, permissions.ActionCancelOutpatients, errMsg); err != nil {
+		return errors.E(err, op)
 	}
 
-	existingOutpatient.Status = schedules.StatusCanceled
-	err = s.data.UpdateOutpatient(existingOutpatient)
+	err = s.cancelOutpatient(op, existingOutpatient, userId, role)
 	if err != nil {
 		return errors.E(err, op)
 	}
-
 	return nil
 }
 
-func (s *scheduleBusiness) RemoveOutpatientById(outpatientId int) error {
-	const op errors.Op = "schedules.business.RemoveOutpatientById"
-	var errMsg errors.ErrClientMessage
+func (s *scheduleBusiness) BookVisit(outpatient schedules.OutpatientCore, patientId int) error {
+	const op errors.Op = "schedules.business.BookVisit"
+	var errMsg errors.ErrClientMessage = "You already have a visit waiting on this work schedule"
 
-	existingOutpatient, err := s.data.SelectOutpatientById(outpatientId)
+	waiting, err := s.data.SelectWaitingOutpatientsByWorkScheduleIds([]int{outpatient.WorkSchedule.ID})
 	if err != nil {
 		return errors.E(err, op)
 	}
 
-	if existingOutpatient.Status == schedules.StatusOnprogress {
-		errMsg = "Cannot remove outpatient that is on progress"
-		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	for _, o := range waiting {
+		if o.Patient.ID == patientId {
+			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+		}
 	}
 
-	err = s.data.DeleteOutpatientById(outpatientId)
+	outpatient.Patient.ID = patientId
+	outpatient.Overbooked = false // left to the staff
+
+	err = s.CreateOutpatient(outpatient, patientId, permissions.RolePatient)
 	if err != nil {
 		return errors.E(err, op)
 	}
 	return nil
 }
 
-func (s *scheduleBusiness) RemovePatientWaitingOutpatients(patientId int) error {
-	const op errors.Op = "schedules.business.RemovePatientWaitingOutpatients"
+func (s *scheduleBusiness) CancelVisit(outpatientId int, patientId int) error {
+	const op errors.Op = "schedules.business.CancelVisit"
+	var errMsg errors.ErrClientMessage
 
-	err := s.data.DeleteWaitingOutpatientsByPatientId(patientId)
+	existingOutpatient, err := s.data.SelectOutpatientById(outpatientId)
 	if err != nil {
 		return errors.E(err, op)
 	}
 
-	return nil
-}
-
-// Private methods
-func (s *scheduleBusiness) repeatEveryDay(start string, end string) ([]string, error) {
-	const op errors.Op = "schedules.business.repeatEveryDay"
-	const INCREMENT_DAY = 1
-
-	dates, err := s.generateDates(start, end, INCREMENT_DAY)
-	if err != nil {
-		return []string{}, errors.E(err, op)
+	// someone else's visit is not told apart from a missing one
+	if existingOutpatient.Patient.ID != patientId {
+		errMsg = "Outpatient not found"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindNotFound)
 	}
-	return dates, nil
-}
 
-func (s *scheduleBusiness) repeatEveryWeek(start string, end string) ([]string, error) {
-	const op errors.Op = "schedules.business.repeatEveryWeek"
-	const INCREMENT_DAY = 7
+	if existingOutpatient.Status != schedules.StatusWaiting {
+		errMsg = "Cannot cancel outpatient that is not waiting"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
 
-	dates, err := s.generateDates(start, end, INCREMENT_DAY)
+	err = s.cancelOutpatient(op, existingOutpatient, patientId, permissions.RolePatient)
 	if err != nil {
-		return []string{}, errors.E(err, op)
+		return errors.E(err, op)
 	}
-	return dates, nil
+	return nil
 }
 
-func (s *scheduleBusiness) repeatEveryMonthSameDay(start string, end string) ([]string, error) {
-	const op errors.Op = "schedules.business.repeatEveryMonthSameDay"
-	const INCREMENT_DAY = 28
+func (s *scheduleBusiness) AmendPrescription(prescription schedules.PrescriptionCore, userId int, role string) (schedules.PrescriptionCore, error) {
+	const op errors.Op = "schedules.business.AmendPrescription"
+	var errMsg errors.ErrClientMessage = "Only the current version of an active prescription can be amended"
 
-	dates, err := s.generateDates(start, end, INCREMENT_DAY)
+	existing, err := s.data.SelectPrescriptionById(prescription.ID)
 	if err != nil {
-		return []string{}, errors.E(err, op)
+		return schedules.PrescriptionCore{}, errors.E(err, op)
 	}
-	return dates, nil
-}
 
-func (s *scheduleBusiness) generateDates(start string, end string, incrementDay int) ([]string, error) {
-	const op errors.Op = "schedules.business.generateDates"
-	var errMessage errors.ErrClientMessage = "Invalid date format"
+	if existing.Status == schedules.PrescriptionDispensed {
+		errMsg = "Prescription has already been dispensed"
+	}
+	if existing.Status != schedules.PrescriptionActive {
+		return schedules.PrescriptionCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
 
-	var dates []string
-	startDate, err := time.Parse("2006-01-02", start)
-	if err != nil {
-		return dates, errors.E(err, op, errMessage, errors.KindBadRequest)
+	if err = s.checkPrescriptionChange(existing, userId, role); err != nil {
+		return schedules.PrescriptionCore{}, errors.E(err, op)
 	}
-	endDate, err := time.Parse("2006-01-02", end)
+
+	checked, err := s.withCatalogue([]schedules.PrescriptionCore{prescription})
 	if err != nil {
-		return dates, errors.E(err, op, errMessage, errors.KindBadRequest)
+		return schedules.PrescriptionCore{}, errors.E(err, op)
 	}
 
-	// add 1 day to include the end date
-	limit := endDate.AddDate(0, 0, 1)
+	amended := checked[0]
+	amended.ID = 0
+	amended.OutpatientID = existing.OutpatientID
+	amended.OriginalID = existing.OriginalID
+	amended.Version = existing.Version + 1
+	amended.Status = schedules.PrescriptionActive
+	amended.PrescribedBy = userId
+	amended.VoidedBy = 0
+	amended.VoidReason = ""
+	amended.VoidedAt = time.Time{}
 
-	for d := startDate; d.Before(limit); d = d.AddDate(0, 0, incrementDay) {
-		dates = append(dates, d.Format("2006-01-02"))
+	amended.ID, err = s.data.AmendPrescription(existing.ID, amended)
+	if err != nil {
+		return schedules.PrescriptionCore{}, errors.E(err, op)
 	}
 
-	return dates, nil
+	s.audit(op, userId, role, audits.EntityPrescription, amended.ID, existing, amended)
+	s.updateStock([]schedules.PrescriptionCore{existing}, []schedules.PrescriptionCore{amended})
+	return amended, nil
 }
 
-func (s *scheduleBusiness) getUniqueSchedules(outpatients []schedules.OutpatientCore) []schedules.WorkScheduleCore {
-	schedulesMap := make(map[int]schedules.WorkScheduleCore)
-	for i := range outpatients {
-		scheduleId := outpatients[i].WorkSchedule.ID
-		schedulesMap[scheduleId] = outpatients[i].WorkSchedule
-	}
+func (s *scheduleBusiness) VoidPrescription(prescriptionId int, reason string, userId int, role string) error {
+	const op errors.Op = "schedules.business.VoidPrescription"
+	var errMsg errors.ErrClientMessage = "Only the current version of an active prescription can be voided"
 
-	schedulesData := make([]schedules.WorkScheduleCore, len(schedulesMap))
-	i := 0
-	for _, schedule := range schedulesMap {
-		schedulesData[i] = schedule
-		i++
+	existing, err := s.data.SelectPrescriptionById(prescriptionId)
+	if err != nil {
+		return errors.E(err, op)
 	}
 
-	return schedulesData
-}
+	if existing.Status == schedules.PrescriptionDispensed {
+		errMsg = "Prescription has already been dispensed"
+	}
+	if existing.Status != schedules.PrescriptionActive {
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
 
-func (s *scheduleBusiness) getUniqueDoctorIds(ws []schedules.WorkScheduleCore) []int {
-	doctorsMap := make(map[int]bool)
-	for _, s := range ws {
-		doctorsMap[s.Doctor.ID] = true
+	if err = s.checkPrescriptionChange(existing, userId, role); err != nil {
+		return errors.E(err, op)
 	}
 
-	doctorIds := make([]int, len(doctorsMap))
-	i := 0
-	for key := range doctorsMap {
-		doctorIds[i] = key
-		i++
+	voided := existing
+	voided.Status = schedules.PrescriptionVoided
+	voided.VoidedBy = userId
+	voided.VoidReason = reason
+	voided.VoidedAt = time.Now().In(config.GetTimeLoc())
+
+	err = s.data.VoidPrescription(voided)
+	if err != nil {
+		return errors.E(err, op)
 	}
 
-	return doctorIds
+	s.audit(op, userId, role, audits.EntityPrescription, existing.ID, existing, voided)
+	s.updateStock([]schedules.PrescriptionCore{existing}, nil)
+	return nil
 }
 
-func (s *scheduleBusiness) getUniqueNurseIds(ws []schedules.WorkScheduleCore) []int {
-	nursesMap := make(map[int]bool)
-	for _, s := range ws {
-		nursesMap[s.Nurse.ID] = true
-	}
+func (s *scheduleBusiness) FindPrescriptionVersions(prescriptionId int, userId int, role string) ([]schedules.PrescriptionCore, error) {
+	const op errors.Op = "schedules.business.FindPrescriptionVersions"
+	var errMsg errors.ErrClientMessage = "You are not allowed to view this prescription"
 
-	nurseIds := make([]int, len(nursesMap))
-	i := 0
-	for key := range nursesMap {
-		nurseIds[i] = key
-		i++
+	prescription, err := s.data.SelectPrescriptionById(prescriptionId)
+	if err != nil {
+		return []schedules.PrescriptionCore{}, errors.E(err, op)
 	}
 
-	return nurseIds
-}
+	outpatient, err := s.data.SelectOutpatientById(prescription.OutpatientID)
+	if err != nil {
+		return []schedules.PrescriptionCore{}, errors.E(err, op)
+	}
 
-func (s *scheduleBusiness) getUniquePatientIds(outpatients []schedules.OutpatientCore) []int {
-	outpatientsMap := make(map[int]bool)
-	for _, o := range outpatients {
-		outpatientsMap[o.Patient.ID] = true
+	visible, err := s.canViewClinicalData(outpatient.WorkSchedule, outpatient.Patient.ID, userId, role)
+	if err != nil {
+		return []schedules.PrescriptionCore{}, errors.E(err, op)
+	}
+	if !visible {
+		return []schedules.PrescriptionCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnauthorized)
 	}
 
-	patientIds := make([]int, len(outpatientsMap))
-	i := 0
-	for key := range outpatientsMap {
-		patientIds[i] = key
-		i++
+	versions, err := s.data.SelectPrescriptionVersions(prescription.OriginalID)
+	if err != nil {
+		return []schedules.PrescriptionCore{}, errors.E(err, op)
 	}
 
-	return patientIds
+	err = s.recordAccess(op, userId, role, "", outpatient.Patient.ID)
+	if err != nil {
+		return []schedules.PrescriptionCore{}, errors.E(err, op)
+	}
+	return versions, nil
 }
 
-func (s *scheduleBusiness) findDoctorsData(ids []int) (map[int]schedules.DoctorCore, error) {
-	const op errors.Op = "schedules.business.findDoctorsData"
+func (s *scheduleBusiness) DispensePrescriptions(prescriptionIds []int, userId int, role string) error {
+	const op errors.Op = "schedules.business.DispensePrescriptions"
+	var errMsg errors.ErrClientMessage = "You are not allowed to dispense prescriptions"
 
-	doctorsMap := make(map[int]schedules.DoctorCore)
-	doctorsData, err := s.doctorBusiness.FindDoctorsByIds(ids)
+	_, err := s.permissionBusiness.Authorize(role, permissions.ActionDispensePrescriptions)
 	if err != nil {
-		return map[int]schedules.DoctorCore{}, errors.E(err, op)
+		return errors.E(err, op, errMsg)
 	}
 
-	for _, d := range doctorsData {
-		doctorsMap[d.ID] = schedules.DoctorCore{
-			ID:        d.ID,
-			Name:      d.Name,
-			Email:     d.Email,
-			Phone:     d.Phone,
-			Specialty: d.Speciality.Name,
-			BirthDate: d.BirthDate,
-			Gender:    d.Gender,
-			Room:      schedules.RoomCore{ID: d.Room.ID, Code: d.Room.Code, Floor: d.Room.Floor},
+	ids := []int{}
+	seen := map[int]bool{}
+	for _, id := range prescriptionIds {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
 		}
 	}
 
-	return doctorsMap, nil
+	prescriptions, err := s.data.SelectPrescriptionsByIds(ids)
+	if err != nil {
+		return errors.E(err, op)
+	}
+	if len(prescriptions) != len(ids) {
+		errMsg = "Prescription not found"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindNotFound)
+	}
+
+	for _, p := range prescriptions {
+		switch p.Status {
+		case schedules.PrescriptionActive:
+			continue
+		case schedules.PrescriptionDispensed:
+			errMsg = "Prescription has already been dispensed"
+		default:
+			errMsg = "Only the current version of an active prescription can be dispensed"
+		}
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	// marked first so that the prescriptions can't be amended or voided meanwhile
+	now := time.Now().In(config.GetTimeLoc())
+	err = s.data.DispensePrescriptions(ids, userId, now)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	requests := stockRequests(prescriptions)
+	if len(requests) > 0 {
+		err = s.medicineBusiness.DispenseStock(requests, userId)
+		if err != nil {
+			if revertErr := s.data.RevertDispensedPrescriptions(ids); revertErr != nil {
+				errors.Log(errors.E(revertErr, op))
+			}
+			return errors.E(err, op)
+		}
+	}
+
+	for _, p := range prescriptions {
+		dispensed := p
+		dispensed.Status = schedules.PrescriptionDispensed
+		dispensed.DispensedBy = userId
+		dispensed.DispensedAt = now
+		s.audit(op, userId, role, audits.EntityPrescription, p.ID, p, dispensed)
+	}
+	return nil
+}
+
+func (s *scheduleBusiness) RemoveOutpatientById(outpatientId int, userId int, role string) error {
+	const op errors.Op = "schedules.business.RemoveOutpatientById"
+	var errMsg errors.ErrClientMessage
+
+	existingOutpatient, err := s.data.SelectOutpatientById(outpatientId)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	if existingOutpatient.Status == schedules.StatusOnprogress {
+		errMsg = "Cannot remove outpatient that is on progress"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	err = s.data.DeleteOutpatientById(outpatientId)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	s.audit(op, userId, role, audits.EntityOutpatient, outpatientId, existingOutpatient, nil)
+
+	// amended and voided versions gave their stock back already, dispensed ones took it
+	held := []schedules.PrescriptionCore{}
+	for _, p := range existingOutpatient.Prescriptions {
+		if p.Status == schedules.PrescriptionActive {
+			held = append(held, p)
+		}
+	}
+	s.updateStock(held, nil)
+	return nil
+}
+
+func (s *scheduleBusiness) RemovePatientWaitingOutpatients(patientId int) error {
+	const op errors.Op = "schedules.business.RemovePatientWaitingOutpatients"
+
+	err := s.data.DeleteWaitingOutpatientsByPatientId(patientId)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	return nil
+}
+
+func (s *scheduleBusiness) MarkNoShows() (int, error) {
+	const op errors.Op = "schedules.business.MarkNoShows"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	now := time.Now().In(config.GetTimeLoc())
+	ws, err := s.data.SelectWaitingWorkSchedulesUntil(now.Format("2006-01-02"))
+	if err != nil {
+		return 0, errors.E(err, op)
+	}
+
+	noShows := []schedules.OutpatientCore{}
+	for i := range ws {
+		end, err := time.ParseInLocation("2006-01-02T15:04:05", fmt.Sprintf("%sT%s", ws[i].Date, ws[i].EndTime), config.GetTimeLoc())
+		if err != nil {
+			return 0, errors.E(err, op, errMsg, errors.KindServerError)
+		}
+
+		if now.Before(end.Add(schedules.EXAMINE_OFFSET)) {
+			continue
+		}
+		noShows = append(noShows, ws[i].Outpatients...)
+	}
+
+	if len(noShows) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]int, len(noShows))
+	for i := range noShows {
+		ids[i] = noShows[i].ID
+	}
+
+	err = s.data.UpdateOutpatientsStatus(ids, schedules.StatusWaiting, schedules.StatusNoShow)
+	if err != nil {
+		return 0, errors.E(err, op)
+	}
+
+	for _, o := range noShows {
+		after := o
+		after.Status = schedules.StatusNoShow
+		s.audit(op, 0, audits.ActorSystem, audits.EntityOutpatient, o.ID, o, after)
+	}
+	return len(noShows), nil
+}
+
+func (s *scheduleBusiness) FindNoShowCounts(patientIds []int) (map[int]int, error) {
+	const op errors.Op = "schedules.business.FindNoShowCounts"
+
+	if len(patientIds) == 0 {
+		return map[int]int{}, nil
+	}
+
+	counts, err := s.data.SelectNoShowCounts(patientIds)
+	if err != nil {
+		return map[int]int{}, errors.E(err, op)
+	}
+	return counts, nil
+}
+
+func (s *scheduleBusiness) SendVisitReminders() (int, error) {
+	const op errors.Op = "schedules.business.SendVisitReminders"
+
+	if s.notifier == nil {
+		return 0, nil
+	}
+
+	tomorrow := time.Now().In(config.GetTimeLoc()).AddDate(0, 0, 1).Format("2006-01-02")
+	ws, err := s.data.SelectWorkSchedulesByDates([]string{tomorrow})
+	if err != nil {
+		return 0, errors.E(err, op)
+	}
+
+	outpatients := []schedules.OutpatientCore{}
+	for _, w := range ws {
+		for _, o := range w.Outpatients {
+			o.WorkSchedule = w
+			o.WorkSchedule.Outpatients = nil
+			outpatients = append(outpatients, o)
+		}
+	}
+
+	s.notify(schedules.NotifyReminder, outpatients...)
+	return len(outpatients), nil
+}
+
+// Private methods
+
+// audit records a change on a work schedule or an outpatient
+func (s *scheduleBusiness) audit(op errors.Op, actorId int, actorRole string, entity string, entityId int, before interface{}, after interface{}) {
+	s.auditBusiness.Record(audits.AuditLogCore{
+		ActorID:   actorId,
+		ActorRole: actorRole,
+		Operation: string(op),
+		Entity:    entity,
+		EntityID:  entityId,
+		Before:    before,
+		After:     after,
+	})
+}
+
+// notify hands outpatients to the notifier, if any
+func (s *scheduleBusiness) notify(event string, outpatients ...schedules.OutpatientCore) {
+	if s.notifier == nil || len(outpatients) == 0 {
+		return
+	}
+	s.notifier.NotifyOutpatients(event, outpatients)
+}
+
+// findWaitingToRemove lists the waiting outpatients deleted along with the work schedules,
+// they are looked up beforehand so their patients and displays can be told afterwards
+func (s *scheduleBusiness) findWaitingToRemove(ws []schedules.WorkScheduleCore) ([]schedules.OutpatientCore, error) {
+	const op errors.Op = "schedules.business.findWaitingToRemove"
+
+	if len(ws) == 0 || (s.notifier == nil && s.queueHub.idle()) {
+		return []schedules.OutpatientCore{}, nil
+	}
+
+	byId := make(map[int]schedules.WorkScheduleCore)
+	ids := make([]int, len(ws))
+	for i := range ws {
+		byId[ws[i].ID] = ws[i]
+		ids[i] = ws[i].ID
+	}
+
+	waiting, err := s.data.SelectWaitingOutpatientsByWorkScheduleIds(ids)
+	if err != nil {
+		return []schedules.OutpatientCore{}, errors.E(err, op)
+	}
+	for i := range waiting {
+		waiting[i].WorkSchedule = byId[waiting[i].WorkSchedule.ID]
+	}
+	return waiting, nil
+}
+
+// announceRemoved tells patients and queue displays about waiting outpatients removed with
+// their work schedule
+func (s *scheduleBusiness) announceRemoved(waiting []schedules.OutpatientCore) {
+	for _, o := range waiting {
+		s.publishQueueEvent(schedules.QueueEventCanceled, o)
+	}
+	s.notify(schedules.NotifyRemoved, waiting...)
+}
+
+// cancelOutpatient cancels a waiting outpatient, audited as caller
+func (s *scheduleBusiness) cancelOutpatient(caller errors.Op, outpatient schedules.OutpatientCore, userId int, role string) error {
+	const op errors.Op = "schedules.business.cancelOutpatient"
+
+	before := outpatient
+	outpatient.Status = schedules.StatusCanceled
+	err := s.data.UpdateOutpatient(outpatient)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	s.audit(caller, userId, role, audits.EntityOutpatient, outpatient.ID, before, outpatient)
+	s.publishQueueEvent(schedules.QueueEventCanceled, outpatient)
+	s.notify(schedules.NotifyCanceled, outpatient)
+	return nil
+}
+
+// checkPrescriptionChange makes sure the outpatient of prescription is finished and
+// user is its doctor
+func (s *scheduleBusiness) checkPrescriptionChange(prescription schedules.PrescriptionCore, userId int, role string) error {
+	const op errors.Op = "schedules.business.checkPrescriptionChange"
+	var errMsg errors.ErrClientMessage
+
+	outpatient, err := s.data.SelectOutpatientById(prescription.OutpatientID)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	if outpatient.Status != schedules.StatusFinished {
+		errMsg = "Prescriptions can only be changed once the outpatient is finished"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	errMsg = "Only doctor of this outpatient work schedule can change its prescriptions"
+	if err = s.authorize(outpatient.WorkSchedule, userId, role, permissions.ActionManagePrescriptions, errMsg); err != nil {
+		return errors.E(err, op)
+	}
+	return nil
+}
+
+// authorize checks the role against permission matrix. When the role is only granted
+// to its own records, user must be the doctor or nurse of the work schedule.
+func (s *scheduleBusiness) authorize(ws schedules.WorkScheduleCore, userId int, role string, action string, errMsg errors.ErrClientMessage) error {
+	const op errors.Op = "schedules.business.authorize"
+
+	scope, err := s.permissionBusiness.Authorize(role, action)
+	if err != nil {
+		return errors.E(err, op, errMsg)
+	}
+
+	if scope == permissions.ScopeAll {
+		return nil
+	}
+
+	switch {
+	case role == permissions.RoleDoctor && userId == ws.Doctor.ID:
+		return nil
+	case role == permissions.RoleNurse && userId == ws.Nurse.ID:
+		return nil
+	}
+	return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnauthorized)
+}
+
+// checkConflicts rejects a work schedule that overlaps another one of the same doctor, nurse
+// or room (the doctor's room). Every conflicting id is listed in the error payload.
+// excludeIds are the work schedules being edited, they can not conflict with themselves.
+func (s *scheduleBusiness) checkConflicts(ws schedules.WorkScheduleCore, doctor doctors.DoctorCore, dates []string, excludeIds []int) error {
+	const op errors.Op = "schedules.business.checkConflicts"
+	var errMsg errors.ErrClientMessage = "Work schedule overlaps with other work schedules"
+
+	doctorIds := []int{doctor.ID}
+	roomDoctors := make(map[int]bool)
+	if doctor.Room.ID != 0 {
+		doctorsInRoom, err := s.doctorBusiness.FindDoctorsByRoomId(doctor.Room.ID)
+		if err != nil {
+			return errors.E(err, op)
+		}
+		for _, d := range doctorsInRoom {
+			if d.ID != doctor.ID {
+				doctorIds = append(doctorIds, d.ID)
+				roomDoctors[d.ID] = true
+			}
+		}
+	}
+
+	conflicts, err := s.data.SelectConflictingWorkSchedules(schedules.ConflictQuery{
+		Dates:      dates,
+		StartTime:  ws.StartTime,
+		EndTime:    ws.EndTime,
+		DoctorIDs:  doctorIds,
+		NurseID:    ws.Nurse.ID,
+		ExcludeIDs: excludeIds,
+	})
+	if err != nil {
+		return errors.E(err, op)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(conflicts))
+	doctorConflicts, nurseConflicts, roomConflicts := []int{}, []int{}, []int{}
+	for i, c := range conflicts {
+		ids[i] = c.ID
+		switch {
+		case c.Doctor.ID == doctor.ID:
+			doctorConflicts = append(doctorConflicts, c.ID)
+		case roomDoctors[c.Doctor.ID]:
+			roomConflicts = append(roomConflicts, c.ID)
+		}
+		if c.Nurse.ID == ws.Nurse.ID {
+			nurseConflicts = append(nurseConflicts, c.ID)
+		}
+	}
+
+	payload := errors.ErrPayload{
+		Data: map[string]interface{}{
+			"workScheduleIds": ids,
+			"doctor":          doctorConflicts,
+			"nurse":           nurseConflicts,
+			"room":            roomConflicts,
+		},
+	}
+	return errors.E(errors.New(string(errMsg)), op, errMsg, payload, errors.KindUnprocessable)
+}
+
+// checkNotEnded rejects a work schedule whose end time has passed
+func (s *scheduleBusiness) checkNotEnded(ws schedules.WorkScheduleCore, errMsg errors.ErrClientMessage) error {
+	const op errors.Op = "schedules.business.checkNotEnded"
+
+	currentTime := time.Now().In(config.GetTimeLoc())
+
+	layout := "2006-01-02T15:04:05"
+	value := fmt.Sprintf("%sT%s", ws.Date, ws.EndTime)
+
+	workScheduleTime, err := time.ParseInLocation(layout, value, config.GetTimeLoc())
+	if err != nil {
+		errMsg = "Something went wrong"
+		return errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	// current time = 2021-10-10 19:30:00
+	// work schedule time = 2021-10-10 19:30:00
+	// it's considered that current time IS AFTER work schedule
+	if currentTime.After(workScheduleTime) {
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+	return nil
+}
+
+// estimateQueue counts who is ahead of a waiting outpatient and estimates when they are
+// examined from the doctor's average, the queue starts moving when the work schedule starts
+func (s *scheduleBusiness) estimateQueue(outpatient schedules.OutpatientCore) (schedules.QueueCore, error) {
+	const op errors.Op = "schedules.business.estimateQueue"
+
+	ws := outpatient.WorkSchedule
+	queue := schedules.QueueCore{Outpatient: outpatient}
+
+	ahead, err := s.data.SelectCountOutpatientsAhead(ws.ID, outpatient.QueueNumber, outpatient.ID)
+	if err != nil {
+		return schedules.QueueCore{}, errors.E(err, op)
+	}
+	queue.WaitingAhead = ahead
+	queue.Position = ahead + 1
+
+	average, samples, err := s.data.SelectAverageExaminationTime(ws.Doctor.ID)
+	if err != nil {
+		return schedules.QueueCore{}, errors.E(err, op)
+	}
+	queue.AverageDuration = schedules.DEFAULT_EXAMINATION_TIME
+	if samples > 0 && average > 0 {
+		queue.AverageDuration = average.Round(time.Second)
+		queue.Historical = true
+	}
+	queue.EstimatedWait = time.Duration(ahead) * queue.AverageDuration
+
+	from := time.Now().In(config.GetTimeLoc())
+	start, err := time.ParseInLocation("2006-01-02T15:04:05", fmt.Sprintf("%sT%s", ws.Date, ws.StartTime), config.GetTimeLoc())
+	if err == nil && start.After(from) {
+		from = start
+	}
+	queue.EstimatedTime = from.Add(queue.EstimatedWait)
+	return queue, nil
+}
+
+// publishQueueEvent pushes the change of outpatient to displays of its work schedule and
+// room. Nothing is looked up while nobody listens, a failed lookup only leaves a field out.
+func (s *scheduleBusiness) publishQueueEvent(eventType string, outpatient schedules.OutpatientCore) {
+	if s.queueHub.idle() {
+		return
+	}
+
+	event := schedules.QueueEventCore{
+		Type:           eventType,
+		WorkScheduleID: outpatient.WorkSchedule.ID,
+		OutpatientID:   outpatient.ID,
+		QueueNumber:    outpatient.QueueNumber,
+		Status:         outpatient.Status,
+		At:             time.Now().In(config.GetTimeLoc()),
+	}
+
+	if patient, err := s.patientBusiness.FindPatientById(outpatient.Patient.ID); err == nil {
+		event.PatientName = patient.Name
+	}
+	if doctor, err := s.doctorBusiness.FindDoctorById(outpatient.WorkSchedule.Doctor.ID); err == nil {
+		event.RoomID = doctor.Room.ID
+	}
+
+	s.queueHub.publish(workScheduleTopic(event.WorkScheduleID), event)
+	if event.RoomID != 0 {
+		s.queueHub.publish(roomTopic(event.RoomID), event)
+	}
+}
+
+// publishQueueEventById reloads the outpatient first, e.g. for its new queue number
+func (s *scheduleBusiness) publishQueueEventById(eventType string, outpatientId int) {
+	if s.queueHub.idle() {
+		return
+	}
+
+	outpatient, err := s.data.SelectOutpatientById(outpatientId)
+	if err != nil {
+		return
+	}
+	s.publishQueueEvent(eventType, outpatient)
+}
+
+// checkMoveTarget finds the work schedule outpatients of source are moved to, it must be
+// another one that hasn't ended and whose doctor has the same speciality
+func (s *scheduleBusiness) checkMoveTarget(source schedules.WorkScheduleCore, targetId int) (schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.business.checkMoveTarget"
+	var errMsg errors.ErrClientMessage
+
+	if source.ID == targetId {
+		errMsg = "Outpatients are already on this work schedule"
+		return schedules.WorkScheduleCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	target, err := s.data.SelectWorkScheduleById(targetId)
+	if err != nil {
+		return schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+
+	errMsg = "Cannot move outpatients to this workschedule, because it has ended"
+	if err = s.checkNotEnded(target, errMsg); err != nil {
+		return schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+
+	if target.LeaveID != 0 {
+		errMsg = "Cannot move outpatients to a work schedule whose staff is on leave"
+		return schedules.WorkScheduleCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	errMsg = "Outpatients can only be moved to a doctor with the same speciality"
+	if err = s.checkSameSpeciality(source.Doctor.ID, target.Doctor.ID, errMsg); err != nil {
+		return schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+	return target, nil
+}
+
+// checkSameSpeciality fails with errMsg when the doctors practise different specialities
+func (s *scheduleBusiness) checkSameSpeciality(doctorId int, otherDoctorId int, errMsg errors.ErrClientMessage) error {
+	const op errors.Op = "schedules.business.checkSameSpeciality"
+
+	if doctorId == otherDoctorId {
+		return nil
+	}
+
+	doctor, err := s.doctorBusiness.FindDoctorById(doctorId)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	otherDoctor, err := s.doctorBusiness.FindDoctorById(otherDoctorId)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	if doctor.Speciality.ID != otherDoctor.Speciality.ID {
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+	return nil
+}
+
+// moveOutpatients moves waiting outpatients to target and keeps the move on each of them,
+// audited as caller
+func (s *scheduleBusiness) moveOutpatients(caller errors.Op, outpatients []schedules.OutpatientCore, target schedules.WorkScheduleCore, reason string, userId int, role string) error {
+	const op errors.Op = "schedules.business.moveOutpatients"
+
+	if len(outpatients) == 0 {
+		return nil
+	}
+
+	moves := make([]schedules.OutpatientMoveCore, len(outpatients))
+	for i, o := range outpatients {
+		moves[i] = schedules.OutpatientMoveCore{
+			OutpatientID:       o.ID,
+			FromWorkScheduleID: o.WorkSchedule.ID,
+			ToWorkScheduleID:   target.ID,
+			Reason:             reason,
+			MovedBy:            userId,
+			MovedByRole:        role,
+		}
+	}
+
+	moves, err := s.placeMoves(target, moves)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = s.data.MoveOutpatients(moves)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	moved := make([]schedules.OutpatientCore, len(outpatients))
+	for i, o := range outpatients {
+		after := o
+		after.WorkSchedule = target
+		after.Moves = append(o.Moves, moves[i])
+		s.audit(caller, userId, role, audits.EntityOutpatient, o.ID, o, after)
+
+		s.publishQueueEvent(schedules.QueueEventMovedOut, o)
+		s.publishQueueEventById(schedules.QueueEventMovedIn, o.ID)
+
+		// they join the end of the queue
+		after.QueueNumber = 0
+		after.SlotTime = moves[i].SlotTime
+		after.Overbooked = moves[i].Overbooked
+		moved[i] = after
+	}
+	s.notify(schedules.NotifyRescheduled, moved...)
+	return nil
+}
+
+// checkShiftConflicts checks work schedules that may differ from each other (edited one
+// by one), once per distinct shift
+func (s *scheduleBusiness) checkShiftConflicts(workSchedules []schedules.WorkScheduleCore, excludeIds []int) error {
+	const op errors.Op = "schedules.business.checkShiftConflicts"
+
+	type shift struct {
+		doctorId  int
+		nurseId   int
+		startTime string
+		endTime   string
+	}
+	shifts := make(map[shift][]string)
+	for _, ws := range workSchedules {
+		key := shift{ws.Doctor.ID, ws.Nurse.ID, ws.StartTime, ws.EndTime}
+		shifts[key] = append(shifts[key], ws.Date)
+	}
+
+	for key, dates := range shifts {
+		doctor, err := s.doctorBusiness.FindDoctorById(key.doctorId)
+		if err != nil {
+			return errors.E(err, op)
+		}
+
+		ws := schedules.WorkScheduleCore{StartTime: key.startTime, EndTime: key.endTime}
+		ws.Nurse.ID = key.nurseId
+		err = s.checkConflicts(ws, doctor, dates, excludeIds)
+		if err != nil {
+			return errors.E(err, op)
+		}
+	}
+	return nil
+}
+
+// selectSeries returns the occurrences of group within scope, counted from workScheduleId
+func (s *scheduleBusiness) selectSeries(group string, workScheduleId int, scope string) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.business.selectSeries"
+	var errMsg errors.ErrClientMessage
+
+	series, err := s.data.SelectWorkSchedulesByGroup(group)
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+	if len(series) == 0 {
+		errMsg = "Work schedule series not found"
+		return []schedules.WorkScheduleCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindNotFound)
+	}
+
+	if scope == schedules.SeriesAll {
+		return series, nil
+	}
+
+	from := -1
+	for i := range series {
+		if series[i].ID == workScheduleId {
+			from = i
+			break
+		}
+	}
+	if from == -1 {
+		errMsg = "Work schedule is not part of the series"
+		return []schedules.WorkScheduleCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindNotFound)
+	}
+
+	switch scope {
+	case schedules.SeriesThis:
+		return series[from : from+1], nil
+	case schedules.SeriesFollowing:
+		// series is ordered by date, occurrences on the same date come after by start time
+		return series[from:], nil
+	}
+
+	errMsg = "Invalid series scope"
+	return []schedules.WorkScheduleCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindBadRequest)
+}
+
+// skipExamined leaves out occurrences with on progress, finished or no-show outpatients,
+// their records must stay as they were. It fails when every occurrence is skipped.
+func (s *scheduleBusiness) skipExamined(ws []schedules.WorkScheduleCore) ([]schedules.WorkScheduleCore, []int, error) {
+	const op errors.Op = "schedules.business.skipExamined"
+	var errMsg errors.ErrClientMessage = "Work schedule already has examined outpatients"
+
+	result, err := s.skipStarted(ws)
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, []int{}, errors.E(err, op)
+	}
+
+	kept := make(map[int]bool)
+	for i := range result {
+		kept[result[i].ID] = true
+	}
+
+	skippedIds := []int{}
+	for i := range ws {
+		if !kept[ws[i].ID] {
+			skippedIds = append(skippedIds, ws[i].ID)
+		}
+	}
+
+	if len(result) == 0 {
+		payload := errors.ErrPayload{Data: map[string]interface{}{"skippedIds": skippedIds}}
+		return []schedules.WorkScheduleCore{}, []int{}, errors.E(errors.New(string(errMsg)), op, errMsg, payload, errors.KindUnprocessable)
+	}
+	return result, skippedIds, nil
+}
+
+// skipStarted leaves out occurrences whose outpatients are already being or have been examined,
+// the staff who started the shift keeps it. Unlike skipExamined it never fails on an empty result.
+func (s *scheduleBusiness) skipStarted(ws []schedules.WorkScheduleCore) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.business.skipStarted"
+
+	ids := make([]int, len(ws))
+	for i := range ws {
+		ids[i] = ws[i].ID
+	}
+
+	examined, err := s.data.SelectCountWorkSchedulesOutpatients(ids, []int{schedules.StatusOnprogress, schedules.StatusFinished, schedules.StatusNoShow})
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op)
+	}
+
+	result := []schedules.WorkScheduleCore{}
+	for i := range ws {
+		if examined[ws[i].ID] == 0 {
+			result = append(result, ws[i])
+		}
+	}
+	return result, nil
+}
+
+// canViewClinicalData tells whether the reader is in the care team: the doctor or nurse of
+// the work schedule, or of any outpatient of the patient when patientId is given.
+func (s *scheduleBusiness) canViewClinicalData(ws schedules.WorkScheduleCore, patientId int, userId int, role string) (bool, error) {
+	const op errors.Op = "schedules.business.canViewClinicalData"
+
+	// patients always see their own
+	if role == permissions.RolePatient {
+		return patientId != 0 && userId == patientId, nil
+	}
+
+	scope, err := s.permissionBusiness.Authorize(role, permissions.ActionViewClinicalData)
+	if err != nil {
+		return false, nil // not granted at all, other staff only get the redacted view
+	}
+
+	switch {
+	case scope == permissions.ScopeAll:
+		return true, nil
+	case role == permissions.RoleDoctor && userId == ws.Doctor.ID:
+		return true, nil
+	case role == permissions.RoleNurse && userId == ws.Nurse.ID:
+		return true, nil
+	case patientId == 0:
+		return false, nil
+	}
+
+	doctorId, nurseId := 0, 0
+	switch role {
+	case permissions.RoleDoctor:
+		doctorId = userId
+	case permissions.RoleNurse:
+		nurseId = userId
+	default:
+		return false, nil
+	}
+
+	total, err := s.data.SelectCountCareTeamOutpatients(patientId, doctorId, nurseId)
+	if err != nil {
+		return false, errors.E(err, op)
+	}
+	return total > 0, nil
+}
+
+// breakGlass tells whether a redacted view is lifted by the reader's reason.
+// It is only taken when needed so that every break glass in the access log is meaningful.
+func (s *scheduleBusiness) breakGlass(visible bool, role string, reason string) (bool, error) {
+	const op errors.Op = "schedules.business.breakGlass"
+	var errMsg errors.ErrClientMessage = "You are not allowed to break glass on clinical data"
+
+	if visible || reason == "" {
+		return false, nil
+	}
+
+	_, err := s.permissionBusiness.Authorize(role, permissions.ActionBreakGlass)
+	if err != nil {
+		return false, errors.E(err, op, errMsg)
+	}
+	return true, nil
+}
+
+// redactClinicalData hides complaint, diagnosis and prescriptions, the schedule is kept
+func (s *scheduleBusiness) redactClinicalData(outpatient schedules.OutpatientCore) schedules.OutpatientCore {
+	outpatient.Complaint = ""
+	outpatient.Diagnosis = ""
+	outpatient.Prescriptions = nil
+	outpatient.Redacted = true
+	return outpatient
+}
+
+// recordAccess logs a read of patient data, the read fails when it can not be logged.
+// A non empty breakGlassReason marks the read as a break glass.
+func (s *scheduleBusiness) recordAccess(op errors.Op, userId int, role string, breakGlassReason string, patientIds ...int) error {
+	logs := make([]audits.AccessLogCore, len(patientIds))
+	for i := range patientIds {
+		logs[i] = audits.AccessLogCore{
+			ReaderID:   userId,
+			ReaderRole: role,
+			PatientID:  patientIds[i],
+			Operation:  string(op),
+			BreakGlass: breakGlassReason != "",
+			Reason:     breakGlassReason,
+		}
+	}
+	return s.auditBusiness.RecordAccess(logs)
+}
+
+// generateDates expands the repeat of q from StartDate to EndDate. EndDate can be left
+// empty when the rule ends by itself (COUNT or UNTIL).
+func (s *scheduleBusiness) generateDates(q schedules.ScheduleQuery) ([]string, error) {
+	const op errors.Op = "schedules.business.generateDates"
+	var errMessage errors.ErrClientMessage = "Invalid date format"
+
+	value := q.Repeat
+	if named, ok := schedules.RepeatRules[q.Repeat]; ok {
+		value = named
+	}
+
+	rule, err := recurrence.Parse(value)
+	if err != nil {
+		return []string{}, errors.E(err, op)
+	}
+
+	startDate, err := time.Parse(recurrence.DATE_LAYOUT, q.StartDate)
+	if err != nil {
+		return []string{}, errors.E(err, op, errMessage, errors.KindBadRequest)
+	}
+
+	var endDate time.Time
+	if q.EndDate != "" {
+		endDate, err = time.Parse(recurrence.DATE_LAYOUT, q.EndDate)
+		if err != nil {
+			return []string{}, errors.E(err, op, errMessage, errors.KindBadRequest)
+		}
+	} else if !rule.Bounded() {
+		errMessage = "End date is required when repeat has no COUNT or UNTIL"
+		return []string{}, errors.E(errors.New(string(errMessage)), op, errMessage, errors.KindBadRequest)
+	}
+
+	excludeDates := make([]time.Time, len(q.ExcludeDates))
+	for i := range q.ExcludeDates {
+		excludeDates[i], err = time.Parse(recurrence.DATE_LAYOUT, q.ExcludeDates[i])
+		if err != nil {
+			return []string{}, errors.E(err, op, errMessage, errors.KindBadRequest)
+		}
+	}
+
+	generated := rule.Dates(startDate, endDate, excludeDates)
+	if len(generated) == 0 {
+		errMessage = "Repeat does not produce any date"
+		return []string{}, errors.E(errors.New(string(errMessage)), op, errMessage, errors.KindUnprocessable)
+	}
+
+	dates := make([]string, len(generated))
+	for i := range generated {
+		dates[i] = generated[i].Format(recurrence.DATE_LAYOUT)
+	}
+	return dates, nil
+}
+
+// skipClosedDates leaves out dates the clinic is closed on, a schedule that would
+// only fall on closed dates is rejected
+func (s *scheduleBusiness) skipClosedDates(dates []string) ([]string, error) {
+	const op errors.Op = "schedules.business.skipClosedDates"
+	var errMessage errors.ErrClientMessage = "Clinic is closed on every date of the work schedule"
+
+	closed, err := s.closureBusiness.FindClosedDates(dates)
+	if err != nil {
+		return []string{}, errors.E(err, op)
+	}
+
+	openDates := []string{}
+	for _, date := range dates {
+		if !closed[date] {
+			openDates = append(openDates, date)
+		}
+	}
+
+	if len(openDates) == 0 {
+		payload := errors.ErrPayload{Data: map[string]interface{}{"closedDates": dates}}
+		return []string{}, errors.E(errors.New(string(errMessage)), op, errMessage, payload, errors.KindUnprocessable)
+	}
+	return openDates, nil
+}
+
+func (s *scheduleBusiness) getUniqueSchedules(outpatients []schedules.OutpatientCore) []schedules.WorkScheduleCore {
+	schedulesMap := make(map[int]schedules.WorkScheduleCore)
+	for i := range outpatients {
+		scheduleId := outpatients[i].WorkSchedule.ID
+		schedulesMap[scheduleId] = outpatients[i].WorkSchedule
+	}
+
+	schedulesData := make([]schedules.WorkScheduleCore, len(schedulesMap))
+	i := 0
+	for _, schedule := range schedulesMap {
+		schedulesData[i] = schedule
+		i++
+	}
+
+	return schedulesData
+}
+
+func (s *scheduleBusiness) getUniqueDoctorIds(ws []schedules.WorkScheduleCore) []int {
+	doctorsMap := make(map[int]bool)
+	for _, s := range ws {
+		doctorsMap[s.Doctor.ID] = true
+	}
+
+	doctorIds := make([]int, len(doctorsMap))
+	i := 0
+	for key := range doctorsMap {
+		doctorIds[i] = key
+		i++
+	}
+
+	return doctorIds
+}
+
+func (s *scheduleBusiness) getUniqueNurseIds(ws []schedules.WorkScheduleCore) []int {
+	nursesMap := make(map[int]bool)
+	for _, s := range ws {
+		nursesMap[s.Nurse.ID] = true
+	}
+
+	nurseIds := make([]int, len(nursesMap))
+	i := 0
+	for key := range nursesMap {
+		nurseIds[i] = key
+		i++
+	}
+
+	return nurseIds
+}
+
+func (s *scheduleBusiness) getUniquePatientIds(outpatients []schedules.OutpatientCore) []int {
+	outpatientsMap := make(map[int]bool)
+	for _, o := range outpatients {
+		outpatientsMap[o.Patient.ID] = true
+	}
+
+	patientIds := make([]int, len(outpatientsMap))
+	i := 0
+	for key := range outpatientsMap {
+		patientIds[i] = key
+		i++
+	}
+
+	return patientIds
+}
+
+func (s *scheduleBusiness) findDoctorsData(ids []int) (map[int]schedules.DoctorCore, error) {
+	const op errors.Op = "schedules.business.findDoctorsData"
+
+	doctorsMap := make(map[int]schedules.DoctorCore)
+	doctorsData, err := s.doctorBusiness.FindDoctorsByIds(ids)
+	if err != nil {
+		return map[int]schedules.DoctorCore{}, errors.E(err, op)
+	}
+
+	for _, d := range doctorsData {
+		doctorsMap[d.ID] = toDoctorCore(d)
+	}
+
+	return doctorsMap, nil
+}
+
+func toDoctorCore(d doctors.DoctorCore) schedules.DoctorCore {
+	return schedules.DoctorCore{
+		ID:        d.ID,
+		Name:      d.Name,
+		Email:     d.Email,
+		Phone:     d.Phone,
+		Specialty: d.Speciality.Name,
+		BirthDate: d.BirthDate,
+		Gender:    d.Gender,
+		Room:      schedules.RoomCore{ID: d.Room.ID, Code: d.Room.Code, Floor: d.Room.Floor},
+	}
 }
 
 func (s *scheduleBusiness) findNursesData(ids []int) (map[int]schedules.NurseCore, error) {
@@ -1,6 +1,7 @@
 package business_test
 
 import (
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -8,19 +9,25 @@ import (
 	"github.com/final-project-alterra/hospital-management-system-api/config"
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
 
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
 	d "github.com/final-project-alterra/hospital-management-system-api/features/doctors"
+	md "github.com/final-project-alterra/hospital-management-system-api/features/medicines"
 	n "github.com/final-project-alterra/hospital-management-system-api/features/nurses"
 
 	p "github.com/final-project-alterra/hospital-management-system-api/features/patients"
 	s "github.com/final-project-alterra/hospital-management-system-api/features/schedules"
 
+	aum "github.com/final-project-alterra/hospital-management-system-api/features/audits/mocks"
+	cm "github.com/final-project-alterra/hospital-management-system-api/features/closures/mocks"
 	dm "github.com/final-project-alterra/hospital-management-system-api/features/doctors/mocks"
+	mdm "github.com/final-project-alterra/hospital-management-system-api/features/medicines/mocks"
 	nm "github.com/final-project-alterra/hospital-management-system-api/features/nurses/mocks"
 	pm "github.com/final-project-alterra/hospital-management-system-api/features/patients/mocks"
 	sm "github.com/final-project-alterra/hospital-management-system-api/features/schedules/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	permissionBusiness "github.com/final-project-alterra/hospital-management-system-api/features/permissions/business"
 	sb "github.com/final-project-alterra/hospital-management-system-api/features/schedules/business"
 )
 
@@ -31,6 +38,11 @@ var (
 	doctorBusiness  dm.IBusiness
 	nurseBusiness   nm.IBusiness
 	patientBusiness pm.IBusiness
+	auditBusiness   aum.IBusiness
+	closureBusiness cm.IBusiness
+	notifier        sm.INotifier
+
+	medicineBusiness mdm.IBusiness
 
 	// emptyPrescription s.PrescriptionCore
 	// emptyOutpatient   s.OutpatientCore
@@ -70,8 +82,16 @@ func TestMain(m *testing.M) {
 		SetDoctorBusiness(&doctorBusiness).
 		SetNurseBusiness(&nurseBusiness).
 		SetPatientBusiness(&patientBusiness).
+		SetClosureBusiness(&closureBusiness).
+		SetMedicineBusiness(&medicineBusiness).
+		SetPermissionBusiness(permissionBusiness.NewPermissionBusinessBuilder().Build()).
+		SetAuditBusiness(&auditBusiness).
+		SetNotifier(&notifier).
 		Build()
 
+	auditBusiness.On("Record", mock.AnythingOfType("audits.AuditLogCore")).Return()
+	notifier.On("NotifyOutpatients", mock.AnythingOfType("string"), mock.AnythingOfType("[]schedules.OutpatientCore")).Return()
+
 	doctorCore1 = d.DoctorCore{ID: 1}
 	nurseCore1 = n.NurseCore{ID: 1}
 	patientCore1 = p.PatientCore{ID: 1}
@@ -109,6 +129,31 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+// patientAccessLogs is what doctor1 reading patient1 through op is logged as
+func patientAccessLogs(op string) []audits.AccessLogCore {
+	return []audits.AccessLogCore{{
+		ReaderID:   doctor1.ID,
+		ReaderRole: "doctor",
+		PatientID:  patient1.ID,
+		Operation:  op,
+	}}
+}
+
+// notified matches outpatients handed to the notifier by their ids
+func notified(ids ...int) interface{} {
+	return mock.MatchedBy(func(outpatients []s.OutpatientCore) bool {
+		if len(outpatients) != len(ids) {
+			return false
+		}
+		for i := range outpatients {
+			if outpatients[i].ID != ids[i] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
 func TestFindWorkSchedules(t *testing.T) {
 	t.Run("valid - everything is fine", func(t *testing.T) {
 		repo.
@@ -324,17 +369,289 @@ func TestCreateWorkSchedule(t *testing.T) {
 			Return(nurseCore1, nil).
 			Once()
 
+		closureBusiness.
+			On("FindClosedDates", any).
+			Return(map[string]bool{}, nil).
+			Once()
+
+		repo.
+			On("SelectConflictingWorkSchedules", any).
+			Return([]s.WorkScheduleCore{}, nil).
+			Once()
+
 		repo.
 			On("InsertWorkSchedules", any).
-			Return(errServer).
+			Return([]int{}, errServer).
+			Once()
+
+		q := s.ScheduleQuery{Repeat: s.RepeatNoRepeat}
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - slot capacity defaults to one", func(t *testing.T) {
+		slotted := workSchedule1
+		slotted.SlotMinutes = 15
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		closureBusiness.
+			On("FindClosedDates", any).
+			Return(map[string]bool{}, nil).
+			Once()
+
+		repo.
+			On("SelectConflictingWorkSchedules", any).
+			Return([]s.WorkScheduleCore{}, nil).
+			Once()
+
+		repo.
+			On("InsertWorkSchedules", mock.MatchedBy(func(ws []s.WorkScheduleCore) bool {
+				return len(ws) == 1 && ws[0].SlotMinutes == 15 && ws[0].SlotCapacity == 1
+			})).
+			Return([]int{1}, nil).
+			Once()
+
+		q := s.ScheduleQuery{Repeat: s.RepeatNoRepeat, StartDate: "2100-01-01"}
+		err := business.CreateWorkSchedule(slotted, q, 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when slot is longer than the shift", func(t *testing.T) {
+		slotted := workSchedule1
+		slotted.SlotMinutes = 13 * 60
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		closureBusiness.
+			On("FindClosedDates", any).
+			Return(map[string]bool{}, nil).
+			Once()
+
+		q := s.ScheduleQuery{Repeat: s.RepeatNoRepeat, StartDate: "2100-01-01"}
+		err := business.CreateWorkSchedule(slotted, q, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when doctor, nurse or room is already booked", func(t *testing.T) {
+		doctorInRoom := d.DoctorCore{ID: 1, Room: d.RoomCore{ID: 1}}
+		otherDoctorInRoom := d.DoctorCore{ID: 2, Room: d.RoomCore{ID: 1}}
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorInRoom, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		closureBusiness.
+			On("FindClosedDates", any).
+			Return(map[string]bool{}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByRoomId", 1).
+			Return([]d.DoctorCore{doctorInRoom, otherDoctorInRoom}, nil).
+			Once()
+
+		repo.
+			On("SelectConflictingWorkSchedules", mock.MatchedBy(func(q s.ConflictQuery) bool {
+				return len(q.Dates) == 1 && assert.ObjectsAreEqual([]int{1, 2}, q.DoctorIDs) && q.NurseID == nurse1.ID
+			})).
+			Return([]s.WorkScheduleCore{
+				{ID: 5, Doctor: doctor1, Nurse: s.NurseCore{ID: 9}},
+				{ID: 6, Doctor: s.DoctorCore{ID: 2}, Nurse: nurse1},
+			}, nil).
+			Once()
+
+		q := s.ScheduleQuery{Repeat: s.RepeatNoRepeat, StartDate: "2100-01-01"}
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+		assert.Equal(t, map[string]interface{}{
+			"workScheduleIds": []int{5, 6},
+			"doctor":          []int{5},
+			"nurse":           []int{6},
+			"room":            []int{6},
+		}, errors.Payload(err).Data)
+	})
+
+	t.Run("valid - SelectConflictingWorkSchedules error", func(t *testing.T) {
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		closureBusiness.
+			On("FindClosedDates", any).
+			Return(map[string]bool{}, nil).
+			Once()
+
+		repo.
+			On("SelectConflictingWorkSchedules", any).
+			Return([]s.WorkScheduleCore{}, errServer).
 			Once()
 
 		q := s.ScheduleQuery{Repeat: s.RepeatNoRepeat}
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+
+	t.Run("valid - when repeat is an rrule", func(t *testing.T) {
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		closureBusiness.
+			On("FindClosedDates", any).
+			Return(map[string]bool{}, nil).
+			Once()
+
+		repo.
+			On("SelectConflictingWorkSchedules", any).
+			Return([]s.WorkScheduleCore{}, nil).
+			Once()
+
+		repo.
+			On("InsertWorkSchedules", mock.MatchedBy(func(ws []s.WorkScheduleCore) bool {
+				return len(ws) == 3 && ws[0].Date == "2030-01-15" && ws[1].Date == "2030-03-15" && ws[2].Date == "2030-04-15"
+			})).
+			Return([]int{1, 2, 3}, nil).
+			Once()
+
+		q := s.ScheduleQuery{
+			Repeat:       "FREQ=MONTHLY;BYMONTHDAY=15;COUNT=4",
+			StartDate:    "2030-01-01",
+			ExcludeDates: []string{"2030-02-15"},
+		}
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when some dates are closed", func(t *testing.T) {
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		closureBusiness.
+			On("FindClosedDates", []string{"2030-01-01", "2030-01-02", "2030-01-03"}).
+			Return(map[string]bool{"2030-01-02": true}, nil).
+			Once()
+
+		repo.
+			On("SelectConflictingWorkSchedules", mock.MatchedBy(func(q s.ConflictQuery) bool {
+				return assert.ObjectsAreEqual([]string{"2030-01-01", "2030-01-03"}, q.Dates)
+			})).
+			Return([]s.WorkScheduleCore{}, nil).
+			Once()
+
+		repo.
+			On("InsertWorkSchedules", mock.MatchedBy(func(ws []s.WorkScheduleCore) bool {
+				return len(ws) == 2 && ws[0].Date == "2030-01-01" && ws[1].Date == "2030-01-03"
+			})).
+			Return([]int{1, 2}, nil).
+			Once()
+
+		q := s.ScheduleQuery{Repeat: s.RepeatDaily, StartDate: "2030-01-01", EndDate: "2030-01-03"}
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when every date is closed", func(t *testing.T) {
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		closureBusiness.
+			On("FindClosedDates", []string{"2030-08-17"}).
+			Return(map[string]bool{"2030-08-17": true}, nil).
+			Once()
+
+		q := s.ScheduleQuery{Repeat: s.RepeatNoRepeat, StartDate: "2030-08-17"}
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+		assert.Equal(t, map[string]interface{}{"closedDates": []string{"2030-08-17"}}, errors.Payload(err).Data)
+	})
+
+	t.Run("valid - FindClosedDates error", func(t *testing.T) {
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		closureBusiness.
+			On("FindClosedDates", any).
+			Return(map[string]bool{}, errServer).
+			Once()
+
+		q := s.ScheduleQuery{Repeat: s.RepeatNoRepeat, StartDate: "2030-01-01"}
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
 
-	repeatTest := 9
+	repeatTest := 12
+
+	// only the four valid repeats reach closures and conflict check
+	closureBusiness.
+		On("FindClosedDates", any).
+		Return(map[string]bool{}, nil).
+		Times(4)
+
+	repo.
+		On("SelectConflictingWorkSchedules", any).
+		Return([]s.WorkScheduleCore{}, nil).
+		Times(4)
 
 	doctorBusiness.
 		On("FindDoctorById", anyInt).
@@ -348,12 +665,12 @@ func TestCreateWorkSchedule(t *testing.T) {
 
 	repo.
 		On("InsertWorkSchedules", any).
-		Return(nil).
+		Return([]int{1}, nil).
 		Times(repeatTest)
 
 	t.Run("valid - when everything is fine for no-repeat", func(t *testing.T) {
 		q := s.ScheduleQuery{Repeat: s.RepeatNoRepeat}
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Nil(t, err)
 	})
 	t.Run("valid - when everything is fine for daily", func(t *testing.T) {
@@ -362,7 +679,7 @@ func TestCreateWorkSchedule(t *testing.T) {
 			StartDate: "2020-01-01",
 			EndDate:   "2020-02-01",
 		}
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Nil(t, err)
 	})
 	t.Run("valid - when everything is fine for weekly", func(t *testing.T) {
@@ -371,7 +688,7 @@ func TestCreateWorkSchedule(t *testing.T) {
 			StartDate: "2020-01-01",
 			EndDate:   "2020-04-01",
 		}
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Nil(t, err)
 	})
 	t.Run("valid - when everything is fine for monthly", func(t *testing.T) {
@@ -380,14 +697,44 @@ func TestCreateWorkSchedule(t *testing.T) {
 			StartDate: "2020-01-01",
 			EndDate:   "2020-04-01",
 		}
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Nil(t, err)
 	})
 
 	t.Run("valid - for unknown repeat", func(t *testing.T) {
 		q := s.ScheduleQuery{Repeat: "some-unknown-repeat"}
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - for rrule without end", func(t *testing.T) {
+		q := s.ScheduleQuery{Repeat: "FREQ=WEEKLY;BYDAY=MO,WE,FR", StartDate: "2030-01-01"}
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
+	})
+
+	t.Run("valid - for rrule that produces no date", func(t *testing.T) {
+		q := s.ScheduleQuery{
+			Repeat:    "FREQ=MONTHLY;BYMONTHDAY=31",
+			StartDate: "2030-02-01",
+			EndDate:   "2030-02-28",
+		}
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - for invalid exclude date", func(t *testing.T) {
+		q := s.ScheduleQuery{
+			Repeat:       s.RepeatDaily,
+			StartDate:    "2030-01-01",
+			EndDate:      "2030-01-10",
+			ExcludeDates: []string{"invalid date"},
+		}
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
 	})
 
 	t.Run("valid - for invalid start date on repeat daily", func(t *testing.T) {
@@ -396,7 +743,7 @@ func TestCreateWorkSchedule(t *testing.T) {
 			StartDate: "invalid start date",
 			EndDate:   "2020-10-20",
 		}
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Error(t, err)
 	})
 	t.Run("valid - for invalid end date on repeat daily", func(t *testing.T) {
@@ -405,7 +752,7 @@ func TestCreateWorkSchedule(t *testing.T) {
 			StartDate: "2020-10-20",
 			EndDate:   "invalid end date",
 		}
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Error(t, err)
 	})
 	t.Run("valid - for invalid date on repeat weekly", func(t *testing.T) {
@@ -414,7 +761,7 @@ func TestCreateWorkSchedule(t *testing.T) {
 			StartDate: "2020-10-20",
 			EndDate:   "invalid end date",
 		}
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Error(t, err)
 	})
 	t.Run("valid - for invalid date on repeat monthly", func(t *testing.T) {
@@ -423,7 +770,7 @@ func TestCreateWorkSchedule(t *testing.T) {
 			StartDate: "2020-10-20",
 			EndDate:   "invalid end date",
 		}
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Error(t, err)
 	})
 
@@ -433,7 +780,7 @@ func TestCreateWorkSchedule(t *testing.T) {
 			Return(d.DoctorCore{}, errNotFound).
 			Once()
 
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Error(t, err)
 	})
 
@@ -448,7 +795,7 @@ func TestCreateWorkSchedule(t *testing.T) {
 			Return(n.NurseCore{}, errNotFound).
 			Once()
 
-		err := business.CreateWorkSchedule(workSchedule1, q)
+		err := business.CreateWorkSchedule(workSchedule1, q, 1, "admin")
 		assert.Error(t, err)
 	})
 }
@@ -470,12 +817,17 @@ func TestEditWorkSchedule(t *testing.T) {
 			Return(nurseCore1, nil).
 			Once()
 
+		repo.
+			On("SelectConflictingWorkSchedules", mock.AnythingOfType("schedules.ConflictQuery")).
+			Return([]s.WorkScheduleCore{}, nil).
+			Once()
+
 		repo.
 			On("UpdateWorkSchedule", any).
 			Return(nil).
 			Once()
 
-		err := business.EditWorkSchedule(workSchedule1)
+		err := business.EditWorkSchedule(workSchedule1, 1, "admin")
 		assert.Nil(t, err)
 	})
 
@@ -485,7 +837,7 @@ func TestEditWorkSchedule(t *testing.T) {
 			Return(s.WorkScheduleCore{}, errServer).
 			Once()
 
-		err := business.EditWorkSchedule(workSchedule1)
+		err := business.EditWorkSchedule(workSchedule1, 1, "admin")
 		assert.Error(t, err)
 	})
 
@@ -500,7 +852,7 @@ func TestEditWorkSchedule(t *testing.T) {
 			Return(d.DoctorCore{}, errServer).
 			Once()
 
-		err := business.EditWorkSchedule(workSchedule1)
+		err := business.EditWorkSchedule(workSchedule1, 1, "admin")
 		assert.Error(t, err)
 	})
 
@@ -520,7 +872,7 @@ func TestEditWorkSchedule(t *testing.T) {
 			Return(n.NurseCore{}, errServer).
 			Once()
 
-		err := business.EditWorkSchedule(workSchedule1)
+		err := business.EditWorkSchedule(workSchedule1, 1, "admin")
 		assert.Error(t, err)
 	})
 
@@ -540,132 +892,151 @@ func TestEditWorkSchedule(t *testing.T) {
 			Return(nurseCore1, nil).
 			Once()
 
+		repo.
+			On("SelectConflictingWorkSchedules", mock.AnythingOfType("schedules.ConflictQuery")).
+			Return([]s.WorkScheduleCore{}, nil).
+			Once()
+
 		repo.
 			On("UpdateWorkSchedule", any).
 			Return(errServer).
 			Once()
 
-		err := business.EditWorkSchedule(workSchedule1)
+		err := business.EditWorkSchedule(workSchedule1, 1, "admin")
 		assert.Error(t, err)
 	})
-}
 
-func TestRemoveWorkScheduleById(t *testing.T) {
-	t.Run("valid - when everything is fine", func(t *testing.T) {
+	t.Run("valid - when edited schedule overlaps another one", func(t *testing.T) {
 		repo.
-			On("DeleteWorkScheduleById", anyInt).
-			Return(nil).
+			On("SelectWorkScheduleById", anyInt).
+			Return(workSchedule1, nil).
 			Once()
 
-		err := business.RemoveWorkScheduleById(1)
-		assert.Nil(t, err)
-	})
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
 
-	t.Run("valid - DeleteWorkScheduleById error", func(t *testing.T) {
 		repo.
-			On("DeleteWorkScheduleById", anyInt).
-			Return(errServer).
+			On("SelectConflictingWorkSchedules", mock.MatchedBy(func(q s.ConflictQuery) bool {
+				return assert.ObjectsAreEqual([]int{workSchedule1.ID}, q.ExcludeIDs)
+			})).
+			Return([]s.WorkScheduleCore{{ID: 2, Doctor: doctor1, Nurse: nurse1}}, nil).
 			Once()
 
-		err := business.RemoveWorkScheduleById(1)
+		err := business.EditWorkSchedule(workSchedule1, 1, "admin")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 }
 
-func TestRemoveDoctorFutureWorkSchedules(t *testing.T) {
-	w := workSchedule1
-	o := outpatient1
-	o.Status = s.StatusWaiting
-	w.Outpatients = []s.OutpatientCore{o}
+func TestRemoveWorkScheduleById(t *testing.T) {
+	waiting := s.OutpatientCore{ID: 40, Status: s.StatusWaiting, Patient: patient1}
 
 	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectWorkSchedulesByDoctorId", anyInt, any).
-			Return([]s.WorkScheduleCore{w}, nil).
+			On("SelectWorkScheduleById", workSchedule1.ID).
+			Return(workSchedule1, nil).
 			Once()
 
 		repo.
-			On("DeleteWorkSchedulesByDoctorId", anyInt, any).
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{workSchedule1.ID}).
+			Return([]s.OutpatientCore{waiting}, nil).
+			Once()
+
+		repo.
+			On("DeleteWorkScheduleById", anyInt).
 			Return(nil).
 			Once()
 
-		err := business.RemoveDoctorFutureWorkSchedules(1)
+		err := business.RemoveWorkScheduleById(workSchedule1.ID, 1, "admin")
 		assert.Nil(t, err)
+		auditBusiness.AssertCalled(t, "Record", mock.MatchedBy(func(log audits.AuditLogCore) bool {
+			return log.Operation == "schedules.business.RemoveWorkScheduleById" && log.EntityID == workSchedule1.ID && log.ActorRole == "admin"
+		}))
+		notifier.AssertCalled(t, "NotifyOutpatients", s.NotifyRemoved, notified(waiting.ID))
 	})
 
-	t.Run("valid - when SelectWorkSchedulesByDoctorId error", func(t *testing.T) {
+	t.Run("valid - SelectWaitingOutpatientsByWorkScheduleIds error", func(t *testing.T) {
 		repo.
-			On("SelectWorkSchedulesByDoctorId", anyInt, any).
-			Return([]s.WorkScheduleCore{}, errServer).
+			On("SelectWorkScheduleById", workSchedule1.ID).
+			Return(workSchedule1, nil).
 			Once()
 
-		err := business.RemoveDoctorFutureWorkSchedules(1)
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{workSchedule1.ID}).
+			Return([]s.OutpatientCore{}, errServer).
+			Once()
+
+		err := business.RemoveWorkScheduleById(workSchedule1.ID, 1, "admin")
 		assert.Error(t, err)
 	})
 
-	t.Run("valid - when there exists ongoing outpatients", func(t *testing.T) {
-		newWorkschedule := w
-		newOutpatient := o
-		newOutpatient.Status = s.StatusOnprogress
-		newWorkschedule.Outpatients = []s.OutpatientCore{newOutpatient}
-
+	t.Run("valid - when work schedule is not found", func(t *testing.T) {
 		repo.
-			On("SelectWorkSchedulesByDoctorId", anyInt, any).
-			Return([]s.WorkScheduleCore{newWorkschedule}, nil).
+			On("SelectWorkScheduleById", workSchedule1.ID).
+			Return(s.WorkScheduleCore{}, errNotFound).
 			Once()
 
-		err := business.RemoveDoctorFutureWorkSchedules(1)
+		err := business.RemoveWorkScheduleById(workSchedule1.ID, 1, "admin")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
 	})
 
-	t.Run("valid - DeleteWorkSchedulesByDoctorId error", func(t *testing.T) {
+	t.Run("valid - DeleteWorkScheduleById error", func(t *testing.T) {
 		repo.
-			On("SelectWorkSchedulesByDoctorId", anyInt, any).
-			Return([]s.WorkScheduleCore{w}, nil).
+			On("SelectWorkScheduleById", workSchedule1.ID).
+			Return(workSchedule1, nil).
 			Once()
 
 		repo.
-			On("DeleteWorkSchedulesByDoctorId", anyInt, any).
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{workSchedule1.ID}).
+			Return([]s.OutpatientCore{}, nil).
+			Once()
+
+		repo.
+			On("DeleteWorkScheduleById", anyInt).
 			Return(errServer).
 			Once()
 
-		err := business.RemoveDoctorFutureWorkSchedules(1)
+		err := business.RemoveWorkScheduleById(workSchedule1.ID, 1, "admin")
 		assert.Error(t, err)
 	})
 }
 
-func TestRemoveNurseFromNextWorkSchedules(t *testing.T) {
-	t.Run("valid - when everything is fine", func(t *testing.T) {
-		repo.
-			On("DeleteNurseFromWorkSchedules", anyInt, any).
-			Return(nil).
-			Once()
-
-		err := business.RemoveNurseFromNextWorkSchedules(1)
-		assert.Nil(t, err)
-	})
-
-	t.Run("valid - DeleteNurseFromWorkSchedules error", func(t *testing.T) {
-		repo.
-			On("DeleteNurseFromWorkSchedules", anyInt, any).
-			Return(errServer).
-			Once()
-
-		err := business.RemoveNurseFromNextWorkSchedules(1)
-		assert.Error(t, err)
-	})
+func seriesOf(ids ...int) []s.WorkScheduleCore {
+	series := make([]s.WorkScheduleCore, len(ids))
+	for i, id := range ids {
+		series[i] = s.WorkScheduleCore{
+			ID:        id,
+			Group:     "series-1",
+			Date:      fmt.Sprintf("2100-01-%02d", id),
+			StartTime: "08:00:00",
+			EndTime:   "12:00:00",
+			Doctor:    doctor1,
+			Nurse:     nurse1,
+		}
+	}
+	return series
 }
 
-func TestFindOutpatients(t *testing.T) {
+var examinedStatuses = []int{s.StatusOnprogress, s.StatusFinished, s.StatusNoShow}
+
+func TestFindWorkSchedulesByGroup(t *testing.T) {
 	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectOutpatients", any).
-			Return([]s.OutpatientCore{outpatient1}, nil).
+			On("SelectWorkSchedulesByGroup", "series-1").
+			Return(seriesOf(1, 2), nil).
 			Once()
 
-		patientBusiness.
-			On("FindPatientsByIds", anySliceInt).
-			Return([]p.PatientCore{patientCore1}, nil).
+		repo.
+			On("SelectCountWorkSchedulesWaitings", []int{1, 2}).
+			Return(map[int]int{1: 3}, nil).
 			Once()
 
 		doctorBusiness.
@@ -678,1008 +1049,3518 @@ func TestFindOutpatients(t *testing.T) {
 			Return([]n.NurseCore{nurseCore1}, nil).
 			Once()
 
-		result, err := business.FindOutpatients(q)
-
+		result, err := business.FindWorkSchedulesByGroup("series-1")
 		assert.Nil(t, err)
-		assert.Equal(t, 1, len(result))
+		assert.Equal(t, 2, len(result))
+		assert.Equal(t, 3, result[0].TotalWaiting)
 	})
 
-	t.Run("valid - SelectOutpatients error", func(t *testing.T) {
+	t.Run("valid - when series does not exist", func(t *testing.T) {
 		repo.
-			On("SelectOutpatients", any).
-			Return([]s.OutpatientCore{}, errServer).
+			On("SelectWorkSchedulesByGroup", "unknown").
+			Return([]s.WorkScheduleCore{}, nil).
 			Once()
 
-		result, err := business.FindOutpatients(q)
-
+		_, err := business.FindWorkSchedulesByGroup("unknown")
 		assert.Error(t, err)
-		assert.Equal(t, 0, len(result))
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
 	})
+}
 
-	t.Run("valid - FindPatientsByIds error", func(t *testing.T) {
-		repo.
-			On("SelectOutpatients", any).
-			Return([]s.OutpatientCore{outpatient1}, nil).
-			Once()
+func TestEditWorkScheduleSeries(t *testing.T) {
+	newNurse := s.WorkScheduleCore{Nurse: s.NurseCore{ID: 2}}
 
-		patientBusiness.
-			On("FindPatientsByIds", anySliceInt).
-			Return([]p.PatientCore{}, errServer).
+	t.Run("valid - when editing this and following occurrences", func(t *testing.T) {
+		repo.
+			On("SelectWorkSchedulesByGroup", "series-1").
+			Return(seriesOf(1, 2, 3), nil).
 			Once()
 
-		result, err := business.FindOutpatients(q)
-
-		assert.Error(t, err)
-		assert.Equal(t, 0, len(result))
-	})
-
-	t.Run("valid - FindDoctorsByIds error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatients", any).
-			Return([]s.OutpatientCore{outpatient1}, nil).
+			On("SelectCountWorkSchedulesOutpatients", []int{2, 3}, examinedStatuses).
+			Return(map[int]int{}, nil).
 			Once()
 
-		patientBusiness.
-			On("FindPatientsByIds", anySliceInt).
-			Return([]p.PatientCore{patientCore1}, nil).
+		nurseBusiness.
+			On("FindNurseById", 2).
+			Return(n.NurseCore{ID: 2}, nil).
 			Once()
 
 		doctorBusiness.
-			On("FindDoctorsByIds", anySliceInt).
-			Return([]d.DoctorCore{}, errServer).
+			On("FindDoctorById", doctor1.ID).
+			Return(doctorCore1, nil).
 			Once()
 
-		result, err := business.FindOutpatients(q)
-
-		assert.Error(t, err)
-		assert.Equal(t, 0, len(result))
-	})
-
-	t.Run("valid - FindNursesByIds error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatients", any).
-			Return([]s.OutpatientCore{outpatient1}, nil).
+			On("SelectConflictingWorkSchedules", mock.MatchedBy(func(q s.ConflictQuery) bool {
+				return q.NurseID == 2 && len(q.Dates) == 2 && assert.ObjectsAreEqual([]int{2, 3}, q.ExcludeIDs)
+			})).
+			Return([]s.WorkScheduleCore{}, nil).
 			Once()
 
-		patientBusiness.
-			On("FindPatientsByIds", anySliceInt).
-			Return([]p.PatientCore{patientCore1}, nil).
+		repo.
+			On("UpdateWorkSchedules", mock.MatchedBy(func(ws []s.WorkScheduleCore) bool {
+				return len(ws) == 2 && ws[0].Nurse.ID == 2 && ws[1].Nurse.ID == 2 && ws[0].StartTime == "08:00:00"
+			})).
+			Return(nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorsByIds", anySliceInt).
-			Return([]d.DoctorCore{doctorCore1}, nil).
-			Once()
+		result, err := business.EditWorkScheduleSeries("series-1", 2, s.SeriesFollowing, newNurse, 1, "admin")
+		assert.Nil(t, err)
+		assert.Equal(t, []int{2, 3}, result.WorkScheduleIDs)
+		assert.Equal(t, []int{}, result.SkippedIDs)
+	})
 
-		nurseBusiness.
-			On("FindNursesByIds", anySliceInt).
-			Return([]n.NurseCore{}, errServer).
+	t.Run("valid - when whole series has an examined occurrence", func(t *testing.T) {
+		repo.
+			On("SelectWorkSchedulesByGroup", "series-1").
+			Return(seriesOf(1, 2), nil).
 			Once()
 
-		result, err := business.FindOutpatients(q)
-
-		assert.Error(t, err)
-		assert.Equal(t, 0, len(result))
-	})
-}
-
-func TestFindOutpatientsByWorkScheduleId(t *testing.T) {
-	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientsByWorkScheduleId", anyInt).
-			Return(workSchedule1, nil).
+			On("SelectCountWorkSchedulesOutpatients", []int{1, 2}, examinedStatuses).
+			Return(map[int]int{1: 1}, nil).
 			Once()
 
 		doctorBusiness.
-			On("FindDoctorById", anyInt).
+			On("FindDoctorById", doctor1.ID).
 			Return(doctorCore1, nil).
 			Once()
 
-		nurseBusiness.
-			On("FindNurseById", anyInt).
-			Return(nurseCore1, nil).
+		repo.
+			On("SelectConflictingWorkSchedules", mock.AnythingOfType("schedules.ConflictQuery")).
+			Return([]s.WorkScheduleCore{}, nil).
 			Once()
 
-		patientBusiness.
-			On("FindPatientsByIds", anySliceInt).
-			Return([]p.PatientCore{patientCore1}, nil).
+		repo.
+			On("UpdateWorkSchedules", mock.MatchedBy(func(ws []s.WorkScheduleCore) bool {
+				return len(ws) == 1 && ws[0].ID == 2 && ws[0].StartTime == "13:00:00" && ws[0].EndTime == "17:00:00"
+			})).
+			Return(nil).
 			Once()
 
-		result, err := business.FindOutpatientsByWorkScheduleId(1)
-
+		changes := s.WorkScheduleCore{StartTime: "13:00:00", EndTime: "17:00:00"}
+		result, err := business.EditWorkScheduleSeries("series-1", 0, s.SeriesAll, changes, 1, "admin")
 		assert.Nil(t, err)
-		assert.Equal(t, 1, len(result.Outpatients))
-		assert.Equal(t, doctorCore1.ID, result.Doctor.ID)
-		assert.Equal(t, nurseCore1.ID, result.Nurse.ID)
+		assert.Equal(t, []int{2}, result.WorkScheduleIDs)
+		assert.Equal(t, []int{1}, result.SkippedIDs)
 	})
 
-	t.Run("valid - SelectOutpatientsByWorkScheduleId error", func(t *testing.T) {
+	t.Run("valid - when this occurrence is examined", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientsByWorkScheduleId", anyInt).
-			Return(s.WorkScheduleCore{}, errNotFound).
+			On("SelectWorkSchedulesByGroup", "series-1").
+			Return(seriesOf(1, 2), nil).
 			Once()
 
-		result, err := business.FindOutpatientsByWorkScheduleId(1)
+		repo.
+			On("SelectCountWorkSchedulesOutpatients", []int{1}, examinedStatuses).
+			Return(map[int]int{1: 2}, nil).
+			Once()
 
+		_, err := business.EditWorkScheduleSeries("series-1", 1, s.SeriesThis, newNurse, 1, "admin")
 		assert.Error(t, err)
-		assert.Equal(t, 0, result.ID)
-		assert.Equal(t, 0, len(result.Outpatients))
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+		assert.Equal(t, map[string]interface{}{"skippedIds": []int{1}}, errors.Payload(err).Data)
 	})
 
-	t.Run("valid - FindDoctorById error", func(t *testing.T) {
+	t.Run("valid - when work schedule is not part of the series", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientsByWorkScheduleId", anyInt).
-			Return(workSchedule1, nil).
+			On("SelectWorkSchedulesByGroup", "series-1").
+			Return(seriesOf(1, 2), nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorById", anyInt).
-			Return(d.DoctorCore{}, errNotFound).
-			Once()
+		_, err := business.EditWorkScheduleSeries("series-1", 9, s.SeriesThis, newNurse, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
 
-		result, err := business.FindOutpatientsByWorkScheduleId(1)
+	t.Run("valid - when scope is unknown", func(t *testing.T) {
+		repo.
+			On("SelectWorkSchedulesByGroup", "series-1").
+			Return(seriesOf(1, 2), nil).
+			Once()
 
+		_, err := business.EditWorkScheduleSeries("series-1", 1, "unknown", newNurse, 1, "admin")
 		assert.Error(t, err)
-		assert.Equal(t, 0, result.ID)
-		assert.Equal(t, 0, len(result.Outpatients))
+		assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
 	})
 
-	t.Run("valid - FindNurseById error", func(t *testing.T) {
+	t.Run("valid - when series conflicts with another schedule", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientsByWorkScheduleId", anyInt).
-			Return(workSchedule1, nil).
+			On("SelectWorkSchedulesByGroup", "series-1").
+			Return(seriesOf(1), nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorById", anyInt).
-			Return(doctorCore1, nil).
+		repo.
+			On("SelectCountWorkSchedulesOutpatients", []int{1}, examinedStatuses).
+			Return(map[int]int{}, nil).
 			Once()
 
 		nurseBusiness.
-			On("FindNurseById", anyInt).
-			Return(n.NurseCore{}, errServer).
+			On("FindNurseById", 2).
+			Return(n.NurseCore{ID: 2}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", doctor1.ID).
+			Return(doctorCore1, nil).
 			Once()
 
-		result, err := business.FindOutpatientsByWorkScheduleId(1)
+		repo.
+			On("SelectConflictingWorkSchedules", mock.AnythingOfType("schedules.ConflictQuery")).
+			Return([]s.WorkScheduleCore{{ID: 7, Nurse: s.NurseCore{ID: 2}}}, nil).
+			Once()
 
+		_, err := business.EditWorkScheduleSeries("series-1", 0, s.SeriesAll, newNurse, 1, "admin")
 		assert.Error(t, err)
-		assert.Equal(t, 0, result.ID)
-		assert.Equal(t, 0, len(result.Outpatients))
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
-
-	t.Run("valid - FindPatientsByIds error", func(t *testing.T) {
+	t.Run("valid - when new doctor has another speciality", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientsByWorkScheduleId", anyInt).
-			Return(workSchedule1, nil).
+			On("SelectWorkSchedulesByGroup", "series-1").
+			Return(seriesOf(1, 2), nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorById", anyInt).
-			Return(doctorCore1, nil).
+		repo.
+			On("SelectCountWorkSchedulesOutpatients", []int{1, 2}, examinedStatuses).
+			Return(map[int]int{}, nil).
 			Once()
 
-		nurseBusiness.
-			On("FindNurseById", anyInt).
-			Return(nurseCore1, nil).
+		doctorBusiness.
+			On("FindDoctorById", doctor1.ID).
+			Return(d.DoctorCore{ID: doctor1.ID, Speciality: d.SpecialityCore{ID: 1}}, nil).
 			Once()
 
-		patientBusiness.
-			On("FindPatientsByIds", anySliceInt).
-			Return([]p.PatientCore{}, errServer).
+		doctorBusiness.
+			On("FindDoctorById", 2).
+			Return(d.DoctorCore{ID: 2, Speciality: d.SpecialityCore{ID: 2}}, nil).
 			Once()
 
-		result, err := business.FindOutpatientsByWorkScheduleId(1)
-
+		changes := s.WorkScheduleCore{Doctor: s.DoctorCore{ID: 2}}
+		_, err := business.EditWorkScheduleSeries("series-1", 0, s.SeriesAll, changes, 1, "admin")
 		assert.Error(t, err)
-		assert.Equal(t, 0, result.ID)
-		assert.Equal(t, 0, len(result.Outpatients))
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 }
 
-func TestFindOutpatientsByPatientId(t *testing.T) {
-	t.Run("valid - when everything is fine", func(t *testing.T) {
+func TestRemoveWorkScheduleSeries(t *testing.T) {
+	t.Run("valid - when removing whole series", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientsByPatientId", anyInt, any).
-			Return([]s.OutpatientCore{outpatient1}, nil).
+			On("SelectWorkSchedulesByGroup", "series-1").
+			Return(seriesOf(1, 2, 3), nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorsByIds", anySliceInt).
-			Return([]d.DoctorCore{doctorCore1}, nil).
+		repo.
+			On("SelectCountWorkSchedulesOutpatients", []int{1, 2, 3}, examinedStatuses).
+			Return(map[int]int{1: 1}, nil).
 			Once()
 
-		nurseBusiness.
-			On("FindNursesByIds", anySliceInt).
-			Return([]n.NurseCore{nurseCore1}, nil).
+		waiting := s.OutpatientCore{ID: 21, Status: s.StatusWaiting, WorkSchedule: s.WorkScheduleCore{ID: 3}}
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{2, 3}).
+			Return([]s.OutpatientCore{waiting}, nil).
 			Once()
 
-		result, err := business.FindOutpatientsByPatientId(1, q)
+		repo.
+			On("DeleteWorkSchedulesByIds", []int{2, 3}).
+			Return(nil).
+			Once()
 
+		result, err := business.RemoveWorkScheduleSeries("series-1", 0, s.SeriesAll, 1, "admin")
 		assert.Nil(t, err)
-		assert.Equal(t, 1, len(result))
+		assert.Equal(t, []int{2, 3}, result.WorkScheduleIDs)
+		assert.Equal(t, []int{1}, result.SkippedIDs)
+		notifier.AssertCalled(t, "NotifyOutpatients", s.NotifyRemoved, notified(waiting.ID))
 	})
 
-	t.Run("valid - SelectOutpatientsByPatientId error", func(t *testing.T) {
+	t.Run("valid - DeleteWorkSchedulesByIds error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientsByPatientId", anyInt, any).
-			Return([]s.OutpatientCore{}, errServer).
+			On("SelectWorkSchedulesByGroup", "series-1").
+			Return(seriesOf(1, 2), nil).
 			Once()
 
-		result, err := business.FindOutpatientsByPatientId(1, q)
-
-		assert.Error(t, err)
-		assert.Equal(t, 0, len(result))
-	})
-
-	t.Run("valid - FindDoctorsByIds error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientsByPatientId", anyInt, any).
-			Return([]s.OutpatientCore{outpatient1}, nil).
+			On("SelectCountWorkSchedulesOutpatients", []int{2}, examinedStatuses).
+			Return(map[int]int{}, nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorsByIds", anySliceInt).
-			Return([]d.DoctorCore{}, errServer).
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{2}).
+			Return([]s.OutpatientCore{}, nil).
 			Once()
 
-		result, err := business.FindOutpatientsByPatientId(1, q)
+		repo.
+			On("DeleteWorkSchedulesByIds", []int{2}).
+			Return(errServer).
+			Once()
 
+		_, err := business.RemoveWorkScheduleSeries("series-1", 2, s.SeriesFollowing, 1, "admin")
 		assert.Error(t, err)
-		assert.Equal(t, 0, len(result))
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
 	})
+}
 
-	t.Run("valid - FindNursesByIds error", func(t *testing.T) {
+func TestFindWorkSchedulesByDates(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientsByPatientId", anyInt, any).
-			Return([]s.OutpatientCore{outpatient1}, nil).
+			On("SelectWorkSchedulesByDates", []string{"2100-01-01"}).
+			Return([]s.WorkScheduleCore{workSchedule1}, nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorsByIds", anySliceInt).
-			Return([]d.DoctorCore{doctorCore1}, nil).
-			Once()
+		result, err := business.FindWorkSchedulesByDates([]string{"2100-01-01"})
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, len(workSchedule1.Outpatients), result[0].TotalWaiting)
+	})
 
-		nurseBusiness.
-			On("FindNursesByIds", anySliceInt).
-			Return([]n.NurseCore{}, errServer).
-			Once()
+	t.Run("valid - when there is no date", func(t *testing.T) {
+		result, err := business.FindWorkSchedulesByDates([]string{})
+		assert.Nil(t, err)
+		assert.Len(t, result, 0)
+	})
 
-		result, err := business.FindOutpatientsByPatientId(1, q)
+	t.Run("valid - SelectWorkSchedulesByDates error", func(t *testing.T) {
+		repo.
+			On("SelectWorkSchedulesByDates", any).
+			Return([]s.WorkScheduleCore{}, errServer).
+			Once()
 
+		_, err := business.FindWorkSchedulesByDates([]string{"2100-01-01"})
 		assert.Error(t, err)
-		assert.Equal(t, 0, len(result))
 	})
 }
 
-func TestFindOutpatientById(t *testing.T) {
-	t.Run("valid - when everything is fine", func(t *testing.T) {
-		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(outpatient1, nil).
-			Once()
+func TestFindAvailability(t *testing.T) {
+	cardiologist := d.DoctorCore{ID: 1, Name: "Cardiologist", Speciality: d.SpecialityCore{ID: 7}}
+	dentist := d.DoctorCore{ID: 2, Speciality: d.SpecialityCore{ID: 8}}
 
-		patientBusiness.
-			On("FindPatientById", anyInt).
-			Return(patientCore1, nil).
-			Once()
+	// 08:00 - 10:00 in slots of half an hour
+	slotted := s.WorkScheduleCore{ID: 1, Doctor: s.DoctorCore{ID: 1}, Date: "2100-01-01", StartTime: "08:00:00", EndTime: "10:00:00", SlotMinutes: 30, SlotCapacity: 1}
+	limited := s.WorkScheduleCore{ID: 2, Doctor: s.DoctorCore{ID: 1}, Date: "2100-01-02", StartTime: "08:00:00", EndTime: "10:00:00", Capacity: 2}
+	unlimited := s.WorkScheduleCore{ID: 3, Doctor: s.DoctorCore{ID: 1}, Date: "2100-01-03", StartTime: "08:00:00", EndTime: "10:00:00"}
+	onLeave := s.WorkScheduleCore{ID: 4, Doctor: s.DoctorCore{ID: 1}, Date: "2100-01-04", StartTime: "08:00:00", EndTime: "10:00:00", LeaveID: 1}
+	ended := s.WorkScheduleCore{ID: 5, Doctor: s.DoctorCore{ID: 1}, Date: "1990-01-01", StartTime: "08:00:00", EndTime: "10:00:00"}
 
+	t.Run("valid - everything is fine", func(t *testing.T) {
 		doctorBusiness.
-			On("FindDoctorById", anyInt).
-			Return(doctorCore1, nil).
+			On("FindDoctors").
+			Return([]d.DoctorCore{cardiologist, dentist}, nil).
 			Once()
 
-		nurseBusiness.
-			On("FindNurseById", anyInt).
-			Return(nurseCore1, nil).
+		repo.
+			On("SelectWorkSchedulesByDoctorIds", []int{1}, q).
+			Return([]s.WorkScheduleCore{slotted, limited, unlimited, onLeave, ended}, nil).
 			Once()
 
-		result, err := business.FindOutpatientById(1)
+		repo.
+			On("SelectBookingCounts", []int{1, 2, 3}).
+			Return(map[int]s.BookingCountCore{
+				1: {Total: 1, Slots: map[string]int{"08:30:00": 1}},
+				2: {Total: 2, Overbooked: 1, Slots: map[string]int{}},
+			}, nil).
+			Once()
 
+		result, err := business.FindAvailability(7, q)
 		assert.Nil(t, err)
-		assert.Equal(t, 1, result.ID)
+		assert.Len(t, result, 2)
+
+		assert.Equal(t, 1, result[0].WorkSchedule.ID)
+		assert.Equal(t, "Cardiologist", result[0].WorkSchedule.Doctor.Name)
+		assert.Equal(t, 3, result[0].Remaining)
+		assert.Equal(t, []s.SlotCore{
+			{StartTime: "08:00:00", EndTime: "08:30:00", Capacity: 1},
+			{StartTime: "09:00:00", EndTime: "09:30:00", Capacity: 1},
+			{StartTime: "09:30:00", EndTime: "10:00:00", Capacity: 1},
+		}, result[0].Slots)
+
+		assert.Equal(t, 3, result[1].WorkSchedule.ID)
+		assert.Equal(t, -1, result[1].Remaining)
 	})
 
-	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
-		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(s.OutpatientCore{}, errServer).
+	t.Run("valid - when no doctor has the speciality", func(t *testing.T) {
+		doctorBusiness.
+			On("FindDoctors").
+			Return([]d.DoctorCore{dentist}, nil).
 			Once()
 
-		result, err := business.FindOutpatientById(1)
-
-		assert.Error(t, err)
-		assert.Equal(t, 0, result.ID)
+		result, err := business.FindAvailability(7, q)
+		assert.Nil(t, err)
+		assert.Len(t, result, 0)
 	})
 
-	t.Run("valid - FindPatientById error", func(t *testing.T) {
+	t.Run("valid - FindDoctors error", func(t *testing.T) {
+		doctorBusiness.
+			On("FindDoctors").
+			Return([]d.DoctorCore{}, errServer).
+			Once()
+
+		_, err := business.FindAvailability(7, q)
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - SelectBookingCounts error", func(t *testing.T) {
+		doctorBusiness.
+			On("FindDoctors").
+			Return([]d.DoctorCore{cardiologist}, nil).
+			Once()
+
+		repo.
+			On("SelectWorkSchedulesByDoctorIds", []int{1}, q).
+			Return([]s.WorkScheduleCore{unlimited}, nil).
+			Once()
+
+		repo.
+			On("SelectBookingCounts", []int{3}).
+			Return(map[int]s.BookingCountCore{}, errServer).
+			Once()
+
+		_, err := business.FindAvailability(7, q)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindStaffWorkSchedules(t *testing.T) {
+	t.Run("valid - doctor with waiting outpatients", func(t *testing.T) {
+		repo.
+			On("SelectWorkSchedulesByDoctorId", doctor1.ID, any).
+			Return([]s.WorkScheduleCore{{ID: 1}, {ID: 2}}, nil).
+			Once()
+
+		waiting := s.OutpatientCore{ID: 1, Status: s.StatusWaiting}
+		waiting.WorkSchedule.ID = 2
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{1, 2}).
+			Return([]s.OutpatientCore{waiting}, nil).
+			Once()
+
+		result, err := business.FindStaffWorkSchedules("doctor", doctor1.ID, q)
+		assert.Nil(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, 0, result[0].TotalWaiting)
+		assert.Equal(t, 1, result[1].TotalWaiting)
+	})
+
+	t.Run("valid - nurse without work schedules", func(t *testing.T) {
+		repo.
+			On("SelectWorkSchedulesByNurseId", nurse1.ID, any).
+			Return([]s.WorkScheduleCore{}, nil).
+			Once()
+
+		result, err := business.FindStaffWorkSchedules("nurse", nurse1.ID, q)
+		assert.Nil(t, err)
+		assert.Len(t, result, 0)
+	})
+
+	t.Run("valid - when role has no work schedules", func(t *testing.T) {
+		_, err := business.FindStaffWorkSchedules("receptionist", 1, q)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
+	})
+
+	t.Run("valid - SelectWaitingOutpatientsByWorkScheduleIds error", func(t *testing.T) {
+		repo.
+			On("SelectWorkSchedulesByDoctorId", doctor1.ID, any).
+			Return([]s.WorkScheduleCore{{ID: 1}}, nil).
+			Once()
+
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{1}).
+			Return(nil, errServer).
+			Once()
+
+		_, err := business.FindStaffWorkSchedules("doctor", doctor1.ID, q)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestReassignWorkSchedules(t *testing.T) {
+	flagged := workSchedule1
+	flagged.LeaveID = 1
+
+	t.Run("valid - reassign to another nurse", func(t *testing.T) {
+		nurseBusiness.
+			On("FindNurseById", 2).
+			Return(n.NurseCore{ID: 2}, nil).
+			Once()
+
+		repo.
+			On("SelectCountWorkSchedulesOutpatients", []int{workSchedule1.ID}, examinedStatuses).
+			Return(map[int]int{}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", doctor1.ID).
+			Return(doctorCore1, nil).
+			Once()
+
+		repo.
+			On("SelectConflictingWorkSchedules", mock.MatchedBy(func(q s.ConflictQuery) bool {
+				return q.NurseID == 2 && assert.ObjectsAreEqual([]int{workSchedule1.ID}, q.ExcludeIDs)
+			})).
+			Return([]s.WorkScheduleCore{}, nil).
+			Once()
+
+		repo.
+			On("UpdateWorkSchedules", mock.MatchedBy(func(ws []s.WorkScheduleCore) bool {
+				return len(ws) == 1 && ws[0].Nurse.ID == 2 && ws[0].Doctor.ID == doctor1.ID && ws[0].LeaveID == 0
+			})).
+			Return(nil).
+			Once()
+
+		err := business.ReassignWorkSchedules([]s.WorkScheduleCore{flagged}, 0, 2, 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when replacement has overlapping work schedules", func(t *testing.T) {
+		repo.
+			On("SelectCountWorkSchedulesOutpatients", []int{workSchedule1.ID}, examinedStatuses).
+			Return(map[int]int{}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", doctor1.ID).
+			Return(doctorCore1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", 2).
+			Return(d.DoctorCore{ID: 2}, nil).
+			Twice()
+
+		conflict := s.WorkScheduleCore{ID: 5, Doctor: s.DoctorCore{ID: 2}}
+		repo.
+			On("SelectConflictingWorkSchedules", mock.MatchedBy(func(q s.ConflictQuery) bool {
+				return assert.ObjectsAreEqual([]int{2}, q.DoctorIDs)
+			})).
+			Return([]s.WorkScheduleCore{conflict}, nil).
+			Once()
+
+		err := business.ReassignWorkSchedules([]s.WorkScheduleCore{workSchedule1}, 2, 0, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when replacement nurse is not found", func(t *testing.T) {
+		nurseBusiness.
+			On("FindNurseById", 3).
+			Return(n.NurseCore{}, errNotFound).
+			Once()
+
+		err := business.ReassignWorkSchedules([]s.WorkScheduleCore{workSchedule1}, 0, 3, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+
+	t.Run("valid - nothing to reassign", func(t *testing.T) {
+		err := business.ReassignWorkSchedules([]s.WorkScheduleCore{}, 2, 0, 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when replacement doctor has another speciality", func(t *testing.T) {
+		repo.
+			On("SelectCountWorkSchedulesOutpatients", []int{workSchedule1.ID}, examinedStatuses).
+			Return(map[int]int{}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", doctor1.ID).
+			Return(doctorCore1, nil).
+			Once()
+
+		otherSpeciality := d.DoctorCore{ID: 2}
+		otherSpeciality.Speciality.ID = 9
+		doctorBusiness.
+			On("FindDoctorById", 2).
+			Return(otherSpeciality, nil).
+			Once()
+
+		err := business.ReassignWorkSchedules([]s.WorkScheduleCore{workSchedule1}, 2, 0, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - started shifts keep their staff", func(t *testing.T) {
+		started := workSchedule1
+		started.ID = 8
+		repo.
+			On("SelectCountWorkSchedulesOutpatients", []int{started.ID}, examinedStatuses).
+			Return(map[int]int{started.ID: 1}, nil).
+			Once()
+
+		err := business.ReassignWorkSchedules([]s.WorkScheduleCore{started}, 2, 0, 1, "admin")
+		assert.Nil(t, err)
+		repo.AssertNotCalled(t, "UpdateWorkSchedules", mock.MatchedBy(func(ws []s.WorkScheduleCore) bool {
+			return len(ws) > 0 && ws[0].ID == started.ID
+		}))
+	})
+}
+
+func TestFlagWorkSchedulesOnLeave(t *testing.T) {
+	t.Run("valid - everything is fine", func(t *testing.T) {
+		repo.
+			On("UpdateWorkSchedules", mock.MatchedBy(func(ws []s.WorkScheduleCore) bool {
+				return len(ws) == 1 && ws[0].LeaveID == 7 && ws[0].Doctor.ID == doctor1.ID && ws[0].Outpatients == nil
+			})).
+			Return(nil).
+			Once()
+
+		err := business.FlagWorkSchedulesOnLeave([]s.WorkScheduleCore{workSchedule1}, 7, 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - UpdateWorkSchedules error", func(t *testing.T) {
+		repo.
+			On("UpdateWorkSchedules", mock.AnythingOfType("[]schedules.WorkScheduleCore")).
+			Return(errServer).
+			Once()
+
+		err := business.FlagWorkSchedulesOnLeave([]s.WorkScheduleCore{workSchedule1}, 7, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestRemoveDoctorFutureWorkSchedules(t *testing.T) {
+	w := workSchedule1
+	o := outpatient1
+	o.Status = s.StatusWaiting
+	w.Outpatients = []s.OutpatientCore{o}
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectWorkSchedulesByDoctorId", anyInt, any).
+			Return([]s.WorkScheduleCore{w}, nil).
+			Once()
+
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{w.ID}).
+			Return([]s.OutpatientCore{o}, nil).
+			Once()
+
+		repo.
+			On("DeleteWorkSchedulesByDoctorId", anyInt, any).
+			Return(nil).
+			Once()
+
+		err := business.RemoveDoctorFutureWorkSchedules(1)
+		assert.Nil(t, err)
+		notifier.AssertCalled(t, "NotifyOutpatients", s.NotifyRemoved, notified(o.ID))
+	})
+
+	t.Run("valid - when SelectWorkSchedulesByDoctorId error", func(t *testing.T) {
+		repo.
+			On("SelectWorkSchedulesByDoctorId", anyInt, any).
+			Return([]s.WorkScheduleCore{}, errServer).
+			Once()
+
+		err := business.RemoveDoctorFutureWorkSchedules(1)
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when there exists ongoing outpatients", func(t *testing.T) {
+		newWorkschedule := w
+		newOutpatient := o
+		newOutpatient.Status = s.StatusOnprogress
+		newWorkschedule.Outpatients = []s.OutpatientCore{newOutpatient}
+
+		repo.
+			On("SelectWorkSchedulesByDoctorId", anyInt, any).
+			Return([]s.WorkScheduleCore{newWorkschedule}, nil).
+			Once()
+
+		err := business.RemoveDoctorFutureWorkSchedules(1)
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - DeleteWorkSchedulesByDoctorId error", func(t *testing.T) {
+		repo.
+			On("SelectWorkSchedulesByDoctorId", anyInt, any).
+			Return([]s.WorkScheduleCore{w}, nil).
+			Once()
+
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{w.ID}).
+			Return([]s.OutpatientCore{o}, nil).
+			Once()
+
+		repo.
+			On("DeleteWorkSchedulesByDoctorId", anyInt, any).
+			Return(errServer).
+			Once()
+
+		err := business.RemoveDoctorFutureWorkSchedules(1)
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoveNurseFromNextWorkSchedules(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("DeleteNurseFromWorkSchedules", anyInt, any).
+			Return(nil).
+			Once()
+
+		err := business.RemoveNurseFromNextWorkSchedules(1)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - DeleteNurseFromWorkSchedules error", func(t *testing.T) {
+		repo.
+			On("DeleteNurseFromWorkSchedules", anyInt, any).
+			Return(errServer).
+			Once()
+
+		err := business.RemoveNurseFromNextWorkSchedules(1)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindOutpatients(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatients", any).
+			Return([]s.OutpatientCore{outpatient1}, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientsByIds", anySliceInt).
+			Return([]p.PatientCore{patientCore1}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", anySliceInt).
+			Return([]d.DoctorCore{doctorCore1}, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNursesByIds", anySliceInt).
+			Return([]n.NurseCore{nurseCore1}, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", patientAccessLogs("schedules.business.FindOutpatients")).
+			Return(nil).
+			Once()
+
+		result, err := business.FindOutpatients(q, doctor1.ID, "doctor")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+	})
+
+	t.Run("valid - SelectOutpatients error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatients", any).
+			Return([]s.OutpatientCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatients(q, doctor1.ID, "doctor")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, len(result))
+	})
+
+	t.Run("valid - FindPatientsByIds error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatients", any).
+			Return([]s.OutpatientCore{outpatient1}, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientsByIds", anySliceInt).
+			Return([]p.PatientCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatients(q, doctor1.ID, "doctor")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, len(result))
+	})
+
+	t.Run("valid - FindDoctorsByIds error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatients", any).
+			Return([]s.OutpatientCore{outpatient1}, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientsByIds", anySliceInt).
+			Return([]p.PatientCore{patientCore1}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", anySliceInt).
+			Return([]d.DoctorCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatients(q, doctor1.ID, "doctor")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, len(result))
+	})
+
+	t.Run("valid - FindNursesByIds error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatients", any).
+			Return([]s.OutpatientCore{outpatient1}, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientsByIds", anySliceInt).
+			Return([]p.PatientCore{patientCore1}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", anySliceInt).
+			Return([]d.DoctorCore{doctorCore1}, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNursesByIds", anySliceInt).
+			Return([]n.NurseCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatients(q, doctor1.ID, "doctor")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, len(result))
+	})
+}
+
+func TestFindOutpatientsByWorkScheduleId(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(workSchedule1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientsByIds", anySliceInt).
+			Return([]p.PatientCore{patientCore1}, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", patientAccessLogs("schedules.business.FindOutpatientsByWorkScheduleId")).
+			Return(nil).
+			Once()
+
+		result, err := business.FindOutpatientsByWorkScheduleId(1, doctor1.ID, "doctor")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result.Outpatients))
+		assert.Equal(t, doctorCore1.ID, result.Doctor.ID)
+		assert.Equal(t, nurseCore1.ID, result.Nurse.ID)
+	})
+
+	t.Run("valid - SelectOutpatientsByWorkScheduleId error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(s.WorkScheduleCore{}, errNotFound).
+			Once()
+
+		result, err := business.FindOutpatientsByWorkScheduleId(1, doctor1.ID, "doctor")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.ID)
+		assert.Equal(t, 0, len(result.Outpatients))
+	})
+
+	t.Run("valid - FindDoctorById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(workSchedule1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(d.DoctorCore{}, errNotFound).
+			Once()
+
+		result, err := business.FindOutpatientsByWorkScheduleId(1, doctor1.ID, "doctor")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.ID)
+		assert.Equal(t, 0, len(result.Outpatients))
+	})
+
+	t.Run("valid - FindNurseById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(workSchedule1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(n.NurseCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatientsByWorkScheduleId(1, doctor1.ID, "doctor")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.ID)
+		assert.Equal(t, 0, len(result.Outpatients))
+	})
+
+	t.Run("valid - FindPatientsByIds error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(workSchedule1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientsByIds", anySliceInt).
+			Return([]p.PatientCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatientsByWorkScheduleId(1, doctor1.ID, "doctor")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.ID)
+		assert.Equal(t, 0, len(result.Outpatients))
+	})
+}
+
+func TestFindOutpatientsByPatientId(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByPatientId", anyInt, any).
+			Return([]s.OutpatientCore{outpatient1}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", anySliceInt).
+			Return([]d.DoctorCore{doctorCore1}, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNursesByIds", anySliceInt).
+			Return([]n.NurseCore{nurseCore1}, nil).
+			Once()
+
+		repo.
+			On("SelectCountCareTeamOutpatients", patient1.ID, doctor1.ID, 0).
+			Return(1, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", patientAccessLogs("schedules.business.FindOutpatientsByPatientId")).
+			Return(nil).
+			Once()
+
+		result, err := business.FindOutpatientsByPatientId(1, q, doctor1.ID, "doctor", "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+		assert.False(t, result[0].Redacted)
+		assert.Equal(t, 1, len(result[0].Prescriptions))
+	})
+
+	t.Run("valid - when patient reads their own visits", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByPatientId", patient1.ID, any).
+			Return([]s.OutpatientCore{outpatient1}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", anySliceInt).
+			Return([]d.DoctorCore{doctorCore1}, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNursesByIds", anySliceInt).
+			Return([]n.NurseCore{nurseCore1}, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", []audits.AccessLogCore{{
+				ReaderID:   patient1.ID,
+				ReaderRole: "patient",
+				PatientID:  patient1.ID,
+				Operation:  "schedules.business.FindOutpatientsByPatientId",
+			}}).
+			Return(nil).
+			Once()
+
+		result, err := business.FindOutpatientsByPatientId(patient1.ID, q, patient1.ID, "patient", "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+		assert.False(t, result[0].Redacted)
+	})
+
+	t.Run("valid - when doctor is outside the care team", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByPatientId", anyInt, any).
+			Return([]s.OutpatientCore{outpatient1}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", anySliceInt).
+			Return([]d.DoctorCore{doctorCore1}, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNursesByIds", anySliceInt).
+			Return([]n.NurseCore{nurseCore1}, nil).
+			Once()
+
+		repo.
+			On("SelectCountCareTeamOutpatients", patient1.ID, doctor1.ID, 0).
+			Return(0, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", patientAccessLogs("schedules.business.FindOutpatientsByPatientId")).
+			Return(nil).
+			Once()
+
+		result, err := business.FindOutpatientsByPatientId(1, q, doctor1.ID, "doctor", "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+		assert.True(t, result[0].Redacted)
+		assert.Equal(t, 0, len(result[0].Prescriptions))
+	})
+
+	t.Run("valid - SelectCountCareTeamOutpatients error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByPatientId", anyInt, any).
+			Return([]s.OutpatientCore{outpatient1}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", anySliceInt).
+			Return([]d.DoctorCore{doctorCore1}, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNursesByIds", anySliceInt).
+			Return([]n.NurseCore{nurseCore1}, nil).
+			Once()
+
+		repo.
+			On("SelectCountCareTeamOutpatients", patient1.ID, doctor1.ID, 0).
+			Return(0, errServer).
+			Once()
+
+		result, err := business.FindOutpatientsByPatientId(1, q, doctor1.ID, "doctor", "")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, len(result))
+	})
+
+	t.Run("valid - SelectOutpatientsByPatientId error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByPatientId", anyInt, any).
+			Return([]s.OutpatientCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatientsByPatientId(1, q, doctor1.ID, "doctor", "")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, len(result))
+	})
+
+	t.Run("valid - FindDoctorsByIds error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByPatientId", anyInt, any).
+			Return([]s.OutpatientCore{outpatient1}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", anySliceInt).
+			Return([]d.DoctorCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatientsByPatientId(1, q, doctor1.ID, "doctor", "")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, len(result))
+	})
+
+	t.Run("valid - FindNursesByIds error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientsByPatientId", anyInt, any).
+			Return([]s.OutpatientCore{outpatient1}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorsByIds", anySliceInt).
+			Return([]d.DoctorCore{doctorCore1}, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNursesByIds", anySliceInt).
+			Return([]n.NurseCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatientsByPatientId(1, q, doctor1.ID, "doctor", "")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, len(result))
+	})
+}
+
+func TestFindOutpatientById(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(outpatient1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", patientAccessLogs("schedules.business.FindOutpatientById")).
+			Return(nil).
+			Once()
+
+		result, err := business.FindOutpatientById(1, doctor1.ID, "doctor", "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, result.ID)
+		assert.False(t, result.Redacted)
+	})
+
+	t.Run("valid - when reader is not in the care team", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(outpatient1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", []audits.AccessLogCore{{
+				ReaderID:   1,
+				ReaderRole: "receptionist",
+				PatientID:  patient1.ID,
+				Operation:  "schedules.business.FindOutpatientById",
+			}}).
+			Return(nil).
+			Once()
+
+		result, err := business.FindOutpatientById(1, 1, "receptionist", "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, result.ID)
+		assert.True(t, result.Redacted)
+		assert.Equal(t, 0, len(result.Prescriptions))
+	})
+
+	t.Run("valid - when doctor breaks the glass", func(t *testing.T) {
+		otherDoctorID := doctor1.ID + 1
+
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(outpatient1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		repo.
+			On("SelectCountCareTeamOutpatients", patient1.ID, otherDoctorID, 0).
+			Return(0, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", []audits.AccessLogCore{{
+				ReaderID:   otherDoctorID,
+				ReaderRole: "doctor",
+				PatientID:  patient1.ID,
+				Operation:  "schedules.business.FindOutpatientById",
+				BreakGlass: true,
+				Reason:     "emergency",
+			}}).
+			Return(nil).
+			Once()
+
+		result, err := business.FindOutpatientById(1, otherDoctorID, "doctor", "emergency")
+
+		assert.Nil(t, err)
+		assert.False(t, result.Redacted)
+		assert.Equal(t, 1, len(result.Prescriptions))
+	})
+
+	t.Run("valid - when receptionist tries to break the glass", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(outpatient1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		result, err := business.FindOutpatientById(1, 1, "receptionist", "emergency")
+
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+		assert.Equal(t, 0, result.ID)
+	})
+
+	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(s.OutpatientCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatientById(1, doctor1.ID, "doctor", "")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.ID)
+	})
+
+	t.Run("valid - FindPatientById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(outpatient1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(p.PatientCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatientById(1, doctor1.ID, "doctor", "")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.ID)
+	})
+
+	t.Run("valid - FindDoctorById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(outpatient1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(d.DoctorCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatientById(1, doctor1.ID, "doctor", "")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.ID)
+	})
+
+	t.Run("valid - FindNurseById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(outpatient1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(n.NurseCore{}, errServer).
+			Once()
+
+		result, err := business.FindOutpatientById(1, doctor1.ID, "doctor", "")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.ID)
+	})
+
+	t.Run("valid - when access can not be recorded", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(outpatient1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", anyInt).
+			Return(doctorCore1, nil).
+			Once()
+
+		nurseBusiness.
+			On("FindNurseById", anyInt).
+			Return(nurseCore1, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", patientAccessLogs("schedules.business.FindOutpatientById")).
+			Return(errServer).
+			Once()
+
+		result, err := business.FindOutpatientById(1, doctor1.ID, "doctor", "")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.ID)
+	})
+}
+
+func TestCreateOutpatient(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectWorkScheduleById", anyInt).
+			Return(workSchedule1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		repo.
+			On("InsertOutpatient", any).
+			Return(1, nil).
+			Once()
+
+		err := business.CreateOutpatient(outpatient1, 1, "admin")
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - FindPatientById error", func(t *testing.T) {
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(p.PatientCore{}, errNotFound).
+			Once()
+
+		err := business.CreateOutpatient(outpatient1, 1, "admin")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - SelectWorkScheduleById error", func(t *testing.T) {
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		repo.
+			On("SelectWorkScheduleById", anyInt).
+			Return(s.WorkScheduleCore{}, errNotFound).
+			Once()
+
+		err := business.CreateOutpatient(outpatient1, 1, "admin")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - time.ParseInLocation error", func(t *testing.T) {
+		invalidWorkschedule := workSchedule1
+		invalidWorkschedule.StartTime = ""
+		invalidWorkschedule.EndTime = ""
+
+		repo.
+			On("SelectWorkScheduleById", anyInt).
+			Return(invalidWorkschedule, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		err := business.CreateOutpatient(outpatient1, 1, "admin")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when work schedule has ended", func(t *testing.T) {
+		endedSchedule := workSchedule1
+		endedSchedule.Date = "1990-01-01"
+
+		repo.
+			On("SelectWorkScheduleById", anyInt).
+			Return(endedSchedule, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		err := business.CreateOutpatient(outpatient1, 1, "admin")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when staff of work schedule is on leave", func(t *testing.T) {
+		onLeave := workSchedule1
+		onLeave.LeaveID = 1
+
+		repo.
+			On("SelectWorkScheduleById", anyInt).
+			Return(onLeave, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		err := business.CreateOutpatient(outpatient1, 1, "admin")
+
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+		repo.AssertNotCalled(t, "InsertOutpatient", mock.MatchedBy(func(o s.OutpatientCore) bool {
+			return o.WorkSchedule.ID == onLeave.ID
+		}))
+	})
+
+	t.Run("valid - InsertOutpatient error", func(t *testing.T) {
+		repo.
+			On("SelectWorkScheduleById", anyInt).
+			Return(workSchedule1, nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		repo.
+			On("InsertOutpatient", any).
+			Return(0, errServer).
+			Once()
+
+		err := business.CreateOutpatient(outpatient1, 1, "admin")
+
+		assert.Error(t, err)
+	})
+
+	// 00:00 - 12:00 in slots of an hour for two outpatients each
+	slotted := workSchedule1
+	slotted.SlotMinutes = 60
+	slotted.SlotCapacity = 2
+	slotted.Overbook = 1
+
+	booking := outpatient1
+	booking.WorkSchedule = slotted
+	booking.SlotTime = "09:00:00"
+
+	bookCreateOutpatient := func(ws s.WorkScheduleCore, booked s.BookingCountCore) {
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		repo.
+			On("SelectWorkScheduleById", anyInt).
+			Return(ws, nil).
+			Once()
+
+		repo.
+			On("SelectBookingCounts", []int{ws.ID}).
+			Return(map[int]s.BookingCountCore{ws.ID: booked}, nil).
+			Once()
+	}
+
+	t.Run("valid - when slot has room", func(t *testing.T) {
+		bookCreateOutpatient(slotted, s.BookingCountCore{Total: 1, Slots: map[string]int{"09:00:00": 1}})
+
+		overbooking := booking
+		overbooking.Overbooked = true
+
+		repo.
+			On("InsertOutpatient", mock.MatchedBy(func(o s.OutpatientCore) bool {
+				return o.SlotTime == "09:00:00" && !o.Overbooked
+			})).
+			Return(1, nil).
+			Once()
+
+		err := business.CreateOutpatient(overbooking, 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when slot is full", func(t *testing.T) {
+		bookCreateOutpatient(slotted, s.BookingCountCore{Total: 2, Slots: map[string]int{"09:00:00": 2}})
+
+		err := business.CreateOutpatient(booking, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when full slot is overbooked", func(t *testing.T) {
+		bookCreateOutpatient(slotted, s.BookingCountCore{Total: 2, Slots: map[string]int{"09:00:00": 2}})
+
+		overbooking := booking
+		overbooking.Overbooked = true
+
+		repo.
+			On("InsertOutpatient", mock.MatchedBy(func(o s.OutpatientCore) bool {
+				return o.SlotTime == "09:00:00" && o.Overbooked
+			})).
+			Return(1, nil).
+			Once()
+
+		err := business.CreateOutpatient(overbooking, 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when no overbooking is left", func(t *testing.T) {
+		bookCreateOutpatient(slotted, s.BookingCountCore{Total: 2, Overbooked: 1, Slots: map[string]int{"09:00:00": 2}})
+
+		overbooking := booking
+		overbooking.Overbooked = true
+
+		err := business.CreateOutpatient(overbooking, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when shift capacity is reached", func(t *testing.T) {
+		limited := workSchedule1
+		limited.Capacity = 3
+
+		bookCreateOutpatient(limited, s.BookingCountCore{Total: 3, Slots: map[string]int{}})
+
+		err := business.CreateOutpatient(outpatient1, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when slot is not on the work schedule", func(t *testing.T) {
+		bookCreateOutpatient(slotted, s.BookingCountCore{Slots: map[string]int{}})
+
+		offGrid := booking
+		offGrid.SlotTime = "09:30:00"
+
+		err := business.CreateOutpatient(offGrid, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when slot is asked without slots", func(t *testing.T) {
+		patientBusiness.
+			On("FindPatientById", anyInt).
+			Return(patientCore1, nil).
+			Once()
+
+		repo.
+			On("SelectWorkScheduleById", anyInt).
+			Return(workSchedule1, nil).
+			Once()
+
+		err := business.CreateOutpatient(booking, 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+}
+
+func TestFindOutpatientQueue(t *testing.T) {
+	waiting := outpatient1
+	waiting.Status = s.StatusWaiting
+	waiting.QueueNumber = 4
+	waiting.WorkSchedule = workSchedule1
+
+	t.Run("valid - estimated from doctor history", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("SelectCountOutpatientsAhead", workSchedule1.ID, 4, waiting.ID).
+			Return(3, nil).
+			Once()
+
+		repo.
+			On("SelectAverageExaminationTime", doctor1.ID).
+			Return(10*time.Minute, 20, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", patientAccessLogs("schedules.business.FindOutpatientQueue")).
+			Return(nil).
+			Once()
+
+		queue, err := business.FindOutpatientQueue(waiting.ID, doctor1.ID, "doctor")
+		assert.Nil(t, err)
+		assert.Equal(t, 4, queue.Position)
+		assert.Equal(t, 3, queue.WaitingAhead)
+		assert.True(t, queue.Historical)
+		assert.Equal(t, 30*time.Minute, queue.EstimatedWait)
+
+		// workSchedule1 starts on 2100-01-01 at midnight
+		start, _ := time.ParseInLocation("2006-01-02", workSchedule1.Date, config.GetTimeLoc())
+		assert.True(t, queue.EstimatedTime.Equal(start.Add(30*time.Minute)))
+	})
+
+	t.Run("valid - default time without history", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("SelectCountOutpatientsAhead", workSchedule1.ID, 4, waiting.ID).
+			Return(2, nil).
+			Once()
+
+		repo.
+			On("SelectAverageExaminationTime", doctor1.ID).
+			Return(time.Duration(0), 0, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", patientAccessLogs("schedules.business.FindOutpatientQueue")).
+			Return(nil).
+			Once()
+
+		queue, err := business.FindOutpatientQueue(waiting.ID, doctor1.ID, "doctor")
+		assert.Nil(t, err)
+		assert.False(t, queue.Historical)
+		assert.Equal(t, 2*s.DEFAULT_EXAMINATION_TIME, queue.EstimatedWait)
+	})
+
+	t.Run("valid - when outpatient is being examined", func(t *testing.T) {
+		onprogress := waiting
+		onprogress.Status = s.StatusOnprogress
+
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(onprogress, nil).
+			Once()
+
+		auditBusiness.
+			On("RecordAccess", patientAccessLogs("schedules.business.FindOutpatientQueue")).
+			Return(nil).
+			Once()
+
+		queue, err := business.FindOutpatientQueue(waiting.ID, doctor1.ID, "doctor")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, queue.Position)
+	})
+
+	t.Run("valid - when outpatient is finished", func(t *testing.T) {
+		finished := waiting
+		finished.Status = s.StatusFinished
+
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(finished, nil).
+			Once()
+
+		_, err := business.FindOutpatientQueue(waiting.ID, doctor1.ID, "doctor")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - SelectAverageExaminationTime error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("SelectCountOutpatientsAhead", workSchedule1.ID, 4, waiting.ID).
+			Return(0, nil).
+			Once()
+
+		repo.
+			On("SelectAverageExaminationTime", doctor1.ID).
+			Return(time.Duration(0), 0, errServer).
+			Once()
+
+		_, err := business.FindOutpatientQueue(waiting.ID, doctor1.ID, "doctor")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestSubscribeQueue(t *testing.T) {
+	waiting := s.OutpatientCore{ID: 7, QueueNumber: 3, Status: s.StatusWaiting, Patient: patient1, WorkSchedule: workSchedule1}
+
+	t.Run("valid - displays of work schedule and room get the event", func(t *testing.T) {
+		repo.
+			On("SelectWorkScheduleById", workSchedule1.ID).
+			Return(workSchedule1, nil).
+			Once()
+
+		scheduleEvents, cancelSchedule, err := business.SubscribeWorkScheduleQueue(workSchedule1.ID)
+		assert.Nil(t, err)
+		defer cancelSchedule()
+
+		roomEvents, cancelRoom, err := business.SubscribeRoomQueue(room1.ID)
+		assert.Nil(t, err)
+		defer cancelRoom()
+
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatient", any).
+			Return(nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", patient1.ID).
+			Return(p.PatientCore{ID: patient1.ID, Name: "Budi"}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", doctor1.ID).
+			Return(d.DoctorCore{ID: doctor1.ID, Room: d.RoomCore{ID: room1.ID}}, nil).
+			Once()
+
+		err = business.CancelOutpatient(waiting.ID, 1, "admin")
+		assert.Nil(t, err)
+
+		for _, events := range []<-chan s.QueueEventCore{scheduleEvents, roomEvents} {
+			event := <-events
+			assert.Equal(t, s.QueueEventCanceled, event.Type)
+			assert.Equal(t, workSchedule1.ID, event.WorkScheduleID)
+			assert.Equal(t, room1.ID, event.RoomID)
+			assert.Equal(t, 3, event.QueueNumber)
+			assert.Equal(t, s.StatusCanceled, event.Status)
+			assert.Equal(t, "Budi", event.PatientName)
+		}
+	})
+
+	t.Run("valid - businesses built with the same hub share events", func(t *testing.T) {
+		hub := sb.NewQueueHub()
+		builder := sb.NewScheduleBusinessBuilder()
+		publisher := builder.
+			SetData(&repo).
+			SetDoctorBusiness(&doctorBusiness).
+			SetPatientBusiness(&patientBusiness).
+			SetPermissionBusiness(permissionBusiness.NewPermissionBusinessBuilder().Build()).
+			SetAuditBusiness(&auditBusiness).
+			SetNotifier(&notifier).
+			SetQueueHub(hub).
+			Build()
+		subscriber := builder.SetData(&repo).SetQueueHub(hub).Build()
+
+		events, cancel, err := subscriber.SubscribeRoomQueue(room1.ID)
+		assert.Nil(t, err)
+		defer cancel()
+
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatient", any).
+			Return(nil).
+			Once()
+
+		patientBusiness.
+			On("FindPatientById", patient1.ID).
+			Return(p.PatientCore{ID: patient1.ID, Name: "Budi"}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", doctor1.ID).
+			Return(d.DoctorCore{ID: doctor1.ID, Room: d.RoomCore{ID: room1.ID}}, nil).
+			Once()
+
+		err = publisher.CancelOutpatient(waiting.ID, 1, "admin")
+		assert.Nil(t, err)
+
+		event := <-events
+		assert.Equal(t, s.QueueEventCanceled, event.Type)
+		assert.Equal(t, room1.ID, event.RoomID)
+	})
+
+	t.Run("valid - channel is closed when the display leaves", func(t *testing.T) {
+		events, cancel, err := business.SubscribeRoomQueue(room1.ID)
+		assert.Nil(t, err)
+
+		cancel()
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("valid - when work schedule is not found", func(t *testing.T) {
+		repo.
+			On("SelectWorkScheduleById", 99).
+			Return(s.WorkScheduleCore{}, errNotFound).
+			Once()
+
+		_, _, err := business.SubscribeWorkScheduleQueue(99)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+
+	t.Run("valid - when room id is invalid", func(t *testing.T) {
+		_, _, err := business.SubscribeRoomQueue(0)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindBadRequest, errors.Kind(err))
+	})
+}
+
+func TestRescheduleOutpatient(t *testing.T) {
+	waiting := outpatient1
+	waiting.Status = s.StatusWaiting
+	waiting.WorkSchedule = workSchedule1
+
+	target := workSchedule1
+	target.ID = 2
+	target.Doctor = s.DoctorCore{ID: 2}
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("SelectWorkScheduleById", target.ID).
+			Return(target, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", doctor1.ID).
+			Return(d.DoctorCore{ID: doctor1.ID, Speciality: d.SpecialityCore{ID: 1}}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", 2).
+			Return(d.DoctorCore{ID: 2, Speciality: d.SpecialityCore{ID: 1}}, nil).
+			Once()
+
+		repo.
+			On("MoveOutpatients", []s.OutpatientMoveCore{{
+				OutpatientID:       waiting.ID,
+				FromWorkScheduleID: workSchedule1.ID,
+				ToWorkScheduleID:   target.ID,
+				Reason:             "doctor is sick",
+				MovedBy:            1,
+				MovedByRole:        "receptionist",
+			}}).
+			Return(nil).
+			Once()
+
+		err := business.RescheduleOutpatient(waiting.ID, target.ID, "doctor is sick", 1, "receptionist")
+		assert.Nil(t, err)
+		notifier.AssertCalled(t, "NotifyOutpatients", s.NotifyRescheduled, mock.MatchedBy(func(outpatients []s.OutpatientCore) bool {
+			return len(outpatients) == 1 && outpatients[0].ID == waiting.ID && outpatients[0].WorkSchedule.ID == target.ID
+		}))
+	})
+
+	t.Run("valid - when outpatient is not waiting", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(outpatient1, nil).
+			Once()
+
+		err := business.RescheduleOutpatient(waiting.ID, target.ID, "", 1, "receptionist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when target is the current work schedule", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
+			Once()
+
+		err := business.RescheduleOutpatient(waiting.ID, workSchedule1.ID, "", 1, "receptionist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when target work schedule has ended", func(t *testing.T) {
+		ended := target
+		ended.Date = "1990-01-01"
+
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("SelectWorkScheduleById", target.ID).
+			Return(ended, nil).
+			Once()
+
+		err := business.RescheduleOutpatient(waiting.ID, target.ID, "", 1, "receptionist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when target doctor has another speciality", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("SelectWorkScheduleById", target.ID).
+			Return(target, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", doctor1.ID).
+			Return(d.DoctorCore{ID: doctor1.ID, Speciality: d.SpecialityCore{ID: 1}}, nil).
+			Once()
+
+		doctorBusiness.
+			On("FindDoctorById", 2).
+			Return(d.DoctorCore{ID: 2, Speciality: d.SpecialityCore{ID: 2}}, nil).
+			Once()
+
+		err := business.RescheduleOutpatient(waiting.ID, target.ID, "", 1, "receptionist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when target staff is on leave", func(t *testing.T) {
+		onLeave := target
+		onLeave.LeaveID = 1
+
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("SelectWorkScheduleById", target.ID).
+			Return(onLeave, nil).
+			Once()
+
+		err := business.RescheduleOutpatient(waiting.ID, target.ID, "", 1, "receptionist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+}
+
+func TestTransferOutpatients(t *testing.T) {
+	target := workSchedule1
+	target.ID = 2
+
+	waiting := []s.OutpatientCore{
+		{ID: 1, Status: s.StatusWaiting, WorkSchedule: s.WorkScheduleCore{ID: workSchedule1.ID}},
+		{ID: 2, Status: s.StatusWaiting, WorkSchedule: s.WorkScheduleCore{ID: workSchedule1.ID}},
+	}
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectWorkScheduleById", workSchedule1.ID).
+			Return(workSchedule1, nil).
+			Once()
+
+		repo.
+			On("SelectWorkScheduleById", target.ID).
+			Return(target, nil).
+			Once()
+
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{workSchedule1.ID}).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("MoveOutpatients", mock.MatchedBy(func(moves []s.OutpatientMoveCore) bool {
+				return len(moves) == 2 && moves[0].ToWorkScheduleID == target.ID && moves[1].OutpatientID == 2
+			})).
+			Return(nil).
+			Once()
+
+		ids, err := business.TransferOutpatients(workSchedule1.ID, target.ID, "shift cancelled", 1, "admin")
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2}, ids)
+	})
+
+	t.Run("valid - when nobody is waiting", func(t *testing.T) {
+		repo.
+			On("SelectWorkScheduleById", workSchedule1.ID).
+			Return(workSchedule1, nil).
+			Once()
+
+		repo.
+			On("SelectWorkScheduleById", target.ID).
+			Return(target, nil).
+			Once()
+
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{workSchedule1.ID}).
+			Return([]s.OutpatientCore{}, nil).
+			Once()
+
+		ids, err := business.TransferOutpatients(workSchedule1.ID, target.ID, "", 1, "admin")
+		assert.Nil(t, err)
+		assert.Equal(t, []int{}, ids)
+	})
+
+	transferTo := func(target s.WorkScheduleCore, booked s.BookingCountCore) {
+		repo.
+			On("SelectWorkScheduleById", workSchedule1.ID).
+			Return(workSchedule1, nil).
+			Once()
+
+		repo.
+			On("SelectWorkScheduleById", target.ID).
+			Return(target, nil).
+			Once()
+
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{workSchedule1.ID}).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("SelectBookingCounts", []int{target.ID}).
+			Return(map[int]s.BookingCountCore{target.ID: booked}, nil).
+			Once()
+	}
+
+	t.Run("valid - overflow is overbooked as far as target allows", func(t *testing.T) {
+		limited := target
+		limited.ID = 3
+		limited.Capacity = 2
+		limited.Overbook = 1
+		transferTo(limited, s.BookingCountCore{Total: 1})
+
+		repo.
+			On("MoveOutpatients", mock.MatchedBy(func(moves []s.OutpatientMoveCore) bool {
+				return len(moves) == 2 && moves[0].ToWorkScheduleID == limited.ID && !moves[0].Overbooked && moves[1].Overbooked
+			})).
+			Return(nil).
+			Once()
+
+		_, err := business.TransferOutpatients(workSchedule1.ID, limited.ID, "shift cancelled", 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when target has no room left", func(t *testing.T) {
+		full := target
+		full.ID = 4
+		full.Capacity = 1
+		transferTo(full, s.BookingCountCore{Total: 1})
+
+		_, err := business.TransferOutpatients(workSchedule1.ID, full.ID, "shift cancelled", 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+		repo.AssertNotCalled(t, "MoveOutpatients", mock.MatchedBy(func(moves []s.OutpatientMoveCore) bool {
+			return len(moves) > 0 && moves[0].ToWorkScheduleID == full.ID
+		}))
+	})
+
+	t.Run("valid - moved outpatients take the earliest free slots", func(t *testing.T) {
+		slotted := target
+		slotted.ID = 5
+		slotted.SlotMinutes = 60
+		slotted.SlotCapacity = 1
+		transferTo(slotted, s.BookingCountCore{Total: 1, Slots: map[string]int{"00:00:00": 1}})
+
+		repo.
+			On("MoveOutpatients", mock.MatchedBy(func(moves []s.OutpatientMoveCore) bool {
+				return len(moves) == 2 && moves[0].ToWorkScheduleID == slotted.ID &&
+					moves[0].SlotTime == "01:00:00" && moves[1].SlotTime == "02:00:00"
+			})).
+			Return(nil).
+			Once()
+
+		_, err := business.TransferOutpatients(workSchedule1.ID, slotted.ID, "shift cancelled", 1, "admin")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - SelectWorkScheduleById error", func(t *testing.T) {
+		repo.
+			On("SelectWorkScheduleById", 99).
+			Return(s.WorkScheduleCore{}, errNotFound).
+			Once()
+
+		_, err := business.TransferOutpatients(99, target.ID, "", 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+
+	t.Run("valid - MoveOutpatients error", func(t *testing.T) {
+		repo.
+			On("SelectWorkScheduleById", workSchedule1.ID).
+			Return(workSchedule1, nil).
+			Once()
+
+		repo.
+			On("SelectWorkScheduleById", target.ID).
+			Return(target, nil).
+			Once()
+
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{workSchedule1.ID}).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("MoveOutpatients", any).
+			Return(errServer).
+			Once()
+
+		_, err := business.TransferOutpatients(workSchedule1.ID, target.ID, "", 1, "admin")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
+
+func TestEditOutpatient(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(outpatient1, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatient", any).
+			Return(nil).
+			Once()
+
+		err := business.EditOutpatient(outpatient1, 1, "admin")
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(s.OutpatientCore{}, errNotFound).
+			Once()
+
+		err := business.EditOutpatient(outpatient1, 1, "admin")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(outpatient1, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatient", any).
+			Return(errServer).
+			Once()
+
+		err := business.EditOutpatient(outpatient1, 1, "admin")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestExamineOutpatient(t *testing.T) {
+	onprogress := s.OutpatientCore{
+		Status: s.StatusOnprogress,
+		WorkSchedule: s.WorkScheduleCore{
+			ID:     2,
+			Doctor: s.DoctorCore{ID: 2},
+			Nurse:  s.NurseCore{ID: 2},
+		},
+	}
+	waiting := s.OutpatientCore{
+		Status: s.StatusWaiting,
+		WorkSchedule: s.WorkScheduleCore{
+			ID:     1,
+			Doctor: doctor1,
+			Nurse:  nurse1,
+		},
+	}
+
+	yearFormat := "2006-01-02"
+	timeFormat := "15:04:05"
+	now := time.Now().In(config.GetTimeLoc())
+
+	schedule := s.WorkScheduleCore{
+		Date:      now.Format(yearFormat),
+		StartTime: now.Format(timeFormat),
+		EndTime:   now.Add(2 * time.Hour).Format(timeFormat),
+	}
+
+	t.Run("valid - for doctor when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		w := schedule
+		w.Outpatients = []s.OutpatientCore{waiting}
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(w, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatient", any).
+			Return(nil).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - for nurse when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		w := schedule
+		w.Outpatients = []s.OutpatientCore{waiting}
+
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(w, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatient", any).
+			Return(nil).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, nurse1.ID, "nurse")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - lowest waiting queue number is told they're next", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		w := schedule
+		w.Outpatients = []s.OutpatientCore{
+			waiting,
+			{ID: 31, QueueNumber: 4, Status: s.StatusWaiting},
+			{ID: 32, QueueNumber: 3, Status: s.StatusWaiting},
+			{ID: 33, QueueNumber: 1, Status: s.StatusCanceled},
+		}
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(w, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatient", any).
+			Return(nil).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
+		assert.Nil(t, err)
+		notifier.AssertCalled(t, "NotifyOutpatients", s.NotifyNext, mock.MatchedBy(func(outpatients []s.OutpatientCore) bool {
+			return len(outpatients) == 1 && outpatients[0].ID == 32 && outpatients[0].WorkSchedule.ID == waiting.WorkSchedule.ID
+		}))
+		notifier.AssertNotCalled(t, "NotifyOutpatients", s.NotifyNext, notified(31))
+	})
+
+	t.Run("valid - when role is unknown", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, 1, "unknown")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - for doctor that is not his schedule", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, 2, "doctor")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - for nurse that is not her schedule", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, 2, "nurse")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(s.OutpatientCore{}, errNotFound).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when outpatient is not on waiting state", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(onprogress, nil).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - SelectOutpatientsByWorkScheduleId error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(s.WorkScheduleCore{ID: 100}, errNotFound).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when schedule examination is not available", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		w := schedule
+
+		w.Outpatients = []s.OutpatientCore{waiting}
+		w.StartTime = now.Add(-6 * time.Hour).Format(timeFormat)
+		w.EndTime = now.Add(-3 * time.Hour).Format(timeFormat)
+
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(w, nil).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, nurse1.ID, "nurse")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when there's an ongoing examination", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		w := schedule
+		w.Outpatients = []s.OutpatientCore{onprogress}
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(w, nil).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - UpdateOutpatient error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		w := schedule
+		w.Outpatients = []s.OutpatientCore{waiting}
+		repo.
+			On("SelectOutpatientsByWorkScheduleId", anyInt).
+			Return(w, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatient", any).
+			Return(errServer).
+			Once()
+
+		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
+		assert.Error(t, err)
+	})
+}
+
+func TestFinishOutpatient(t *testing.T) {
+	onprogress := s.OutpatientCore{
+		Status:       s.StatusOnprogress,
+		WorkSchedule: workSchedule1,
+	}
+	waiting := s.OutpatientCore{Status: s.StatusWaiting}
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(onprogress, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatientReservingStock", mock.MatchedBy(func(o s.OutpatientCore) bool {
+				if len(o.Prescriptions) != 1 {
+					return false
+				}
+				p := o.Prescriptions[0]
+				return p.ID == 0 && p.Version == 1 && p.Status == s.PrescriptionActive && p.PrescribedBy == doctor1.ID
+			}), mock.AnythingOfType("string")).
+			Return(nil).
+			Once()
+
+		finished := onprogress
+		finished.Prescriptions = []s.PrescriptionCore{{ID: 9, Medicine: "Paracetamol", Dose: 500, Unit: "mg", Quantity: 10}}
+		err := business.FinishOutpatient(finished, doctor1.ID, "doctor")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - prescriptions from the catalogue are saved with their stock", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(onprogress, nil).
+			Once()
+
+		medicineBusiness.
+			On("FindMedicinesByIds", []int{3}).
+			Return([]md.MedicineCore{{ID: 3, Name: "Paracetamol 500 mg", Stock: 10}}, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatientReservingStock", mock.MatchedBy(func(o s.OutpatientCore) bool {
+				return len(o.Prescriptions) == 1 && o.Prescriptions[0].Medicine == "Paracetamol 500 mg"
+			}), mock.AnythingOfType("string")).
+			Return(nil).
+			Once()
+
+		finished := onprogress
+		finished.Prescriptions = []s.PrescriptionCore{{MedicineID: 3, Medicine: "paracetamol", Dose: 500, Unit: "mg", Quantity: 10}}
+		err := business.FinishOutpatient(finished, doctor1.ID, "doctor")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when prescribed medicine is short on stock", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(onprogress, nil).
+			Once()
+
+		amoxicillin := md.MedicineCore{ID: 5, Name: "Amoxicillin 500 mg", Stock: 4}
+		medicineBusiness.
+			On("FindMedicinesByIds", []int{5, 5}).
+			Return([]md.MedicineCore{amoxicillin}, nil).
+			Once()
+
+		payload := errors.ErrPayload{Data: map[string]interface{}{"medicineIds": []int{5}}}
+		repo.
+			On("UpdateOutpatientReservingStock", mock.MatchedBy(func(o s.OutpatientCore) bool {
+				return len(o.Prescriptions) == 2 && o.Prescriptions[0].MedicineID == 5
+			}), mock.AnythingOfType("string")).
+			Return(errors.E(errors.New("Not enough stock"), payload, errors.KindUnprocessable)).
+			Once()
+
+		finished := onprogress
+		finished.Prescriptions = []s.PrescriptionCore{
+			{MedicineID: 5, Dose: 500, Unit: "mg", Quantity: 3},
+			{MedicineID: 5, Dose: 500, Unit: "mg", Quantity: 3},
+		}
+		err := business.FinishOutpatient(finished, doctor1.ID, "doctor")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+		assert.Equal(t, []int{5}, errors.Payload(err).Data.(map[string]interface{})["medicineIds"])
+	})
+
+	t.Run("valid - when medicine is not in the catalogue", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(onprogress, nil).
+			Once()
+
+		medicineBusiness.
+			On("FindMedicinesByIds", []int{4}).
+			Return([]md.MedicineCore{}, nil).
+			Once()
+
+		finished := onprogress
+		finished.Prescriptions = []s.PrescriptionCore{{MedicineID: 4, Dose: 500, Unit: "mg", Quantity: 10}}
+		err := business.FinishOutpatient(finished, doctor1.ID, "doctor")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when the doctor is not his work schedule", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(onprogress, nil).
+			Once()
+
+		err := business.FinishOutpatient(onprogress, 2, "doctor")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when role is not doctor", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(onprogress, nil).
+			Once()
+
+		err := business.FinishOutpatient(onprogress, 2, "admin")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(s.OutpatientCore{}, errNotFound).
+			Once()
+
+		err := business.FinishOutpatient(onprogress, doctor1.ID, "doctor")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when outpatient status is not onprogress", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		err := business.FinishOutpatient(waiting, doctor1.ID, "doctor")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - UpdateOutpatientReservingStock error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(onprogress, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatientReservingStock", any, mock.AnythingOfType("string")).
+			Return(errServer).
+			Once()
+
+		err := business.FinishOutpatient(onprogress, doctor1.ID, "doctor")
+		assert.Error(t, err)
+	})
+
+}
+
+func TestCancelOutpatient(t *testing.T) {
+	onprogress := s.OutpatientCore{Status: s.StatusOnprogress}
+	waiting := s.OutpatientCore{ID: 41, Status: s.StatusWaiting, WorkSchedule: workSchedule1}
+
+	t.Run("valid - for admin when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatient", any).
+			Return(nil).
+			Once()
+
+		err := business.CancelOutpatient(waiting.ID, 1, "admin")
+		assert.Nil(t, err)
+		notifier.AssertCalled(t, "NotifyOutpatients", s.NotifyCanceled, notified(waiting.ID))
+	})
+
+	t.Run("valid - for doctor when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatient", any).
+			Return(nil).
+			Once()
+
+		err := business.CancelOutpatient(waiting.ID, doctorCore1.ID, "doctor")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - for nurse when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		repo.
+			On("UpdateOutpatient", any).
+			Return(nil).
+			Once()
+
+		err := business.CancelOutpatient(waiting.ID, nurse1.ID, "nurse")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when doctor is not his work schedule", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		err := business.CancelOutpatient(waiting.ID, 2, "doctor")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when nurse is not her work schedule", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		err := business.CancelOutpatient(waiting.ID, 2, "nurse")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when role is unknown", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
+			Once()
+
+		err := business.CancelOutpatient(waiting.ID, 2, "unkown")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(s.OutpatientCore{}, errNotFound).
+			Once()
+
+		err := business.CancelOutpatient(waiting.ID, doctor1.ID, "doctor")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - when outpatient status is not waiting", func(t *testing.T) {
 		repo.
 			On("SelectOutpatientById", anyInt).
-			Return(outpatient1, nil).
+			Return(onprogress, nil).
 			Once()
 
-		patientBusiness.
-			On("FindPatientById", anyInt).
-			Return(p.PatientCore{}, errServer).
+		err := business.CancelOutpatient(onprogress.ID, doctor1.ID, "doctor")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - UpdateOutpatient error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
 			Once()
 
-		result, err := business.FindOutpatientById(1)
+		repo.
+			On("UpdateOutpatient", any).
+			Return(errServer).
+			Once()
 
+		err := business.CancelOutpatient(waiting.ID, doctor1.ID, "doctor")
 		assert.Error(t, err)
-		assert.Equal(t, 0, result.ID)
 	})
+}
 
-	t.Run("valid - FindDoctorById error", func(t *testing.T) {
+func TestBookVisit(t *testing.T) {
+	visit := s.OutpatientCore{WorkSchedule: s.WorkScheduleCore{ID: workSchedule1.ID}, Complaint: "Headache", Overbooked: true}
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(outpatient1, nil).
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{workSchedule1.ID}).
+			Return([]s.OutpatientCore{{ID: 2, Patient: s.PatientCore{ID: 2}}}, nil).
 			Once()
 
 		patientBusiness.
-			On("FindPatientById", anyInt).
+			On("FindPatientById", patient1.ID).
 			Return(patientCore1, nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorById", anyInt).
-			Return(d.DoctorCore{}, errServer).
+		repo.
+			On("SelectWorkScheduleById", workSchedule1.ID).
+			Return(workSchedule1, nil).
 			Once()
 
-		result, err := business.FindOutpatientById(1)
+		repo.
+			On("InsertOutpatient", mock.MatchedBy(func(o s.OutpatientCore) bool {
+				return o.Patient.ID == patient1.ID && !o.Overbooked && o.Status == s.StatusWaiting
+			})).
+			Return(1, nil).
+			Once()
+
+		err := business.BookVisit(visit, patient1.ID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when patient already waits on the work schedule", func(t *testing.T) {
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{workSchedule1.ID}).
+			Return([]s.OutpatientCore{outpatient1}, nil).
+			Once()
 
+		err := business.BookVisit(visit, patient1.ID)
 		assert.Error(t, err)
-		assert.Equal(t, 0, result.ID)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 
-	t.Run("valid - FindNurseById error", func(t *testing.T) {
+	t.Run("valid - when the full work schedule would need overbooking", func(t *testing.T) {
+		full := workSchedule1
+		full.Capacity = 1
+
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(outpatient1, nil).
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{workSchedule1.ID}).
+			Return([]s.OutpatientCore{}, nil).
 			Once()
 
 		patientBusiness.
-			On("FindPatientById", anyInt).
+			On("FindPatientById", patient1.ID).
 			Return(patientCore1, nil).
 			Once()
 
-		doctorBusiness.
-			On("FindDoctorById", anyInt).
-			Return(doctorCore1, nil).
+		repo.
+			On("SelectWorkScheduleById", workSchedule1.ID).
+			Return(full, nil).
 			Once()
 
-		nurseBusiness.
-			On("FindNurseById", anyInt).
-			Return(n.NurseCore{}, errServer).
+		repo.
+			On("SelectBookingCounts", []int{full.ID}).
+			Return(map[int]s.BookingCountCore{full.ID: {Total: 1}}, nil).
 			Once()
 
-		result, err := business.FindOutpatientById(1)
+		err := business.BookVisit(visit, patient1.ID)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - SelectWaitingOutpatientsByWorkScheduleIds error", func(t *testing.T) {
+		repo.
+			On("SelectWaitingOutpatientsByWorkScheduleIds", []int{workSchedule1.ID}).
+			Return([]s.OutpatientCore{}, errServer).
+			Once()
 
+		err := business.BookVisit(visit, patient1.ID)
 		assert.Error(t, err)
-		assert.Equal(t, 0, result.ID)
 	})
 }
 
-func TestCreateOutpatient(t *testing.T) {
+func TestCancelVisit(t *testing.T) {
+	waiting := s.OutpatientCore{ID: 1, Status: s.StatusWaiting, Patient: patient1, WorkSchedule: workSchedule1}
+
 	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectWorkScheduleById", anyInt).
-			Return(workSchedule1, nil).
-			Once()
-
-		patientBusiness.
-			On("FindPatientById", anyInt).
-			Return(patientCore1, nil).
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
 			Once()
 
 		repo.
-			On("InsertOutpatient", any).
+			On("UpdateOutpatient", mock.MatchedBy(func(o s.OutpatientCore) bool {
+				return o.Status == s.StatusCanceled
+			})).
 			Return(nil).
 			Once()
 
-		err := business.CreateOutpatient(outpatient1)
-
+		err := business.CancelVisit(waiting.ID, patient1.ID)
 		assert.Nil(t, err)
 	})
 
-	t.Run("valid - FindPatientById error", func(t *testing.T) {
-
-		patientBusiness.
-			On("FindPatientById", anyInt).
-			Return(p.PatientCore{}, errNotFound).
+	t.Run("valid - when visit belongs to another patient", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(waiting, nil).
 			Once()
 
-		err := business.CreateOutpatient(outpatient1)
-
+		err := business.CancelVisit(waiting.ID, 2)
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
 	})
 
-	t.Run("valid - SelectWorkScheduleById error", func(t *testing.T) {
-		patientBusiness.
-			On("FindPatientById", anyInt).
-			Return(patientCore1, nil).
-			Once()
+	t.Run("valid - when visit is not waiting", func(t *testing.T) {
+		onprogress := waiting
+		onprogress.Status = s.StatusOnprogress
 
 		repo.
-			On("SelectWorkScheduleById", anyInt).
-			Return(s.WorkScheduleCore{}, errNotFound).
+			On("SelectOutpatientById", waiting.ID).
+			Return(onprogress, nil).
 			Once()
 
-		err := business.CreateOutpatient(outpatient1)
+		err := business.CancelVisit(waiting.ID, patient1.ID)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", waiting.ID).
+			Return(s.OutpatientCore{}, errNotFound).
+			Once()
 
+		err := business.CancelVisit(waiting.ID, patient1.ID)
 		assert.Error(t, err)
 	})
+}
 
-	t.Run("valid - time.ParseInLocation error", func(t *testing.T) {
-		invalidWorkschedule := workSchedule1
-		invalidWorkschedule.StartTime = ""
-		invalidWorkschedule.EndTime = ""
+func TestRemoveOutpatientById(t *testing.T) {
+	onprogress := s.OutpatientCore{Status: s.StatusOnprogress}
+	waiting := s.OutpatientCore{Status: s.StatusWaiting}
 
+	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectWorkScheduleById", anyInt).
-			Return(invalidWorkschedule, nil).
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
 			Once()
 
-		patientBusiness.
-			On("FindPatientById", anyInt).
-			Return(patientCore1, nil).
+		repo.
+			On("DeleteOutpatientById", anyInt).
+			Return(nil).
 			Once()
 
-		err := business.CreateOutpatient(outpatient1)
-
-		assert.Error(t, err)
+		err := business.RemoveOutpatientById(waiting.ID, 1, "admin")
+		assert.Nil(t, err)
 	})
 
-	t.Run("valid - when work schedule has ended", func(t *testing.T) {
-		endedSchedule := workSchedule1
-		endedSchedule.Date = "1990-01-01"
+	t.Run("valid - only prescriptions still holding stock are released", func(t *testing.T) {
+		finished := s.OutpatientCore{ID: 8, Status: s.StatusFinished}
+		finished.Prescriptions = []s.PrescriptionCore{
+			{ID: 21, MedicineID: 3, Quantity: 10, Status: s.PrescriptionActive},
+			{ID: 22, MedicineID: 3, Quantity: 10, Status: s.PrescriptionDispensed},
+			{ID: 23, MedicineID: 3, Quantity: 10, Status: s.PrescriptionVoided},
+			{ID: 24, MedicineID: 3, Quantity: 10, Status: s.PrescriptionAmended},
+		}
 
 		repo.
-			On("SelectWorkScheduleById", anyInt).
-			Return(endedSchedule, nil).
+			On("SelectOutpatientById", finished.ID).
+			Return(finished, nil).
 			Once()
 
-		patientBusiness.
-			On("FindPatientById", anyInt).
-			Return(patientCore1, nil).
+		repo.
+			On("DeleteOutpatientById", finished.ID).
+			Return(nil).
+			Once()
+
+		medicineBusiness.
+			On("ReleaseStock", []int{21}).
+			Return(nil).
 			Once()
 
-		err := business.CreateOutpatient(outpatient1)
+		err := business.RemoveOutpatientById(finished.ID, 1, "admin")
+		assert.Nil(t, err)
+		medicineBusiness.AssertCalled(t, "ReleaseStock", []int{21})
+	})
+
+	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(s.OutpatientCore{}, errNotFound).
+			Once()
 
+		err := business.RemoveOutpatientById(waiting.ID, 1, "admin")
 		assert.Error(t, err)
 	})
 
-	t.Run("valid - InsertOutpatient error", func(t *testing.T) {
+	t.Run("valid - when outpatient status is onprogress", func(t *testing.T) {
 		repo.
-			On("SelectWorkScheduleById", anyInt).
-			Return(workSchedule1, nil).
+			On("SelectOutpatientById", anyInt).
+			Return(onprogress, nil).
 			Once()
 
-		patientBusiness.
-			On("FindPatientById", anyInt).
-			Return(patientCore1, nil).
+		err := business.RemoveOutpatientById(onprogress.ID, 1, "admin")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid - DeleteOutpatientById error", func(t *testing.T) {
+		repo.
+			On("SelectOutpatientById", anyInt).
+			Return(waiting, nil).
 			Once()
 
 		repo.
-			On("InsertOutpatient", any).
+			On("DeleteOutpatientById", anyInt).
 			Return(errServer).
 			Once()
 
-		err := business.CreateOutpatient(outpatient1)
+		err := business.RemoveOutpatientById(waiting.ID, 1, "admin")
+		assert.Error(t, err)
+	})
+}
+
+func TestRemovePatientWaitingOutpatients(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("DeleteWaitingOutpatientsByPatientId", anyInt).
+			Return(nil).
+			Once()
+
+		err := business.RemovePatientWaitingOutpatients(1)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - DeleteWaitingOutpatientsByPatientId error", func(t *testing.T) {
+		repo.
+			On("DeleteWaitingOutpatientsByPatientId", anyInt).
+			Return(errServer).
+			Once()
 
+		err := business.RemovePatientWaitingOutpatients(1)
 		assert.Error(t, err)
 	})
 }
 
-func TestEditOutpatient(t *testing.T) {
+func TestMarkNoShows(t *testing.T) {
+	ended := s.WorkScheduleCore{
+		ID:        2,
+		Date:      "2000-01-01",
+		StartTime: "08:00:00",
+		EndTime:   "12:00:00",
+		Outpatients: []s.OutpatientCore{
+			{ID: 2, Status: s.StatusWaiting, Patient: patient1},
+			{ID: 3, Status: s.StatusWaiting, Patient: patient1},
+		},
+	}
+
 	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(outpatient1, nil).
+			On("SelectWaitingWorkSchedulesUntil", mock.AnythingOfType("string")).
+			Return([]s.WorkScheduleCore{ended, workSchedule1}, nil).
 			Once()
 
 		repo.
-			On("UpdateOutpatient", any).
+			On("UpdateOutpatientsStatus", []int{2, 3}, s.StatusWaiting, s.StatusNoShow).
 			Return(nil).
 			Once()
 
-		err := business.EditOutpatient(outpatient1)
-
+		total, err := business.MarkNoShows()
 		assert.Nil(t, err)
+		assert.Equal(t, 2, total)
 	})
 
-	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
+	t.Run("valid - when no work schedule is over yet", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(s.OutpatientCore{}, errNotFound).
+			On("SelectWaitingWorkSchedulesUntil", mock.AnythingOfType("string")).
+			Return([]s.WorkScheduleCore{workSchedule1}, nil).
 			Once()
 
-		err := business.EditOutpatient(outpatient1)
+		total, err := business.MarkNoShows()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, total)
+	})
+
+	t.Run("valid - SelectWaitingWorkSchedulesUntil error", func(t *testing.T) {
+		repo.
+			On("SelectWaitingWorkSchedulesUntil", mock.AnythingOfType("string")).
+			Return([]s.WorkScheduleCore{}, errServer).
+			Once()
 
+		_, err := business.MarkNoShows()
 		assert.Error(t, err)
 	})
 
-	t.Run("valid - when everything is fine", func(t *testing.T) {
+	t.Run("valid - UpdateOutpatientsStatus error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(outpatient1, nil).
+			On("SelectWaitingWorkSchedulesUntil", mock.AnythingOfType("string")).
+			Return([]s.WorkScheduleCore{ended}, nil).
 			Once()
 
 		repo.
-			On("UpdateOutpatient", any).
+			On("UpdateOutpatientsStatus", []int{2, 3}, s.StatusWaiting, s.StatusNoShow).
 			Return(errServer).
 			Once()
 
-		err := business.EditOutpatient(outpatient1)
-
+		_, err := business.MarkNoShows()
 		assert.Error(t, err)
 	})
 }
 
-func TestExamineOutpatient(t *testing.T) {
-	onprogress := s.OutpatientCore{
-		Status: s.StatusOnprogress,
-		WorkSchedule: s.WorkScheduleCore{
-			ID:     2,
-			Doctor: s.DoctorCore{ID: 2},
-			Nurse:  s.NurseCore{ID: 2},
-		},
-	}
-	waiting := s.OutpatientCore{
-		Status: s.StatusWaiting,
-		WorkSchedule: s.WorkScheduleCore{
-			ID:     1,
-			Doctor: doctor1,
-			Nurse:  nurse1,
-		},
-	}
+func TestFindNoShowCounts(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectNoShowCounts", []int{patient1.ID}).
+			Return(map[int]int{patient1.ID: 3}, nil).
+			Once()
 
-	yearFormat := "2006-01-02"
-	timeFormat := "15:04:05"
-	now := time.Now().In(config.GetTimeLoc())
+		counts, err := business.FindNoShowCounts([]int{patient1.ID})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, counts[patient1.ID])
+	})
 
-	schedule := s.WorkScheduleCore{
-		Date:      now.Format(yearFormat),
-		StartTime: now.Format(timeFormat),
-		EndTime:   now.Add(2 * time.Hour).Format(timeFormat),
-	}
+	t.Run("valid - when there are no patients", func(t *testing.T) {
+		counts, err := business.FindNoShowCounts([]int{})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(counts))
+	})
 
-	t.Run("valid - for doctor when everything is fine", func(t *testing.T) {
+	t.Run("valid - SelectNoShowCounts error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectNoShowCounts", []int{patient1.ID}).
+			Return(map[int]int{}, errServer).
 			Once()
 
-		w := schedule
-		w.Outpatients = []s.OutpatientCore{waiting}
+		_, err := business.FindNoShowCounts([]int{patient1.ID})
+		assert.Error(t, err)
+	})
+}
+
+func TestSendVisitReminders(t *testing.T) {
+	tomorrow := time.Now().In(config.GetTimeLoc()).AddDate(0, 0, 1).Format("2006-01-02")
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		ws := workSchedule1
+		ws.Date = tomorrow
+		ws.Outpatients = []s.OutpatientCore{
+			{ID: 50, Status: s.StatusWaiting, Patient: patient1},
+			{ID: 51, Status: s.StatusWaiting, Patient: patient1},
+		}
+
 		repo.
-			On("SelectOutpatientsByWorkScheduleId", anyInt).
-			Return(w, nil).
+			On("SelectWorkSchedulesByDates", []string{tomorrow}).
+			Return([]s.WorkScheduleCore{ws}, nil).
 			Once()
 
+		total, err := business.SendVisitReminders()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, total)
+		notifier.AssertCalled(t, "NotifyOutpatients", s.NotifyReminder, mock.MatchedBy(func(outpatients []s.OutpatientCore) bool {
+			return len(outpatients) == 2 && outpatients[0].ID == 50 && outpatients[1].WorkSchedule.Date == tomorrow
+		}))
+	})
+
+	t.Run("valid - when nobody is waiting tomorrow", func(t *testing.T) {
 		repo.
-			On("UpdateOutpatient", any).
-			Return(nil).
+			On("SelectWorkSchedulesByDates", []string{tomorrow}).
+			Return([]s.WorkScheduleCore{}, nil).
 			Once()
 
-		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
+		total, err := business.SendVisitReminders()
 		assert.Nil(t, err)
+		assert.Equal(t, 0, total)
 	})
 
-	t.Run("valid - for nurse when everything is fine", func(t *testing.T) {
+	t.Run("valid - SelectWorkSchedulesByDates error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectWorkSchedulesByDates", []string{tomorrow}).
+			Return([]s.WorkScheduleCore{}, errServer).
 			Once()
 
-		w := schedule
-		w.Outpatients = []s.OutpatientCore{waiting}
+		_, err := business.SendVisitReminders()
+		assert.Error(t, err)
+	})
+}
+
+func TestAmendPrescription(t *testing.T) {
+	finished := outpatient1
+	finished.Status = s.StatusFinished
+	finished.WorkSchedule = workSchedule1
+
+	active := s.PrescriptionCore{ID: 5, OutpatientID: finished.ID, OriginalID: 5, Version: 1, Status: s.PrescriptionActive, Medicine: "Amoxicillin"}
+	change := s.PrescriptionCore{ID: active.ID, Medicine: "Amoxicillin", Dose: 250, Unit: "mg", Quantity: 15, Reason: "dose too high"}
 
+	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientsByWorkScheduleId", anyInt).
-			Return(w, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(active, nil).
 			Once()
 
 		repo.
-			On("UpdateOutpatient", any).
-			Return(nil).
+			On("SelectOutpatientById", finished.ID).
+			Return(finished, nil).
 			Once()
 
-		err := business.ExamineOutpatient(outpatient1.ID, nurse1.ID, "nurse")
+		repo.
+			On("AmendPrescription", active.ID, mock.MatchedBy(func(p s.PrescriptionCore) bool {
+				return p.ID == 0 && p.OriginalID == active.OriginalID && p.Version == 2 && p.Status == s.PrescriptionActive &&
+					p.OutpatientID == finished.ID && p.PrescribedBy == doctor1.ID && p.Dose == 250 && p.Reason == "dose too high"
+			})).
+			Return(6, nil).
+			Once()
+
+		amended, err := business.AmendPrescription(change, doctor1.ID, "doctor")
 		assert.Nil(t, err)
+		assert.Equal(t, 6, amended.ID)
+		assert.Equal(t, 2, amended.Version)
+		auditBusiness.AssertCalled(t, "Record", mock.MatchedBy(func(log audits.AuditLogCore) bool {
+			return log.Operation == "schedules.business.AmendPrescription" && log.Entity == audits.EntityPrescription && log.EntityID == 6
+		}))
 	})
 
-	t.Run("valid - when role is unknown", func(t *testing.T) {
+	t.Run("valid - when prescription is not the current version", func(t *testing.T) {
+		amended := active
+		amended.Status = s.PrescriptionAmended
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(amended, nil).
 			Once()
 
-		err := business.ExamineOutpatient(outpatient1.ID, 1, "unknown")
+		_, err := business.AmendPrescription(change, doctor1.ID, "doctor")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 
-	t.Run("valid - for doctor that is not his schedule", func(t *testing.T) {
+	t.Run("valid - when prescription is already dispensed", func(t *testing.T) {
+		dispensed := active
+		dispensed.Status = s.PrescriptionDispensed
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(dispensed, nil).
 			Once()
 
-		err := business.ExamineOutpatient(outpatient1.ID, 2, "doctor")
+		_, err := business.AmendPrescription(change, doctor1.ID, "doctor")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+		assert.Equal(t, "Prescription has already been dispensed", string(errors.ClientMessage(err)))
 	})
 
-	t.Run("valid - for nurse that is not her schedule", func(t *testing.T) {
+	t.Run("valid - stock moves to the new version", func(t *testing.T) {
+		stocked := active
+		stocked.MedicineID = 3
+		stocked.Quantity = 20
+
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(stocked, nil).
 			Once()
 
-		err := business.ExamineOutpatient(outpatient1.ID, 2, "nurse")
-		assert.Error(t, err)
-	})
-
-	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(s.OutpatientCore{}, errNotFound).
+			On("SelectOutpatientById", finished.ID).
+			Return(finished, nil).
 			Once()
 
-		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
-		assert.Error(t, err)
-	})
+		medicineBusiness.
+			On("FindMedicinesByIds", []int{3}).
+			Return([]md.MedicineCore{{ID: 3, Name: "Amoxicillin 500 mg"}}, nil).
+			Once()
 
-	t.Run("valid - when outpatient is not on waiting state", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(onprogress, nil).
+			On("AmendPrescription", active.ID, mock.MatchedBy(func(p s.PrescriptionCore) bool {
+				return p.MedicineID == 3 && p.Medicine == "Amoxicillin 500 mg"
+			})).
+			Return(8, nil).
 			Once()
 
-		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
-		assert.Error(t, err)
+		medicineBusiness.
+			On("ReleaseStock", []int{active.ID}).
+			Return(nil).
+			Once()
+
+		medicineBusiness.
+			On("ReserveStock", []md.ReservationCore{{PrescriptionID: 8, MedicineID: 3, Quantity: 15}}).
+			Return([]md.ReservationCore{}, nil).
+			Once()
+
+		restocked := change
+		restocked.MedicineID = 3
+		_, err := business.AmendPrescription(restocked, doctor1.ID, "doctor")
+		assert.Nil(t, err)
+		medicineBusiness.AssertCalled(t, "ReleaseStock", []int{active.ID})
+		medicineBusiness.AssertCalled(t, "ReserveStock", []md.ReservationCore{{PrescriptionID: 8, MedicineID: 3, Quantity: 15}})
 	})
 
-	t.Run("valid - SelectOutpatientsByWorkScheduleId error", func(t *testing.T) {
+	t.Run("valid - when outpatient is not finished", func(t *testing.T) {
+		onprogress := finished
+		onprogress.Status = s.StatusOnprogress
+
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(active, nil).
 			Once()
 
 		repo.
-			On("SelectOutpatientsByWorkScheduleId", anyInt).
-			Return(s.WorkScheduleCore{ID: 100}, errNotFound).
+			On("SelectOutpatientById", finished.ID).
+			Return(onprogress, nil).
 			Once()
 
-		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
+		_, err := business.AmendPrescription(change, doctor1.ID, "doctor")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 
-	t.Run("valid - when schedule examination is not available", func(t *testing.T) {
+	t.Run("valid - when doctor is not of the work schedule", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(active, nil).
 			Once()
 
-		w := schedule
-
-		w.Outpatients = []s.OutpatientCore{waiting}
-		w.StartTime = now.Add(-6 * time.Hour).Format(timeFormat)
-		w.EndTime = now.Add(-3 * time.Hour).Format(timeFormat)
-
 		repo.
-			On("SelectOutpatientsByWorkScheduleId", anyInt).
-			Return(w, nil).
+			On("SelectOutpatientById", finished.ID).
+			Return(finished, nil).
 			Once()
 
-		err := business.ExamineOutpatient(outpatient1.ID, nurse1.ID, "nurse")
+		_, err := business.AmendPrescription(change, 2, "doctor")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
 	})
 
-	t.Run("valid - when there's an ongoing examination", func(t *testing.T) {
-		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
-			Once()
-
-		w := schedule
-		w.Outpatients = []s.OutpatientCore{onprogress}
+	t.Run("valid - when prescription is not found", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientsByWorkScheduleId", anyInt).
-			Return(w, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(s.PrescriptionCore{}, errNotFound).
 			Once()
 
-		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
+		_, err := business.AmendPrescription(change, doctor1.ID, "doctor")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
 	})
 
-	t.Run("valid - UpdateOutpatient error", func(t *testing.T) {
+	t.Run("valid - AmendPrescription error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(active, nil).
 			Once()
 
-		w := schedule
-		w.Outpatients = []s.OutpatientCore{waiting}
 		repo.
-			On("SelectOutpatientsByWorkScheduleId", anyInt).
-			Return(w, nil).
+			On("SelectOutpatientById", finished.ID).
+			Return(finished, nil).
 			Once()
 
 		repo.
-			On("UpdateOutpatient", any).
-			Return(errServer).
+			On("AmendPrescription", active.ID, mock.AnythingOfType("schedules.PrescriptionCore")).
+			Return(0, errServer).
 			Once()
 
-		err := business.ExamineOutpatient(outpatient1.ID, doctor1.ID, "doctor")
+		_, err := business.AmendPrescription(change, doctor1.ID, "doctor")
 		assert.Error(t, err)
 	})
 }
 
-func TestFinishOutpatient(t *testing.T) {
-	onprogress := s.OutpatientCore{
-		Status:       s.StatusOnprogress,
-		WorkSchedule: workSchedule1,
-	}
-	waiting := s.OutpatientCore{Status: s.StatusWaiting}
+func TestVoidPrescription(t *testing.T) {
+	finished := outpatient1
+	finished.Status = s.StatusFinished
+	finished.WorkSchedule = workSchedule1
+
+	active := s.PrescriptionCore{ID: 7, OutpatientID: finished.ID, OriginalID: 7, Version: 1, Status: s.PrescriptionActive}
 
 	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(onprogress, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(active, nil).
 			Once()
 
 		repo.
-			On("UpdateOutpatient", any).
+			On("SelectOutpatientById", finished.ID).
+			Return(finished, nil).
+			Once()
+
+		repo.
+			On("VoidPrescription", mock.MatchedBy(func(p s.PrescriptionCore) bool {
+				return p.ID == active.ID && p.Status == s.PrescriptionVoided && p.VoidedBy == doctor1.ID && p.VoidReason == "allergy" && !p.VoidedAt.IsZero()
+			})).
 			Return(nil).
 			Once()
 
-		err := business.FinishOutpatient(onprogress, doctor1.ID, "doctor")
+		err := business.VoidPrescription(active.ID, "allergy", doctor1.ID, "doctor")
 		assert.Nil(t, err)
 	})
 
-	t.Run("valid - when the doctor is not his work schedule", func(t *testing.T) {
+	t.Run("valid - when prescription is already voided", func(t *testing.T) {
+		voided := active
+		voided.Status = s.PrescriptionVoided
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(onprogress, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(voided, nil).
 			Once()
 
-		err := business.FinishOutpatient(onprogress, 2, "doctor")
+		err := business.VoidPrescription(active.ID, "allergy", doctor1.ID, "doctor")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 
 	t.Run("valid - when role is not doctor", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(onprogress, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(active, nil).
 			Once()
 
-		err := business.FinishOutpatient(onprogress, 2, "admin")
-		assert.Error(t, err)
-	})
-
-	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(s.OutpatientCore{}, errNotFound).
+			On("SelectOutpatientById", finished.ID).
+			Return(finished, nil).
 			Once()
 
-		err := business.FinishOutpatient(onprogress, doctor1.ID, "doctor")
+		err := business.VoidPrescription(active.ID, "allergy", nurse1.ID, "nurse")
 		assert.Error(t, err)
 	})
 
-	t.Run("valid - when outpatient status is not onprogress", func(t *testing.T) {
+	t.Run("valid - VoidPrescription error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectPrescriptionById", active.ID).
+			Return(active, nil).
 			Once()
 
-		err := business.FinishOutpatient(waiting, doctor1.ID, "doctor")
-		assert.Error(t, err)
-	})
-
-	t.Run("valid - UpdateOutpatient error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(onprogress, nil).
+			On("SelectOutpatientById", finished.ID).
+			Return(finished, nil).
 			Once()
 
 		repo.
-			On("UpdateOutpatient", any).
+			On("VoidPrescription", mock.AnythingOfType("schedules.PrescriptionCore")).
 			Return(errServer).
 			Once()
 
-		err := business.FinishOutpatient(onprogress, doctor1.ID, "doctor")
+		err := business.VoidPrescription(active.ID, "allergy", doctor1.ID, "doctor")
 		assert.Error(t, err)
 	})
-
 }
 
-func TestCancelOutpatient(t *testing.T) {
-	onprogress := s.OutpatientCore{Status: s.StatusOnprogress}
-	waiting := s.OutpatientCore{Status: s.StatusWaiting, WorkSchedule: workSchedule1}
+func TestFindPrescriptionVersions(t *testing.T) {
+	finished := outpatient1
+	finished.Status = s.StatusFinished
+	finished.WorkSchedule = workSchedule1
 
-	t.Run("valid - for admin when everything is fine", func(t *testing.T) {
+	versions := []s.PrescriptionCore{
+		{ID: 5, OriginalID: 5, Version: 1, Status: s.PrescriptionAmended, OutpatientID: finished.ID},
+		{ID: 6, OriginalID: 5, Version: 2, Status: s.PrescriptionActive, OutpatientID: finished.ID},
+	}
+
+	t.Run("valid - for doctor of the work schedule", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectPrescriptionById", 6).
+			Return(versions[1], nil).
 			Once()
 
 		repo.
-			On("UpdateOutpatient", any).
-			Return(nil).
+			On("SelectOutpatientById", finished.ID).
+			Return(finished, nil).
 			Once()
 
-		err := business.CancelOutpatient(waiting.ID, 1, "admin")
-		assert.Nil(t, err)
-	})
-
-	t.Run("valid - for doctor when everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectPrescriptionVersions", 5).
+			Return(versions, nil).
 			Once()
 
-		repo.
-			On("UpdateOutpatient", any).
+		auditBusiness.
+			On("RecordAccess", patientAccessLogs("schedules.business.FindPrescriptionVersions")).
 			Return(nil).
 			Once()
 
-		err := business.CancelOutpatient(waiting.ID, doctorCore1.ID, "doctor")
+		result, err := business.FindPrescriptionVersions(6, doctor1.ID, "doctor")
 		assert.Nil(t, err)
+		assert.Equal(t, 2, len(result))
+		assert.Equal(t, s.PrescriptionAmended, result[0].Status)
 	})
 
-	t.Run("valid - for nurse when everything is fine", func(t *testing.T) {
-		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
-			Once()
-
+	t.Run("valid - when reader is outside the care team", func(t *testing.T) {
 		repo.
-			On("UpdateOutpatient", any).
-			Return(nil).
+			On("SelectPrescriptionById", 6).
+			Return(versions[1], nil).
 			Once()
 
-		err := business.CancelOutpatient(waiting.ID, nurse1.ID, "nurse")
-		assert.Nil(t, err)
-	})
-
-	t.Run("valid - when doctor is not his work schedule", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectOutpatientById", finished.ID).
+			Return(finished, nil).
 			Once()
 
-		err := business.CancelOutpatient(waiting.ID, 2, "doctor")
+		_, err := business.FindPrescriptionVersions(6, 1, "receptionist")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
 	})
 
-	t.Run("valid - when nurse is not her work schedule", func(t *testing.T) {
+	t.Run("valid - SelectPrescriptionVersions error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectPrescriptionById", 6).
+			Return(versions[1], nil).
 			Once()
 
-		err := business.CancelOutpatient(waiting.ID, 2, "nurse")
-		assert.Error(t, err)
-	})
-
-	t.Run("valid - when role is unknown", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectOutpatientById", finished.ID).
+			Return(finished, nil).
 			Once()
 
-		err := business.CancelOutpatient(waiting.ID, 2, "unkown")
-		assert.Error(t, err)
-	})
-
-	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(s.OutpatientCore{}, errNotFound).
+			On("SelectPrescriptionVersions", 5).
+			Return([]s.PrescriptionCore{}, errServer).
 			Once()
 
-		err := business.CancelOutpatient(waiting.ID, doctor1.ID, "doctor")
+		_, err := business.FindPrescriptionVersions(6, doctor1.ID, "doctor")
 		assert.Error(t, err)
 	})
+}
 
-	t.Run("valid - when outpatient status is not waiting", func(t *testing.T) {
+func TestDispensePrescriptions(t *testing.T) {
+	stocked := s.PrescriptionCore{ID: 21, OutpatientID: 1, Status: s.PrescriptionActive, MedicineID: 3, Quantity: 10}
+	written := s.PrescriptionCore{ID: 22, OutpatientID: 1, Status: s.PrescriptionActive, Medicine: "Compounded powder", Quantity: 9}
+	requests := []md.ReservationCore{{PrescriptionID: stocked.ID, MedicineID: 3, Quantity: 10}}
+
+	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(onprogress, nil).
+			On("SelectPrescriptionsByIds", []int{stocked.ID, written.ID}).
+			Return([]s.PrescriptionCore{stocked, written}, nil).
 			Once()
 
-		err := business.CancelOutpatient(onprogress.ID, doctor1.ID, "doctor")
-		assert.Error(t, err)
-	})
-
-	t.Run("valid - UpdateOutpatient error", func(t *testing.T) {
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("DispensePrescriptions", []int{stocked.ID, written.ID}, 7, mock.AnythingOfType("time.Time")).
+			Return(nil).
 			Once()
 
-		repo.
-			On("UpdateOutpatient", any).
-			Return(errServer).
+		medicineBusiness.
+			On("DispenseStock", requests, 7).
+			Return(nil).
 			Once()
 
-		err := business.CancelOutpatient(waiting.ID, doctor1.ID, "doctor")
-		assert.Error(t, err)
+		err := business.DispensePrescriptions([]int{stocked.ID, written.ID, stocked.ID}, 7, "pharmacist")
+		assert.Nil(t, err)
+		auditBusiness.AssertCalled(t, "Record", mock.MatchedBy(func(log audits.AuditLogCore) bool {
+			return log.Operation == "schedules.business.DispensePrescriptions" && log.EntityID == written.ID
+		}))
 	})
-}
-
-func TestRemoveOutpatientById(t *testing.T) {
-	onprogress := s.OutpatientCore{Status: s.StatusOnprogress}
-	waiting := s.OutpatientCore{Status: s.StatusWaiting}
 
-	t.Run("valid - when everything is fine", func(t *testing.T) {
-		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
-			Once()
+	t.Run("valid - when role cannot dispense", func(t *testing.T) {
+		err := business.DispensePrescriptions([]int{stocked.ID}, doctor1.ID, "doctor")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnauthorized, errors.Kind(err))
+	})
 
+	t.Run("valid - when prescription is not found", func(t *testing.T) {
 		repo.
-			On("DeleteOutpatientById", anyInt).
-			Return(nil).
+			On("SelectPrescriptionsByIds", []int{stocked.ID, 99}).
+			Return([]s.PrescriptionCore{stocked}, nil).
 			Once()
 
-		err := business.RemoveOutpatientById(waiting.ID)
-		assert.Nil(t, err)
+		err := business.DispensePrescriptions([]int{stocked.ID, 99}, 7, "pharmacist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
 	})
 
-	t.Run("valid - SelectOutpatientById error", func(t *testing.T) {
+	t.Run("valid - when prescription is already dispensed", func(t *testing.T) {
+		dispensed := stocked
+		dispensed.Status = s.PrescriptionDispensed
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(s.OutpatientCore{}, errNotFound).
+			On("SelectPrescriptionsByIds", []int{stocked.ID}).
+			Return([]s.PrescriptionCore{dispensed}, nil).
 			Once()
 
-		err := business.RemoveOutpatientById(waiting.ID)
+		err := business.DispensePrescriptions([]int{stocked.ID}, 7, "pharmacist")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 
-	t.Run("valid - when outpatient status is onprogress", func(t *testing.T) {
+	t.Run("valid - when prescription is voided", func(t *testing.T) {
+		voided := stocked
+		voided.Status = s.PrescriptionVoided
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(onprogress, nil).
+			On("SelectPrescriptionsByIds", []int{stocked.ID}).
+			Return([]s.PrescriptionCore{voided}, nil).
 			Once()
 
-		err := business.RemoveOutpatientById(onprogress.ID)
+		err := business.DispensePrescriptions([]int{stocked.ID}, 7, "pharmacist")
 		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
 	})
 
-	t.Run("valid - DeleteOutpatientById error", func(t *testing.T) {
+	t.Run("valid - when stock is short the prescriptions stay active", func(t *testing.T) {
+		errShort := errors.E(errors.New("short"), errors.KindUnprocessable)
+
 		repo.
-			On("SelectOutpatientById", anyInt).
-			Return(waiting, nil).
+			On("SelectPrescriptionsByIds", []int{stocked.ID}).
+			Return([]s.PrescriptionCore{stocked}, nil).
 			Once()
 
 		repo.
-			On("DeleteOutpatientById", anyInt).
-			Return(errServer).
+			On("DispensePrescriptions", []int{stocked.ID}, 7, mock.AnythingOfType("time.Time")).
+			Return(nil).
 			Once()
 
-		err := business.RemoveOutpatientById(waiting.ID)
-		assert.Error(t, err)
-	})
-}
+		medicineBusiness.
+			On("DispenseStock", requests, 7).
+			Return(errShort).
+			Once()
 
-func TestRemovePatientWaitingOutpatients(t *testing.T) {
-	t.Run("valid - when everything is fine", func(t *testing.T) {
 		repo.
-			On("DeleteWaitingOutpatientsByPatientId", anyInt).
+			On("RevertDispensedPrescriptions", []int{stocked.ID}).
 			Return(nil).
 			Once()
 
-		err := business.RemovePatientWaitingOutpatients(1)
-		assert.Nil(t, err)
+		err := business.DispensePrescriptions([]int{stocked.ID}, 7, "pharmacist")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+		repo.AssertCalled(t, "RevertDispensedPrescriptions", []int{stocked.ID})
 	})
 
-	t.Run("valid - DeleteWaitingOutpatientsByPatientId error", func(t *testing.T) {
+	t.Run("valid - DispensePrescriptions error", func(t *testing.T) {
 		repo.
-			On("DeleteWaitingOutpatientsByPatientId", anyInt).
+			On("SelectPrescriptionsByIds", []int{stocked.ID}).
+			Return([]s.PrescriptionCore{stocked}, nil).
+			Once()
+
+		repo.
+			On("DispensePrescriptions", []int{stocked.ID}, 7, mock.AnythingOfType("time.Time")).
 			Return(errServer).
 			Once()
 
-		err := business.RemovePatientWaitingOutpatients(1)
+		err := business.DispensePrescriptions([]int{stocked.ID}, 7, "pharmacist")
 		assert.Error(t, err)
 	})
 }
@@ -0,0 +1,40 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/jobs"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+// NoShowJob marks no-shows on NO_SHOW_SCHEDULE
+func NoShowJob(business schedules.IBusiness) jobs.JobCore {
+	return jobs.JobCore{
+		Name:        "no-shows",
+		Description: "Marks outpatients still waiting after the examine window as no-show",
+		Schedule:    schedules.NO_SHOW_SCHEDULE,
+		Func: func() (string, error) {
+			total, err := business.MarkNoShows()
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d outpatients marked as no-show", total), nil
+		},
+	}
+}
+
+// ReminderJob reminds patients of their visits tomorrow on REMINDER_SCHEDULE
+func ReminderJob(business schedules.IBusiness) jobs.JobCore {
+	return jobs.JobCore{
+		Name:        "visit-reminders",
+		Description: "Reminds patients of their outpatient visits tomorrow",
+		Schedule:    schedules.REMINDER_SCHEDULE,
+		Func: func() (string, error) {
+			total, err := business.SendVisitReminders()
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d outpatients to remind", total), nil
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package business
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+// queueHub fans queue events out to the displays subscribed to a work schedule or a room.
+// It is kept in memory, each instance of the API only reaches its own subscribers.
+type queueHub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*queueSubscriber]bool
+}
+
+type queueSubscriber struct {
+	events chan schedules.QueueEventCore
+	once   sync.Once
+}
+
+// NewQueueHub is shared by every schedule business of the API through SetQueueHub, so
+// events published by one reach the displays subscribed through another
+func NewQueueHub() *queueHub {
+	return &queueHub{subscribers: make(map[string]map[*queueSubscriber]bool)}
+}
+
+func workScheduleTopic(workScheduleId int) string {
+	return fmt.Sprintf("work-schedules/%d", workScheduleId)
+}
+
+func roomTopic(roomId int) string {
+	return fmt.Sprintf("rooms/%d", roomId)
+}
+
+// subscribe returns the events of topic and the function that ends the subscription
+func (h *queueHub) subscribe(topic string) (<-chan schedules.QueueEventCore, func()) {
+	sub := &queueSubscriber{events: make(chan schedules.QueueEventCore, schedules.QUEUE_EVENT_BUFFER)}
+
+	h.mu.Lock()
+	if h.subscribers[topic] == nil {
+		h.subscribers[topic] = make(map[*queueSubscriber]bool)
+	}
+	h.subscribers[topic][sub] = true
+	h.mu.Unlock()
+
+	return sub.events, func() { h.remove(topic, sub) }
+}
+
+// idle tells whether nobody listens, events are not even built then
+func (h *queueHub) idle() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers) == 0
+}
+
+// publish never blocks, a subscriber whose buffer is full is dropped and its channel
+// closed so the display reconnects and reloads the queue instead of missing events
+func (h *queueHub) publish(topic string, event schedules.QueueEventCore) {
+	h.mu.RLock()
+	slow := []*queueSubscriber{}
+	for sub := range h.subscribers[topic] {
+		select {
+		case sub.events <- event:
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, sub := range slow {
+		h.remove(topic, sub)
+	}
+}
+
+func (h *queueHub) remove(topic string, sub *queueSubscriber) {
+	h.mu.Lock()
+	delete(h.subscribers[topic], sub)
+	if len(h.subscribers[topic]) == 0 {
+		delete(h.subscribers, topic)
+	}
+	h.mu.Unlock()
+
+	sub.once.Do(func() { close(sub.events) })
+}
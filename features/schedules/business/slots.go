@@ -0,0 +1,267 @@
+package business
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+// slotsOf lists the appointment slots of a work schedule, they start with the shift and
+// a last slot that would run past its end is left out
+func slotsOf(ws schedules.WorkScheduleCore) ([]schedules.SlotCore, error) {
+	if ws.SlotMinutes <= 0 {
+		return []schedules.SlotCore{}, nil
+	}
+
+	start, err := time.Parse("15:04:05", ws.StartTime)
+	if err != nil {
+		return []schedules.SlotCore{}, err
+	}
+	end, err := time.Parse("15:04:05", ws.EndTime)
+	if err != nil {
+		return []schedules.SlotCore{}, err
+	}
+
+	length := time.Duration(ws.SlotMinutes) * time.Minute
+	slots := []schedules.SlotCore{}
+	for t := start; !t.Add(length).After(end); t = t.Add(length) {
+		slots = append(slots, schedules.SlotCore{
+			StartTime: t.Format("15:04:05"),
+			EndTime:   t.Add(length).Format("15:04:05"),
+			Capacity:  ws.SlotCapacity,
+		})
+	}
+	return slots, nil
+}
+
+// slotEnd is when the slot of a work schedule is over
+func slotEnd(ws schedules.WorkScheduleCore, slot schedules.SlotCore) (time.Time, error) {
+	value := fmt.Sprintf("%sT%s", ws.Date, slot.EndTime)
+	return time.ParseInLocation("2006-01-02T15:04:05", value, config.GetTimeLoc())
+}
+
+// checkSlotSettings fills the slot capacity left out and makes sure the shift has at
+// least one slot
+func (s *scheduleBusiness) checkSlotSettings(ws schedules.WorkScheduleCore) (schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.business.checkSlotSettings"
+	var errMsg errors.ErrClientMessage
+
+	if ws.SlotMinutes == 0 {
+		ws.SlotCapacity = 0
+		return ws, nil
+	}
+	if ws.SlotCapacity == 0 {
+		ws.SlotCapacity = 1
+	}
+
+	slots, err := slotsOf(ws)
+	if err != nil {
+		errMsg = "Invalid time format"
+		return schedules.WorkScheduleCore{}, errors.E(err, op, errMsg, errors.KindBadRequest)
+	}
+
+	if len(slots) == 0 {
+		errMsg = "Slot length is longer than the work schedule"
+		return schedules.WorkScheduleCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+	return ws, nil
+}
+
+// checkBooking makes sure the outpatient fits the slots and capacity of the work schedule.
+// A full slot or shift only takes an outpatient who asked to be overbooked, while the
+// work schedule still allows it.
+func (s *scheduleBusiness) checkBooking(ws schedules.WorkScheduleCore, outpatient schedules.OutpatientCore) (schedules.OutpatientCore, error) {
+	const op errors.Op = "schedules.business.checkBooking"
+	var errMsg errors.ErrClientMessage
+
+	if ws.SlotMinutes == 0 && outpatient.SlotTime != "" {
+		errMsg = "This work schedule has no appointment slots"
+		return schedules.OutpatientCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	// unlimited, nothing to count
+	if ws.SlotMinutes == 0 && ws.Capacity == 0 {
+		outpatient.Overbooked = false
+		return outpatient, nil
+	}
+
+	counts, err := s.data.SelectBookingCounts([]int{ws.ID})
+	if err != nil {
+		return schedules.OutpatientCore{}, errors.E(err, op)
+	}
+	booked := counts[ws.ID]
+
+	full := false
+	if ws.Capacity > 0 && booked.Total >= ws.Capacity {
+		errMsg = "This work schedule is fully booked"
+		full = true
+	}
+
+	if ws.SlotMinutes > 0 {
+		slot, err := s.findSlot(ws, outpatient.SlotTime)
+		if err != nil {
+			return schedules.OutpatientCore{}, errors.E(err, op)
+		}
+
+		if !full && booked.Slots[slot.StartTime] >= slot.Capacity {
+			errMsg = "This appointment slot is fully booked"
+			full = true
+		}
+	}
+
+	if !full {
+		outpatient.Overbooked = false
+		return outpatient, nil
+	}
+
+	if !outpatient.Overbooked {
+		return schedules.OutpatientCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	if booked.Overbooked >= ws.Overbook {
+		errMsg = "This work schedule cannot be overbooked any further"
+		return schedules.OutpatientCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+	return outpatient, nil
+}
+
+// placeMoves fits moved outpatients on the target, each on the earliest slot with room left.
+// Those that don't fit are overbooked as far as the target allows, the move fails beyond that.
+func (s *scheduleBusiness) placeMoves(target schedules.WorkScheduleCore, moves []schedules.OutpatientMoveCore) ([]schedules.OutpatientMoveCore, error) {
+	const op errors.Op = "schedules.business.placeMoves"
+	var errMsg errors.ErrClientMessage = "Target work schedule has no room left for the moved outpatients"
+
+	// unlimited, nothing to count
+	if target.SlotMinutes == 0 && target.Capacity == 0 {
+		return moves, nil
+	}
+
+	counts, err := s.data.SelectBookingCounts([]int{target.ID})
+	if err != nil {
+		return []schedules.OutpatientMoveCore{}, errors.E(err, op)
+	}
+	booked := counts[target.ID]
+
+	availability, err := availabilityOf(target, booked, time.Now().In(config.GetTimeLoc()))
+	if err != nil {
+		errMsg = "Something went wrong"
+		return []schedules.OutpatientMoveCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	remaining := availability.Remaining
+	slots := availability.Slots
+	for i := range moves {
+		if remaining != 0 {
+			for len(slots) > 0 && slots[0].Booked >= slots[0].Capacity {
+				slots = slots[1:]
+			}
+			if len(slots) > 0 {
+				moves[i].SlotTime = slots[0].StartTime
+				slots[0].Booked++
+			}
+			if remaining > 0 {
+				remaining--
+			}
+			continue
+		}
+
+		if booked.Overbooked >= target.Overbook {
+			// the outpatients placed so far are all the target can take
+			payload := errors.ErrPayload{Data: map[string]interface{}{"room": i, "moving": len(moves)}}
+			return []schedules.OutpatientMoveCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, payload, errors.KindUnprocessable)
+		}
+		booked.Overbooked++
+		moves[i].Overbooked = true
+	}
+	return moves, nil
+}
+
+// findSlot finds the slot starting at slotTime that hasn't passed yet
+func (s *scheduleBusiness) findSlot(ws schedules.WorkScheduleCore, slotTime string) (schedules.SlotCore, error) {
+	const op errors.Op = "schedules.business.findSlot"
+	var errMsg errors.ErrClientMessage
+
+	if slotTime == "" {
+		errMsg = "Choose an appointment slot of this work schedule"
+		return schedules.SlotCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+
+	slots, err := slotsOf(ws)
+	if err != nil {
+		errMsg = "Something went wrong"
+		return schedules.SlotCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	for _, slot := range slots {
+		if slot.StartTime != slotTime {
+			continue
+		}
+
+		end, err := slotEnd(ws, slot)
+		if err != nil {
+			errMsg = "Something went wrong"
+			return schedules.SlotCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+		}
+
+		if !end.After(time.Now().In(config.GetTimeLoc())) {
+			errMsg = "This appointment slot has passed"
+			return schedules.SlotCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+		}
+		return slot, nil
+	}
+
+	errMsg = "Appointment slot is not on this work schedule"
+	return schedules.SlotCore{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+}
+
+// availabilityOf counts what is left of a work schedule, overbooking is left to the staff
+// and not offered. Slots that have passed are left out.
+func availabilityOf(ws schedules.WorkScheduleCore, booked schedules.BookingCountCore, now time.Time) (schedules.AvailabilityCore, error) {
+	availability := schedules.AvailabilityCore{
+		WorkSchedule: ws,
+		Booked:       booked.Total + booked.Overbooked,
+		Remaining:    -1,
+		Slots:        []schedules.SlotCore{},
+	}
+
+	if ws.Capacity > 0 {
+		availability.Remaining = ws.Capacity - booked.Total
+		if availability.Remaining < 0 {
+			availability.Remaining = 0
+		}
+	}
+
+	if ws.SlotMinutes == 0 {
+		return availability, nil
+	}
+
+	slots, err := slotsOf(ws)
+	if err != nil {
+		return schedules.AvailabilityCore{}, err
+	}
+
+	free := 0
+	for _, slot := range slots {
+		end, err := slotEnd(ws, slot)
+		if err != nil {
+			return schedules.AvailabilityCore{}, err
+		}
+		if !end.After(now) {
+			continue
+		}
+
+		slot.Booked = booked.Slots[slot.StartTime]
+		if slot.Booked < slot.Capacity {
+			free += slot.Capacity - slot.Booked
+			availability.Slots = append(availability.Slots, slot)
+		}
+	}
+
+	if availability.Remaining == -1 || free < availability.Remaining {
+		availability.Remaining = free
+	}
+	return availability, nil
+}
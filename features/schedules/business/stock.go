@@ -0,0 +1,87 @@
+package business
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
+
+// withCatalogue checks the medicines prescriptions refer to, their names are taken from
+// the catalogue. Medicines outside the catalogue are kept as written.
+func (s *scheduleBusiness) withCatalogue(prescriptions []schedules.PrescriptionCore) ([]schedules.PrescriptionCore, error) {
+	const op errors.Op = "schedules.business.withCatalogue"
+	var errMsg errors.ErrClientMessage = "Medicine is not in the catalogue"
+
+	ids := []int{}
+	for _, p := range prescriptions {
+		if p.MedicineID > 0 {
+			ids = append(ids, p.MedicineID)
+		}
+	}
+	if len(ids) == 0 {
+		return prescriptions, nil
+	}
+
+	found, err := s.medicineBusiness.FindMedicinesByIds(ids)
+	if err != nil {
+		return nil, errors.E(err, op)
+	}
+
+	names := make(map[int]string)
+	for _, m := range found {
+		names[m.ID] = m.Name
+	}
+
+	result := make([]schedules.PrescriptionCore, len(prescriptions))
+	for i, p := range prescriptions {
+		if p.MedicineID > 0 {
+			name, ok := names[p.MedicineID]
+			if !ok {
+				return nil, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+			}
+			p.Medicine = name
+		}
+		result[i] = p
+	}
+	return result, nil
+}
+
+// updateStock releases what the released prescriptions held and reserves stock for the
+// reserved ones. The prescriptions are saved by then, so failures (e.g. stock taken by
+// another prescription meanwhile) are only logged, dispensing takes whatever was not
+// reserved from the stock.
+func (s *scheduleBusiness) updateStock(released []schedules.PrescriptionCore, reserved []schedules.PrescriptionCore) {
+	const op errors.Op = "schedules.business.updateStock"
+
+	ids := []int{}
+	for _, r := range stockRequests(released) {
+		ids = append(ids, r.PrescriptionID)
+	}
+	if len(ids) > 0 {
+		if err := s.medicineBusiness.ReleaseStock(ids); err != nil {
+			errors.Log(errors.E(err, op))
+		}
+	}
+
+	requests := stockRequests(reserved)
+	if len(requests) > 0 {
+		if _, err := s.medicineBusiness.ReserveStock(requests); err != nil {
+			errors.Log(errors.E(err, op))
+		}
+	}
+}
+
+// stockRequests of the prescriptions that take medicines from the catalogue
+func stockRequests(prescriptions []schedules.PrescriptionCore) []medicines.ReservationCore {
+	requests := []medicines.ReservationCore{}
+	for _, p := range prescriptions {
+		if p.MedicineID > 0 && p.Quantity > 0 {
+			requests = append(requests, medicines.ReservationCore{
+				PrescriptionID: p.ID,
+				MedicineID:     p.MedicineID,
+				Quantity:       p.Quantity,
+			})
+		}
+	}
+	return requests
+}
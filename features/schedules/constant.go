@@ -1,10 +1,31 @@
 package schedules
 
+import "time"
+
 type ScheduleQuery struct {
-	StartDate string
-	EndDate   string
-	Limit     int
-	Repeat    string
+	StartDate    string
+	EndDate      string
+	Limit        int
+	Repeat       string   // one of the named repeats or an RRULE, see utils/recurrence
+	ExcludeDates []string // dates skipped by the repeat
+}
+
+// ConflictQuery finds work schedules on Dates that overlap StartTime - EndTime
+// and share a doctor, a nurse or a room
+type ConflictQuery struct {
+	Dates      []string
+	StartTime  string
+	EndTime    string
+	DoctorIDs  []int // the doctor and every doctor sharing their room
+	NurseID    int
+	ExcludeIDs []int // the work schedules being edited
+}
+
+// Named repeats are kept for existing clients, they are expanded as these RRULEs
+var RepeatRules = map[string]string{
+	RepeatDaily:   "FREQ=DAILY",
+	RepeatWeekly:  "FREQ=WEEKLY",
+	RepeatMonthly: "FREQ=MONTHLY",
 }
 
 const (
@@ -17,4 +38,66 @@ const (
 	StatusWaiting    = 2
 	StatusFinished   = 3
 	StatusCanceled   = 4
+	StatusNoShow     = 5 // still waiting when the examine window closed
+)
+
+// Prescription versions, a voided one stays current but is not to be handed out
+const (
+	PrescriptionActive    = "active"
+	PrescriptionAmended   = "amended" // replaced by a later version
+	PrescriptionVoided    = "voided"
+	PrescriptionDispensed = "dispensed" // handed out by the pharmacy
+
+	RouteOral       = "oral"
+	RouteTopical    = "topical"
+	RouteInjection  = "injection"
+	RouteInhalation = "inhalation"
+	RouteRectal     = "rectal"
+	RouteSublingual = "sublingual"
+	RouteOphthalmic = "ophthalmic"
+	RouteOther      = "other"
+)
+
+// Occurrences of a work schedule series (same Group) affected by a series operation
+const (
+	SeriesThis      = "this"      // only the given occurrence
+	SeriesFollowing = "following" // the given occurrence and every later one
+	SeriesAll       = "all"
+)
+
+// Outpatients are examined from EXAMINE_OFFSET before the work schedule starts until
+// EXAMINE_OFFSET after it ends, those still waiting afterwards become no-shows
+const (
+	EXAMINE_OFFSET   = 2 * time.Hour
+	NO_SHOW_SCHEDULE = "*/5 * * * *" // cron expression of the no-show job
+)
+
+// Events patients are notified of, see INotifier
+const (
+	NotifyReminder    = "reminder"    // the day before the visit, once
+	NotifyCanceled    = "canceled"    // the outpatient was canceled
+	NotifyRescheduled = "rescheduled" // moved to another work schedule
+	NotifyRemoved     = "removed"     // the work schedule was removed
+	NotifyNext        = "next"        // first in the queue, once
+
+	REMINDER_SCHEDULE = "0 17 * * *" // cron expression of the reminder job
+)
+
+// Queue estimation, the average is taken over the doctor's most recent finished outpatients
+const (
+	DEFAULT_EXAMINATION_TIME = 15 * time.Minute
+	EXAMINATION_SAMPLE_SIZE  = 100
+)
+
+// Queue events pushed to displays
+const (
+	QueueEventCreated  = "created"
+	QueueEventExamined = "examined"
+	QueueEventFinished = "finished"
+	QueueEventCanceled = "canceled"
+	QueueEventMovedOut = "moved-out"
+	QueueEventMovedIn  = "moved-in"
+
+	QUEUE_EVENT_BUFFER     = 32               // events a slow display may fall behind before it is dropped
+	QUEUE_STREAM_HEARTBEAT = 25 * time.Second // keeps idle connections open through proxies
 )
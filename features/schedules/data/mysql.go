@@ -1,9 +1,13 @@
 package data
 
 import (
+	"time"
+
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/medicines"
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type mySQLRepository struct {
@@ -116,7 +120,187 @@ func (r *mySQLRepository) SelectWorkSchedulesByNurseId(nurseId int, q schedules.
 	return toSliceWorkScheduleCore(ws), nil
 }
 
-func (r *mySQLRepository) InsertWorkSchedules(workSchedules []schedules.WorkScheduleCore) error {
+func (r *mySQLRepository) SelectWorkSchedulesByDoctorIds(doctorIds []int, q schedules.ScheduleQuery) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.data.SelectWorkSchedulesByDoctorIds"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	ws := []WorkSchedule{}
+	err := r.db.
+		Where("doctor_id IN ? AND (date BETWEEN ? AND ?) ", doctorIds, q.StartDate, q.EndDate).
+		Order("date, start_time, id").
+		Limit(q.Limit).
+		Find(&ws).
+		Error
+
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	return toSliceWorkScheduleCore(ws), nil
+}
+
+func (r *mySQLRepository) SelectConflictingWorkSchedules(q schedules.ConflictQuery) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.data.SelectConflictingWorkSchedules"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	// Touching intervals (one ends when the other starts) do not overlap
+	excludeIds := q.ExcludeIDs
+	if len(excludeIds) == 0 {
+		excludeIds = []int{0} // NOT IN () would match nothing
+	}
+
+	ws := []WorkSchedule{}
+	err := r.db.
+		Where("date IN ? AND start_time < ? AND end_time > ? AND id NOT IN ?", q.Dates, q.EndTime, q.StartTime, excludeIds).
+		Where("doctor_id IN ? OR nurse_id = ?", q.DoctorIDs, q.NurseID).
+		Find(&ws).
+		Error
+
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	return toSliceWorkScheduleCore(ws), nil
+}
+
+func (r *mySQLRepository) SelectWorkSchedulesByGroup(group string) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.data.SelectWorkSchedulesByGroup"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	ws := []WorkSchedule{}
+	err := r.db.
+		Where("`group` = ?", group).
+		Order("date, start_time").
+		Find(&ws).
+		Error
+
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	return toSliceWorkScheduleCore(ws), nil
+}
+
+func (r *mySQLRepository) SelectWorkSchedulesByDates(dates []string) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.data.SelectWorkSchedulesByDates"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	ws := []WorkSchedule{}
+	err := r.db.
+		Preload("Outpatients", "status = ?", schedules.StatusWaiting).
+		Where("date IN ?", dates).
+		Order("date, start_time").
+		Find(&ws).
+		Error
+
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	return toSliceWorkScheduleCore(ws), nil
+}
+
+func (r *mySQLRepository) SelectWaitingWorkSchedulesUntil(date string) ([]schedules.WorkScheduleCore, error) {
+	const op errors.Op = "schedules.data.SelectWaitingWorkSchedulesUntil"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	waiting := r.db.
+		Model(&Outpatient{}).
+		Select("work_schedule_id").
+		Where("status = ?", schedules.StatusWaiting)
+
+	ws := []WorkSchedule{}
+	err := r.db.
+		Preload("Outpatients", "status = ?", schedules.StatusWaiting).
+		Where("date <= ? AND id IN (?)", date, waiting).
+		Order("date, start_time").
+		Find(&ws).
+		Error
+
+	if err != nil {
+		return []schedules.WorkScheduleCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	return toSliceWorkScheduleCore(ws), nil
+}
+
+func (r *mySQLRepository) SelectCountWorkSchedulesOutpatients(ids []int, statuses []int) (map[int]int, error) {
+	const op errors.Op = "schedules.data.SelectCountWorkSchedulesOutpatients"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	totals := []TotalWaiting{}
+	query := `
+		SELECT w.id, COUNT(w.id) AS total FROM work_schedules w
+		JOIN outpatients o
+		ON (
+			o.work_schedule_id = w.id AND
+			o.deleted_at IS NULL AND
+			w.deleted_at IS NULL AND
+			o.status IN (?) AND
+			w.id IN (?)
+		)
+		GROUP BY w.id
+	`
+
+	err := r.db.Raw(query, statuses, ids).Scan(&totals).Error
+	if err != nil {
+		return map[int]int{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	result := make(map[int]int)
+	for _, t := range totals {
+		result[t.ID] = t.Total
+	}
+	return result, nil
+}
+
+func (r *mySQLRepository) SelectBookingCounts(workScheduleIds []int) (map[int]schedules.BookingCountCore, error) {
+	const op errors.Op = "schedules.data.SelectBookingCounts"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	result, err := bookingCounts(r.db, workScheduleIds)
+	if err != nil {
+		return map[int]schedules.BookingCountCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return result, nil
+}
+
+// bookingCounts counts the bookings of the work schedules, within tx it sees the
+// bookings made by that transaction as well
+func bookingCounts(tx *gorm.DB, workScheduleIds []int) (map[int]schedules.BookingCountCore, error) {
+	counts := []BookingCount{}
+	query := `
+		SELECT o.work_schedule_id, o.slot_time, o.overbooked, COUNT(o.id) AS total FROM outpatients o
+		WHERE o.deleted_at IS NULL AND o.status <> ? AND o.work_schedule_id IN (?)
+		GROUP BY o.work_schedule_id, o.slot_time, o.overbooked
+	`
+
+	err := tx.Raw(query, schedules.StatusCanceled, workScheduleIds).Scan(&counts).Error
+	if err != nil {
+		return map[int]schedules.BookingCountCore{}, err
+	}
+
+	result := make(map[int]schedules.BookingCountCore)
+	for _, c := range counts {
+		booking, ok := result[c.WorkScheduleID]
+		if !ok {
+			booking.Slots = make(map[string]int)
+		}
+
+		if c.Overbooked {
+			booking.Overbooked += c.Total
+		} else {
+			booking.Total += c.Total
+			if slot := c.SlotTime.String(); slot != "" {
+				booking.Slots[slot] += c.Total
+			}
+		}
+		result[c.WorkScheduleID] = booking
+	}
+	return result, nil
+}
+
+func (r *mySQLRepository) InsertWorkSchedules(workSchedules []schedules.WorkScheduleCore) ([]int, error) {
 	const op errors.Op = "schedules.data.InsertWorkSchedules"
 	var errMsg errors.ErrClientMessage = "Something went wrong"
 
@@ -125,30 +309,38 @@ func (r *mySQLRepository) InsertWorkSchedules(workSchedules []schedules.WorkSche
 		start, err := NewMyTime(w.StartTime)
 		if err != nil {
 			errMsg = "Invalid time format"
-			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindBadRequest)
+			return []int{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindBadRequest)
 		}
 		end, err := NewMyTime(w.EndTime)
 		if err != nil {
 			errMsg = "Invalid time format"
-			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindBadRequest)
+			return []int{}, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindBadRequest)
 		}
 
 		ws[i] = WorkSchedule{
-			DoctorID:  w.Doctor.ID,
-			NurseID:   w.Nurse.ID,
-			Group:     w.Group,
-			Date:      w.Date,
-			StartTime: start,
-			EndTime:   end,
+			DoctorID:     w.Doctor.ID,
+			NurseID:      w.Nurse.ID,
+			Group:        w.Group,
+			Date:         w.Date,
+			StartTime:    start,
+			EndTime:      end,
+			SlotMinutes:  w.SlotMinutes,
+			SlotCapacity: w.SlotCapacity,
+			Capacity:     w.Capacity,
+			Overbook:     w.Overbook,
 		}
 	}
 
 	err := r.db.Create(&ws).Error
 	if err != nil {
-		return errors.E(err, op, errMsg, errors.KindServerError)
+		return []int{}, errors.E(err, op, errMsg, errors.KindServerError)
 	}
 
-	return nil
+	ids := make([]int, len(ws))
+	for i := range ws {
+		ids[i] = int(ws[i].ID)
+	}
+	return ids, nil
 }
 
 func (r *mySQLRepository) UpdateWorkSchedule(workSchedule schedules.WorkScheduleCore) error {
@@ -177,6 +369,12 @@ func (r *mySQLRepository) UpdateWorkSchedule(workSchedule schedules.WorkSchedule
 		Date:      workSchedule.Date,
 		StartTime: start,
 		EndTime:   end,
+		LeaveID:   workSchedule.LeaveID,
+
+		SlotMinutes:  workSchedule.SlotMinutes,
+		SlotCapacity: workSchedule.SlotCapacity,
+		Capacity:     workSchedule.Capacity,
+		Overbook:     workSchedule.Overbook,
 	}
 
 	err = r.db.Save(&updatedWorkSchedule).Error
@@ -187,6 +385,60 @@ func (r *mySQLRepository) UpdateWorkSchedule(workSchedule schedules.WorkSchedule
 	return nil
 }
 
+func (r *mySQLRepository) UpdateWorkSchedules(workSchedules []schedules.WorkScheduleCore) error {
+	const op errors.Op = "schedules.data.UpdateWorkSchedules"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	ws := make([]WorkSchedule, len(workSchedules))
+	for i, w := range workSchedules {
+		start, err := NewMyTime(w.StartTime)
+		if err != nil {
+			errMsg = "Invalid time format"
+			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindBadRequest)
+		}
+		end, err := NewMyTime(w.EndTime)
+		if err != nil {
+			errMsg = "Invalid time format"
+			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindBadRequest)
+		}
+
+		ws[i] = WorkSchedule{
+			Model: gorm.Model{
+				ID:        uint(w.ID),
+				CreatedAt: w.CreatedAt,
+			},
+			DoctorID:  w.Doctor.ID,
+			NurseID:   w.Nurse.ID,
+			Group:     w.Group,
+			Date:      w.Date,
+			StartTime: start,
+			EndTime:   end,
+			LeaveID:   w.LeaveID,
+
+			SlotMinutes:  w.SlotMinutes,
+			SlotCapacity: w.SlotCapacity,
+			Capacity:     w.Capacity,
+			Overbook:     w.Overbook,
+		}
+	}
+
+	trasaction := func(tx *gorm.DB) error {
+		for i := range ws {
+			if err := tx.Save(&ws[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err := r.db.Transaction(trasaction)
+	if err != nil {
+		return errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	return nil
+}
+
 func (r *mySQLRepository) DeleteWorkScheduleById(workScheduleId int) error {
 	// also remove outpatient schedules
 	const op errors.Op = "schedules.data.DeleteWorkScheduleById"
@@ -231,6 +483,52 @@ func (r *mySQLRepository) DeleteWorkScheduleById(workScheduleId int) error {
 	return nil
 }
 
+func (r *mySQLRepository) DeleteWorkSchedulesByIds(workScheduleIds []int) error {
+	// also remove outpatient schedules
+	const op errors.Op = "schedules.data.DeleteWorkSchedulesByIds"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	trasaction := func(tx *gorm.DB) error {
+		os := []Outpatient{}
+
+		err := tx.Where("work_schedule_id IN (?)", workScheduleIds).Find(&os).Error
+		if err != nil {
+			return err
+		}
+
+		if len(os) > 0 {
+			outpatientIds := make([]uint, len(os))
+			for i := range os {
+				outpatientIds[i] = os[i].ID
+			}
+
+			err = tx.Where("outpatient_id IN (?)", outpatientIds).Delete(&Prescription{}).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Where("work_schedule_id IN (?)", workScheduleIds).Delete(&Outpatient{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.Delete(&WorkSchedule{}, workScheduleIds).Error
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	err := r.db.Transaction(trasaction)
+	if err != nil {
+		return errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	return nil
+}
+
 func (r *mySQLRepository) DeleteWorkSchedulesByDoctorId(doctorId int, q schedules.ScheduleQuery) error {
 	const op errors.Op = "schedules.data.DeleteWorkSchedulesByDoctorId"
 	var errMsg errors.ErrClientMessage = "Something went wrong"
@@ -292,10 +590,14 @@ func (r *mySQLRepository) SelectOutpatients(q schedules.ScheduleQuery) ([]schedu
 	SELECT 
 		outpatients.id, outpatients.created_at, outpatients.updated_at, outpatients.deleted_at, outpatients.work_schedule_id, outpatients.patient_id, 
 		outpatients.complaint, outpatients.status, outpatients.start_time, outpatients.end_time, outpatients.diagnosis,
+		outpatients.queue_number, outpatients.slot_time, outpatients.overbooked,
 		WorkSchedule.id AS WorkSchedule__id, WorkSchedule.created_at AS WorkSchedule__created_at, WorkSchedule.updated_at AS WorkSchedule__updated_at, 
 		WorkSchedule.deleted_at AS WorkSchedule__deleted_at, WorkSchedule.doctor_id AS WorkSchedule__doctor_id, 
 		WorkSchedule.nurse_id AS WorkSchedule__nurse_id, WorkSchedule.group AS WorkSchedule__group, WorkSchedule.date AS WorkSchedule__date, 
-		WorkSchedule.start_time AS WorkSchedule__start_time, WorkSchedule.end_time AS WorkSchedule__end_time FROM outpatients 
+		WorkSchedule.start_time AS WorkSchedule__start_time, WorkSchedule.end_time AS WorkSchedule__end_time, 
+		WorkSchedule.leave_id AS WorkSchedule__leave_id, WorkSchedule.slot_minutes AS WorkSchedule__slot_minutes, 
+		WorkSchedule.slot_capacity AS WorkSchedule__slot_capacity, WorkSchedule.capacity AS WorkSchedule__capacity, 
+		WorkSchedule.overbook AS WorkSchedule__overbook FROM outpatients 
 	JOIN work_schedules WorkSchedule 
 	ON (
 		outpatients.work_schedule_id = WorkSchedule.id AND 
@@ -332,10 +634,14 @@ func (r *mySQLRepository) SelectOutpatientsByPatientId(patientId int, q schedule
 	SELECT 
 		outpatients.id, outpatients.created_at, outpatients.updated_at, outpatients.deleted_at, outpatients.work_schedule_id, outpatients.patient_id, 
 		outpatients.complaint, outpatients.status, outpatients.start_time, outpatients.end_time, outpatients.diagnosis,
+		outpatients.queue_number, outpatients.slot_time, outpatients.overbooked,
 		WorkSchedule.id AS WorkSchedule__id, WorkSchedule.created_at AS WorkSchedule__created_at, WorkSchedule.updated_at AS WorkSchedule__updated_at, 
 		WorkSchedule.deleted_at AS WorkSchedule__deleted_at, WorkSchedule.doctor_id AS WorkSchedule__doctor_id, 
 		WorkSchedule.nurse_id AS WorkSchedule__nurse_id, WorkSchedule.group AS WorkSchedule__group, WorkSchedule.date AS WorkSchedule__date, 
-		WorkSchedule.start_time AS WorkSchedule__start_time, WorkSchedule.end_time AS WorkSchedule__end_time FROM outpatients 
+		WorkSchedule.start_time AS WorkSchedule__start_time, WorkSchedule.end_time AS WorkSchedule__end_time, 
+		WorkSchedule.leave_id AS WorkSchedule__leave_id, WorkSchedule.slot_minutes AS WorkSchedule__slot_minutes, 
+		WorkSchedule.slot_capacity AS WorkSchedule__slot_capacity, WorkSchedule.capacity AS WorkSchedule__capacity, 
+		WorkSchedule.overbook AS WorkSchedule__overbook FROM outpatients 
 	JOIN work_schedules WorkSchedule 
 	ON (
 		outpatients.work_schedule_id = WorkSchedule.id AND 
@@ -365,7 +671,7 @@ func (r *mySQLRepository) SelectOutpatientsByWorkScheduleId(workScheduleId int)
 
 	// err := r.db.Where("work_schedule_id = ?", workScheduleId).Find(&os).Error
 	err := r.db.Preload("Outpatients", func(db *gorm.DB) *gorm.DB {
-		return db.Order("outpatients.status")
+		return db.Order("outpatients.status, outpatients.queue_number")
 	}).
 		Order("date").
 		First(&w, workScheduleId).
@@ -391,7 +697,10 @@ func (r *mySQLRepository) SelectOutpatientById(outpatientId int) (schedules.Outp
 	o := Outpatient{}
 	err := r.db.
 		Preload("WorkSchedule").
-		Preload("Prescriptions").
+		Preload("Prescriptions", "status <> ?", schedules.PrescriptionAmended).
+		Preload("Moves", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at, id")
+		}).
 		First(&o, outpatientId).
 		Error
 
@@ -408,61 +717,324 @@ func (r *mySQLRepository) SelectOutpatientById(outpatientId int) (schedules.Outp
 	return o.toOutpatientCore(), nil
 }
 
-func (r *mySQLRepository) InsertOutpatient(outpatient schedules.OutpatientCore) error {
+func (r *mySQLRepository) SelectWaitingOutpatientsByWorkScheduleIds(workScheduleIds []int) ([]schedules.OutpatientCore, error) {
+	const op errors.Op = "schedules.data.SelectWaitingOutpatientsByWorkScheduleIds"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	os := []Outpatient{}
+	err := r.db.
+		Where("work_schedule_id IN ? AND status = ?", workScheduleIds, schedules.StatusWaiting).
+		Order("queue_number, id").
+		Find(&os).
+		Error
+
+	if err != nil {
+		return []schedules.OutpatientCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	result := toSliceOutpatientCore(os)
+	for i := range os {
+		result[i].WorkSchedule.ID = int(os[i].WorkScheduleID) // not preloaded
+	}
+	return result, nil
+}
+
+func (r *mySQLRepository) SelectCountCareTeamOutpatients(patientId int, doctorId int, nurseId int) (int, error) {
+	const op errors.Op = "schedules.data.SelectCountCareTeamOutpatients"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	// A zero id never matches, nurse_id is zeroed or NULL once a nurse is removed
+	var total int
+	query := `
+		SELECT COUNT(o.id) AS total FROM outpatients o
+		JOIN work_schedules w
+		ON (
+			o.work_schedule_id = w.id AND
+			o.deleted_at IS NULL AND
+			w.deleted_at IS NULL
+		)
+		WHERE o.patient_id = ? AND (
+			(? <> 0 AND w.doctor_id = ?) OR
+			(? <> 0 AND w.nurse_id = ?)
+		)
+	`
+
+	err := r.db.Raw(query, patientId, doctorId, doctorId, nurseId, nurseId).Scan(&total).Error
+	if err != nil {
+		return 0, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return total, nil
+}
+
+func (r *mySQLRepository) SelectNoShowCounts(patientIds []int) (map[int]int, error) {
+	const op errors.Op = "schedules.data.SelectNoShowCounts"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	totals := []TotalPatient{}
+	query := `
+		SELECT o.patient_id, COUNT(o.id) AS total FROM outpatients o
+		WHERE o.deleted_at IS NULL AND o.status = ? AND o.patient_id IN (?)
+		GROUP BY o.patient_id
+	`
+
+	err := r.db.Raw(query, schedules.StatusNoShow, patientIds).Scan(&totals).Error
+	if err != nil {
+		return map[int]int{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	result := make(map[int]int)
+	for _, t := range totals {
+		result[t.PatientID] = t.Total
+	}
+	return result, nil
+}
+
+func (r *mySQLRepository) InsertOutpatient(outpatient schedules.OutpatientCore) (int, error) {
 	const op errors.Op = "schedules.data.InsertOutpatient"
 	var errMsg errors.ErrClientMessage = "Something went wrong"
 
+	slot, err := NewMyTime(outpatient.SlotTime)
+	if err != nil {
+		return 0, errors.E(err, op, errors.KindServerError)
+	}
+
 	newOutpatient := Outpatient{
 		WorkScheduleID: uint(outpatient.WorkSchedule.ID),
 		PatientID:      outpatient.Patient.ID,
 		Complaint:      outpatient.Complaint,
 		Status:         outpatient.Status,
+		SlotTime:       slot,
+		Overbooked:     outpatient.Overbooked,
 	}
 
-	err := r.db.Create(&newOutpatient).Error
+	trasaction := func(tx *gorm.DB) error {
+		queueNumber, err := nextQueueNumber(tx, newOutpatient.WorkScheduleID)
+		if err != nil {
+			return err
+		}
+
+		// counted again now that the work schedule is locked, a booking made meanwhile may have taken the room
+		err = checkRoom(tx, newOutpatient.WorkScheduleID, slot.String(), newOutpatient.Overbooked)
+		if err != nil {
+			return err
+		}
+
+		newOutpatient.QueueNumber = queueNumber
+		return tx.Create(&newOutpatient).Error
+	}
+
+	err = r.db.Transaction(trasaction)
+	if err == errFullyBooked {
+		errMsg = "This work schedule is fully booked"
+		return 0, errors.E(err, op, errMsg, errors.KindUnprocessable)
+	}
 	if err != nil {
-		return errors.E(err, op, errMsg, errors.KindServerError)
+		return 0, errors.E(err, op, errMsg, errors.KindServerError)
 	}
 
-	return nil
+	return int(newOutpatient.ID), nil
+}
+
+func (r *mySQLRepository) SelectCountOutpatientsAhead(workScheduleId int, queueNumber int, outpatientId int) (int, error) {
+	const op errors.Op = "schedules.data.SelectCountOutpatientsAhead"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	var total int64
+	err := r.db.
+		Model(&Outpatient{}).
+		Where("work_schedule_id = ?", workScheduleId).
+		Where(
+			r.db.
+				Where("status = ?", schedules.StatusOnprogress).
+				Or("status = ? AND (queue_number < ? OR (queue_number = ? AND id < ?))", schedules.StatusWaiting, queueNumber, queueNumber, outpatientId),
+		).
+		Count(&total).
+		Error
+
+	if err != nil {
+		return 0, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return int(total), nil
+}
+
+func (r *mySQLRepository) SelectAverageExaminationTime(doctorId int) (time.Duration, int, error) {
+	const op errors.Op = "schedules.data.SelectAverageExaminationTime"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	result := struct {
+		Total   int
+		Seconds float64
+	}{}
+
+	query := `
+		SELECT COUNT(*) AS total, COALESCE(AVG(TIME_TO_SEC(TIMEDIFF(recent.end_time, recent.start_time))), 0) AS seconds
+		FROM (
+			SELECT o.start_time, o.end_time
+			FROM outpatients o
+			INNER JOIN work_schedules w ON w.id = o.work_schedule_id
+			WHERE w.doctor_id = ? AND o.status = ? AND o.deleted_at IS NULL AND o.end_time > o.start_time
+			ORDER BY w.date DESC, o.end_time DESC
+			LIMIT ?
+		) recent
+	`
+
+	err := r.db.Raw(query, doctorId, schedules.StatusFinished, schedules.EXAMINATION_SAMPLE_SIZE).Scan(&result).Error
+	if err != nil {
+		return 0, 0, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return time.Duration(result.Seconds * float64(time.Second)), result.Total, nil
 }
 
 func (r *mySQLRepository) UpdateOutpatient(outpatient schedules.OutpatientCore) error {
 	const op errors.Op = "schedules.data.UpdateOutpatient"
 	var errMsg errors.ErrClientMessage = "Something went wrong"
 
-	ps := make([]Prescription, len(outpatient.Prescriptions))
-	for i := range outpatient.Prescriptions {
-		ps[i] = Prescription{
-			OutpatientID: uint(outpatient.ID),
-			Medicine:     outpatient.Prescriptions[i].Medicine,
-			Instruction:  outpatient.Prescriptions[i].Instruction,
-		}
+	updatedOutpatient, err := toOutpatientRecord(outpatient)
+	if err != nil {
+		return errors.E(err, op, errors.KindServerError)
 	}
 
-	start, err := NewMyTime(outpatient.StartTime)
+	err = r.db.Save(&updatedOutpatient).Error
 	if err != nil {
-		return errors.E(err, op, errors.KindServerError)
+		return errors.E(err, op, errMsg, errors.KindServerError)
 	}
 
-	end, err := NewMyTime(outpatient.EndTime)
+	return nil
+}
+
+func (r *mySQLRepository) UpdateOutpatientReservingStock(outpatient schedules.OutpatientCore, today string) error {
+	const op errors.Op = "schedules.data.UpdateOutpatientReservingStock"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	updatedOutpatient, err := toOutpatientRecord(outpatient)
 	if err != nil {
 		return errors.E(err, op, errors.KindServerError)
 	}
 
-	updatedOutpatient := Outpatient{
-		Model:          gorm.Model{ID: uint(outpatient.ID), CreatedAt: outpatient.CreatedAt},
-		WorkScheduleID: uint(outpatient.WorkSchedule.ID),
-		PatientID:      outpatient.Patient.ID,
-		Complaint:      outpatient.Complaint,
-		Diagnosis:      outpatient.Diagnosis,
-		Status:         outpatient.Status,
-		StartTime:      start,
-		EndTime:        end,
-		Prescriptions:  ps,
+	shortIds := []int{}
+	short := map[int]bool{}
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Save(&updatedOutpatient).Error
+		if err != nil {
+			return err
+		}
+
+		// new prescriptions have their ids once saved
+		for _, p := range updatedOutpatient.Prescriptions {
+			if p.MedicineID <= 0 || p.Quantity <= 0 {
+				continue
+			}
+
+			missing, err := reserveStock(tx, int(p.ID), uint(p.MedicineID), p.Quantity, today)
+			if err != nil {
+				return err
+			}
+			if missing && !short[p.MedicineID] {
+				short[p.MedicineID] = true
+				shortIds = append(shortIds, p.MedicineID)
+			}
+		}
+
+		if len(shortIds) > 0 {
+			return errStockShort
+		}
+		return nil
+	})
+
+	if err == errStockShort {
+		errMsg = "Not enough stock for some of the prescribed medicines"
+		payload := errors.ErrPayload{Data: map[string]interface{}{"medicineIds": shortIds}}
+		return errors.E(err, op, errMsg, payload, errors.KindUnprocessable)
+	}
+	if err != nil {
+		return errors.E(err, op, errMsg, errors.KindServerError)
 	}
 
-	err = r.db.Save(&updatedOutpatient).Error
+	return nil
+}
+
+func (r *mySQLRepository) UpdateOutpatientsStatus(outpatientIds []int, from int, to int) error {
+	const op errors.Op = "schedules.data.UpdateOutpatientsStatus"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.
+		Model(&Outpatient{}).
+		Where("id IN ? AND status = ?", outpatientIds, from).
+		Update("status", to).
+		Error
+
+	if err != nil {
+		return errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepository) MoveOutpatients(moves []schedules.OutpatientMoveCore) error {
+	const op errors.Op = "schedules.data.MoveOutpatients"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	if len(moves) == 0 {
+		return nil
+	}
+
+	records := make([]OutpatientMove, len(moves))
+	for i, m := range moves {
+		records[i] = OutpatientMove{
+			OutpatientID:       uint(m.OutpatientID),
+			FromWorkScheduleID: uint(m.FromWorkScheduleID),
+			ToWorkScheduleID:   uint(m.ToWorkScheduleID),
+			Reason:             m.Reason,
+			MovedBy:            m.MovedBy,
+			MovedByRole:        m.MovedByRole,
+		}
+	}
+
+	slots := make([]MyTime, len(moves))
+	for i, m := range moves {
+		slot, err := NewMyTime(m.SlotTime)
+		if err != nil {
+			return errors.E(err, op, errors.KindServerError)
+		}
+		slots[i] = slot
+	}
+
+	trasaction := func(tx *gorm.DB) error {
+		for i, m := range records {
+			queueNumber, err := nextQueueNumber(tx, m.ToWorkScheduleID)
+			if err != nil {
+				return err
+			}
+
+			err = checkRoom(tx, m.ToWorkScheduleID, slots[i].String(), moves[i].Overbooked)
+			if err != nil {
+				return err
+			}
+
+			// only waiting outpatients are moved, one examined meanwhile fails the whole move
+			result := tx.
+				Model(&Outpatient{}).
+				Where("id = ? AND work_schedule_id = ? AND status = ?", m.OutpatientID, m.FromWorkScheduleID, schedules.StatusWaiting).
+				Updates(map[string]interface{}{
+					"work_schedule_id": m.ToWorkScheduleID,
+					"queue_number":     queueNumber,
+					"slot_time":        slots[i],
+					"overbooked":       moves[i].Overbooked,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errors.New("Outpatient is no longer waiting on the work schedule")
+			}
+		}
+		return tx.Create(&records).Error
+	}
+
+	err := r.db.Transaction(trasaction)
+	if err == errFullyBooked {
+		errMsg = "Target work schedule has no room left for the moved outpatients"
+		return errors.E(err, op, errMsg, errors.KindUnprocessable)
+	}
 	if err != nil {
 		return errors.E(err, op, errMsg, errors.KindServerError)
 	}
@@ -507,3 +1079,316 @@ func (r *mySQLRepository) DeleteOutpatientById(outpatientId int) error {
 
 	return nil
 }
+
+// toOutpatientRecord keeps only the new prescriptions, versions are changed with
+// AmendPrescription and VoidPrescription
+func toOutpatientRecord(outpatient schedules.OutpatientCore) (Outpatient, error) {
+	ps := []Prescription{}
+	for _, p := range outpatient.Prescriptions {
+		if p.ID == 0 {
+			p.OutpatientID = outpatient.ID
+			ps = append(ps, toPrescriptionRecord(p))
+		}
+	}
+
+	start, err := NewMyTime(outpatient.StartTime)
+	if err != nil {
+		return Outpatient{}, err
+	}
+
+	end, err := NewMyTime(outpatient.EndTime)
+	if err != nil {
+		return Outpatient{}, err
+	}
+
+	slot, err := NewMyTime(outpatient.SlotTime)
+	if err != nil {
+		return Outpatient{}, err
+	}
+
+	return Outpatient{
+		Model:          gorm.Model{ID: uint(outpatient.ID), CreatedAt: outpatient.CreatedAt},
+		WorkScheduleID: uint(outpatient.WorkSchedule.ID),
+		QueueNumber:    outpatient.QueueNumber,
+		PatientID:      outpatient.Patient.ID,
+		Complaint:      outpatient.Complaint,
+		Diagnosis:      outpatient.Diagnosis,
+		Status:         outpatient.Status,
+		StartTime:      start,
+		EndTime:        end,
+		SlotTime:       slot,
+		Overbooked:     outpatient.Overbooked,
+		Prescriptions:  ps,
+	}, nil
+}
+
+// errStockShort rolls back a save whose prescriptions can not all be reserved
+var errStockShort = errors.New("Not enough stock")
+
+// reserveStock holds quantity of the medicine for the prescription, first expiry first.
+// Every batch is taken with a conditional increment of its reserved units, so units are
+// never reserved twice. short tells the unexpired batches did not hold enough.
+func reserveStock(tx *gorm.DB, prescriptionId int, medicineId uint, quantity int, today string) (bool, error) {
+	batches := []StockBatch{}
+	err := tx.
+		Table("batches").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("medicine_id = ? AND expiry_date > ? AND remaining > reserved", medicineId, today).
+		Order("expiry_date, id").
+		Find(&batches).
+		Error
+	if err != nil {
+		return false, err
+	}
+
+	for _, b := range batches {
+		if quantity == 0 {
+			break
+		}
+
+		take := b.Remaining - b.Reserved
+		if take > quantity {
+			take = quantity
+		}
+
+		result := tx.
+			Table("batches").
+			Where("id = ? AND remaining - reserved >= ?", b.ID, take).
+			Update("reserved", gorm.Expr("reserved + ?", take))
+		if result.Error != nil {
+			return false, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		reservation := StockReservation{
+			PrescriptionID: prescriptionId,
+			MedicineID:     medicineId,
+			BatchID:        b.ID,
+			Quantity:       take,
+			Status:         medicines.ReservationReserved,
+		}
+		if err = tx.Table("reservations").Create(&reservation).Error; err != nil {
+			return false, err
+		}
+		quantity -= take
+	}
+	return quantity > 0, nil
+}
+
+// errFullyBooked fails a booking that no longer fits once the work schedule is locked
+var errFullyBooked = errors.New("Work schedule is fully booked")
+
+// checkRoom fails with errFullyBooked when one more outpatient on slotTime does not fit the
+// work schedule, locked beforehand by nextQueueNumber. An overbooked outpatient only needs
+// room in the overbook allowance.
+func checkRoom(tx *gorm.DB, workScheduleId uint, slotTime string, overbooked bool) error {
+	ws := WorkSchedule{}
+	err := tx.First(&ws, workScheduleId).Error
+	if err != nil {
+		return err
+	}
+
+	counts, err := bookingCounts(tx, []int{int(workScheduleId)})
+	if err != nil {
+		return err
+	}
+	booked := counts[int(workScheduleId)]
+
+	if overbooked {
+		if booked.Overbooked >= ws.Overbook {
+			return errFullyBooked
+		}
+		return nil
+	}
+
+	if ws.Capacity > 0 && booked.Total >= ws.Capacity {
+		return errFullyBooked
+	}
+	if ws.SlotMinutes > 0 && slotTime != "" && booked.Slots[slotTime] >= ws.SlotCapacity {
+		return errFullyBooked
+	}
+	return nil
+}
+
+// nextQueueNumber locks the work schedule until tx ends so concurrent outpatients get
+// different numbers, numbers of deleted outpatients are not reused
+func nextQueueNumber(tx *gorm.DB, workScheduleId uint) (int, error) {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&WorkSchedule{}, workScheduleId).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var last int
+	err = tx.
+		Unscoped().
+		Model(&Outpatient{}).
+		Where("work_schedule_id = ?", workScheduleId).
+		Select("COALESCE(MAX(queue_number), 0)").
+		Scan(&last).
+		Error
+	if err != nil {
+		return 0, err
+	}
+	return last + 1, nil
+}
+
+func (r *mySQLRepository) SelectPrescriptionById(prescriptionId int) (schedules.PrescriptionCore, error) {
+	const op errors.Op = "schedules.data.SelectPrescriptionById"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	p := Prescription{}
+	err := r.db.First(&p, prescriptionId).Error
+	if err != nil {
+		kind := errors.KindServerError
+		switch err {
+		case gorm.ErrRecordNotFound:
+			kind = errors.KindNotFound
+			errMsg = "Prescription not found"
+		}
+		return schedules.PrescriptionCore{}, errors.E(err, op, errMsg, kind)
+	}
+
+	return p.toPrescriptionCore(), nil
+}
+
+func (r *mySQLRepository) SelectPrescriptionVersions(originalId int) ([]schedules.PrescriptionCore, error) {
+	const op errors.Op = "schedules.data.SelectPrescriptionVersions"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	ps := []Prescription{}
+	err := r.db.
+		Where("id = ? OR original_id = ?", originalId, originalId).
+		Order("version").
+		Find(&ps).
+		Error
+
+	if err != nil {
+		return []schedules.PrescriptionCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	return toSlicePrescriptionCore(ps), nil
+}
+
+func (r *mySQLRepository) AmendPrescription(prescriptionId int, amended schedules.PrescriptionCore) (int, error) {
+	const op errors.Op = "schedules.data.AmendPrescription"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	record := toPrescriptionRecord(amended)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&Prescription{}).
+			Where("id = ? AND status = ?", prescriptionId, schedules.PrescriptionActive).
+			Update("status", schedules.PrescriptionAmended)
+
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			errMsg = "Prescription was changed in the meantime"
+			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+		}
+
+		return tx.Create(&record).Error
+	})
+
+	if err != nil {
+		if errors.Kind(err) == errors.KindUnprocessable {
+			return 0, errors.E(err, op)
+		}
+		return 0, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return int(record.ID), nil
+}
+
+func (r *mySQLRepository) VoidPrescription(prescription schedules.PrescriptionCore) error {
+	const op errors.Op = "schedules.data.VoidPrescription"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	record := toPrescriptionRecord(prescription)
+	result := r.db.
+		Model(&Prescription{}).
+		Where("id = ? AND status = ?", prescription.ID, schedules.PrescriptionActive).
+		Updates(map[string]interface{}{
+			"status":      schedules.PrescriptionVoided,
+			"voided_by":   record.VoidedBy,
+			"void_reason": record.VoidReason,
+			"voided_at":   record.VoidedAt,
+		})
+
+	if result.Error != nil {
+		return errors.E(result.Error, op, errMsg, errors.KindServerError)
+	}
+	if result.RowsAffected == 0 {
+		errMsg = "Prescription was changed in the meantime"
+		return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+	}
+	return nil
+}
+
+func (r *mySQLRepository) SelectPrescriptionsByIds(prescriptionIds []int) ([]schedules.PrescriptionCore, error) {
+	const op errors.Op = "schedules.data.SelectPrescriptionsByIds"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	ps := []Prescription{}
+	err := r.db.Where("id IN ?", prescriptionIds).Find(&ps).Error
+	if err != nil {
+		return []schedules.PrescriptionCore{}, errors.E(err, op, errMsg, errors.KindServerError)
+	}
+
+	return toSlicePrescriptionCore(ps), nil
+}
+
+func (r *mySQLRepository) DispensePrescriptions(prescriptionIds []int, dispensedBy int, dispensedAt time.Time) error {
+	const op errors.Op = "schedules.data.DispensePrescriptions"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&Prescription{}).
+			Where("id IN ? AND status = ?", prescriptionIds, schedules.PrescriptionActive).
+			Updates(map[string]interface{}{
+				"status":       schedules.PrescriptionDispensed,
+				"dispensed_by": dispensedBy,
+				"dispensed_at": dispensedAt,
+			})
+
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(prescriptionIds)) {
+			errMsg = "Prescription was changed in the meantime"
+			return errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindUnprocessable)
+		}
+		return nil
+	})
+
+	if err != nil {
+		if errors.Kind(err) == errors.KindUnprocessable {
+			return errors.E(err, op)
+		}
+		return errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepository) RevertDispensedPrescriptions(prescriptionIds []int) error {
+	const op errors.Op = "schedules.data.RevertDispensedPrescriptions"
+	var errMsg errors.ErrClientMessage = "Something went wrong"
+
+	err := r.db.
+		Model(&Prescription{}).
+		Where("id IN ? AND status = ?", prescriptionIds, schedules.PrescriptionDispensed).
+		Updates(map[string]interface{}{
+			"status":       schedules.PrescriptionActive,
+			"dispensed_by": 0,
+			"dispensed_at": nil,
+		}).
+		Error
+
+	if err != nil {
+		return errors.E(err, op, errMsg, errors.KindServerError)
+	}
+	return nil
+}
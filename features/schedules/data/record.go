@@ -2,6 +2,7 @@ package data
 
 import (
 	"strings"
+	"time"
 
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
 	"gorm.io/gorm"
@@ -15,13 +16,20 @@ type WorkSchedule struct {
 	Date        string `gorm:"type:date;not null"`
 	StartTime   MyTime `gorm:"not null"`
 	EndTime     MyTime `gorm:"not null"`
+	LeaveID     int    `gorm:"not null;default:0;index"`
 	Outpatients []Outpatient
+
+	SlotMinutes  int `gorm:"not null;default:0"`
+	SlotCapacity int `gorm:"not null;default:0"`
+	Capacity     int `gorm:"not null;default:0"`
+	Overbook     int `gorm:"not null;default:0"`
 }
 
 type Outpatient struct {
 	gorm.Model
-	WorkScheduleID uint `gorm:"not null"`
+	WorkScheduleID uint `gorm:"not null;index:idx_outpatients_queue"`
 	WorkSchedule   WorkSchedule
+	QueueNumber    int `gorm:"not null;default:0;index:idx_outpatients_queue"`
 
 	PatientID     int `gorm:"not null"`
 	Complaint     string
@@ -29,15 +37,48 @@ type Outpatient struct {
 	Status        int    `gorm:"not null"`
 	StartTime     MyTime `gorm:"default:null"`
 	EndTime       MyTime `gorm:"default:null"`
+	SlotTime      MyTime `gorm:"default:null"`
+	Overbooked    bool   `gorm:"not null;default:false"`
 	Prescriptions []Prescription
+	Moves         []OutpatientMove
+}
+
+type OutpatientMove struct {
+	ID                 uint `gorm:"primarykey"`
+	OutpatientID       uint `gorm:"not null;index"`
+	FromWorkScheduleID uint `gorm:"not null"`
+	ToWorkScheduleID   uint `gorm:"not null"`
+	Reason             string
+	MovedBy            int    `gorm:"not null"`
+	MovedByRole        string `gorm:"type:varchar(16)"`
+	CreatedAt          time.Time
 }
 
+// Prescription is one version, rows written before versioning are first versions
 type Prescription struct {
 	gorm.Model
-	OutpatientID uint `gorm:"not null"`
+	OutpatientID uint   `gorm:"not null;index"`
+	OriginalID   uint   `gorm:"not null;default:0;index"` // zero on the first version
+	Version      int    `gorm:"not null;default:1"`
+	Status       string `gorm:"type:varchar(16);not null;default:active"`
+
+	MedicineID   int
+	Medicine     string `gorm:"type:varchar(64)"`
+	Dose         float64
+	Unit         string `gorm:"type:varchar(16)"`
+	Frequency    string `gorm:"type:varchar(64)"`
+	Route        string `gorm:"type:varchar(16)"`
+	DurationDays int
+	Quantity     int
+	Instruction  string
 
-	Medicine    string `gorm:"type:varchar(64)"`
-	Instruction string
+	PrescribedBy int
+	Reason       string
+	VoidedBy     int
+	VoidReason   string
+	VoidedAt     *time.Time
+	DispensedBy  int
+	DispensedAt  *time.Time
 }
 
 // SELECT id, COUNT(*) FROM work_schedules GROUP BY id HAVING COUNT(*) > 1;
@@ -46,46 +87,158 @@ type TotalWaiting struct {
 	Total int
 }
 
+type TotalPatient struct {
+	PatientID int
+	Total     int
+}
+
+// StockBatch and StockReservation are rows of the batches and reservations of medicines,
+// written by UpdateOutpatientReservingStock so that prescriptions and the stock they hold
+// are saved together
+type StockBatch struct {
+	gorm.Model
+	MedicineID uint
+	ExpiryDate string
+	Remaining  int
+	Reserved   int
+}
+
+type StockReservation struct {
+	gorm.Model
+	PrescriptionID int
+	MedicineID     uint
+	BatchID        uint
+	Quantity       int
+	Status         string
+}
+
+// Outpatients of a work schedule grouped by slot and whether they are overbooked
+type BookingCount struct {
+	WorkScheduleID int
+	SlotTime       MyTime
+	Overbooked     bool
+	Total          int
+}
+
 func (w *WorkSchedule) toWorkScheduleCore() schedules.WorkScheduleCore {
 
 	return schedules.WorkScheduleCore{
-		ID:          int(w.ID),
-		Group:       w.Group,
-		Date:        strings.Split(w.Date, "T")[0],
-		StartTime:   w.StartTime.String(),
-		EndTime:     w.EndTime.String(),
-		Doctor:      schedules.DoctorCore{ID: w.DoctorID},
-		Nurse:       schedules.NurseCore{ID: w.NurseID},
-		Outpatients: toSliceOutpatientCore(w.Outpatients),
-		CreatedAt:   w.CreatedAt,
-		UpdatedAt:   w.UpdatedAt,
+		ID:           int(w.ID),
+		Group:        w.Group,
+		Date:         strings.Split(w.Date, "T")[0],
+		StartTime:    w.StartTime.String(),
+		EndTime:      w.EndTime.String(),
+		LeaveID:      w.LeaveID,
+		SlotMinutes:  w.SlotMinutes,
+		SlotCapacity: w.SlotCapacity,
+		Capacity:     w.Capacity,
+		Overbook:     w.Overbook,
+		Doctor:       schedules.DoctorCore{ID: w.DoctorID},
+		Nurse:        schedules.NurseCore{ID: w.NurseID},
+		Outpatients:  toSliceOutpatientCore(w.Outpatients),
+		CreatedAt:    w.CreatedAt,
+		UpdatedAt:    w.UpdatedAt,
 	}
 }
 
 func (o *Outpatient) toOutpatientCore() schedules.OutpatientCore {
 	return schedules.OutpatientCore{
 		ID:            int(o.ID),
+		QueueNumber:   o.QueueNumber,
 		Complaint:     o.Complaint,
 		Diagnosis:     o.Diagnosis,
 		Status:        o.Status,
 		StartTime:     o.StartTime.String(),
 		EndTime:       o.EndTime.String(),
+		SlotTime:      o.SlotTime.String(),
+		Overbooked:    o.Overbooked,
 		Patient:       schedules.PatientCore{ID: o.PatientID},
 		WorkSchedule:  o.WorkSchedule.toWorkScheduleCore(),
 		CreatedAt:     o.CreatedAt,
 		UpdatedAt:     o.UpdatedAt,
 		Prescriptions: toSlicePrescriptionCore(o.Prescriptions),
+		Moves:         toSliceOutpatientMoveCore(o.Moves),
+	}
+}
+
+func (m *OutpatientMove) toOutpatientMoveCore() schedules.OutpatientMoveCore {
+	return schedules.OutpatientMoveCore{
+		ID:                 int(m.ID),
+		OutpatientID:       int(m.OutpatientID),
+		FromWorkScheduleID: int(m.FromWorkScheduleID),
+		ToWorkScheduleID:   int(m.ToWorkScheduleID),
+		Reason:             m.Reason,
+		MovedBy:            m.MovedBy,
+		MovedByRole:        m.MovedByRole,
+		CreatedAt:          m.CreatedAt,
 	}
 }
 
 func (p *Prescription) toPrescriptionCore() schedules.PrescriptionCore {
-	return schedules.PrescriptionCore{
-		ID:          int(p.ID),
-		Medicine:    p.Medicine,
-		Instruction: p.Instruction,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+	prescription := schedules.PrescriptionCore{
+		ID:           int(p.ID),
+		OutpatientID: int(p.OutpatientID),
+		OriginalID:   int(p.OriginalID),
+		Version:      p.Version,
+		Status:       p.Status,
+		MedicineID:   p.MedicineID,
+		Medicine:     p.Medicine,
+		Dose:         p.Dose,
+		Unit:         p.Unit,
+		Frequency:    p.Frequency,
+		Route:        p.Route,
+		DurationDays: p.DurationDays,
+		Quantity:     p.Quantity,
+		Instruction:  p.Instruction,
+		PrescribedBy: p.PrescribedBy,
+		Reason:       p.Reason,
+		VoidedBy:     p.VoidedBy,
+		VoidReason:   p.VoidReason,
+		DispensedBy:  p.DispensedBy,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+	if prescription.OriginalID == 0 {
+		prescription.OriginalID = prescription.ID
+	}
+	if p.VoidedAt != nil {
+		prescription.VoidedAt = *p.VoidedAt
+	}
+	if p.DispensedAt != nil {
+		prescription.DispensedAt = *p.DispensedAt
+	}
+	return prescription
+}
+
+func toPrescriptionRecord(p schedules.PrescriptionCore) Prescription {
+	record := Prescription{
+		Model:        gorm.Model{ID: uint(p.ID)},
+		OutpatientID: uint(p.OutpatientID),
+		OriginalID:   uint(p.OriginalID),
+		Version:      p.Version,
+		Status:       p.Status,
+		MedicineID:   p.MedicineID,
+		Medicine:     p.Medicine,
+		Dose:         p.Dose,
+		Unit:         p.Unit,
+		Frequency:    p.Frequency,
+		Route:        p.Route,
+		DurationDays: p.DurationDays,
+		Quantity:     p.Quantity,
+		Instruction:  p.Instruction,
+		PrescribedBy: p.PrescribedBy,
+		Reason:       p.Reason,
+		VoidedBy:     p.VoidedBy,
+		VoidReason:   p.VoidReason,
+		DispensedBy:  p.DispensedBy,
+	}
+	if !p.VoidedAt.IsZero() {
+		record.VoidedAt = &p.VoidedAt
+	}
+	if !p.DispensedAt.IsZero() {
+		record.DispensedAt = &p.DispensedAt
 	}
+	return record
 }
 
 func toSliceWorkScheduleCore(ws []WorkSchedule) []schedules.WorkScheduleCore {
@@ -104,6 +257,14 @@ func toSliceOutpatientCore(o []Outpatient) []schedules.OutpatientCore {
 	return oc
 }
 
+func toSliceOutpatientMoveCore(m []OutpatientMove) []schedules.OutpatientMoveCore {
+	mc := make([]schedules.OutpatientMoveCore, len(m))
+	for i := range m {
+		mc[i] = m[i].toOutpatientMoveCore()
+	}
+	return mc
+}
+
 func toSlicePrescriptionCore(p []Prescription) []schedules.PrescriptionCore {
 	pc := make([]schedules.PrescriptionCore, len(p))
 	for i := range p {
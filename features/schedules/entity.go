@@ -2,27 +2,95 @@ package schedules
 
 import "time"
 
+// PrescriptionCore is one version of a prescription. Amending it after the outpatient is
+// finished adds a version, earlier ones are kept.
 type PrescriptionCore struct {
-	ID          int
-	Medicine    string
-	Instruction string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           int
+	OutpatientID int
+	OriginalID   int // first version, its own ID on the first version
+	Version      int
+	Status       string
+
+	MedicineID   int // catalogue reference, zero for a medicine outside the catalogue
+	Medicine     string
+	Dose         float64
+	Unit         string // of the dose, e.g. mg, ml or tablet
+	Frequency    string // e.g. 3x daily or every 8 hours
+	Route        string
+	DurationDays int // zero when taken as needed
+	Quantity     int // units to hand out
+	Instruction  string
+
+	PrescribedBy int    // doctor who wrote this version
+	Reason       string // why this version replaced the previous one
+	VoidedBy     int
+	VoidReason   string
+	VoidedAt     time.Time // zero unless voided
+	DispensedBy  int       // pharmacist who handed it out
+	DispensedAt  time.Time // zero unless dispensed
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 type OutpatientCore struct {
-	ID        int
-	Complaint string
-	Diagnosis string
-	Status    int
-	StartTime string
-	EndTime   string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          int
+	QueueNumber int // within its work schedule, given when created or moved and never reused
+	Complaint   string
+	Diagnosis   string
+	Status      int
+	StartTime   string
+	EndTime     string
+	SlotTime    string // start of the booked slot, empty when the work schedule has no slots
+	Overbooked  bool   // booked over the capacity of its slot or work schedule
+	Redacted    bool   // clinical data is hidden from a reader outside the care team
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 
 	WorkSchedule  WorkScheduleCore
 	Prescriptions []PrescriptionCore
 	Patient       PatientCore
+	Moves         []OutpatientMoveCore // oldest first
+}
+
+// QueueCore is the place of a waiting outpatient in the queue of its work schedule.
+// The wait is estimated from the doctor's average examination time.
+type QueueCore struct {
+	Outpatient      OutpatientCore
+	Position        int // 1 is next, 0 is being examined
+	WaitingAhead    int
+	AverageDuration time.Duration
+	Historical      bool // false when the doctor has no finished outpatients and the default is used
+	EstimatedWait   time.Duration
+	EstimatedTime   time.Time
+}
+
+// QueueEventCore tells displays of a work schedule and its room that the queue changed
+type QueueEventCore struct {
+	Type           string
+	WorkScheduleID int
+	RoomID         int
+	OutpatientID   int
+	QueueNumber    int
+	Status         int
+	PatientName    string // left out on patient facing displays
+	At             time.Time
+}
+
+// OutpatientMoveCore is one move of a waiting outpatient to another work schedule,
+// by a reschedule or a transfer of the whole work schedule
+type OutpatientMoveCore struct {
+	ID                 int
+	OutpatientID       int
+	FromWorkScheduleID int
+	ToWorkScheduleID   int
+	Reason             string
+	MovedBy            int
+	MovedByRole        string
+	CreatedAt          time.Time
+
+	// where the outpatient lands on the target, they are not kept with the move
+	SlotTime   string
+	Overbooked bool
 }
 
 type WorkScheduleCore struct {
@@ -32,6 +100,11 @@ type WorkScheduleCore struct {
 	StartTime    string
 	EndTime      string
 	TotalWaiting int
+	LeaveID      int // approved leave of the doctor or nurse, zero once the shift is staffed again
+	SlotMinutes  int // length of an appointment slot, zero books the shift without slots
+	SlotCapacity int // outpatients per slot
+	Capacity     int // outpatients per shift, zero is unlimited
+	Overbook     int // outpatients that may be booked over the capacity
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	Nurse        NurseCore
@@ -40,6 +113,37 @@ type WorkScheduleCore struct {
 	Outpatients []OutpatientCore
 }
 
+// SlotCore is an appointment slot of a work schedule, from StartTime up to EndTime
+type SlotCore struct {
+	StartTime string
+	EndTime   string
+	Booked    int
+	Capacity  int
+}
+
+// BookingCountCore counts the outpatients booked on a work schedule, canceled ones are left
+// out. Overbooked outpatients are only counted in Overbooked.
+type BookingCountCore struct {
+	Total      int
+	Overbooked int
+	Slots      map[string]int // by slot start time
+}
+
+// AvailabilityCore is a work schedule that still takes outpatients
+type AvailabilityCore struct {
+	WorkSchedule WorkScheduleCore
+	Booked       int
+	Remaining    int        // -1 is unlimited
+	Slots        []SlotCore // free slots that haven't passed, empty without slots
+}
+
+// SeriesResultCore lists occurrences changed by a series operation and the ones skipped
+// because they already have on progress or finished outpatients
+type SeriesResultCore struct {
+	WorkScheduleIDs []int
+	SkippedIDs      []int
+}
+
 type PatientCore struct {
 	ID        int
 	NIK       string
@@ -80,25 +184,79 @@ type IBusiness interface {
 	FindWorkSchedules(q ScheduleQuery) ([]WorkScheduleCore, error)
 	FindDoctorWorkSchedules(doctorId int, q ScheduleQuery) ([]WorkScheduleCore, error)
 	FindNurseWorkSchedules(nurseId int, q ScheduleQuery) ([]WorkScheduleCore, error)
-	CreateWorkSchedule(workSchedule WorkScheduleCore, q ScheduleQuery) error // GENERATE LIST
-	EditWorkSchedule(workSchedule WorkScheduleCore) error
-	RemoveWorkScheduleById(workScheduleId int) error
+	CreateWorkSchedule(workSchedule WorkScheduleCore, q ScheduleQuery, userId int, role string) error // GENERATE LIST
+	EditWorkSchedule(workSchedule WorkScheduleCore, userId int, role string) error
+	RemoveWorkScheduleById(workScheduleId int, userId int, role string) error
+
+	// Series are work schedules created together, they share the same Group
+	FindWorkSchedulesByGroup(group string) ([]WorkScheduleCore, error)
+	EditWorkScheduleSeries(group string, workScheduleId int, scope string, changes WorkScheduleCore, userId int, role string) (SeriesResultCore, error) // only non zero doctor, nurse and time are changed
+	RemoveWorkScheduleSeries(group string, workScheduleId int, scope string, userId int, role string) (SeriesResultCore, error)
+
+	FindWorkSchedulesByDates(dates []string) ([]WorkScheduleCore, error) // with waiting outpatients, used to report schedules on closed dates
+
+	// Work schedules of doctors with the speciality that haven't ended, aren't on leave and
+	// have room left, ordered by date and start time
+	FindAvailability(specialityId int, q ScheduleQuery) ([]AvailabilityCore, error)
+
+	// Used by leaves, staffRole is either doctor or nurse
+	FindStaffWorkSchedules(staffRole string, staffId int, q ScheduleQuery) ([]WorkScheduleCore, error)                // with waiting outpatients
+	ReassignWorkSchedules(workSchedules []WorkScheduleCore, doctorId int, nurseId int, userId int, role string) error // zero keeps the current doctor or nurse, started shifts are kept as they are
+	FlagWorkSchedulesOnLeave(workSchedules []WorkScheduleCore, leaveId int, userId int, role string) error
+
 	RemoveDoctorFutureWorkSchedules(doctorId int) error
 	RemoveNurseFromNextWorkSchedules(nurseId int) error
 
-	FindOutpatients(q ScheduleQuery) ([]OutpatientCore, error)
-	FindOutpatientsByWorkScheduleId(workScheduleId int) (WorkScheduleCore, error)
-	FindOutpatientsByPatientId(patientId int, q ScheduleQuery) ([]OutpatientCore, error)
-	FindOutpatientById(outpatientId int) (OutpatientCore, error)
-	CreateOutpatient(outpatient OutpatientCore) error
+	// Outpatients carry patient identity and clinical data, every read is logged as access.
+	// Clinical data is redacted outside the care team unless a break glass reason is given.
+	FindOutpatients(q ScheduleQuery, userId int, role string) ([]OutpatientCore, error)
+	FindOutpatientsByWorkScheduleId(workScheduleId int, userId int, role string) (WorkScheduleCore, error)
+	FindOutpatientsByPatientId(patientId int, q ScheduleQuery, userId int, role string, breakGlassReason string) ([]OutpatientCore, error)
+	FindOutpatientById(outpatientId int, userId int, role string, breakGlassReason string) (OutpatientCore, error)
+	CreateOutpatient(outpatient OutpatientCore, userId int, role string) error // into SlotTime, over the capacity only when Overbooked is asked
+
+	// Patient portal, patients book and cancel their own visits. Their history is read
+	// with FindOutpatientsByPatientId, patients always see their own clinical data.
+	BookVisit(outpatient OutpatientCore, patientId int) error // never overbooked, one waiting visit per work schedule
+	CancelVisit(outpatientId int, patientId int) error
+	FindOutpatientQueue(outpatientId int, userId int, role string) (QueueCore, error)
+
+	// Queue events of a work schedule or a room, until the returned function is called.
+	// The channel is closed when the subscriber falls behind.
+	SubscribeWorkScheduleQueue(workScheduleId int) (<-chan QueueEventCore, func(), error)
+	SubscribeRoomQueue(roomId int) (<-chan QueueEventCore, func(), error)
 
-	EditOutpatient(outpatient OutpatientCore) error // ONLY EDIT COMPLAINT
+	// Only waiting outpatients are moved, to a work schedule that hasn't ended and whose
+	// doctor has the same speciality
+	RescheduleOutpatient(outpatientId int, workScheduleId int, reason string, userId int, role string) error
+	TransferOutpatients(fromWorkScheduleId int, toWorkScheduleId int, reason string, userId int, role string) ([]int, error) // ids of moved outpatients
+
+	EditOutpatient(outpatient OutpatientCore, userId int, role string) error // ONLY EDIT COMPLAINT
 	ExamineOutpatient(outpatientId int, userId int, role string) error
 	FinishOutpatient(outpatient OutpatientCore, userId int, role string) error // UpdateOutpatient + InsertPrescriptions
 	CancelOutpatient(outpatientId int, userId int, role string) error
 
-	RemoveOutpatientById(outpatientId int) error
+	// Prescriptions of a finished outpatient are changed by its doctor, only the current
+	// version can be amended or voided. The outpatient only carries current versions.
+	AmendPrescription(prescription PrescriptionCore, userId int, role string) (PrescriptionCore, error) // ID is the version replaced, Reason is required
+	VoidPrescription(prescriptionId int, reason string, userId int, role string) error
+	FindPrescriptionVersions(prescriptionId int, userId int, role string) ([]PrescriptionCore, error) // oldest first, clinical data
+	// DispensePrescriptions takes the units of active prescriptions off the stock, a
+	// dispensed prescription can no longer be amended or voided
+	DispensePrescriptions(prescriptionIds []int, userId int, role string) error
+
+	RemoveOutpatientById(outpatientId int, userId int, role string) error
 	RemovePatientWaitingOutpatients(patientId int) error
+
+	MarkNoShows() (int, error)                              // returns how many outpatients became no-shows
+	FindNoShowCounts(patientIds []int) (map[int]int, error) // patients without no-shows are left out
+	SendVisitReminders() (int, error)                       // waiting outpatients of tomorrow, returns how many were handed to the notifier
+}
+
+// INotifier tells patients about their outpatients, see features/notifications. The
+// outpatients come with their work schedule, delivery happens in the background.
+type INotifier interface {
+	NotifyOutpatients(event string, outpatients []OutpatientCore)
 }
 
 type IData interface {
@@ -107,9 +265,18 @@ type IData interface {
 	SelectWorkScheduleById(workScheduleId int) (WorkScheduleCore, error)
 	SelectWorkSchedulesByDoctorId(doctorId int, q ScheduleQuery) ([]WorkScheduleCore, error)
 	SelectWorkSchedulesByNurseId(nurseId int, q ScheduleQuery) ([]WorkScheduleCore, error)
-	InsertWorkSchedules(workSchedules []WorkScheduleCore) error
+	SelectWorkSchedulesByDoctorIds(doctorIds []int, q ScheduleQuery) ([]WorkScheduleCore, error) // ordered by date and start time
+	SelectConflictingWorkSchedules(q ConflictQuery) ([]WorkScheduleCore, error)
+	SelectWorkSchedulesByGroup(group string) ([]WorkScheduleCore, error)     // ordered by date
+	SelectWorkSchedulesByDates(dates []string) ([]WorkScheduleCore, error)   // with waiting outpatients, ordered by date
+	SelectWaitingWorkSchedulesUntil(date string) ([]WorkScheduleCore, error) // up to date that still have waiting outpatients, preloaded
+	SelectCountWorkSchedulesOutpatients(ids []int, statuses []int) (map[int]int, error)
+	SelectBookingCounts(workScheduleIds []int) (map[int]BookingCountCore, error)
+	InsertWorkSchedules(workSchedules []WorkScheduleCore) ([]int, error)
 	UpdateWorkSchedule(workSchedule WorkScheduleCore) error
-	DeleteWorkScheduleById(workScheduleId int) error // also remove outpatient schedules
+	UpdateWorkSchedules(workSchedules []WorkScheduleCore) error
+	DeleteWorkScheduleById(workScheduleId int) error      // also remove outpatient schedules
+	DeleteWorkSchedulesByIds(workScheduleIds []int) error // also remove outpatient schedules
 	DeleteWorkSchedulesByDoctorId(doctorId int, q ScheduleQuery) error
 	DeleteNurseFromWorkSchedules(nurseId int, q ScheduleQuery) error
 
@@ -117,12 +284,26 @@ type IData interface {
 	SelectOutpatientsByWorkScheduleId(workScheduleId int) (WorkScheduleCore, error)
 	SelectOutpatientsByPatientId(patientId int, q ScheduleQuery) ([]OutpatientCore, error)
 	SelectOutpatientById(outpatientId int) (OutpatientCore, error)
-	InsertOutpatient(outpatient OutpatientCore) error
+	SelectWaitingOutpatientsByWorkScheduleIds(workScheduleIds []int) ([]OutpatientCore, error)
+	SelectCountCareTeamOutpatients(patientId int, doctorId int, nurseId int) (int, error)           // outpatients of patient under the doctor or nurse
+	SelectCountOutpatientsAhead(workScheduleId int, queueNumber int, outpatientId int) (int, error) // waiting with a lower number and on progress
+	SelectAverageExaminationTime(doctorId int) (time.Duration, int, error)                          // average and number of recent finished outpatients used
+	SelectNoShowCounts(patientIds []int) (map[int]int, error)
+	InsertOutpatient(outpatient OutpatientCore) (int, error) // with the next queue number of its work schedule, fails when it has no room left by then
 	UpdateOutpatient(outpatient OutpatientCore) error
+	UpdateOutpatientReservingStock(outpatient OutpatientCore, today string) error // in one transaction, nothing is saved when a medicine is short
+	UpdateOutpatientsStatus(outpatientIds []int, from int, to int) error          // only those still in status from
+	MoveOutpatients(moves []OutpatientMoveCore) error                             // also records the moves, they join the end of the queue on the slot of the move
 	DeleteWaitingOutpatientsByPatientId(patientId int) error
 	DeleteOutpatientById(outpatientId int) error
 
-	// maybe needed in future
-	// ? UpdatePresctiption(PrescriptionCore) error
-	// ? DeleltePrescriptionById(int) error
+	SelectPrescriptionById(prescriptionId int) (PrescriptionCore, error)
+	SelectPrescriptionVersions(originalId int) ([]PrescriptionCore, error) // oldest first
+	// AmendPrescription marks prescriptionId amended and inserts the new version, it fails
+	// with KindUnprocessable when prescriptionId is no longer active
+	AmendPrescription(prescriptionId int, amended PrescriptionCore) (int, error)
+	VoidPrescription(prescription PrescriptionCore) error // only while active, same as AmendPrescription
+	SelectPrescriptionsByIds(prescriptionIds []int) ([]PrescriptionCore, error)
+	DispensePrescriptions(prescriptionIds []int, dispensedBy int, dispensedAt time.Time) error // only while active, all or none
+	RevertDispensedPrescriptions(prescriptionIds []int) error                                  // back to active when the stock could not be taken
 }
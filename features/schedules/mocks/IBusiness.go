@@ -12,6 +12,41 @@ type IBusiness struct {
 	mock.Mock
 }
 
+// AmendPrescription provides a mock function with given fields: prescription, userId, role
+func (_m *IBusiness) AmendPrescription(prescription schedules.PrescriptionCore, userId int, role string) (schedules.PrescriptionCore, error) {
+	ret := _m.Called(prescription, userId, role)
+
+	var r0 schedules.PrescriptionCore
+	if rf, ok := ret.Get(0).(func(schedules.PrescriptionCore, int, string) schedules.PrescriptionCore); ok {
+		r0 = rf(prescription, userId, role)
+	} else {
+		r0 = ret.Get(0).(schedules.PrescriptionCore)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(schedules.PrescriptionCore, int, string) error); ok {
+		r1 = rf(prescription, userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BookVisit provides a mock function with given fields: outpatient, patientId
+func (_m *IBusiness) BookVisit(outpatient schedules.OutpatientCore, patientId int) error {
+	ret := _m.Called(outpatient, patientId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(schedules.OutpatientCore, int) error); ok {
+		r0 = rf(outpatient, patientId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CancelOutpatient provides a mock function with given fields: outpatientId, userId, role
 func (_m *IBusiness) CancelOutpatient(outpatientId int, userId int, role string) error {
 	ret := _m.Called(outpatientId, userId, role)
@@ -26,13 +61,41 @@ func (_m *IBusiness) CancelOutpatient(outpatientId int, userId int, role string)
 	return r0
 }
 
-// CreateOutpatient provides a mock function with given fields: outpatient
-func (_m *IBusiness) CreateOutpatient(outpatient schedules.OutpatientCore) error {
-	ret := _m.Called(outpatient)
+// CancelVisit provides a mock function with given fields: outpatientId, patientId
+func (_m *IBusiness) CancelVisit(outpatientId int, patientId int) error {
+	ret := _m.Called(outpatientId, patientId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(outpatientId, patientId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateOutpatient provides a mock function with given fields: outpatient, userId, role
+func (_m *IBusiness) CreateOutpatient(outpatient schedules.OutpatientCore, userId int, role string) error {
+	ret := _m.Called(outpatient, userId, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(schedules.OutpatientCore, int, string) error); ok {
+		r0 = rf(outpatient, userId, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateWorkSchedule provides a mock function with given fields: workSchedule, q, userId, role
+func (_m *IBusiness) CreateWorkSchedule(workSchedule schedules.WorkScheduleCore, q schedules.ScheduleQuery, userId int, role string) error {
+	ret := _m.Called(workSchedule, q, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(schedules.OutpatientCore) error); ok {
-		r0 = rf(outpatient)
+	if rf, ok := ret.Get(0).(func(schedules.WorkScheduleCore, schedules.ScheduleQuery, int, string) error); ok {
+		r0 = rf(workSchedule, q, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -40,13 +103,13 @@ func (_m *IBusiness) CreateOutpatient(outpatient schedules.OutpatientCore) error
 	return r0
 }
 
-// CreateWorkSchedule provides a mock function with given fields: workSchedule, q
-func (_m *IBusiness) CreateWorkSchedule(workSchedule schedules.WorkScheduleCore, q schedules.ScheduleQuery) error {
-	ret := _m.Called(workSchedule, q)
+// DispensePrescriptions provides a mock function with given fields: prescriptionIds, userId, role
+func (_m *IBusiness) DispensePrescriptions(prescriptionIds []int, userId int, role string) error {
+	ret := _m.Called(prescriptionIds, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(schedules.WorkScheduleCore, schedules.ScheduleQuery) error); ok {
-		r0 = rf(workSchedule, q)
+	if rf, ok := ret.Get(0).(func([]int, int, string) error); ok {
+		r0 = rf(prescriptionIds, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -54,13 +117,13 @@ func (_m *IBusiness) CreateWorkSchedule(workSchedule schedules.WorkScheduleCore,
 	return r0
 }
 
-// EditOutpatient provides a mock function with given fields: outpatient
-func (_m *IBusiness) EditOutpatient(outpatient schedules.OutpatientCore) error {
-	ret := _m.Called(outpatient)
+// EditOutpatient provides a mock function with given fields: outpatient, userId, role
+func (_m *IBusiness) EditOutpatient(outpatient schedules.OutpatientCore, userId int, role string) error {
+	ret := _m.Called(outpatient, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(schedules.OutpatientCore) error); ok {
-		r0 = rf(outpatient)
+	if rf, ok := ret.Get(0).(func(schedules.OutpatientCore, int, string) error); ok {
+		r0 = rf(outpatient, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -68,13 +131,13 @@ func (_m *IBusiness) EditOutpatient(outpatient schedules.OutpatientCore) error {
 	return r0
 }
 
-// EditWorkSchedule provides a mock function with given fields: workSchedule
-func (_m *IBusiness) EditWorkSchedule(workSchedule schedules.WorkScheduleCore) error {
-	ret := _m.Called(workSchedule)
+// EditWorkSchedule provides a mock function with given fields: workSchedule, userId, role
+func (_m *IBusiness) EditWorkSchedule(workSchedule schedules.WorkScheduleCore, userId int, role string) error {
+	ret := _m.Called(workSchedule, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(schedules.WorkScheduleCore) error); ok {
-		r0 = rf(workSchedule)
+	if rf, ok := ret.Get(0).(func(schedules.WorkScheduleCore, int, string) error); ok {
+		r0 = rf(workSchedule, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -82,6 +145,27 @@ func (_m *IBusiness) EditWorkSchedule(workSchedule schedules.WorkScheduleCore) e
 	return r0
 }
 
+// EditWorkScheduleSeries provides a mock function with given fields: group, workScheduleId, scope, changes, userId, role
+func (_m *IBusiness) EditWorkScheduleSeries(group string, workScheduleId int, scope string, changes schedules.WorkScheduleCore, userId int, role string) (schedules.SeriesResultCore, error) {
+	ret := _m.Called(group, workScheduleId, scope, changes, userId, role)
+
+	var r0 schedules.SeriesResultCore
+	if rf, ok := ret.Get(0).(func(string, int, string, schedules.WorkScheduleCore, int, string) schedules.SeriesResultCore); ok {
+		r0 = rf(group, workScheduleId, scope, changes, userId, role)
+	} else {
+		r0 = ret.Get(0).(schedules.SeriesResultCore)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, schedules.WorkScheduleCore, int, string) error); ok {
+		r1 = rf(group, workScheduleId, scope, changes, userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExamineOutpatient provides a mock function with given fields: outpatientId, userId, role
 func (_m *IBusiness) ExamineOutpatient(outpatientId int, userId int, role string) error {
 	ret := _m.Called(outpatientId, userId, role)
@@ -96,6 +180,29 @@ func (_m *IBusiness) ExamineOutpatient(outpatientId int, userId int, role string
 	return r0
 }
 
+// FindAvailability provides a mock function with given fields: specialityId, q
+func (_m *IBusiness) FindAvailability(specialityId int, q schedules.ScheduleQuery) ([]schedules.AvailabilityCore, error) {
+	ret := _m.Called(specialityId, q)
+
+	var r0 []schedules.AvailabilityCore
+	if rf, ok := ret.Get(0).(func(int, schedules.ScheduleQuery) []schedules.AvailabilityCore); ok {
+		r0 = rf(specialityId, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.AvailabilityCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, schedules.ScheduleQuery) error); ok {
+		r1 = rf(specialityId, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindDoctorWorkSchedules provides a mock function with given fields: doctorId, q
 func (_m *IBusiness) FindDoctorWorkSchedules(doctorId int, q schedules.ScheduleQuery) ([]schedules.WorkScheduleCore, error) {
 	ret := _m.Called(doctorId, q)
@@ -119,6 +226,29 @@ func (_m *IBusiness) FindDoctorWorkSchedules(doctorId int, q schedules.ScheduleQ
 	return r0, r1
 }
 
+// FindNoShowCounts provides a mock function with given fields: patientIds
+func (_m *IBusiness) FindNoShowCounts(patientIds []int) (map[int]int, error) {
+	ret := _m.Called(patientIds)
+
+	var r0 map[int]int
+	if rf, ok := ret.Get(0).(func([]int) map[int]int); ok {
+		r0 = rf(patientIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(patientIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindNurseWorkSchedules provides a mock function with given fields: nurseId, q
 func (_m *IBusiness) FindNurseWorkSchedules(nurseId int, q schedules.ScheduleQuery) ([]schedules.WorkScheduleCore, error) {
 	ret := _m.Called(nurseId, q)
@@ -142,20 +272,20 @@ func (_m *IBusiness) FindNurseWorkSchedules(nurseId int, q schedules.ScheduleQue
 	return r0, r1
 }
 
-// FindOutpatientById provides a mock function with given fields: outpatientId
-func (_m *IBusiness) FindOutpatientById(outpatientId int) (schedules.OutpatientCore, error) {
-	ret := _m.Called(outpatientId)
+// FindOutpatientById provides a mock function with given fields: outpatientId, userId, role, breakGlassReason
+func (_m *IBusiness) FindOutpatientById(outpatientId int, userId int, role string, breakGlassReason string) (schedules.OutpatientCore, error) {
+	ret := _m.Called(outpatientId, userId, role, breakGlassReason)
 
 	var r0 schedules.OutpatientCore
-	if rf, ok := ret.Get(0).(func(int) schedules.OutpatientCore); ok {
-		r0 = rf(outpatientId)
+	if rf, ok := ret.Get(0).(func(int, int, string, string) schedules.OutpatientCore); ok {
+		r0 = rf(outpatientId, userId, role, breakGlassReason)
 	} else {
 		r0 = ret.Get(0).(schedules.OutpatientCore)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(outpatientId)
+	if rf, ok := ret.Get(1).(func(int, int, string, string) error); ok {
+		r1 = rf(outpatientId, userId, role, breakGlassReason)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -163,13 +293,34 @@ func (_m *IBusiness) FindOutpatientById(outpatientId int) (schedules.OutpatientC
 	return r0, r1
 }
 
-// FindOutpatients provides a mock function with given fields: q
-func (_m *IBusiness) FindOutpatients(q schedules.ScheduleQuery) ([]schedules.OutpatientCore, error) {
-	ret := _m.Called(q)
+// FindOutpatientQueue provides a mock function with given fields: outpatientId, userId, role
+func (_m *IBusiness) FindOutpatientQueue(outpatientId int, userId int, role string) (schedules.QueueCore, error) {
+	ret := _m.Called(outpatientId, userId, role)
+
+	var r0 schedules.QueueCore
+	if rf, ok := ret.Get(0).(func(int, int, string) schedules.QueueCore); ok {
+		r0 = rf(outpatientId, userId, role)
+	} else {
+		r0 = ret.Get(0).(schedules.QueueCore)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int, string) error); ok {
+		r1 = rf(outpatientId, userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOutpatients provides a mock function with given fields: q, userId, role
+func (_m *IBusiness) FindOutpatients(q schedules.ScheduleQuery, userId int, role string) ([]schedules.OutpatientCore, error) {
+	ret := _m.Called(q, userId, role)
 
 	var r0 []schedules.OutpatientCore
-	if rf, ok := ret.Get(0).(func(schedules.ScheduleQuery) []schedules.OutpatientCore); ok {
-		r0 = rf(q)
+	if rf, ok := ret.Get(0).(func(schedules.ScheduleQuery, int, string) []schedules.OutpatientCore); ok {
+		r0 = rf(q, userId, role)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]schedules.OutpatientCore)
@@ -177,8 +328,8 @@ func (_m *IBusiness) FindOutpatients(q schedules.ScheduleQuery) ([]schedules.Out
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(schedules.ScheduleQuery) error); ok {
-		r1 = rf(q)
+	if rf, ok := ret.Get(1).(func(schedules.ScheduleQuery, int, string) error); ok {
+		r1 = rf(q, userId, role)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -186,13 +337,13 @@ func (_m *IBusiness) FindOutpatients(q schedules.ScheduleQuery) ([]schedules.Out
 	return r0, r1
 }
 
-// FindOutpatientsByPatientId provides a mock function with given fields: patientId, q
-func (_m *IBusiness) FindOutpatientsByPatientId(patientId int, q schedules.ScheduleQuery) ([]schedules.OutpatientCore, error) {
-	ret := _m.Called(patientId, q)
+// FindOutpatientsByPatientId provides a mock function with given fields: patientId, q, userId, role, breakGlassReason
+func (_m *IBusiness) FindOutpatientsByPatientId(patientId int, q schedules.ScheduleQuery, userId int, role string, breakGlassReason string) ([]schedules.OutpatientCore, error) {
+	ret := _m.Called(patientId, q, userId, role, breakGlassReason)
 
 	var r0 []schedules.OutpatientCore
-	if rf, ok := ret.Get(0).(func(int, schedules.ScheduleQuery) []schedules.OutpatientCore); ok {
-		r0 = rf(patientId, q)
+	if rf, ok := ret.Get(0).(func(int, schedules.ScheduleQuery, int, string, string) []schedules.OutpatientCore); ok {
+		r0 = rf(patientId, q, userId, role, breakGlassReason)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]schedules.OutpatientCore)
@@ -200,8 +351,8 @@ func (_m *IBusiness) FindOutpatientsByPatientId(patientId int, q schedules.Sched
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, schedules.ScheduleQuery) error); ok {
-		r1 = rf(patientId, q)
+	if rf, ok := ret.Get(1).(func(int, schedules.ScheduleQuery, int, string, string) error); ok {
+		r1 = rf(patientId, q, userId, role, breakGlassReason)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -209,20 +360,66 @@ func (_m *IBusiness) FindOutpatientsByPatientId(patientId int, q schedules.Sched
 	return r0, r1
 }
 
-// FindOutpatientsByWorkScheduleId provides a mock function with given fields: workScheduleId
-func (_m *IBusiness) FindOutpatientsByWorkScheduleId(workScheduleId int) (schedules.WorkScheduleCore, error) {
-	ret := _m.Called(workScheduleId)
+// FindOutpatientsByWorkScheduleId provides a mock function with given fields: workScheduleId, userId, role
+func (_m *IBusiness) FindOutpatientsByWorkScheduleId(workScheduleId int, userId int, role string) (schedules.WorkScheduleCore, error) {
+	ret := _m.Called(workScheduleId, userId, role)
 
 	var r0 schedules.WorkScheduleCore
-	if rf, ok := ret.Get(0).(func(int) schedules.WorkScheduleCore); ok {
-		r0 = rf(workScheduleId)
+	if rf, ok := ret.Get(0).(func(int, int, string) schedules.WorkScheduleCore); ok {
+		r0 = rf(workScheduleId, userId, role)
 	} else {
 		r0 = ret.Get(0).(schedules.WorkScheduleCore)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(workScheduleId)
+	if rf, ok := ret.Get(1).(func(int, int, string) error); ok {
+		r1 = rf(workScheduleId, userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPrescriptionVersions provides a mock function with given fields: prescriptionId, userId, role
+func (_m *IBusiness) FindPrescriptionVersions(prescriptionId int, userId int, role string) ([]schedules.PrescriptionCore, error) {
+	ret := _m.Called(prescriptionId, userId, role)
+
+	var r0 []schedules.PrescriptionCore
+	if rf, ok := ret.Get(0).(func(int, int, string) []schedules.PrescriptionCore); ok {
+		r0 = rf(prescriptionId, userId, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.PrescriptionCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int, string) error); ok {
+		r1 = rf(prescriptionId, userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStaffWorkSchedules provides a mock function with given fields: staffRole, staffId, q
+func (_m *IBusiness) FindStaffWorkSchedules(staffRole string, staffId int, q schedules.ScheduleQuery) ([]schedules.WorkScheduleCore, error) {
+	ret := _m.Called(staffRole, staffId, q)
+
+	var r0 []schedules.WorkScheduleCore
+	if rf, ok := ret.Get(0).(func(string, int, schedules.ScheduleQuery) []schedules.WorkScheduleCore); ok {
+		r0 = rf(staffRole, staffId, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.WorkScheduleCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, schedules.ScheduleQuery) error); ok {
+		r1 = rf(staffRole, staffId, q)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -253,6 +450,52 @@ func (_m *IBusiness) FindWorkSchedules(q schedules.ScheduleQuery) ([]schedules.W
 	return r0, r1
 }
 
+// FindWorkSchedulesByDates provides a mock function with given fields: dates
+func (_m *IBusiness) FindWorkSchedulesByDates(dates []string) ([]schedules.WorkScheduleCore, error) {
+	ret := _m.Called(dates)
+
+	var r0 []schedules.WorkScheduleCore
+	if rf, ok := ret.Get(0).(func([]string) []schedules.WorkScheduleCore); ok {
+		r0 = rf(dates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.WorkScheduleCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(dates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindWorkSchedulesByGroup provides a mock function with given fields: group
+func (_m *IBusiness) FindWorkSchedulesByGroup(group string) ([]schedules.WorkScheduleCore, error) {
+	ret := _m.Called(group)
+
+	var r0 []schedules.WorkScheduleCore
+	if rf, ok := ret.Get(0).(func(string) []schedules.WorkScheduleCore); ok {
+		r0 = rf(group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.WorkScheduleCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FinishOutpatient provides a mock function with given fields: outpatient, userId, role
 func (_m *IBusiness) FinishOutpatient(outpatient schedules.OutpatientCore, userId int, role string) error {
 	ret := _m.Called(outpatient, userId, role)
@@ -267,6 +510,55 @@ func (_m *IBusiness) FinishOutpatient(outpatient schedules.OutpatientCore, userI
 	return r0
 }
 
+// FlagWorkSchedulesOnLeave provides a mock function with given fields: workSchedules, leaveId, userId, role
+func (_m *IBusiness) FlagWorkSchedulesOnLeave(workSchedules []schedules.WorkScheduleCore, leaveId int, userId int, role string) error {
+	ret := _m.Called(workSchedules, leaveId, userId, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]schedules.WorkScheduleCore, int, int, string) error); ok {
+		r0 = rf(workSchedules, leaveId, userId, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkNoShows provides a mock function with given fields:
+func (_m *IBusiness) MarkNoShows() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReassignWorkSchedules provides a mock function with given fields: workSchedules, doctorId, nurseId, userId, role
+func (_m *IBusiness) ReassignWorkSchedules(workSchedules []schedules.WorkScheduleCore, doctorId int, nurseId int, userId int, role string) error {
+	ret := _m.Called(workSchedules, doctorId, nurseId, userId, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]schedules.WorkScheduleCore, int, int, int, string) error); ok {
+		r0 = rf(workSchedules, doctorId, nurseId, userId, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveDoctorFutureWorkSchedules provides a mock function with given fields: doctorId
 func (_m *IBusiness) RemoveDoctorFutureWorkSchedules(doctorId int) error {
 	ret := _m.Called(doctorId)
@@ -295,13 +587,13 @@ func (_m *IBusiness) RemoveNurseFromNextWorkSchedules(nurseId int) error {
 	return r0
 }
 
-// RemoveOutpatientById provides a mock function with given fields: outpatientId
-func (_m *IBusiness) RemoveOutpatientById(outpatientId int) error {
-	ret := _m.Called(outpatientId)
+// RemoveOutpatientById provides a mock function with given fields: outpatientId, userId, role
+func (_m *IBusiness) RemoveOutpatientById(outpatientId int, userId int, role string) error {
+	ret := _m.Called(outpatientId, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(outpatientId)
+	if rf, ok := ret.Get(0).(func(int, int, string) error); ok {
+		r0 = rf(outpatientId, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -323,13 +615,170 @@ func (_m *IBusiness) RemovePatientWaitingOutpatients(patientId int) error {
 	return r0
 }
 
-// RemoveWorkScheduleById provides a mock function with given fields: workScheduleId
-func (_m *IBusiness) RemoveWorkScheduleById(workScheduleId int) error {
-	ret := _m.Called(workScheduleId)
+// RemoveWorkScheduleById provides a mock function with given fields: workScheduleId, userId, role
+func (_m *IBusiness) RemoveWorkScheduleById(workScheduleId int, userId int, role string) error {
+	ret := _m.Called(workScheduleId, userId, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
+	if rf, ok := ret.Get(0).(func(int, int, string) error); ok {
+		r0 = rf(workScheduleId, userId, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveWorkScheduleSeries provides a mock function with given fields: group, workScheduleId, scope, userId, role
+func (_m *IBusiness) RemoveWorkScheduleSeries(group string, workScheduleId int, scope string, userId int, role string) (schedules.SeriesResultCore, error) {
+	ret := _m.Called(group, workScheduleId, scope, userId, role)
+
+	var r0 schedules.SeriesResultCore
+	if rf, ok := ret.Get(0).(func(string, int, string, int, string) schedules.SeriesResultCore); ok {
+		r0 = rf(group, workScheduleId, scope, userId, role)
+	} else {
+		r0 = ret.Get(0).(schedules.SeriesResultCore)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string, int, string) error); ok {
+		r1 = rf(group, workScheduleId, scope, userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RescheduleOutpatient provides a mock function with given fields: outpatientId, workScheduleId, reason, userId, role
+func (_m *IBusiness) RescheduleOutpatient(outpatientId int, workScheduleId int, reason string, userId int, role string) error {
+	ret := _m.Called(outpatientId, workScheduleId, reason, userId, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int, string, int, string) error); ok {
+		r0 = rf(outpatientId, workScheduleId, reason, userId, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendVisitReminders provides a mock function with given fields:
+func (_m *IBusiness) SendVisitReminders() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeRoomQueue provides a mock function with given fields: roomId
+func (_m *IBusiness) SubscribeRoomQueue(roomId int) (<-chan schedules.QueueEventCore, func(), error) {
+	ret := _m.Called(roomId)
+
+	var r0 <-chan schedules.QueueEventCore
+	if rf, ok := ret.Get(0).(func(int) <-chan schedules.QueueEventCore); ok {
+		r0 = rf(roomId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan schedules.QueueEventCore)
+		}
+	}
+
+	var r1 func()
+	if rf, ok := ret.Get(1).(func(int) func()); ok {
+		r1 = rf(roomId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(roomId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribeWorkScheduleQueue provides a mock function with given fields: workScheduleId
+func (_m *IBusiness) SubscribeWorkScheduleQueue(workScheduleId int) (<-chan schedules.QueueEventCore, func(), error) {
+	ret := _m.Called(workScheduleId)
+
+	var r0 <-chan schedules.QueueEventCore
+	if rf, ok := ret.Get(0).(func(int) <-chan schedules.QueueEventCore); ok {
 		r0 = rf(workScheduleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan schedules.QueueEventCore)
+		}
+	}
+
+	var r1 func()
+	if rf, ok := ret.Get(1).(func(int) func()); ok {
+		r1 = rf(workScheduleId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(workScheduleId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// TransferOutpatients provides a mock function with given fields: fromWorkScheduleId, toWorkScheduleId, reason, userId, role
+func (_m *IBusiness) TransferOutpatients(fromWorkScheduleId int, toWorkScheduleId int, reason string, userId int, role string) ([]int, error) {
+	ret := _m.Called(fromWorkScheduleId, toWorkScheduleId, reason, userId, role)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(int, int, string, int, string) []int); ok {
+		r0 = rf(fromWorkScheduleId, toWorkScheduleId, reason, userId, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int, string, int, string) error); ok {
+		r1 = rf(fromWorkScheduleId, toWorkScheduleId, reason, userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoidPrescription provides a mock function with given fields: prescriptionId, reason, userId, role
+func (_m *IBusiness) VoidPrescription(prescriptionId int, reason string, userId int, role string) error {
+	ret := _m.Called(prescriptionId, reason, userId, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string, int, string) error); ok {
+		r0 = rf(prescriptionId, reason, userId, role)
 	} else {
 		r0 = ret.Error(0)
 	}
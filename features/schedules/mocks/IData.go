@@ -5,6 +5,7 @@ package mocks
 import (
 	schedules "github.com/final-project-alterra/hospital-management-system-api/features/schedules"
 	mock "github.com/stretchr/testify/mock"
+	time "time"
 )
 
 // IData is an autogenerated mock type for the IData type
@@ -12,6 +13,27 @@ type IData struct {
 	mock.Mock
 }
 
+// AmendPrescription provides a mock function with given fields: prescriptionId, amended
+func (_m *IData) AmendPrescription(prescriptionId int, amended schedules.PrescriptionCore) (int, error) {
+	ret := _m.Called(prescriptionId, amended)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, schedules.PrescriptionCore) int); ok {
+		r0 = rf(prescriptionId, amended)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, schedules.PrescriptionCore) error); ok {
+		r1 = rf(prescriptionId, amended)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteNurseFromWorkSchedules provides a mock function with given fields: nurseId, q
 func (_m *IData) DeleteNurseFromWorkSchedules(nurseId int, q schedules.ScheduleQuery) error {
 	ret := _m.Called(nurseId, q)
@@ -82,13 +104,27 @@ func (_m *IData) DeleteWorkSchedulesByDoctorId(doctorId int, q schedules.Schedul
 	return r0
 }
 
-// InsertOutpatient provides a mock function with given fields: outpatient
-func (_m *IData) InsertOutpatient(outpatient schedules.OutpatientCore) error {
-	ret := _m.Called(outpatient)
+// DeleteWorkSchedulesByIds provides a mock function with given fields: workScheduleIds
+func (_m *IData) DeleteWorkSchedulesByIds(workScheduleIds []int) error {
+	ret := _m.Called(workScheduleIds)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(schedules.OutpatientCore) error); ok {
-		r0 = rf(outpatient)
+	if rf, ok := ret.Get(0).(func([]int) error); ok {
+		r0 = rf(workScheduleIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DispensePrescriptions provides a mock function with given fields: prescriptionIds, dispensedBy, dispensedAt
+func (_m *IData) DispensePrescriptions(prescriptionIds []int, dispensedBy int, dispensedAt time.Time) error {
+	ret := _m.Called(prescriptionIds, dispensedBy, dispensedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]int, int, time.Time) error); ok {
+		r0 = rf(prescriptionIds, dispensedBy, dispensedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -96,13 +132,71 @@ func (_m *IData) InsertOutpatient(outpatient schedules.OutpatientCore) error {
 	return r0
 }
 
+// InsertOutpatient provides a mock function with given fields: outpatient
+func (_m *IData) InsertOutpatient(outpatient schedules.OutpatientCore) (int, error) {
+	ret := _m.Called(outpatient)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(schedules.OutpatientCore) int); ok {
+		r0 = rf(outpatient)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(schedules.OutpatientCore) error); ok {
+		r1 = rf(outpatient)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InsertWorkSchedules provides a mock function with given fields: workSchedules
-func (_m *IData) InsertWorkSchedules(workSchedules []schedules.WorkScheduleCore) error {
+func (_m *IData) InsertWorkSchedules(workSchedules []schedules.WorkScheduleCore) ([]int, error) {
 	ret := _m.Called(workSchedules)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func([]schedules.WorkScheduleCore) error); ok {
+	var r0 []int
+	if rf, ok := ret.Get(0).(func([]schedules.WorkScheduleCore) []int); ok {
 		r0 = rf(workSchedules)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]schedules.WorkScheduleCore) error); ok {
+		r1 = rf(workSchedules)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveOutpatients provides a mock function with given fields: moves
+func (_m *IData) MoveOutpatients(moves []schedules.OutpatientMoveCore) error {
+	ret := _m.Called(moves)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]schedules.OutpatientMoveCore) error); ok {
+		r0 = rf(moves)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevertDispensedPrescriptions provides a mock function with given fields: prescriptionIds
+func (_m *IData) RevertDispensedPrescriptions(prescriptionIds []int) error {
+	ret := _m.Called(prescriptionIds)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]int) error); ok {
+		r0 = rf(prescriptionIds)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -110,6 +204,145 @@ func (_m *IData) InsertWorkSchedules(workSchedules []schedules.WorkScheduleCore)
 	return r0
 }
 
+// SelectAverageExaminationTime provides a mock function with given fields: doctorId
+func (_m *IData) SelectAverageExaminationTime(doctorId int) (time.Duration, int, error) {
+	ret := _m.Called(doctorId)
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func(int) time.Duration); ok {
+		r0 = rf(doctorId)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(doctorId)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(doctorId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SelectBookingCounts provides a mock function with given fields: workScheduleIds
+func (_m *IData) SelectBookingCounts(workScheduleIds []int) (map[int]schedules.BookingCountCore, error) {
+	ret := _m.Called(workScheduleIds)
+
+	var r0 map[int]schedules.BookingCountCore
+	if rf, ok := ret.Get(0).(func([]int) map[int]schedules.BookingCountCore); ok {
+		r0 = rf(workScheduleIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]schedules.BookingCountCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(workScheduleIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelectConflictingWorkSchedules provides a mock function with given fields: q
+func (_m *IData) SelectConflictingWorkSchedules(q schedules.ConflictQuery) ([]schedules.WorkScheduleCore, error) {
+	ret := _m.Called(q)
+
+	var r0 []schedules.WorkScheduleCore
+	if rf, ok := ret.Get(0).(func(schedules.ConflictQuery) []schedules.WorkScheduleCore); ok {
+		r0 = rf(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.WorkScheduleCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(schedules.ConflictQuery) error); ok {
+		r1 = rf(q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelectCountCareTeamOutpatients provides a mock function with given fields: patientId, doctorId, nurseId
+func (_m *IData) SelectCountCareTeamOutpatients(patientId int, doctorId int, nurseId int) (int, error) {
+	ret := _m.Called(patientId, doctorId, nurseId)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, int, int) int); ok {
+		r0 = rf(patientId, doctorId, nurseId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int, int) error); ok {
+		r1 = rf(patientId, doctorId, nurseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelectCountOutpatientsAhead provides a mock function with given fields: workScheduleId, queueNumber, outpatientId
+func (_m *IData) SelectCountOutpatientsAhead(workScheduleId int, queueNumber int, outpatientId int) (int, error) {
+	ret := _m.Called(workScheduleId, queueNumber, outpatientId)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, int, int) int); ok {
+		r0 = rf(workScheduleId, queueNumber, outpatientId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int, int) error); ok {
+		r1 = rf(workScheduleId, queueNumber, outpatientId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelectCountWorkSchedulesOutpatients provides a mock function with given fields: ids, statuses
+func (_m *IData) SelectCountWorkSchedulesOutpatients(ids []int, statuses []int) (map[int]int, error) {
+	ret := _m.Called(ids, statuses)
+
+	var r0 map[int]int
+	if rf, ok := ret.Get(0).(func([]int, []int) map[int]int); ok {
+		r0 = rf(ids, statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]int, []int) error); ok {
+		r1 = rf(ids, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SelectCountWorkSchedulesWaitings provides a mock function with given fields: ids
 func (_m *IData) SelectCountWorkSchedulesWaitings(ids []int) (map[int]int, error) {
 	ret := _m.Called(ids)
@@ -133,6 +366,29 @@ func (_m *IData) SelectCountWorkSchedulesWaitings(ids []int) (map[int]int, error
 	return r0, r1
 }
 
+// SelectNoShowCounts provides a mock function with given fields: patientIds
+func (_m *IData) SelectNoShowCounts(patientIds []int) (map[int]int, error) {
+	ret := _m.Called(patientIds)
+
+	var r0 map[int]int
+	if rf, ok := ret.Get(0).(func([]int) map[int]int); ok {
+		r0 = rf(patientIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(patientIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SelectOutpatientById provides a mock function with given fields: outpatientId
 func (_m *IData) SelectOutpatientById(outpatientId int) (schedules.OutpatientCore, error) {
 	ret := _m.Called(outpatientId)
@@ -221,6 +477,119 @@ func (_m *IData) SelectOutpatientsByWorkScheduleId(workScheduleId int) (schedule
 	return r0, r1
 }
 
+// SelectPrescriptionById provides a mock function with given fields: prescriptionId
+func (_m *IData) SelectPrescriptionById(prescriptionId int) (schedules.PrescriptionCore, error) {
+	ret := _m.Called(prescriptionId)
+
+	var r0 schedules.PrescriptionCore
+	if rf, ok := ret.Get(0).(func(int) schedules.PrescriptionCore); ok {
+		r0 = rf(prescriptionId)
+	} else {
+		r0 = ret.Get(0).(schedules.PrescriptionCore)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(prescriptionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelectPrescriptionVersions provides a mock function with given fields: originalId
+func (_m *IData) SelectPrescriptionVersions(originalId int) ([]schedules.PrescriptionCore, error) {
+	ret := _m.Called(originalId)
+
+	var r0 []schedules.PrescriptionCore
+	if rf, ok := ret.Get(0).(func(int) []schedules.PrescriptionCore); ok {
+		r0 = rf(originalId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.PrescriptionCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(originalId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelectPrescriptionsByIds provides a mock function with given fields: prescriptionIds
+func (_m *IData) SelectPrescriptionsByIds(prescriptionIds []int) ([]schedules.PrescriptionCore, error) {
+	ret := _m.Called(prescriptionIds)
+
+	var r0 []schedules.PrescriptionCore
+	if rf, ok := ret.Get(0).(func([]int) []schedules.PrescriptionCore); ok {
+		r0 = rf(prescriptionIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.PrescriptionCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(prescriptionIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelectWaitingOutpatientsByWorkScheduleIds provides a mock function with given fields: workScheduleIds
+func (_m *IData) SelectWaitingOutpatientsByWorkScheduleIds(workScheduleIds []int) ([]schedules.OutpatientCore, error) {
+	ret := _m.Called(workScheduleIds)
+
+	var r0 []schedules.OutpatientCore
+	if rf, ok := ret.Get(0).(func([]int) []schedules.OutpatientCore); ok {
+		r0 = rf(workScheduleIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.OutpatientCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(workScheduleIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelectWaitingWorkSchedulesUntil provides a mock function with given fields: date
+func (_m *IData) SelectWaitingWorkSchedulesUntil(date string) ([]schedules.WorkScheduleCore, error) {
+	ret := _m.Called(date)
+
+	var r0 []schedules.WorkScheduleCore
+	if rf, ok := ret.Get(0).(func(string) []schedules.WorkScheduleCore); ok {
+		r0 = rf(date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.WorkScheduleCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SelectWorkScheduleById provides a mock function with given fields: workScheduleId
 func (_m *IData) SelectWorkScheduleById(workScheduleId int) (schedules.WorkScheduleCore, error) {
 	ret := _m.Called(workScheduleId)
@@ -265,6 +634,29 @@ func (_m *IData) SelectWorkSchedules(q schedules.ScheduleQuery) ([]schedules.Wor
 	return r0, r1
 }
 
+// SelectWorkSchedulesByDates provides a mock function with given fields: dates
+func (_m *IData) SelectWorkSchedulesByDates(dates []string) ([]schedules.WorkScheduleCore, error) {
+	ret := _m.Called(dates)
+
+	var r0 []schedules.WorkScheduleCore
+	if rf, ok := ret.Get(0).(func([]string) []schedules.WorkScheduleCore); ok {
+		r0 = rf(dates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.WorkScheduleCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(dates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SelectWorkSchedulesByDoctorId provides a mock function with given fields: doctorId, q
 func (_m *IData) SelectWorkSchedulesByDoctorId(doctorId int, q schedules.ScheduleQuery) ([]schedules.WorkScheduleCore, error) {
 	ret := _m.Called(doctorId, q)
@@ -288,6 +680,52 @@ func (_m *IData) SelectWorkSchedulesByDoctorId(doctorId int, q schedules.Schedul
 	return r0, r1
 }
 
+// SelectWorkSchedulesByDoctorIds provides a mock function with given fields: doctorIds, q
+func (_m *IData) SelectWorkSchedulesByDoctorIds(doctorIds []int, q schedules.ScheduleQuery) ([]schedules.WorkScheduleCore, error) {
+	ret := _m.Called(doctorIds, q)
+
+	var r0 []schedules.WorkScheduleCore
+	if rf, ok := ret.Get(0).(func([]int, schedules.ScheduleQuery) []schedules.WorkScheduleCore); ok {
+		r0 = rf(doctorIds, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.WorkScheduleCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]int, schedules.ScheduleQuery) error); ok {
+		r1 = rf(doctorIds, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelectWorkSchedulesByGroup provides a mock function with given fields: group
+func (_m *IData) SelectWorkSchedulesByGroup(group string) ([]schedules.WorkScheduleCore, error) {
+	ret := _m.Called(group)
+
+	var r0 []schedules.WorkScheduleCore
+	if rf, ok := ret.Get(0).(func(string) []schedules.WorkScheduleCore); ok {
+		r0 = rf(group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]schedules.WorkScheduleCore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SelectWorkSchedulesByNurseId provides a mock function with given fields: nurseId, q
 func (_m *IData) SelectWorkSchedulesByNurseId(nurseId int, q schedules.ScheduleQuery) ([]schedules.WorkScheduleCore, error) {
 	ret := _m.Called(nurseId, q)
@@ -325,6 +763,34 @@ func (_m *IData) UpdateOutpatient(outpatient schedules.OutpatientCore) error {
 	return r0
 }
 
+// UpdateOutpatientReservingStock provides a mock function with given fields: outpatient, today
+func (_m *IData) UpdateOutpatientReservingStock(outpatient schedules.OutpatientCore, today string) error {
+	ret := _m.Called(outpatient, today)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(schedules.OutpatientCore, string) error); ok {
+		r0 = rf(outpatient, today)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOutpatientsStatus provides a mock function with given fields: outpatientIds, from, to
+func (_m *IData) UpdateOutpatientsStatus(outpatientIds []int, from int, to int) error {
+	ret := _m.Called(outpatientIds, from, to)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]int, int, int) error); ok {
+		r0 = rf(outpatientIds, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateWorkSchedule provides a mock function with given fields: workSchedule
 func (_m *IData) UpdateWorkSchedule(workSchedule schedules.WorkScheduleCore) error {
 	ret := _m.Called(workSchedule)
@@ -338,3 +804,31 @@ func (_m *IData) UpdateWorkSchedule(workSchedule schedules.WorkScheduleCore) err
 
 	return r0
 }
+
+// UpdateWorkSchedules provides a mock function with given fields: workSchedules
+func (_m *IData) UpdateWorkSchedules(workSchedules []schedules.WorkScheduleCore) error {
+	ret := _m.Called(workSchedules)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]schedules.WorkScheduleCore) error); ok {
+		r0 = rf(workSchedules)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VoidPrescription provides a mock function with given fields: prescription
+func (_m *IData) VoidPrescription(prescription schedules.PrescriptionCore) error {
+	ret := _m.Called(prescription)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(schedules.PrescriptionCore) error); ok {
+		r0 = rf(prescription)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -3,7 +3,10 @@ package presentation
 import (
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/final-project-alterra/hospital-management-system-api/config"
 	"github.com/final-project-alterra/hospital-management-system-api/errors"
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
 	"github.com/final-project-alterra/hospital-management-system-api/features/schedules/presentation/request"
@@ -25,6 +28,7 @@ func NewSchedulePresentation(business schedules.IBusiness) *SchedulePresentation
 	_ = validate.RegisterValidation("ValidateCreateScheduleTime", request.ValidateCreateScheduleTime)
 	_ = validate.RegisterValidation("ValidateUpdateScheduleTime", request.ValidateUpdateScheduleTime)
 	_ = validate.RegisterValidation("ValidateUpdateScheduleDate", request.ValidateUpdateScheduleDate)
+	_ = validate.RegisterValidation("ValidateUpdateSeriesTime", request.ValidateUpdateSeriesTime)
 
 	return &SchedulePresentation{
 		business: business,
@@ -123,6 +127,40 @@ func (p *SchedulePresentation) GetNurseWorkSchedules(c echo.Context) error {
 	return response.Success(c, code, message, response.ListNurseSchedule(schedulesData))
 }
 
+func (p *SchedulePresentation) GetAvailability(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.GetAvailability"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully get availability"
+
+	// from today unless asked otherwise
+	query := request.NewQueryParamsRequest()
+	query.StartDate = time.Now().In(config.GetTimeLoc()).Format("2006-01-02")
+	if err := c.Bind(&query); err != nil {
+		errMsg = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	specialityID, err := strconv.Atoi(c.QueryParam("specialityId"))
+	if err != nil || specialityID < 1 {
+		errMsg = "Invalid speciality id"
+		return response.Error(c, errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(query); err != nil {
+		errMsg = "Invalid query. Makesure date in the format of YYYY-MM-DD"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	availability, err := p.business.FindAvailability(specialityID, query.ToScheduleQuery())
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, response.ListAvailability(availability))
+}
+
 func (p *SchedulePresentation) PostWorkSchedules(c echo.Context) error {
 	const op errors.Op = "schedules.presentation.PostWorkSchedules"
 	var errMsg errors.ErrClientMessage
@@ -146,13 +184,20 @@ func (p *SchedulePresentation) PostWorkSchedules(c echo.Context) error {
 	workSchedule.Nurse.ID = schedule.NurseID
 	workSchedule.StartTime = schedule.StartTime
 	workSchedule.EndTime = schedule.EndTime
+	workSchedule.SlotMinutes = schedule.SlotMinutes
+	workSchedule.SlotCapacity = schedule.SlotCapacity
+	workSchedule.Capacity = schedule.Capacity
+	workSchedule.Overbook = schedule.Overbook
 
 	query := schedules.ScheduleQuery{}
 	query.Repeat = schedule.Repeat
 	query.StartDate = schedule.StartDate
 	query.EndDate = schedule.EndDate
+	query.ExcludeDates = schedule.ExcludeDates
 
-	err := p.business.CreateWorkSchedule(workSchedule, query)
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err := p.business.CreateWorkSchedule(workSchedule, query, userID, role)
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -178,7 +223,9 @@ func (p *SchedulePresentation) PutEditWorkSchedule(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
 	}
 
-	err := p.business.EditWorkSchedule(updatedSchedule.ToWorkScheduleCore())
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err := p.business.EditWorkSchedule(updatedSchedule.ToWorkScheduleCore(), userID, role)
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -199,7 +246,9 @@ func (p *SchedulePresentation) DeleteWorkSchedule(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
 	}
 
-	err = p.business.RemoveWorkScheduleById(scheduleID)
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = p.business.RemoveWorkScheduleById(scheduleID, userID, role)
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -207,6 +256,194 @@ func (p *SchedulePresentation) DeleteWorkSchedule(c echo.Context) error {
 	return response.Success(c, code, message, nil)
 }
 
+func (p *SchedulePresentation) PostTransferOutpatients(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.PostTransferOutpatients"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully transferring waiting outpatients"
+
+	scheduleID, err := strconv.Atoi(c.Param("workScheduleId"))
+	if err != nil {
+		errMsg = "Invalid work schedule id"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	transfer := request.TransferOutpatientsRequest{}
+	if err := c.Bind(&transfer); err != nil {
+		errMsg = "Unable to parse request body"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(transfer); err != nil {
+		errMsg = "Invalid request. Make sure all fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
+	}
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	outpatientIds, err := p.business.TransferOutpatients(scheduleID, transfer.ToWorkScheduleID, transfer.Reason, userID, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, response.Transfer(transfer.ToWorkScheduleID, outpatientIds))
+}
+
+func (p *SchedulePresentation) GetWorkScheduleSeries(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.GetWorkScheduleSeries"
+
+	code := http.StatusOK
+	message := "Successfully retrieving work schedule series"
+
+	schedulesData, err := p.business.FindWorkSchedulesByGroup(c.Param("group"))
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, response.ListWorkSchedule(schedulesData))
+}
+
+func (p *SchedulePresentation) PutEditWorkScheduleSeries(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.PutEditWorkScheduleSeries"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully updating work schedule series"
+
+	series := request.UpdateWorkScheduleSeriesRequest{}
+	if err := c.Bind(&series); err != nil {
+		errMsg = "Unable to parse request body"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(series); err != nil {
+		errMsg = "Invalid request. Makesure all fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
+	}
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	result, err := p.business.EditWorkScheduleSeries(c.Param("group"), series.WorkScheduleID, series.Scope, series.ToWorkScheduleCore(), userID, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, response.SeriesResult(result))
+}
+
+func (p *SchedulePresentation) DeleteWorkScheduleSeries(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.DeleteWorkScheduleSeries"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully deleting work schedule series"
+
+	query := request.RemoveWorkScheduleSeriesRequest{}
+	if err := c.Bind(&query); err != nil {
+		errMsg = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(query); err != nil {
+		errMsg = "Invalid query. Scope must be one of this, following or all"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	result, err := p.business.RemoveWorkScheduleSeries(c.Param("group"), query.WorkScheduleID, query.Scope, userID, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, response.SeriesResult(result))
+}
+
+/* Queue streams, staff see patient names and displays only queue numbers */
+func (p *SchedulePresentation) GetWorkScheduleQueueStream(c echo.Context) error {
+	return p.workScheduleQueueStream(c, "schedules.presentation.GetWorkScheduleQueueStream", false)
+}
+
+func (p *SchedulePresentation) GetWorkScheduleDisplayStream(c echo.Context) error {
+	return p.workScheduleQueueStream(c, "schedules.presentation.GetWorkScheduleDisplayStream", true)
+}
+
+func (p *SchedulePresentation) GetRoomQueueStream(c echo.Context) error {
+	return p.roomQueueStream(c, "schedules.presentation.GetRoomQueueStream", false)
+}
+
+func (p *SchedulePresentation) GetRoomDisplayStream(c echo.Context) error {
+	return p.roomQueueStream(c, "schedules.presentation.GetRoomDisplayStream", true)
+}
+
+func (p *SchedulePresentation) workScheduleQueueStream(c echo.Context, op errors.Op, display bool) error {
+	var errMsg errors.ErrClientMessage
+
+	workScheduleID, err := strconv.Atoi(c.Param("workScheduleId"))
+	if err != nil {
+		errMsg = "Invalid work schedule id"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	events, cancel, err := p.business.SubscribeWorkScheduleQueue(workScheduleID)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	defer cancel()
+
+	return p.streamQueue(c, events, display)
+}
+
+func (p *SchedulePresentation) roomQueueStream(c echo.Context, op errors.Op, display bool) error {
+	var errMsg errors.ErrClientMessage
+
+	roomID, err := strconv.Atoi(c.Param("roomId"))
+	if err != nil {
+		errMsg = "Invalid room id"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	events, cancel, err := p.business.SubscribeRoomQueue(roomID)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	defer cancel()
+
+	return p.streamQueue(c, events, display)
+}
+
+// streamQueue writes events until the client leaves or the subscription is dropped
+func (p *SchedulePresentation) streamQueue(c echo.Context, events <-chan schedules.QueueEventCore, display bool) error {
+	response.StartStream(c)
+
+	heartbeat := time.NewTicker(schedules.QUEUE_STREAM_HEARTBEAT)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-heartbeat.C:
+			if err := response.Heartbeat(c); err != nil {
+				return nil
+			}
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			var data interface{} = response.QueueEvent(event)
+			if display {
+				data = response.DisplayQueueEvent(event)
+			}
+			if err := response.Event(c, event.Type, data); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
 /* Outpatients */
 func (p *SchedulePresentation) GetOutpatients(c echo.Context) error {
 	const op errors.Op = "schedules.presentation.GetOutpatients"
@@ -226,7 +463,9 @@ func (p *SchedulePresentation) GetOutpatients(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
 	}
 
-	outpatients, err := p.business.FindOutpatients(query.ToScheduleQuery())
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	outpatients, err := p.business.FindOutpatients(query.ToScheduleQuery(), userID, role)
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -258,7 +497,14 @@ func (p *SchedulePresentation) GetPatientOutpatients(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
 	}
 
-	outpatients, err := p.business.FindOutpatientsByPatientId(patientID, query.ToScheduleQuery())
+	reason, err := breakGlassReason(c)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	outpatients, err := p.business.FindOutpatientsByPatientId(patientID, query.ToScheduleQuery(), userID, role, reason)
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -279,7 +525,9 @@ func (p *SchedulePresentation) GetWorkScheduleOutpatients(c echo.Context) error
 		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
 	}
 
-	workSchedule, err := p.business.FindOutpatientsByWorkScheduleId(workScheduleID)
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	workSchedule, err := p.business.FindOutpatientsByWorkScheduleId(workScheduleID, userID, role)
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -300,7 +548,14 @@ func (p *SchedulePresentation) GetDetailOutpatient(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
 	}
 
-	outpatient, err := p.business.FindOutpatientById(outpatientID)
+	reason, err := breakGlassReason(c)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	outpatient, err := p.business.FindOutpatientById(outpatientID, userID, role, reason)
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -308,6 +563,29 @@ func (p *SchedulePresentation) GetDetailOutpatient(c echo.Context) error {
 	return response.Success(c, code, message, response.OutpatientDetail(outpatient))
 }
 
+func (p *SchedulePresentation) GetOutpatientQueue(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.GetOutpatientQueue"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully retrieving outpatient queue"
+
+	outpatientID, err := strconv.Atoi(c.Param("outpatientId"))
+	if err != nil {
+		errMsg = "Invalid outpatient id"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	queue, err := p.business.FindOutpatientQueue(outpatientID, userID, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, response.Queue(queue))
+}
+
 func (p *SchedulePresentation) PostOutpatient(c echo.Context) error {
 	const op errors.Op = "schedules.presentation.PostOutpatient"
 	var errMsg errors.ErrClientMessage
@@ -326,7 +604,9 @@ func (p *SchedulePresentation) PostOutpatient(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
 	}
 
-	err := p.business.CreateOutpatient(outpatient.ToOutpatientCore())
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err := p.business.CreateOutpatient(outpatient.ToOutpatientCore(), userID, role)
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -352,7 +632,9 @@ func (p *SchedulePresentation) PutEditOutpatient(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
 	}
 
-	err := p.business.EditOutpatient(outpatient.ToOutpatientCore())
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err := p.business.EditOutpatient(outpatient.ToOutpatientCore(), userID, role)
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
@@ -389,6 +671,34 @@ func (p *SchedulePresentation) PutExamineOutpatient(c echo.Context) error {
 	return response.Success(c, code, message, nil)
 }
 
+func (p *SchedulePresentation) PutRescheduleOutpatient(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.PutRescheduleOutpatient"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully rescheduling outpatient"
+
+	outpatient := request.RescheduleOutpatientRequest{}
+	if err := c.Bind(&outpatient); err != nil {
+		errMsg = "Unable to parse request body"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(outpatient); err != nil {
+		errMsg = "Invalid request. Make sure all fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
+	}
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err := p.business.RescheduleOutpatient(outpatient.ID, outpatient.WorkScheduleID, outpatient.Reason, userID, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, nil)
+}
+
 func (p *SchedulePresentation) PutCancelOutpatient(c echo.Context) error {
 	const op errors.Op = "schedules.presentation.PutCancelOutpatient"
 	var errMsg errors.ErrClientMessage
@@ -447,6 +757,116 @@ func (p *SchedulePresentation) PutFinishOutpatient(c echo.Context) error {
 	return response.Success(c, code, message, nil)
 }
 
+func (p *SchedulePresentation) GetPrescriptionVersions(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.GetPrescriptionVersions"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully retrieving prescription versions"
+
+	prescriptionID, err := strconv.Atoi(c.Param("prescriptionId"))
+	if err != nil {
+		errMsg = "Invalid prescription id"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	versions, err := p.business.FindPrescriptionVersions(prescriptionID, userID, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, response.ListPrescription(versions))
+}
+
+func (p *SchedulePresentation) PutAmendPrescription(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.PutAmendPrescription"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully amending prescription"
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	prescription := request.AmendPrescriptionRequest{}
+
+	if err := c.Bind(&prescription); err != nil {
+		errMsg = "Unable to parse request body"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(prescription); err != nil {
+		errMsg = "Invalid request. Make sure all fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
+	}
+
+	amended, err := p.business.AmendPrescription(prescription.ToPrescriptionCore(), userID, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, response.Prescription(amended))
+}
+
+func (p *SchedulePresentation) PutVoidPrescription(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.PutVoidPrescription"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully voiding prescription"
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	prescription := request.VoidPrescriptionRequest{}
+
+	if err := c.Bind(&prescription); err != nil {
+		errMsg = "Unable to parse request body"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(prescription); err != nil {
+		errMsg = "Invalid request. Make sure all fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
+	}
+
+	err := p.business.VoidPrescription(prescription.ID, prescription.Reason, userID, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, nil)
+}
+
+func (p *SchedulePresentation) PutDispensePrescriptions(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.PutDispensePrescriptions"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully dispensing prescriptions"
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	prescriptions := request.DispensePrescriptionsRequest{}
+
+	if err := c.Bind(&prescriptions); err != nil {
+		errMsg = "Unable to parse request body"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(prescriptions); err != nil {
+		errMsg = "Invalid request. Make sure all fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
+	}
+
+	err := p.business.DispensePrescriptions(prescriptions.IDs, userID, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, nil)
+}
+
 func (p *SchedulePresentation) DeleteOutpatient(c echo.Context) error {
 	const op errors.Op = "schedules.presentation.DeleteOutpatient"
 	var errMsg errors.ErrClientMessage
@@ -460,10 +880,108 @@ func (p *SchedulePresentation) DeleteOutpatient(c echo.Context) error {
 		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
 	}
 
-	err = p.business.RemoveOutpatientById(outpatientID)
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	err = p.business.RemoveOutpatientById(outpatientID, userID, role)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, nil)
+}
+
+func (p *SchedulePresentation) GetOwnVisits(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.GetOwnVisits"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully retrieving visits"
+
+	query := request.NewQueryParamsRequest()
+	if err := c.Bind(&query); err != nil {
+		errMsg = "Unable to parse query params"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(query); err != nil {
+		errMsg = "Invalid query. Make sure all query is valid"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
+	}
+
+	userID := c.Get("userId").(int)
+	role := c.Get("role").(string)
+	outpatients, err := p.business.FindOutpatientsByPatientId(userID, query.ToScheduleQuery(), userID, role, "")
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, response.ListPatientOutpatients(outpatients))
+}
+
+func (p *SchedulePresentation) PostBookVisit(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.PostBookVisit"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusCreated
+	message := "Successfully booking visit"
+
+	visit := request.BookVisitRequest{}
+	if err := c.Bind(&visit); err != nil {
+		errMsg = "Unable to parse request body"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(visit); err != nil {
+		errMsg = "Invalid request. Make sure all fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
+	}
+
+	userID := c.Get("userId").(int)
+	err := p.business.BookVisit(visit.ToOutpatientCore(), userID)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, code, message, nil)
+}
+
+func (p *SchedulePresentation) PutCancelVisit(c echo.Context) error {
+	const op errors.Op = "schedules.presentation.PutCancelVisit"
+	var errMsg errors.ErrClientMessage
+
+	code := http.StatusOK
+	message := "Successfully canceled visit"
+
+	visit := request.CancelOutpatientRequest{}
+	if err := c.Bind(&visit); err != nil {
+		errMsg = "Unable to parse request body"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindBadRequest))
+	}
+
+	if err := p.validate.Struct(visit); err != nil {
+		errMsg = "Invalid request. Make sure all fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMsg, errors.KindUnprocessable))
+	}
+
+	userID := c.Get("userId").(int)
+	err := p.business.CancelVisit(visit.ID, userID)
 	if err != nil {
 		return response.Error(c, errors.E(err, op))
 	}
 
 	return response.Success(c, code, message, nil)
 }
+
+// breakGlassReason reads ?breakGlassReason=, a reason is required to see clinical data
+// of a patient outside the reader's care team
+func breakGlassReason(c echo.Context) (string, error) {
+	const op errors.Op = "schedules.presentation.breakGlassReason"
+	const MAX_LENGTH = 255
+	var errMsg errors.ErrClientMessage = "Break glass reason is too long"
+
+	reason := strings.TrimSpace(c.QueryParam("breakGlassReason"))
+	if len(reason) > MAX_LENGTH {
+		return "", errors.E(errors.New(string(errMsg)), op, errMsg, errors.KindBadRequest)
+	}
+	return reason, nil
+}
@@ -6,6 +6,8 @@ type CreateOutpatientRequest struct {
 	WorkScheduleID int    `json:"workScheduleId" validate:"required,gt=0"`
 	PatientID      int    `json:"patientId" validate:"required,gt=0"`
 	Complaint      string `json:"complaint" validate:"required"`
+	SlotTime       string `json:"slotTime" validate:"omitempty,datetime=15:04:05"`
+	Overbook       bool   `json:"overbook"` // allowed when the slot or work schedule is full
 }
 
 func (o CreateOutpatientRequest) ToOutpatientCore() schedules.OutpatientCore {
@@ -13,6 +15,24 @@ func (o CreateOutpatientRequest) ToOutpatientCore() schedules.OutpatientCore {
 	core.WorkSchedule.ID = o.WorkScheduleID
 	core.Patient.ID = o.PatientID
 	core.Complaint = o.Complaint
+	core.SlotTime = o.SlotTime
+	core.Overbooked = o.Overbook
+
+	return core
+}
+
+// BookVisitRequest is sent by a patient, the patient is the one signed in
+type BookVisitRequest struct {
+	WorkScheduleID int    `json:"workScheduleId" validate:"required,gt=0"`
+	Complaint      string `json:"complaint" validate:"required"`
+	SlotTime       string `json:"slotTime" validate:"omitempty,datetime=15:04:05"`
+}
+
+func (o BookVisitRequest) ToOutpatientCore() schedules.OutpatientCore {
+	core := schedules.OutpatientCore{}
+	core.WorkSchedule.ID = o.WorkScheduleID
+	core.Complaint = o.Complaint
+	core.SlotTime = o.SlotTime
 
 	return core
 }
@@ -33,7 +53,7 @@ func (o UpdateOutpatientRequest) ToOutpatientCore() schedules.OutpatientCore {
 type FinishOutpatientRequest struct {
 	ID            int                   `json:"id" validate:"gt=0"`
 	Diagnosis     string                `json:"diagnosis" validate:"required"`
-	Prescriptions []PrescriptionRequest `json:"prescriptions" validate:"required"`
+	Prescriptions []PrescriptionRequest `json:"prescriptions" validate:"required,dive"`
 }
 
 func (o FinishOutpatientRequest) ToOutpatientCore() schedules.OutpatientCore {
@@ -51,17 +71,53 @@ func (o FinishOutpatientRequest) ToOutpatientCore() schedules.OutpatientCore {
 }
 
 type PrescriptionRequest struct {
-	Medicine    string `json:"medicine" validate:"required"`
-	Instruction string `json:"instruction" validate:"required"`
+	MedicineID   int     `json:"medicineId" validate:"gte=0"`
+	Medicine     string  `json:"medicine" validate:"required_without=MedicineID,max=64"` // taken from the catalogue when MedicineID is set
+	Dose         float64 `json:"dose" validate:"gt=0"`
+	Unit         string  `json:"unit" validate:"required,max=16"`
+	Frequency    string  `json:"frequency" validate:"required,max=64"`
+	Route        string  `json:"route" validate:"required,oneof=oral topical injection inhalation rectal sublingual ophthalmic other"`
+	DurationDays int     `json:"durationDays" validate:"gte=0"`
+	Quantity     int     `json:"quantity" validate:"gt=0"`
+	Instruction  string  `json:"instruction"`
 }
 
 func (p PrescriptionRequest) ToPrescriptionCore() schedules.PrescriptionCore {
 	return schedules.PrescriptionCore{
-		Medicine:    p.Medicine,
-		Instruction: p.Instruction,
+		MedicineID:   p.MedicineID,
+		Medicine:     p.Medicine,
+		Dose:         p.Dose,
+		Unit:         p.Unit,
+		Frequency:    p.Frequency,
+		Route:        p.Route,
+		DurationDays: p.DurationDays,
+		Quantity:     p.Quantity,
+		Instruction:  p.Instruction,
 	}
 }
 
+type AmendPrescriptionRequest struct {
+	ID     int    `json:"id" validate:"gt=0"`
+	Reason string `json:"reason" validate:"required"`
+	PrescriptionRequest
+}
+
+func (a AmendPrescriptionRequest) ToPrescriptionCore() schedules.PrescriptionCore {
+	prescription := a.PrescriptionRequest.ToPrescriptionCore()
+	prescription.ID = a.ID
+	prescription.Reason = a.Reason
+	return prescription
+}
+
+type VoidPrescriptionRequest struct {
+	ID     int    `json:"id" validate:"gt=0"`
+	Reason string `json:"reason" validate:"required"`
+}
+
+type DispensePrescriptionsRequest struct {
+	IDs []int `json:"ids" validate:"required,min=1,dive,gt=0"`
+}
+
 type ExamineOutpatientRequest struct {
 	ID int `json:"id" validate:"gt=0"`
 }
@@ -69,3 +125,14 @@ type ExamineOutpatientRequest struct {
 type CancelOutpatientRequest struct {
 	ID int `json:"id" validate:"gt=0"`
 }
+
+type RescheduleOutpatientRequest struct {
+	ID             int    `json:"id" validate:"gt=0"`
+	WorkScheduleID int    `json:"workScheduleId" validate:"gt=0"`
+	Reason         string `json:"reason" validate:"max=255"`
+}
+
+type TransferOutpatientsRequest struct {
+	ToWorkScheduleID int    `json:"toWorkScheduleId" validate:"gt=0"`
+	Reason           string `json:"reason" validate:"max=255"`
+}
@@ -13,9 +13,18 @@ type CreateWorkScheduleRequest struct {
 	StartTime string `json:"startTime" validate:"required,ValidateCreateScheduleTime"`
 	EndTime   string `json:"endTime" validate:"required"`
 
-	StartDate string `json:"startDate" validate:"required,ValidateCreateScheduleDate"`
-	EndDate   string `json:"endDate"`
-	Repeat    string `json:"repeat" validate:"required,oneof='no-repeat' 'daily' 'weekly' 'monthly'"`
+	// Without slotMinutes outpatients are booked on the shift, capacity zero is unlimited
+	SlotMinutes  int `json:"slotMinutes" validate:"omitempty,min=5,max=480"`
+	SlotCapacity int `json:"slotCapacity" validate:"gte=0"`
+	Capacity     int `json:"capacity" validate:"gte=0"`
+	Overbook     int `json:"overbook" validate:"gte=0"`
+
+	// Repeat is 'no-repeat', 'daily', 'weekly', 'monthly' or an RRULE such as
+	// "FREQ=WEEKLY;BYDAY=MO,WE,FR". EndDate can be empty when the RRULE has COUNT or UNTIL.
+	StartDate    string   `json:"startDate" validate:"required,ValidateCreateScheduleDate"`
+	EndDate      string   `json:"endDate"`
+	Repeat       string   `json:"repeat" validate:"required,max=255"`
+	ExcludeDates []string `json:"excludeDates" validate:"dive,datetime=2006-01-02"`
 }
 
 type UpdateWorkScheduleRequest struct {
@@ -25,6 +34,37 @@ type UpdateWorkScheduleRequest struct {
 	Date      string `json:"date" validate:"required,ValidateUpdateScheduleDate"`
 	StartTime string `json:"startTime" validate:"required,ValidateUpdateScheduleTime"`
 	EndTime   string `json:"endTime" validate:"required"`
+
+	SlotMinutes  int `json:"slotMinutes" validate:"omitempty,min=5,max=480"`
+	SlotCapacity int `json:"slotCapacity" validate:"gte=0"`
+	Capacity     int `json:"capacity" validate:"gte=0"`
+	Overbook     int `json:"overbook" validate:"gte=0"`
+}
+
+// Only the given fields are changed on every occurrence in scope. WorkScheduleID is the
+// occurrence the scope is counted from, it is not needed for scope 'all'.
+type UpdateWorkScheduleSeriesRequest struct {
+	Scope          string `json:"scope" validate:"required,oneof='this' 'following' 'all'"`
+	WorkScheduleID int    `json:"workScheduleId" validate:"gte=0"`
+	DoctorID       int    `json:"doctorId" validate:"gte=0"`
+	NurseID        int    `json:"nurseId" validate:"gte=0"`
+	StartTime      string `json:"startTime" validate:"ValidateUpdateSeriesTime"`
+	EndTime        string `json:"endTime"`
+}
+
+func (w UpdateWorkScheduleSeriesRequest) ToWorkScheduleCore() schedules.WorkScheduleCore {
+	wc := schedules.WorkScheduleCore{}
+	wc.Doctor.ID = w.DoctorID
+	wc.Nurse.ID = w.NurseID
+	wc.StartTime = w.StartTime
+	wc.EndTime = w.EndTime
+
+	return wc
+}
+
+type RemoveWorkScheduleSeriesRequest struct {
+	Scope          string `query:"scope" validate:"required,oneof='this' 'following' 'all'"`
+	WorkScheduleID int    `query:"workScheduleId" validate:"gte=0"`
 }
 
 func (w UpdateWorkScheduleRequest) ToWorkScheduleCore() schedules.WorkScheduleCore {
@@ -35,6 +75,10 @@ func (w UpdateWorkScheduleRequest) ToWorkScheduleCore() schedules.WorkScheduleCo
 	wc.Date = w.Date
 	wc.StartTime = w.StartTime
 	wc.EndTime = w.EndTime
+	wc.SlotMinutes = w.SlotMinutes
+	wc.SlotCapacity = w.SlotCapacity
+	wc.Capacity = w.Capacity
+	wc.Overbook = w.Overbook
 
 	return wc
 }
@@ -45,15 +89,16 @@ func ValidateCreateScheduleDate(fl validator.FieldLevel) bool {
 		return false
 	}
 
-	if input.Repeat != schedules.RepeatNoRepeat && input.EndDate == "" {
-		return false
-	}
-
 	start, err := time.Parse("2006-01-02", input.StartDate)
 	if err != nil {
 		return false
 	}
 
+	// checked against the repeat rule by business
+	if input.Repeat == schedules.RepeatNoRepeat || input.EndDate == "" {
+		return true
+	}
+
 	end, err := time.Parse("2006-01-02", input.EndDate)
 	if err != nil {
 		return false
@@ -108,3 +153,27 @@ func ValidateUpdateScheduleDate(fl validator.FieldLevel) bool {
 	_, err := time.Parse("2006-01-02", input.Date)
 	return err == nil
 }
+
+func ValidateUpdateSeriesTime(fl validator.FieldLevel) bool {
+	input, ok := fl.Parent().Interface().(UpdateWorkScheduleSeriesRequest)
+	if !ok {
+		return false
+	}
+
+	// time is kept when both are empty
+	if input.StartTime == "" && input.EndTime == "" {
+		return true
+	}
+
+	startTime, err := time.Parse("15:04:05", input.StartTime)
+	if err != nil {
+		return false
+	}
+
+	endTime, err := time.Parse("15:04:05", input.EndTime)
+	if err != nil {
+		return false
+	}
+
+	return startTime.Before(endTime)
+}
@@ -18,8 +18,9 @@ type SuccessResponse struct {
 
 type ErrorResponse struct {
 	Error struct {
-		Code    int    `json:"code"`
-		Message string `json:"message"`
+		Code    int         `json:"code"`
+		Message string      `json:"message"`
+		Data    interface{} `json:"data,omitempty"` // errors.ErrPayload, e.g. conflicting work schedule ids
 	} `json:"error"`
 }
 
@@ -36,6 +37,7 @@ func Error(c echo.Context, err error) error {
 	resp := ErrorResponse{}
 	resp.Error.Code = int(errors.Kind(err))
 	resp.Error.Message = string(errors.ClientMessage(err))
+	resp.Error.Data = errors.Payload(err).Data
 
 	// log stack trace error
 	if e, ok := err.(*errors.Error); ok {
@@ -7,30 +7,38 @@ import (
 )
 
 type OutpatientResponse struct {
-	ID        int                `json:"id"`
-	Status    int                `json:"status"`
-	Date      string             `json:"date"`
-	StartTime string             `json:"startTime"`
-	EndTime   string             `json:"endTime"`
-	CreatedAt time.Time          `json:"createdAt"`
-	UpdatedAt time.Time          `json:"updatedAt"`
-	Complaint string             `json:"complaint"`
-	Diagnosis string             `json:"diagnosis"`
-	Patient   Outpatient_Patient `json:"patient"`
-	Doctor    Outpatient_Doctor  `json:"doctor"`
-	Nurse     Outpatient_Nurse   `json:"nurse"`
+	ID          int                `json:"id"`
+	QueueNumber int                `json:"queueNumber"`
+	SlotTime    string             `json:"slotTime"`
+	Overbooked  bool               `json:"overbooked"`
+	Status      int                `json:"status"`
+	Date        string             `json:"date"`
+	StartTime   string             `json:"startTime"`
+	EndTime     string             `json:"endTime"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+	Complaint   string             `json:"complaint"`
+	Diagnosis   string             `json:"diagnosis"`
+	Redacted    bool               `json:"redacted"`
+	Patient     Outpatient_Patient `json:"patient"`
+	Doctor      Outpatient_Doctor  `json:"doctor"`
+	Nurse       Outpatient_Nurse   `json:"nurse"`
 }
 
 type PatientOutpatientResponse struct {
-	ID        int       `json:"id"`
-	Status    int       `json:"status"`
-	Date      string    `json:"date"`
-	StartTime string    `json:"startTime"`
-	EndTime   string    `json:"endTime"`
-	Complaint string    `json:"complaint"`
-	Diagnosis string    `json:"diagnosis"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID          int       `json:"id"`
+	QueueNumber int       `json:"queueNumber"`
+	SlotTime    string    `json:"slotTime"`
+	Overbooked  bool      `json:"overbooked"`
+	Status      int       `json:"status"`
+	Date        string    `json:"date"`
+	StartTime   string    `json:"startTime"`
+	EndTime     string    `json:"endTime"`
+	Complaint   string    `json:"complaint"`
+	Diagnosis   string    `json:"diagnosis"`
+	Redacted    bool      `json:"redacted"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
 
 	Doctor Outpatient_Doctor `json:"doctor"`
 	Nurse  Outpatient_Nurse  `json:"nurse"`
@@ -48,38 +56,94 @@ type WorkScheduleOutPatientResponse struct {
 }
 
 type OutpatientDetailResponse struct {
-	ID           int                    `json:"id"`
-	CreatedAt    time.Time              `json:"createdAt"`
-	UpdatedAt    time.Time              `json:"updatedAt"`
-	Status       int                    `json:"status"`
-	Date         string                 `json:"date"`
-	StartTime    string                 `json:"startTime"`
-	EndTime      string                 `json:"endTime"`
-	Complaint    string                 `json:"complaint"`
-	Diagnosis    string                 `json:"diagnosis"`
-	Patient      Outpatient_Patient     `json:"patient"`
-	Doctor       Outpatient_Doctor      `json:"doctor"`
-	Nurse        Outpatient_Nurse       `json:"nurse"`
-	Prescription []PrescriptionResponse `json:"prescription"`
+	ID           int                      `json:"id"`
+	QueueNumber  int                      `json:"queueNumber"`
+	SlotTime     string                   `json:"slotTime"`
+	Overbooked   bool                     `json:"overbooked"`
+	CreatedAt    time.Time                `json:"createdAt"`
+	UpdatedAt    time.Time                `json:"updatedAt"`
+	Status       int                      `json:"status"`
+	Date         string                   `json:"date"`
+	StartTime    string                   `json:"startTime"`
+	EndTime      string                   `json:"endTime"`
+	Complaint    string                   `json:"complaint"`
+	Diagnosis    string                   `json:"diagnosis"`
+	Redacted     bool                     `json:"redacted"`
+	Patient      Outpatient_Patient       `json:"patient"`
+	Doctor       Outpatient_Doctor        `json:"doctor"`
+	Nurse        Outpatient_Nurse         `json:"nurse"`
+	Prescription []PrescriptionResponse   `json:"prescription"`
+	Moves        []OutpatientMoveResponse `json:"moves"`
+}
+
+type OutpatientMoveResponse struct {
+	FromWorkScheduleID int       `json:"fromWorkScheduleId"`
+	ToWorkScheduleID   int       `json:"toWorkScheduleId"`
+	Reason             string    `json:"reason"`
+	MovedBy            int       `json:"movedBy"`
+	MovedByRole        string    `json:"movedByRole"`
+	CreatedAt          time.Time `json:"createdAt"`
+}
+
+type QueueResponse struct {
+	OutpatientID         int       `json:"outpatientId"`
+	WorkScheduleID       int       `json:"workScheduleId"`
+	QueueNumber          int       `json:"queueNumber"`
+	SlotTime             string    `json:"slotTime"`
+	Overbooked           bool      `json:"overbooked"`
+	Status               int       `json:"status"`
+	Position             int       `json:"position"`
+	WaitingAhead         int       `json:"waitingAhead"`
+	AverageMinutes       float64   `json:"averageMinutes"`
+	Historical           bool      `json:"historical"`
+	EstimatedWaitMinutes float64   `json:"estimatedWaitMinutes"`
+	EstimatedTime        time.Time `json:"estimatedTime"`
+}
+
+type TransferResponse struct {
+	ToWorkScheduleID int   `json:"toWorkScheduleId"`
+	OutpatientIDs    []int `json:"outpatientIds"`
 }
 
 type PrescriptionResponse struct {
-	ID          int    `json:"id"`
-	Medicine    string `json:"medicine"`
-	Instruction string `json:"instruction"`
+	ID           int        `json:"id"`
+	OriginalID   int        `json:"originalId"`
+	Version      int        `json:"version"`
+	Status       string     `json:"status"`
+	MedicineID   int        `json:"medicineId"`
+	Medicine     string     `json:"medicine"`
+	Dose         float64    `json:"dose"`
+	Unit         string     `json:"unit"`
+	Frequency    string     `json:"frequency"`
+	Route        string     `json:"route"`
+	DurationDays int        `json:"durationDays"`
+	Quantity     int        `json:"quantity"`
+	Instruction  string     `json:"instruction"`
+	PrescribedBy int        `json:"prescribedBy"`
+	Reason       string     `json:"reason"`
+	VoidedBy     int        `json:"voidedBy"`
+	VoidReason   string     `json:"voidReason"`
+	VoidedAt     *time.Time `json:"voidedAt"`
+	DispensedBy  int        `json:"dispensedBy"`
+	DispensedAt  *time.Time `json:"dispensedAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
 }
 
 func Outpatient(o schedules.OutpatientCore) OutpatientResponse {
 	return OutpatientResponse{
-		ID:        o.ID,
-		Status:    o.Status,
-		Date:      o.WorkSchedule.Date,
-		StartTime: o.StartTime,
-		EndTime:   o.EndTime,
-		Complaint: o.Complaint,
-		Diagnosis: o.Diagnosis,
-		CreatedAt: o.CreatedAt,
-		UpdatedAt: o.UpdatedAt,
+		ID:          o.ID,
+		QueueNumber: o.QueueNumber,
+		SlotTime:    o.SlotTime,
+		Overbooked:  o.Overbooked,
+		Status:      o.Status,
+		Date:        o.WorkSchedule.Date,
+		StartTime:   o.StartTime,
+		EndTime:     o.EndTime,
+		Complaint:   o.Complaint,
+		Diagnosis:   o.Diagnosis,
+		Redacted:    o.Redacted,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
 
 		Patient: Outpatient_Patient{}.FromCore(o.Patient),
 		Doctor:  Outpatient_Doctor{}.FromCore(o.WorkSchedule.Doctor),
@@ -89,15 +153,19 @@ func Outpatient(o schedules.OutpatientCore) OutpatientResponse {
 
 func PatientOutpatient(o schedules.OutpatientCore) PatientOutpatientResponse {
 	return PatientOutpatientResponse{
-		ID:        o.ID,
-		Status:    o.Status,
-		Date:      o.WorkSchedule.Date,
-		StartTime: o.StartTime,
-		EndTime:   o.EndTime,
-		Complaint: o.Complaint,
-		Diagnosis: o.Diagnosis,
-		CreatedAt: o.CreatedAt,
-		UpdatedAt: o.UpdatedAt,
+		ID:          o.ID,
+		QueueNumber: o.QueueNumber,
+		SlotTime:    o.SlotTime,
+		Overbooked:  o.Overbooked,
+		Status:      o.Status,
+		Date:        o.WorkSchedule.Date,
+		StartTime:   o.StartTime,
+		EndTime:     o.EndTime,
+		Complaint:   o.Complaint,
+		Diagnosis:   o.Diagnosis,
+		Redacted:    o.Redacted,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
 
 		Doctor: Outpatient_Doctor{}.FromCore(o.WorkSchedule.Doctor),
 		Nurse:  Outpatient_Nurse{}.FromCore(o.WorkSchedule.Nurse),
@@ -124,30 +192,91 @@ func WorkScheduleOutpatient(w schedules.WorkScheduleCore) WorkScheduleOutPatient
 
 func OutpatientDetail(o schedules.OutpatientCore) OutpatientDetailResponse {
 	return OutpatientDetailResponse{
-		ID:        o.ID,
-		Status:    o.Status,
-		Date:      o.WorkSchedule.Date,
-		StartTime: o.StartTime,
-		EndTime:   o.EndTime,
-		Complaint: o.Complaint,
-		Diagnosis: o.Diagnosis,
-		CreatedAt: o.CreatedAt,
-		UpdatedAt: o.UpdatedAt,
+		ID:          o.ID,
+		QueueNumber: o.QueueNumber,
+		SlotTime:    o.SlotTime,
+		Overbooked:  o.Overbooked,
+		Status:      o.Status,
+		Date:        o.WorkSchedule.Date,
+		StartTime:   o.StartTime,
+		EndTime:     o.EndTime,
+		Complaint:   o.Complaint,
+		Diagnosis:   o.Diagnosis,
+		Redacted:    o.Redacted,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
 
 		Patient: Outpatient_Patient{}.FromCore(o.Patient),
 		Doctor:  Outpatient_Doctor{}.FromCore(o.WorkSchedule.Doctor),
 		Nurse:   Outpatient_Nurse{}.FromCore(o.WorkSchedule.Nurse),
 
 		Prescription: ListPrescription(o.Prescriptions),
+		Moves:        ListOutpatientMoves(o.Moves),
+	}
+}
+
+func OutpatientMove(m schedules.OutpatientMoveCore) OutpatientMoveResponse {
+	return OutpatientMoveResponse{
+		FromWorkScheduleID: m.FromWorkScheduleID,
+		ToWorkScheduleID:   m.ToWorkScheduleID,
+		Reason:             m.Reason,
+		MovedBy:            m.MovedBy,
+		MovedByRole:        m.MovedByRole,
+		CreatedAt:          m.CreatedAt,
+	}
+}
+
+func Queue(q schedules.QueueCore) QueueResponse {
+	return QueueResponse{
+		OutpatientID:         q.Outpatient.ID,
+		WorkScheduleID:       q.Outpatient.WorkSchedule.ID,
+		QueueNumber:          q.Outpatient.QueueNumber,
+		Status:               q.Outpatient.Status,
+		Position:             q.Position,
+		WaitingAhead:         q.WaitingAhead,
+		AverageMinutes:       q.AverageDuration.Minutes(),
+		Historical:           q.Historical,
+		EstimatedWaitMinutes: q.EstimatedWait.Minutes(),
+		EstimatedTime:        q.EstimatedTime,
+	}
+}
+
+func Transfer(toWorkScheduleId int, outpatientIds []int) TransferResponse {
+	return TransferResponse{
+		ToWorkScheduleID: toWorkScheduleId,
+		OutpatientIDs:    outpatientIds,
 	}
 }
 
 func Prescription(p schedules.PrescriptionCore) PrescriptionResponse {
-	return PrescriptionResponse{
-		ID:          p.ID,
-		Medicine:    p.Medicine,
-		Instruction: p.Instruction,
+	prescription := PrescriptionResponse{
+		ID:           p.ID,
+		OriginalID:   p.OriginalID,
+		Version:      p.Version,
+		Status:       p.Status,
+		MedicineID:   p.MedicineID,
+		Medicine:     p.Medicine,
+		Dose:         p.Dose,
+		Unit:         p.Unit,
+		Frequency:    p.Frequency,
+		Route:        p.Route,
+		DurationDays: p.DurationDays,
+		Quantity:     p.Quantity,
+		Instruction:  p.Instruction,
+		PrescribedBy: p.PrescribedBy,
+		Reason:       p.Reason,
+		VoidedBy:     p.VoidedBy,
+		VoidReason:   p.VoidReason,
+		DispensedBy:  p.DispensedBy,
+		CreatedAt:    p.CreatedAt,
 	}
+	if !p.VoidedAt.IsZero() {
+		prescription.VoidedAt = &p.VoidedAt
+	}
+	if !p.DispensedAt.IsZero() {
+		prescription.DispensedAt = &p.DispensedAt
+	}
+	return prescription
 }
 
 /* List */
@@ -181,6 +310,16 @@ func ListPrescription(ps []schedules.PrescriptionCore) []PrescriptionResponse {
 	return resp
 }
 
+func ListOutpatientMoves(ms []schedules.OutpatientMoveCore) []OutpatientMoveResponse {
+	resp := make([]OutpatientMoveResponse, len(ms))
+
+	for i := range ms {
+		resp[i] = OutpatientMove(ms[i])
+	}
+
+	return resp
+}
+
 /* Nested struct for outpatients */
 type Outpatient_Patient struct {
 	ID        int    `json:"id"`
@@ -211,15 +350,19 @@ type Outpatient_Nurse struct {
 }
 
 type Outpatient_WorkScheduleOutPatient_Outpatient struct {
-	ID        int                `json:"id"`
-	Status    int                `json:"status"`
-	StartTime string             `json:"startTime"`
-	EndTime   string             `json:"endTime"`
-	Complaint string             `json:"complaint"`
-	Diagnosis string             `json:"diagnosis"`
-	Patient   Outpatient_Patient `json:"patient"`
-	CreatedAt time.Time          `json:"createdAt"`
-	UpdatedAt time.Time          `json:"updatedAt"`
+	ID          int                `json:"id"`
+	QueueNumber int                `json:"queueNumber"`
+	SlotTime    string             `json:"slotTime"`
+	Overbooked  bool               `json:"overbooked"`
+	Status      int                `json:"status"`
+	StartTime   string             `json:"startTime"`
+	EndTime     string             `json:"endTime"`
+	Complaint   string             `json:"complaint"`
+	Diagnosis   string             `json:"diagnosis"`
+	Redacted    bool               `json:"redacted"`
+	Patient     Outpatient_Patient `json:"patient"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
 }
 
 func (p Outpatient_Patient) FromCore(c schedules.PatientCore) Outpatient_Patient {
@@ -258,14 +401,18 @@ func (n Outpatient_Nurse) FromCore(c schedules.NurseCore) Outpatient_Nurse {
 
 func (wo Outpatient_WorkScheduleOutPatient_Outpatient) FromCore(o schedules.OutpatientCore) Outpatient_WorkScheduleOutPatient_Outpatient {
 	return Outpatient_WorkScheduleOutPatient_Outpatient{
-		ID:        o.ID,
-		Status:    o.Status,
-		StartTime: o.StartTime,
-		EndTime:   o.EndTime,
-		Complaint: o.Complaint,
-		Diagnosis: o.Diagnosis,
-		CreatedAt: o.CreatedAt,
-		UpdatedAt: o.UpdatedAt,
+		ID:          o.ID,
+		QueueNumber: o.QueueNumber,
+		SlotTime:    o.SlotTime,
+		Overbooked:  o.Overbooked,
+		Status:      o.Status,
+		StartTime:   o.StartTime,
+		EndTime:     o.EndTime,
+		Complaint:   o.Complaint,
+		Diagnosis:   o.Diagnosis,
+		Redacted:    o.Redacted,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
 
 		Patient: Outpatient_Patient{}.FromCore(o.Patient),
 	}
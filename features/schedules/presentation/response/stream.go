@@ -0,0 +1,46 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// StartStream answers with a Server-Sent Events stream, events are written with Event
+func StartStream(c echo.Context) {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no") // keep proxies from buffering the stream
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+}
+
+func Event(c echo.Context, name string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	res := c.Response()
+	_, err = fmt.Fprintf(res, "event: %s\ndata: %s\n\n", name, payload)
+	if err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
+
+// Heartbeat is a comment line, it keeps idle connections from being closed
+func Heartbeat(c echo.Context) error {
+	res := c.Response()
+	_, err := fmt.Fprint(res, ": heartbeat\n\n")
+	if err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
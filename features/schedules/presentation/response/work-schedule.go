@@ -1,13 +1,23 @@
 package response
 
-import "github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/schedules"
+)
 
 type WorkScheduleResponse struct {
 	ID           int    `json:"id"`
+	Group        string `json:"group"`
 	Date         string `json:"date"`
 	StartTime    string `json:"startTime"`
 	EndTime      string `json:"endTime"`
 	TotalWaiting int    `json:"totalWaiting"`
+	LeaveID      int    `json:"leaveId"`
+	SlotMinutes  int    `json:"slotMinutes"`
+	SlotCapacity int    `json:"slotCapacity"`
+	Capacity     int    `json:"capacity"`
+	Overbook     int    `json:"overbook"`
 
 	Doctor struct {
 		ID         int    `json:"id"`
@@ -78,10 +88,16 @@ func WorkSchedule(w schedules.WorkScheduleCore) WorkScheduleResponse {
 	resp := WorkScheduleResponse{}
 
 	resp.ID = w.ID
+	resp.Group = w.Group
+	resp.LeaveID = w.LeaveID
 	resp.Date = w.Date
 	resp.StartTime = w.StartTime
 	resp.EndTime = w.EndTime
 	resp.TotalWaiting = w.TotalWaiting
+	resp.SlotMinutes = w.SlotMinutes
+	resp.SlotCapacity = w.SlotCapacity
+	resp.Capacity = w.Capacity
+	resp.Overbook = w.Overbook
 
 	resp.Doctor.ID = w.Doctor.ID
 	resp.Doctor.Name = w.Doctor.Name
@@ -169,3 +185,129 @@ func ListNurseSchedule(w []schedules.WorkScheduleCore) []NurseWorkScheduleRespon
 	}
 	return result
 }
+
+type SeriesResultResponse struct {
+	WorkScheduleIDs []int `json:"workScheduleIds"`
+	SkippedIDs      []int `json:"skippedIds"`
+}
+
+func SeriesResult(r schedules.SeriesResultCore) SeriesResultResponse {
+	return SeriesResultResponse{
+		WorkScheduleIDs: r.WorkScheduleIDs,
+		SkippedIDs:      r.SkippedIDs,
+	}
+}
+
+type AvailabilityResponse struct {
+	WorkScheduleID int            `json:"workScheduleId"`
+	Date           string         `json:"date"`
+	StartTime      string         `json:"startTime"`
+	EndTime        string         `json:"endTime"`
+	Booked         int            `json:"booked"`
+	Remaining      int            `json:"remaining"` // -1 is unlimited
+	Slots          []SlotResponse `json:"slots"`
+
+	Doctor struct {
+		ID         int    `json:"id"`
+		Name       string `json:"name"`
+		Speciality string `json:"speciality"`
+
+		Room struct {
+			ID    int    `json:"id"`
+			Code  string `json:"code"`
+			Floor string `json:"floor"`
+		} `json:"room"`
+	} `json:"doctor"`
+}
+
+type SlotResponse struct {
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+	Booked    int    `json:"booked"`
+	Capacity  int    `json:"capacity"`
+}
+
+func Availability(a schedules.AvailabilityCore) AvailabilityResponse {
+	resp := AvailabilityResponse{}
+
+	resp.WorkScheduleID = a.WorkSchedule.ID
+	resp.Date = a.WorkSchedule.Date
+	resp.StartTime = a.WorkSchedule.StartTime
+	resp.EndTime = a.WorkSchedule.EndTime
+	resp.Booked = a.Booked
+	resp.Remaining = a.Remaining
+
+	resp.Slots = make([]SlotResponse, len(a.Slots))
+	for i, slot := range a.Slots {
+		resp.Slots[i] = SlotResponse{
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+			Booked:    slot.Booked,
+			Capacity:  slot.Capacity,
+		}
+	}
+
+	resp.Doctor.ID = a.WorkSchedule.Doctor.ID
+	resp.Doctor.Name = a.WorkSchedule.Doctor.Name
+	resp.Doctor.Speciality = a.WorkSchedule.Doctor.Specialty
+
+	resp.Doctor.Room.ID = a.WorkSchedule.Doctor.Room.ID
+	resp.Doctor.Room.Code = a.WorkSchedule.Doctor.Room.Code
+	resp.Doctor.Room.Floor = a.WorkSchedule.Doctor.Room.Floor
+
+	return resp
+}
+
+func ListAvailability(a []schedules.AvailabilityCore) []AvailabilityResponse {
+	result := make([]AvailabilityResponse, len(a))
+	for i := range a {
+		result[i] = Availability(a[i])
+	}
+	return result
+}
+
+type QueueEventResponse struct {
+	Type           string    `json:"type"`
+	WorkScheduleID int       `json:"workScheduleId"`
+	RoomID         int       `json:"roomId"`
+	OutpatientID   int       `json:"outpatientId"`
+	QueueNumber    int       `json:"queueNumber"`
+	Status         int       `json:"status"`
+	PatientName    string    `json:"patientName"`
+	At             time.Time `json:"at"`
+}
+
+// DisplayQueueEventResponse is shown in waiting rooms, patients are only known by their
+// queue number
+type DisplayQueueEventResponse struct {
+	Type           string    `json:"type"`
+	WorkScheduleID int       `json:"workScheduleId"`
+	RoomID         int       `json:"roomId"`
+	QueueNumber    int       `json:"queueNumber"`
+	Status         int       `json:"status"`
+	At             time.Time `json:"at"`
+}
+
+func QueueEvent(e schedules.QueueEventCore) QueueEventResponse {
+	return QueueEventResponse{
+		Type:           e.Type,
+		WorkScheduleID: e.WorkScheduleID,
+		RoomID:         e.RoomID,
+		OutpatientID:   e.OutpatientID,
+		QueueNumber:    e.QueueNumber,
+		Status:         e.Status,
+		PatientName:    e.PatientName,
+		At:             e.At,
+	}
+}
+
+func DisplayQueueEvent(e schedules.QueueEventCore) DisplayQueueEventResponse {
+	return DisplayQueueEventResponse{
+		Type:           e.Type,
+		WorkScheduleID: e.WorkScheduleID,
+		RoomID:         e.RoomID,
+		QueueNumber:    e.QueueNumber,
+		Status:         e.Status,
+		At:             e.At,
+	}
+}
@@ -0,0 +1,55 @@
+package business
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/staffs"
+)
+
+type staffBusinessBuilder struct {
+	staffRepo       staffs.IData
+	adminBusiness   admins.IBusiness
+	accountBusiness accounts.IBusiness
+	auditBusiness   audits.IBusiness
+}
+
+func NewStaffBusinessBuilder() *staffBusinessBuilder {
+	return &staffBusinessBuilder{}
+}
+
+func (s *staffBusinessBuilder) Build() staffs.IBusiness {
+	staffBusiness := &staffBusiness{
+		data:            s.staffRepo,
+		adminBusiness:   s.adminBusiness,
+		accountBusiness: s.accountBusiness,
+		auditBusiness:   s.auditBusiness,
+	}
+
+	s.staffRepo = nil
+	s.adminBusiness = nil
+	s.accountBusiness = nil
+	s.auditBusiness = nil
+
+	return staffBusiness
+}
+
+func (s *staffBusinessBuilder) SetData(data staffs.IData) *staffBusinessBuilder {
+	s.staffRepo = data
+	return s
+}
+
+func (s *staffBusinessBuilder) SetAdminBusiness(adminBusiness admins.IBusiness) *staffBusinessBuilder {
+	s.adminBusiness = adminBusiness
+	return s
+}
+
+func (s *staffBusinessBuilder) SetAccountBusiness(accountBusiness accounts.IBusiness) *staffBusinessBuilder {
+	s.accountBusiness = accountBusiness
+	return s
+}
+
+func (s *staffBusinessBuilder) SetAuditBusiness(auditBusiness audits.IBusiness) *staffBusinessBuilder {
+	s.auditBusiness = auditBusiness
+	return s
+}
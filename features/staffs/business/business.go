@@ -0,0 +1,220 @@
+package business
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/features/staffs"
+)
+
+type staffBusiness struct {
+	data            staffs.IData
+	adminBusiness   admins.IBusiness
+	accountBusiness accounts.IBusiness
+	auditBusiness   audits.IBusiness
+}
+
+func (s *staffBusiness) FindStaffs() ([]staffs.StaffCore, error) {
+	const op errors.Op = "staffs.business.FindStaffs"
+
+	staffsData, err := s.data.SelectStaffs()
+	if err != nil {
+		return []staffs.StaffCore{}, errors.E(err, op)
+	}
+	return staffsData, nil
+}
+
+func (s *staffBusiness) FindStaffById(id int) (staffs.StaffCore, error) {
+	const op errors.Op = "staffs.business.FindStaffById"
+
+	staffData, err := s.data.SelectStaffById(id)
+	if err != nil {
+		return staffs.StaffCore{}, errors.E(err, op)
+	}
+	return staffData, nil
+}
+
+func (s *staffBusiness) CreateStaff(staff staffs.StaffCore) error {
+	const op errors.Op = "staffs.business.CreateStaff"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	if staff.Role != permissions.RoleReceptionist && staff.Role != permissions.RolePharmacist {
+		errMessage = "Staff role must be receptionist or pharmacist"
+		return errors.E(errors.New(string(errMessage)), op, errMessage, errors.KindUnprocessable)
+	}
+
+	_, err := s.adminBusiness.FindAdminById(staff.CreatedBy)
+	if err != nil {
+		switch errors.Kind(err) {
+		case errors.KindNotFound:
+			errMessage = "Admin who wants to create this staff is not found"
+			return errors.E(err, op, errMessage, errors.KindNotFound)
+
+		default:
+			return errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+
+	if err = s.accountBusiness.CheckEmail(staff.Email); err != nil {
+		return errors.E(err, op)
+	}
+
+	staffId, err := s.data.InsertStaff(staff)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	account := accounts.AccountCore{
+		Email:    staff.Email,
+		Password: staff.Password,
+		Role:     staff.Role,
+		UserID:   staffId,
+	}
+	err = s.accountBusiness.CreateAccount(account)
+	if err != nil {
+		// Staff without account can not login, so roll it back
+		_ = s.data.DeleteStaffById(staffId, staff.CreatedBy)
+		return errors.E(err, op)
+	}
+
+	staff.ID = staffId
+	staff.Password = ""
+	s.audit(op, staff.CreatedBy, permissions.RoleAdmin, staffId, nil, staff)
+	return nil
+}
+
+func (s *staffBusiness) EditStaff(staff staffs.StaffCore) error {
+	const op errors.Op = "staffs.business.EditStaff"
+
+	_, err := s.adminBusiness.FindAdminById(staff.UpdatedBy)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	existingStaff, err := s.data.SelectStaffById(staff.ID)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	before := existingStaff
+	existingStaff.UpdatedBy = staff.UpdatedBy
+	existingStaff.Name = staff.Name
+	existingStaff.BirthDate = staff.BirthDate
+	existingStaff.Phone = staff.Phone
+	existingStaff.Address = staff.Address
+	existingStaff.Gender = staff.Gender
+
+	err = s.data.UpdateStaff(existingStaff)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	s.audit(op, staff.UpdatedBy, permissions.RoleAdmin, existingStaff.ID, before, existingStaff)
+	return nil
+}
+
+func (s *staffBusiness) EditStaffPassword(id int, updatedBy int, oldPassword string, newPassword string) error {
+	const op errors.Op = "staffs.business.EditStaffPassword"
+
+	_, err := s.adminBusiness.FindAdminById(updatedBy)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	existingStaff, err := s.data.SelectStaffById(id)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = s.accountBusiness.EditAccountPassword(id, existingStaff.Role, oldPassword, newPassword)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	// Password itself is never part of the audit log
+	s.audit(op, updatedBy, permissions.RoleAdmin, id, nil, nil)
+	return nil
+}
+
+func (s *staffBusiness) EditStaffOwnProfile(staff staffs.StaffCore) error {
+	const op errors.Op = "staffs.business.EditStaffOwnProfile"
+
+	existingStaff, err := s.data.SelectStaffById(staff.ID)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	// Personal data is managed by admin, staff can only change their own contact
+	before := existingStaff
+	existingStaff.Phone = staff.Phone
+	existingStaff.Address = staff.Address
+
+	err = s.data.UpdateStaff(existingStaff)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	s.audit(op, staff.ID, existingStaff.Role, staff.ID, before, existingStaff)
+	return nil
+}
+
+func (s *staffBusiness) EditStaffOwnPassword(id int, oldPassword string, newPassword string) error {
+	const op errors.Op = "staffs.business.EditStaffOwnPassword"
+
+	existingStaff, err := s.data.SelectStaffById(id)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = s.accountBusiness.EditAccountPassword(id, existingStaff.Role, oldPassword, newPassword)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	s.audit(op, id, existingStaff.Role, id, nil, nil)
+	return nil
+}
+
+func (s *staffBusiness) RemoveStaffById(id int, updatedBy int) error {
+	const op errors.Op = "staffs.business.RemoveStaffById"
+
+	_, err := s.adminBusiness.FindAdminById(updatedBy)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	existingStaff, err := s.data.SelectStaffById(id)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = s.data.DeleteStaffById(id, updatedBy)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	err = s.accountBusiness.RemoveAccountByUser(id, existingStaff.Role)
+	if err != nil {
+		return errors.E(err, op)
+	}
+
+	s.audit(op, updatedBy, permissions.RoleAdmin, id, existingStaff, nil)
+	return nil
+}
+
+// Private methods
+
+// audit records a change on a staff member, done either by an admin or by the staff member themself
+func (s *staffBusiness) audit(op errors.Op, actorId int, actorRole string, staffId int, before interface{}, after interface{}) {
+	s.auditBusiness.Record(audits.AuditLogCore{
+		ActorID:   actorId,
+		ActorRole: actorRole,
+		Operation: string(op),
+		Entity:    audits.EntityStaff,
+		EntityID:  staffId,
+		Before:    before,
+		After:     after,
+	})
+}
@@ -0,0 +1,339 @@
+package business_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/accounts"
+	acm "github.com/final-project-alterra/hospital-management-system-api/features/accounts/mocks"
+	"github.com/final-project-alterra/hospital-management-system-api/features/admins"
+	am "github.com/final-project-alterra/hospital-management-system-api/features/admins/mocks"
+	"github.com/final-project-alterra/hospital-management-system-api/features/audits"
+	aum "github.com/final-project-alterra/hospital-management-system-api/features/audits/mocks"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/features/staffs"
+	sb "github.com/final-project-alterra/hospital-management-system-api/features/staffs/business"
+	sm "github.com/final-project-alterra/hospital-management-system-api/features/staffs/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	repo sm.IData
+
+	business        staffs.IBusiness
+	adminBusiness   am.IBusiness
+	accountBusiness acm.IBusiness
+	auditBusiness   aum.IBusiness
+
+	admin1 admins.AdminCore
+	staff1 staffs.StaffCore
+
+	errServer   error
+	errNotFound error
+)
+
+func TestMain(t *testing.M) {
+	business = sb.NewStaffBusinessBuilder().
+		SetData(&repo).
+		SetAdminBusiness(&adminBusiness).
+		SetAccountBusiness(&accountBusiness).
+		SetAuditBusiness(&auditBusiness).
+		Build()
+
+	auditBusiness.On("Record", mock.AnythingOfType("audits.AuditLogCore")).Return()
+
+	admin1 = admins.AdminCore{
+		ID:   1,
+		Name: "admin1",
+	}
+
+	staff1 = staffs.StaffCore{
+		ID:        1,
+		CreatedBy: 1,
+		UpdatedBy: 1,
+		Role:      permissions.RolePharmacist,
+		Name:      "Pharmacist 1",
+		Email:     "pharmacist@mail.com",
+		Password:  "password",
+	}
+
+	errNotFound = errors.E(errors.New("not found"), errors.KindNotFound)
+	errServer = errors.E(errors.New("server error"), errors.KindServerError)
+
+	os.Exit(t.Run())
+}
+
+func TestFindStaffs(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectStaffs").
+			Return([]staffs.StaffCore{staff1}, nil).
+			Once()
+
+		result, err := business.FindStaffs()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+	})
+
+	t.Run("valid - when SelectStaffs return error", func(t *testing.T) {
+		repo.
+			On("SelectStaffs").
+			Return([]staffs.StaffCore{}, errServer).
+			Once()
+
+		result, err := business.FindStaffs()
+		assert.Error(t, err)
+		assert.Equal(t, 0, len(result))
+	})
+}
+
+func TestFindStaffById(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectStaffById", staff1.ID).
+			Return(staff1, nil).
+			Once()
+
+		result, err := business.FindStaffById(staff1.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, staff1, result)
+	})
+
+	t.Run("valid - when staff is not found", func(t *testing.T) {
+		repo.
+			On("SelectStaffById", staff1.ID).
+			Return(staffs.StaffCore{}, errNotFound).
+			Once()
+
+		_, err := business.FindStaffById(staff1.ID)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+}
+
+func TestCreateStaff(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		adminBusiness.
+			On("FindAdminById", staff1.CreatedBy).
+			Return(admin1, nil).
+			Once()
+
+		accountBusiness.
+			On("CheckEmail", staff1.Email).
+			Return(nil).
+			Once()
+
+		repo.
+			On("InsertStaff", mock.AnythingOfType("staffs.StaffCore")).
+			Return(staff1.ID, nil).
+			Once()
+
+		accountBusiness.
+			On("CreateAccount", mock.MatchedBy(func(a accounts.AccountCore) bool {
+				return a.Role == permissions.RolePharmacist && a.UserID == staff1.ID
+			})).
+			Return(nil).
+			Once()
+
+		err := business.CreateStaff(staff1)
+		assert.Nil(t, err)
+		auditBusiness.AssertCalled(t, "Record", mock.MatchedBy(func(log audits.AuditLogCore) bool {
+			after, ok := log.After.(staffs.StaffCore)
+			return log.Operation == "staffs.business.CreateStaff" && ok && after.Password == ""
+		}))
+	})
+
+	t.Run("valid - when role is not a staff role", func(t *testing.T) {
+		doctor := staff1
+		doctor.Role = permissions.RoleDoctor
+
+		err := business.CreateStaff(doctor)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when admin is not found", func(t *testing.T) {
+		adminBusiness.
+			On("FindAdminById", staff1.CreatedBy).
+			Return(admins.AdminCore{}, errNotFound).
+			Once()
+
+		err := business.CreateStaff(staff1)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+
+	t.Run("valid - when email is already used", func(t *testing.T) {
+		adminBusiness.
+			On("FindAdminById", staff1.CreatedBy).
+			Return(admin1, nil).
+			Once()
+
+		accountBusiness.
+			On("CheckEmail", staff1.Email).
+			Return(errors.E(errors.New("Email already exist"), errors.KindUnprocessable)).
+			Once()
+
+		err := business.CreateStaff(staff1)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+
+	t.Run("valid - when CreateAccount return error", func(t *testing.T) {
+		adminBusiness.
+			On("FindAdminById", staff1.CreatedBy).
+			Return(admin1, nil).
+			Once()
+
+		accountBusiness.
+			On("CheckEmail", staff1.Email).
+			Return(nil).
+			Once()
+
+		repo.
+			On("InsertStaff", mock.AnythingOfType("staffs.StaffCore")).
+			Return(staff1.ID, nil).
+			Once()
+
+		accountBusiness.
+			On("CreateAccount", mock.AnythingOfType("accounts.AccountCore")).
+			Return(errServer).
+			Once()
+
+		repo.
+			On("DeleteStaffById", staff1.ID, staff1.CreatedBy).
+			Return(nil).
+			Once()
+
+		err := business.CreateStaff(staff1)
+		assert.Error(t, err)
+		repo.AssertCalled(t, "DeleteStaffById", staff1.ID, staff1.CreatedBy)
+	})
+}
+
+func TestEditStaff(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		adminBusiness.
+			On("FindAdminById", staff1.UpdatedBy).
+			Return(admin1, nil).
+			Once()
+
+		repo.
+			On("SelectStaffById", staff1.ID).
+			Return(staff1, nil).
+			Once()
+
+		repo.
+			On("UpdateStaff", mock.MatchedBy(func(s staffs.StaffCore) bool {
+				return s.Name == "Renamed" && s.Role == permissions.RolePharmacist
+			})).
+			Return(nil).
+			Once()
+
+		edited := staff1
+		edited.Name = "Renamed"
+		edited.Role = permissions.RoleReceptionist
+		err := business.EditStaff(edited)
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when staff is not found", func(t *testing.T) {
+		adminBusiness.
+			On("FindAdminById", staff1.UpdatedBy).
+			Return(admin1, nil).
+			Once()
+
+		repo.
+			On("SelectStaffById", staff1.ID).
+			Return(staffs.StaffCore{}, errNotFound).
+			Once()
+
+		err := business.EditStaff(staff1)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindNotFound, errors.Kind(err))
+	})
+}
+
+func TestEditStaffOwnPassword(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		repo.
+			On("SelectStaffById", staff1.ID).
+			Return(staff1, nil).
+			Once()
+
+		accountBusiness.
+			On("EditAccountPassword", staff1.ID, permissions.RolePharmacist, "password", "new password").
+			Return(nil).
+			Once()
+
+		err := business.EditStaffOwnPassword(staff1.ID, "password", "new password")
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid - when old password is wrong", func(t *testing.T) {
+		repo.
+			On("SelectStaffById", staff1.ID).
+			Return(staff1, nil).
+			Once()
+
+		accountBusiness.
+			On("EditAccountPassword", staff1.ID, permissions.RolePharmacist, "wrong", "new password").
+			Return(errors.E(errors.New("Wrong password"), errors.KindUnprocessable)).
+			Once()
+
+		err := business.EditStaffOwnPassword(staff1.ID, "wrong", "new password")
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindUnprocessable, errors.Kind(err))
+	})
+}
+
+func TestRemoveStaffById(t *testing.T) {
+	t.Run("valid - when everything is fine", func(t *testing.T) {
+		adminBusiness.
+			On("FindAdminById", admin1.ID).
+			Return(admin1, nil).
+			Once()
+
+		repo.
+			On("SelectStaffById", staff1.ID).
+			Return(staff1, nil).
+			Once()
+
+		repo.
+			On("DeleteStaffById", staff1.ID, admin1.ID).
+			Return(nil).
+			Once()
+
+		accountBusiness.
+			On("RemoveAccountByUser", staff1.ID, permissions.RolePharmacist).
+			Return(nil).
+			Once()
+
+		err := business.RemoveStaffById(staff1.ID, admin1.ID)
+		assert.Nil(t, err)
+		accountBusiness.AssertCalled(t, "RemoveAccountByUser", staff1.ID, permissions.RolePharmacist)
+	})
+
+	t.Run("valid - when DeleteStaffById return error", func(t *testing.T) {
+		adminBusiness.
+			On("FindAdminById", admin1.ID).
+			Return(admin1, nil).
+			Once()
+
+		repo.
+			On("SelectStaffById", staff1.ID).
+			Return(staff1, nil).
+			Once()
+
+		repo.
+			On("DeleteStaffById", staff1.ID, admin1.ID).
+			Return(errServer).
+			Once()
+
+		err := business.RemoveStaffById(staff1.ID, admin1.ID)
+		assert.Error(t, err)
+		assert.Equal(t, errors.KindServerError, errors.Kind(err))
+	})
+}
@@ -0,0 +1,117 @@
+package data
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/staffs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type mySQLRepo struct {
+	db *gorm.DB
+}
+
+func NewMySQLRepo(db *gorm.DB) *mySQLRepo {
+	return &mySQLRepo{
+		db: db,
+	}
+}
+
+func (r *mySQLRepo) SelectStaffs() ([]staffs.StaffCore, error) {
+	const op errors.Op = "staffs.data.SelectStaffs"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	var staffRecords []Staff
+	err := r.db.Find(&staffRecords).Error
+	if err != nil {
+		return []staffs.StaffCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return toSliceStaffCore(staffRecords), nil
+}
+
+func (r *mySQLRepo) SelectStaffById(id int) (staffs.StaffCore, error) {
+	const op errors.Op = "staffs.data.SelectStaffById"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	var staffRecord Staff
+	err := r.db.First(&staffRecord, id).Error
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			errMessage = "Staff not found"
+			return staffs.StaffCore{}, errors.E(err, op, errMessage, errors.KindNotFound)
+		default:
+			return staffs.StaffCore{}, errors.E(err, op, errMessage, errors.KindServerError)
+		}
+	}
+	return staffRecord.toStaffCore(), nil
+}
+
+func (r *mySQLRepo) InsertStaff(staff staffs.StaffCore) (int, error) {
+	const op errors.Op = "staffs.data.InsertStaff"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	newStaff := Staff{
+		CreatedBy: staff.CreatedBy,
+		Email:     staff.Email,
+		Role:      staff.Role,
+		Name:      staff.Name,
+		BirthDate: staff.BirthDate,
+		Phone:     staff.Phone,
+		Address:   staff.Address,
+		Gender:    staff.Gender,
+	}
+
+	err := r.db.Create(&newStaff).Error
+	if err != nil {
+		return 0, errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return int(newStaff.ID), nil
+}
+
+func (r *mySQLRepo) UpdateStaff(staff staffs.StaffCore) error {
+	const op errors.Op = "staffs.data.UpdateStaff"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	updatedStaff := Staff{
+		Model: gorm.Model{
+			ID:        uint(staff.ID),
+			CreatedAt: staff.CreatedAt,
+		},
+		CreatedBy: staff.CreatedBy,
+		UpdatedBy: staff.UpdatedBy,
+
+		Email:     staff.Email,
+		Role:      staff.Role,
+		Name:      staff.Name,
+		BirthDate: staff.BirthDate,
+		Phone:     staff.Phone,
+		Address:   staff.Address,
+		Gender:    staff.Gender,
+	}
+
+	err := r.db.Save(&updatedStaff).Error
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
+
+func (r *mySQLRepo) DeleteStaffById(id int, updatedBy int) error {
+	const op errors.Op = "staffs.data.DeleteStaffById"
+	var errMessage errors.ErrClientMessage = "Something went wrong"
+
+	email := uuid.New().String()
+	now := time.Now().In(config.GetTimeLoc())
+	err := r.db.
+		Exec("UPDATE staffs SET updated_by = ?, deleted_at = ?, email = ? WHERE id = ?", updatedBy, now, email, id).
+		Error
+
+	if err != nil {
+		return errors.E(err, op, errMessage, errors.KindServerError)
+	}
+	return nil
+}
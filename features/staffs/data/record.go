@@ -0,0 +1,50 @@
+package data
+
+import (
+	"strings"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/staffs"
+	"gorm.io/gorm"
+)
+
+type Staff struct {
+	gorm.Model
+
+	CreatedBy int
+	UpdatedBy int
+
+	Email     string `gorm:"type:varchar(64);unique;not null"`
+	Role      string `gorm:"type:varchar(16);not null"`
+	Name      string `gorm:"type:varchar(64);not null"`
+	BirthDate string `gorm:"type:date;not null"`
+	Phone     string `gorm:"type:varchar(16)"`
+	Address   string
+	Gender    string `gorm:"type:varchar(1);not null"`
+}
+
+func (s Staff) toStaffCore() staffs.StaffCore {
+	return staffs.StaffCore{
+		ID:        int(s.ID),
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+
+		CreatedBy: s.CreatedBy,
+		UpdatedBy: s.UpdatedBy,
+
+		Email:     s.Email,
+		Role:      s.Role,
+		Name:      s.Name,
+		BirthDate: strings.Split(s.BirthDate, "T")[0],
+		Phone:     s.Phone,
+		Address:   s.Address,
+		Gender:    s.Gender,
+	}
+}
+
+func toSliceStaffCore(s []Staff) []staffs.StaffCore {
+	result := make([]staffs.StaffCore, len(s))
+	for i := range s {
+		result[i] = s[i].toStaffCore()
+	}
+	return result
+}
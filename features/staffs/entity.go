@@ -0,0 +1,40 @@
+package staffs
+
+import "time"
+
+// StaffCore is a staff member without clinical work schedules, a receptionist or
+// a pharmacist. Role is fixed when the staff member is created.
+type StaffCore struct {
+	ID        int
+	CreatedBy int
+	UpdatedBy int
+	Email     string
+	Password  string // only filled on create, stored in accounts
+	Role      string
+	Name      string
+	BirthDate string
+	Phone     string
+	Address   string
+	Gender    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type IBusiness interface {
+	FindStaffs() ([]StaffCore, error)
+	FindStaffById(id int) (StaffCore, error)
+	CreateStaff(staff StaffCore) error
+	EditStaff(staff StaffCore) error
+	EditStaffPassword(id int, updatedBy int, oldPassword string, newPassword string) error
+	EditStaffOwnProfile(staff StaffCore) error // only contact, the rest is managed by admin
+	EditStaffOwnPassword(id int, oldPassword string, newPassword string) error
+	RemoveStaffById(id int, updatedBy int) error
+}
+
+type IData interface {
+	SelectStaffs() ([]StaffCore, error)
+	SelectStaffById(id int) (StaffCore, error)
+	InsertStaff(staff StaffCore) (int, error)
+	UpdateStaff(staff StaffCore) error
+	DeleteStaffById(id int, updatedBy int) error
+}
@@ -0,0 +1,246 @@
+package presentation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/features/staffs"
+	"github.com/final-project-alterra/hospital-management-system-api/features/staffs/presentation/request"
+	"github.com/final-project-alterra/hospital-management-system-api/features/staffs/presentation/response"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type StaffPresentation struct {
+	business staffs.IBusiness
+	validate *validator.Validate
+}
+
+func NewStaffPresentation(business staffs.IBusiness) *StaffPresentation {
+	validate := validator.New()
+	_ = validate.RegisterValidation("ValidateBirthDate", request.ValidateBirthDate)
+
+	return &StaffPresentation{
+		business: business,
+		validate: validate,
+	}
+}
+
+func (sp *StaffPresentation) GetStaffs(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving staffs data"
+	const op errors.Op = "staffs.presentation.GetStaffs"
+
+	staffsData, err := sp.business.FindStaffs()
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.ListStaffs(staffsData))
+}
+
+func (sp *StaffPresentation) GetDetailStaff(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving staff data"
+	const op errors.Op = "staffs.presentation.GetDetailStaff"
+	var errMessage errors.ErrClientMessage
+
+	staffId, err := strconv.Atoi(c.Param("staffId"))
+	if err != nil {
+		errMessage = "Invalid staff id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	staffData, err := sp.business.FindStaffById(staffId)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.DetailStaff(staffData))
+}
+
+func (sp *StaffPresentation) PostStaff(c echo.Context) error {
+	status := http.StatusCreated
+	message := "Success creating staff"
+	const op errors.Op = "staffs.presentation.PostStaff"
+	var errMessage errors.ErrClientMessage
+
+	createdBy, ok := c.Get("userId").(int)
+	if !ok || createdBy < 1 {
+		err := errors.New("Invalid admin id")
+		errMessage = "Invalid admin id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	staff := request.CreateStaffRequest{CreatedBy: createdBy}
+	if err := c.Bind(&staff); err != nil {
+		errMessage = "Unable to parse staff request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := sp.validate.Struct(staff); err != nil {
+		errMessage = "Invalid staff data. Makesure all required fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	if err := sp.business.CreateStaff(staff.ToCore()); err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
+func (sp *StaffPresentation) PutEditStaff(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success updating staff profile data"
+	const op errors.Op = "staffs.presentation.PutEditStaff"
+	var errMessage errors.ErrClientMessage
+
+	updatedBy, ok := c.Get("userId").(int)
+	if !ok || updatedBy < 1 {
+		err := errors.New("Invalid admin id")
+		errMessage = "Invalid admin id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	staff := request.UpdateStaffRequest{UpdatedBy: updatedBy}
+	if err := c.Bind(&staff); err != nil {
+		errMessage = "Unable to parse staff request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := sp.validate.Struct(staff); err != nil {
+		errMessage = "Invalid staff data. Makesure all required fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	if err := sp.business.EditStaff(staff.ToCore()); err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
+func (sp *StaffPresentation) PutEditStaffPassword(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success editing staff password"
+	const op errors.Op = "staffs.presentation.PutEditStaffPassword"
+	var errMessage errors.ErrClientMessage
+
+	updatedBy, ok := c.Get("userId").(int)
+	if !ok || updatedBy < 1 {
+		err := errors.New("Invalid admin id")
+		errMessage = "Invalid admin id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	staff := request.UpdateStaffPasswordRequest{}
+	if err := c.Bind(&staff); err != nil {
+		errMessage = "Unable to parse staff request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := sp.validate.Struct(staff); err != nil {
+		errMessage = "Invalid staff data. Makesure all fields are filled correctly and new password is min 8 characters"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	err := sp.business.EditStaffPassword(staff.ID, updatedBy, staff.OldPassword, staff.NewPassword)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
+func (sp *StaffPresentation) DeleteStaff(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success deleting staff"
+	const op errors.Op = "staffs.presentation.DeleteStaff"
+	var errMessage errors.ErrClientMessage
+
+	updatedBy, ok := c.Get("userId").(int)
+	if !ok || updatedBy < 1 {
+		err := errors.New("Invalid admin id")
+		errMessage = "Invalid admin id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	staffId, err := strconv.Atoi(c.Param("staffId"))
+	if err != nil {
+		errMessage = "Invalid staff id"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	err = sp.business.RemoveStaffById(staffId, updatedBy)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
+func (sp *StaffPresentation) GetOwnProfile(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success retrieving own profile"
+	const op errors.Op = "staffs.presentation.GetOwnProfile"
+
+	staffId := c.Get("userId").(int)
+
+	staffData, err := sp.business.FindStaffById(staffId)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+	return response.Success(c, status, message, response.DetailStaff(staffData))
+}
+
+func (sp *StaffPresentation) PutEditOwnProfile(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success updating own profile"
+	const op errors.Op = "staffs.presentation.PutEditOwnProfile"
+	var errMessage errors.ErrClientMessage
+
+	staff := request.UpdateOwnStaffRequest{}
+	if err := c.Bind(&staff); err != nil {
+		errMessage = "Unable to parse staff request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+	staff.ID = c.Get("userId").(int)
+
+	if err := sp.validate.Struct(staff); err != nil {
+		errMessage = "Invalid staff data. Makesure all required fields are filled correctly"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	if err := sp.business.EditStaffOwnProfile(staff.ToCore()); err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
+
+func (sp *StaffPresentation) PutEditOwnPassword(c echo.Context) error {
+	status := http.StatusOK
+	message := "Success editing own password"
+	const op errors.Op = "staffs.presentation.PutEditOwnPassword"
+	var errMessage errors.ErrClientMessage
+
+	staffId := c.Get("userId").(int)
+
+	req := request.UpdateOwnPasswordRequest{}
+	if err := c.Bind(&req); err != nil {
+		errMessage = "Unable to parse staff request payload"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindBadRequest))
+	}
+
+	if err := sp.validate.Struct(req); err != nil {
+		errMessage = "Invalid staff data. Makesure all fields are filled correctly and new password is min 8 characters"
+		return response.Error(c, errors.E(err, op, errMessage, errors.KindUnprocessable))
+	}
+
+	err := sp.business.EditStaffOwnPassword(staffId, req.OldPassword, req.NewPassword)
+	if err != nil {
+		return response.Error(c, errors.E(err, op))
+	}
+
+	return response.Success(c, status, message, nil)
+}
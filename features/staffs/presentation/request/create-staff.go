@@ -0,0 +1,30 @@
+package request
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/staffs"
+
+type CreateStaffRequest struct {
+	CreatedBy int
+
+	Email     string `json:"email" validate:"required,email"`
+	Password  string `json:"password" validate:"required,min=8"`
+	Role      string `json:"role" validate:"required,oneof=receptionist pharmacist"`
+	Name      string `json:"name" validate:"required"`
+	BirthDate string `json:"birthDate" validate:"required,ValidateBirthDate"`
+	Phone     string `json:"phone"`
+	Address   string `json:"address"`
+	Gender    string `json:"gender" validate:"required,oneof='L' 'P'"`
+}
+
+func (c CreateStaffRequest) ToCore() staffs.StaffCore {
+	return staffs.StaffCore{
+		CreatedBy: c.CreatedBy,
+		Email:     c.Email,
+		Password:  c.Password,
+		Role:      c.Role,
+		Name:      c.Name,
+		BirthDate: c.BirthDate,
+		Phone:     c.Phone,
+		Address:   c.Address,
+		Gender:    c.Gender,
+	}
+}
@@ -0,0 +1,24 @@
+package request
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/staffs"
+
+// Staff can only change their own contact, the rest is managed by admin
+type UpdateOwnStaffRequest struct {
+	ID int
+
+	Phone   string `json:"phone"`
+	Address string `json:"address"`
+}
+
+func (c UpdateOwnStaffRequest) ToCore() staffs.StaffCore {
+	return staffs.StaffCore{
+		ID:      c.ID,
+		Phone:   c.Phone,
+		Address: c.Address,
+	}
+}
+
+type UpdateOwnPasswordRequest struct {
+	OldPassword string `json:"oldPassword" validate:"required"`
+	NewPassword string `json:"newPassword" validate:"required,min=8"`
+}
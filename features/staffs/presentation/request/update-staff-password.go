@@ -0,0 +1,7 @@
+package request
+
+type UpdateStaffPasswordRequest struct {
+	ID          int    `json:"id" validate:"gt=0"`
+	OldPassword string `json:"oldPassword" validate:"required"`
+	NewPassword string `json:"newPassword" validate:"required,min=8"`
+}
@@ -0,0 +1,27 @@
+package request
+
+import "github.com/final-project-alterra/hospital-management-system-api/features/staffs"
+
+// Role is not editable, a staff member changing role gets a new account
+type UpdateStaffRequest struct {
+	ID        int `json:"id" validate:"gt=0"`
+	UpdatedBy int
+
+	Name      string `json:"name" validate:"required"`
+	BirthDate string `json:"birthDate" validate:"required,ValidateBirthDate"`
+	Phone     string `json:"phone"`
+	Address   string `json:"address"`
+	Gender    string `json:"gender" validate:"required,oneof='L' 'P'"`
+}
+
+func (c UpdateStaffRequest) ToCore() staffs.StaffCore {
+	return staffs.StaffCore{
+		ID:        c.ID,
+		UpdatedBy: c.UpdatedBy,
+		Name:      c.Name,
+		BirthDate: c.BirthDate,
+		Phone:     c.Phone,
+		Address:   c.Address,
+		Gender:    c.Gender,
+	}
+}
@@ -0,0 +1,43 @@
+package response
+
+import (
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/staffs"
+)
+
+type StaffResponse struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	Name      string    `json:"name"`
+	BirthDate string    `json:"birthDate"`
+	Phone     string    `json:"phone"`
+	Address   string    `json:"address"`
+	Gender    string    `json:"gender"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func DetailStaff(s staffs.StaffCore) StaffResponse {
+	return StaffResponse{
+		ID:        s.ID,
+		Email:     s.Email,
+		Role:      s.Role,
+		Name:      s.Name,
+		BirthDate: s.BirthDate,
+		Phone:     s.Phone,
+		Address:   s.Address,
+		Gender:    s.Gender,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func ListStaffs(s []staffs.StaffCore) []StaffResponse {
+	result := make([]StaffResponse, len(s))
+	for i := range s {
+		result[i] = DetailStaff(s[i])
+	}
+	return result
+}
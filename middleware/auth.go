@@ -2,14 +2,22 @@ package middleware
 
 import (
 	"errors"
-	"net/http"
 
 	"github.com/final-project-alterra/hospital-management-system-api/config"
+	"github.com/final-project-alterra/hospital-management-system-api/features/auth"
 	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
+// authBusiness is used to reject tokens whose session has been revoked.
+// It is set once on startup, see SetAuthBusiness.
+var authBusiness auth.IBusiness
+
+func SetAuthBusiness(ab auth.IBusiness) {
+	authBusiness = ab
+}
+
 func IsAuth() echo.MiddlewareFunc {
 	parseToken := func(auth string, c echo.Context) (interface{}, error) {
 		keyFunction := func(token *jwt.Token) (interface{}, error) {
@@ -41,18 +49,19 @@ func IsAuth() echo.MiddlewareFunc {
 			return nil, errors.New("Invalid role")
 		}
 
+		sessionId, err := validateSession(claims, int(userId), role)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Set("sessionId", sessionId)
 		c.Set("userId", int(userId))
 		c.Set("role", role)
 		return claims, nil
 	}
 
 	errorHandlerWithContext := func(err error, c echo.Context) error {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"error": map[string]interface{}{
-				"code":    http.StatusUnauthorized,
-				"message": "Unauthorized user!",
-			},
-		})
+		return unauthorized(c)
 	}
 
 	jwtConfig := middleware.JWTConfig{
@@ -63,55 +72,16 @@ func IsAuth() echo.MiddlewareFunc {
 	return middleware.JWTWithConfig(jwtConfig)
 }
 
-func IsAdmin() echo.MiddlewareFunc {
-	parseToken := func(auth string, c echo.Context) (interface{}, error) {
-		keyFunction := func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(config.ENV.JWT_SECRET), nil
-		}
-
-		token, err := jwt.Parse(auth, keyFunction)
-		if err != nil {
-			return nil, err
-		}
-		if !token.Valid {
-			return nil, errors.New("Invalid token")
-		}
-
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return nil, errors.New("Invalid claims")
-		}
-
-		if claims["role"] != "admin" {
-			return nil, errors.New("Unauthorized user!")
-		}
-
-		userId, ok := claims["userId"].(float64)
-		if !ok {
-			return nil, errors.New("Invalid userId")
-		}
-
-		c.Set("userId", int(userId))
-		c.Set("role", "admin")
-		return claims, nil
-	}
-
-	errorHandlerWithContext := func(err error, c echo.Context) error {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"error": map[string]interface{}{
-				"code":    http.StatusUnauthorized,
-				"message": "Unauthorized user!",
-			},
-		})
+func validateSession(claims jwt.MapClaims, userId int, role string) (int, error) {
+	sessionId, ok := claims["sessionId"].(float64)
+	if !ok {
+		return 0, errors.New("Invalid sessionId")
 	}
 
-	jwtConfig := middleware.JWTConfig{
-		ParseTokenFunc:          parseToken,
-		ErrorHandlerWithContext: errorHandlerWithContext,
+	if authBusiness != nil {
+		if err := authBusiness.ValidateSession(int(sessionId), userId, role); err != nil {
+			return 0, err
+		}
 	}
-
-	return middleware.JWTWithConfig(jwtConfig)
+	return int(sessionId), nil
 }
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/labstack/echo/v4"
+)
+
+// permissionBusiness holds the role-action matrix.
+// It is set once on startup, see SetPermissionBusiness.
+var permissionBusiness permissions.IBusiness
+
+func SetPermissionBusiness(pb permissions.IBusiness) {
+	permissionBusiness = pb
+}
+
+// HasPermission must be placed after IsAuth, since it reads the role IsAuth puts in the context
+func HasPermission(action string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role, ok := c.Get("role").(string)
+			if !ok {
+				return unauthorized(c)
+			}
+
+			if _, err := permissionBusiness.Authorize(role, action); err != nil {
+				return unauthorized(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    http.StatusUnauthorized,
+			"message": "Unauthorized user!",
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package migration
+
+import (
+	"log"
+
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"gorm.io/gorm"
+)
+
+// moveCredentialsToAccounts copies email & password of every user table into
+// accounts, then drops the password column. A user whose email is already taken
+// by another account is skipped and logged, its table keeps the password column
+// (made nullable) until the duplicate is resolved and the server restarted.
+func moveCredentialsToAccounts(db *gorm.DB) {
+	tables := []struct {
+		name string
+		role string
+	}{
+		{"admins", permissions.RoleAdmin},
+		{"doctors", permissions.RoleDoctor},
+		{"nurses", permissions.RoleNurse},
+	}
+
+	for _, table := range tables {
+		if !db.Migrator().HasColumn(table.name, "password") {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			err := tx.Exec(
+				"INSERT INTO accounts (email, password, role, user_id, created_at, updated_at) "+
+					"SELECT t.email, t.password, ?, t.id, NOW(), NOW() FROM "+table.name+" t "+
+					"WHERE t.deleted_at IS NULL AND NOT EXISTS ("+
+					"SELECT 1 FROM accounts a WHERE a.email = t.email OR (a.role = ? AND a.user_id = t.id))",
+				table.role, table.role,
+			).Error
+			if err != nil {
+				return err
+			}
+
+			var conflicts []struct {
+				ID    int
+				Email string
+			}
+			err = tx.Raw(
+				"SELECT t.id, t.email FROM "+table.name+" t "+
+					"WHERE t.deleted_at IS NULL AND NOT EXISTS ("+
+					"SELECT 1 FROM accounts a WHERE a.role = ? AND a.user_id = t.id)",
+				table.role,
+			).Scan(&conflicts).Error
+			if err != nil {
+				return err
+			}
+
+			if len(conflicts) > 0 {
+				for _, c := range conflicts {
+					log.Printf("Account migration: %s %d can not login, email %s is used by another account\n", table.role, c.ID, c.Email)
+				}
+				return tx.Exec("ALTER TABLE " + table.name + " MODIFY password varchar(128) NULL").Error
+			}
+			return tx.Migrator().DropColumn(table.name, "password")
+		})
+
+		if err != nil {
+			panic(err)
+		}
+	}
+}
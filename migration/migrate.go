@@ -2,29 +2,59 @@ package migration
 
 import (
 	"github.com/final-project-alterra/hospital-management-system-api/config"
+	accountsData "github.com/final-project-alterra/hospital-management-system-api/features/accounts/data"
 	adminsData "github.com/final-project-alterra/hospital-management-system-api/features/admins/data"
+	auditsData "github.com/final-project-alterra/hospital-management-system-api/features/audits/data"
+	authData "github.com/final-project-alterra/hospital-management-system-api/features/auth/data"
+	closuresData "github.com/final-project-alterra/hospital-management-system-api/features/closures/data"
 	doctorsData "github.com/final-project-alterra/hospital-management-system-api/features/doctors/data"
+	jobsData "github.com/final-project-alterra/hospital-management-system-api/features/jobs/data"
+	leavesData "github.com/final-project-alterra/hospital-management-system-api/features/leaves/data"
+	medicinesData "github.com/final-project-alterra/hospital-management-system-api/features/medicines/data"
+	notificationsData "github.com/final-project-alterra/hospital-management-system-api/features/notifications/data"
 	nursesData "github.com/final-project-alterra/hospital-management-system-api/features/nurses/data"
 	patientsData "github.com/final-project-alterra/hospital-management-system-api/features/patients/data"
 	schedulesData "github.com/final-project-alterra/hospital-management-system-api/features/schedules/data"
+	staffsData "github.com/final-project-alterra/hospital-management-system-api/features/staffs/data"
 )
 
 func AutoMigrate() {
 	db := config.DB
 
 	err := db.AutoMigrate(
+		&accountsData.Account{},
+		&auditsData.AuditLog{},
+		&auditsData.AccessLog{},
+		&authData.Session{},
+		&authData.PasswordReset{},
+		&authData.PatientCode{},
+		&authData.LoginAttempt{},
+		&authData.TwoFactor{},
+		&authData.RecoveryCode{},
 		&adminsData.Admin{},
 		&doctorsData.Room{},
 		&doctorsData.Speciality{},
 		&doctorsData.Doctor{},
 		&nursesData.Nurse{},
+		&staffsData.Staff{},
 		&patientsData.Patient{},
 		&schedulesData.WorkSchedule{},
 		&schedulesData.Outpatient{},
 		&schedulesData.Prescription{},
+		&schedulesData.OutpatientMove{},
+		&closuresData.Closure{},
+		&leavesData.Leave{},
+		&jobsData.JobLock{},
+		&jobsData.JobRun{},
+		&notificationsData.Notification{},
+		&medicinesData.Medicine{},
+		&medicinesData.Batch{},
+		&medicinesData.Reservation{},
 	)
 
 	if err != nil {
 		panic(err)
 	}
+
+	moveCredentialsToAccounts(db)
 }
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func setupAuditRoutes(e *echo.Echo, presenter *factory.Presenter) {
+	audit := e.Group("/audit-logs")
+
+	audit.GET("", presenter.AuditPresentation.GetAuditLogs, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewAuditLogs))
+
+	// e.g. /access-logs?breakGlass=true to review reads outside the care team
+	access := e.Group("/access-logs")
+
+	access.GET("", presenter.AuditPresentation.GetAccessLogs, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewAccessLogs))
+}
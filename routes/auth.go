@@ -2,6 +2,8 @@ package routes
 
 import (
 	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/middleware"
 	"github.com/labstack/echo/v4"
 )
 
@@ -9,4 +11,15 @@ func setupAuthRoutes(e *echo.Echo, presenter *factory.Presenter) {
 	auth := e.Group("/auth")
 
 	auth.POST("/login", presenter.AuthPresentation.PostLogin)
+	auth.POST("/login/2fa", presenter.AuthPresentation.PostLoginTwoFactor)
+	auth.POST("/patients/code", presenter.AuthPresentation.PostPatientCode)
+	auth.POST("/patients/login", presenter.AuthPresentation.PostPatientLogin)
+	auth.POST("/refresh", presenter.AuthPresentation.PostRefresh)
+	auth.POST("/password-reset", presenter.AuthPresentation.PostPasswordReset)
+	auth.POST("/password-reset/confirm", presenter.AuthPresentation.PostConfirmPasswordReset)
+	auth.POST("/logout", presenter.AuthPresentation.PostLogout, middleware.IsAuth())
+	auth.PUT("/revoke", presenter.AuthPresentation.PutRevokeSessions, middleware.IsAuth(), middleware.HasPermission(permissions.ActionRevokeSessions))
+	auth.PUT("/unlock", presenter.AuthPresentation.PutUnlockAccount, middleware.IsAuth(), middleware.HasPermission(permissions.ActionUnlockAccounts))
+	auth.POST("/2fa/enroll", presenter.AuthPresentation.PostEnrollTwoFactor, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnTwoFactor))
+	auth.POST("/2fa/verify", presenter.AuthPresentation.PostVerifyTwoFactor, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnTwoFactor))
 }
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func setupClosureRoutes(e *echo.Echo, presenter *factory.Presenter) {
+	closure := e.Group("/closures")
+
+	closure.GET("", presenter.ClosurePresentation.GetClosures, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewClosures))
+	closure.GET("/:closureId", presenter.ClosurePresentation.GetDetailClosure, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewClosures))
+	closure.POST("", presenter.ClosurePresentation.PostClosure, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageClosures))
+	closure.POST("/import", presenter.ClosurePresentation.PostImportClosures, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageClosures))
+	closure.PUT("", presenter.ClosurePresentation.PutEditClosure, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageClosures))
+	closure.DELETE("/:closureId", presenter.ClosurePresentation.DeleteClosure, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageClosures))
+}
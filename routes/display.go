@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/labstack/echo/v4"
+)
+
+// Waiting room screens are not signed in, their streams only carry queue numbers
+func setupDisplayRoutes(e *echo.Echo, presenter *factory.Presenter) {
+	display := e.Group("/displays")
+
+	display.GET("/work-schedules/:workScheduleId/stream", presenter.SchedulePresentation.GetWorkScheduleDisplayStream)
+	display.GET("/rooms/:roomId/stream", presenter.SchedulePresentation.GetRoomDisplayStream)
+}
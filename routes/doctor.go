@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -9,14 +10,20 @@ import (
 func setupDoctorRoutes(e *echo.Echo, presenter *factory.Presenter) {
 	doctor := e.Group("/doctors")
 
-	doctor.GET("", presenter.DoctorPresentation.GetDoctors, middleware.IsAuth())
-	doctor.GET("/:doctorId", presenter.DoctorPresentation.GetDetailDoctor, middleware.IsAuth())
-	doctor.POST("", presenter.DoctorPresentation.PostDoctor, middleware.IsAdmin())
-	doctor.PUT("", presenter.DoctorPresentation.PutEditDoctor, middleware.IsAdmin())
-	doctor.PUT("/password", presenter.DoctorPresentation.PutEditDoctorPassword, middleware.IsAdmin())
-	doctor.PUT("/image-profile", presenter.DoctorPresentation.PutEditImageProfile, middleware.IsAdmin())
-	doctor.DELETE("/:doctorId", presenter.DoctorPresentation.DeleteDoctor, middleware.IsAdmin())
-	doctor.DELETE("/:doctorId/image-profile", presenter.DoctorPresentation.DeleteImageProfile, middleware.IsAdmin())
+	doctor.GET("", presenter.DoctorPresentation.GetDoctors, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewDoctors))
+	doctor.GET("/:doctorId", presenter.DoctorPresentation.GetDetailDoctor, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewDoctors))
+	doctor.POST("", presenter.DoctorPresentation.PostDoctor, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageDoctors))
+	doctor.PUT("", presenter.DoctorPresentation.PutEditDoctor, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageDoctors))
+	doctor.PUT("/password", presenter.DoctorPresentation.PutEditDoctorPassword, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageDoctors))
+	doctor.PUT("/image-profile", presenter.DoctorPresentation.PutEditImageProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageDoctors))
+	doctor.DELETE("/:doctorId", presenter.DoctorPresentation.DeleteDoctor, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageDoctors))
+	doctor.DELETE("/:doctorId/image-profile", presenter.DoctorPresentation.DeleteImageProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageDoctors))
 
-	doctor.GET("/:doctorId/work-schedules", presenter.SchedulePresentation.GetDoctorWorkSchedules, middleware.IsAuth())
+	doctor.GET("/me", presenter.DoctorPresentation.GetOwnProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnDoctor))
+	doctor.PUT("/me", presenter.DoctorPresentation.PutEditOwnProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnDoctor))
+	doctor.PUT("/me/password", presenter.DoctorPresentation.PutEditOwnPassword, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnDoctor))
+	doctor.PUT("/me/image-profile", presenter.DoctorPresentation.PutEditOwnImageProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnDoctor))
+	doctor.DELETE("/me/image-profile", presenter.DoctorPresentation.DeleteOwnImageProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnDoctor))
+
+	doctor.GET("/:doctorId/work-schedules", presenter.SchedulePresentation.GetDoctorWorkSchedules, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewWorkSchedules))
 }
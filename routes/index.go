@@ -21,10 +21,15 @@ func SetupRoutes() *echo.Echo {
 	e.Static("/static", path.Join(project.GetMainDir(), "files"))
 
 	setupAuthRoutes(e, presenter)
+	setupPermissionRoutes(e, presenter)
+	setupAuditRoutes(e, presenter)
+	setupJobRoutes(e, presenter)
+	setupNotificationRoutes(e, presenter)
 
 	setupAdminRoutes(e, presenter)
 
 	setupNurseRoutes(e, presenter)
+	setupStaffRoutes(e, presenter)
 
 	setupPatientRoutes(e, presenter)
 
@@ -33,8 +38,14 @@ func SetupRoutes() *echo.Echo {
 	setupSpecialityRoutes(e, presenter)
 
 	setupScheduleRoutes(e, presenter)
+	setupClosureRoutes(e, presenter)
+	setupLeaveRoutes(e, presenter)
 
 	setupOutpatientRoutes(e, presenter)
+	setupMedicineRoutes(e, presenter)
+	setupDisplayRoutes(e, presenter)
+
+	setupPortalRoutes(e, presenter)
 
 	return e
 }
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func setupJobRoutes(e *echo.Echo, presenter *factory.Presenter) {
+	job := e.Group("/jobs")
+
+	job.GET("", presenter.JobPresentation.GetJobs, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewJobs))
+	job.GET("/:name/runs", presenter.JobPresentation.GetJobRuns, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewJobs))
+	job.POST("/:name/run", presenter.JobPresentation.PostRunJob, middleware.IsAuth(), middleware.HasPermission(permissions.ActionRunJobs))
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func setupLeaveRoutes(e *echo.Echo, presenter *factory.Presenter) {
+	leave := e.Group("/leaves")
+
+	leave.GET("", presenter.LeavePresentation.GetLeaves, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewLeaves))
+	leave.GET("/:leaveId", presenter.LeavePresentation.GetDetailLeave, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewLeaves))
+	leave.GET("/:leaveId/work-schedules", presenter.LeavePresentation.GetLeaveWorkSchedules, middleware.IsAuth(), middleware.HasPermission(permissions.ActionReviewLeaves))
+	leave.PUT("/:leaveId/approve", presenter.LeavePresentation.PutApproveLeave, middleware.IsAuth(), middleware.HasPermission(permissions.ActionReviewLeaves))
+	leave.PUT("/:leaveId/reject", presenter.LeavePresentation.PutRejectLeave, middleware.IsAuth(), middleware.HasPermission(permissions.ActionReviewLeaves))
+
+	leave.GET("/me", presenter.LeavePresentation.GetOwnLeaves, middleware.IsAuth(), middleware.HasPermission(permissions.ActionRequestLeave))
+	leave.POST("", presenter.LeavePresentation.PostLeave, middleware.IsAuth(), middleware.HasPermission(permissions.ActionRequestLeave))
+	leave.PUT("/:leaveId/cancel", presenter.LeavePresentation.PutCancelLeave, middleware.IsAuth(), middleware.HasPermission(permissions.ActionRequestLeave))
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func setupMedicineRoutes(e *echo.Echo, presenter *factory.Presenter) {
+	medicine := e.Group("/medicines")
+
+	medicine.GET("", presenter.MedicinePresentation.GetMedicines, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewMedicines))
+	medicine.GET("/:medicineId", presenter.MedicinePresentation.GetDetailMedicine, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewMedicines))
+	medicine.POST("", presenter.MedicinePresentation.PostMedicine, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageMedicines))
+	medicine.PUT("", presenter.MedicinePresentation.PutEditMedicine, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageMedicines))
+	medicine.DELETE("/:medicineId", presenter.MedicinePresentation.DeleteMedicine, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageMedicines))
+
+	medicine.GET("/:medicineId/batches", presenter.MedicinePresentation.GetMedicineBatches, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewMedicines))
+	medicine.POST("/batches", presenter.MedicinePresentation.PostBatch, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageStock))
+	medicine.PUT("/batches/adjust", presenter.MedicinePresentation.PutAdjustBatch, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageStock))
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func setupNotificationRoutes(e *echo.Echo, presenter *factory.Presenter) {
+	notification := e.Group("/notifications")
+
+	notification.GET("", presenter.NotificationPresentation.GetNotifications, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewNotifications))
+}
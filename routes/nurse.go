@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -9,14 +10,20 @@ import (
 func setupNurseRoutes(e *echo.Echo, presenter *factory.Presenter) {
 	nurses := e.Group("/nurses")
 
-	nurses.GET("", presenter.NursePresentation.GetNurses, middleware.IsAuth())
-	nurses.GET("/:nurseId", presenter.NursePresentation.GetDetailNurse, middleware.IsAuth())
-	nurses.POST("", presenter.NursePresentation.PostNurse, middleware.IsAdmin())
-	nurses.PUT("", presenter.NursePresentation.PutEditNurse, middleware.IsAdmin())
-	nurses.PUT("/password", presenter.NursePresentation.PutEditNursePassword, middleware.IsAdmin())
-	nurses.PUT("/image-profile", presenter.NursePresentation.PutEditImageProfile, middleware.IsAdmin())
-	nurses.DELETE("/:nurseId", presenter.NursePresentation.DeleteNurse, middleware.IsAdmin())
-	nurses.DELETE("/:nurseId/image-profile", presenter.NursePresentation.DeleteImageProfile, middleware.IsAdmin())
+	nurses.GET("", presenter.NursePresentation.GetNurses, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewNurses))
+	nurses.GET("/:nurseId", presenter.NursePresentation.GetDetailNurse, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewNurses))
+	nurses.POST("", presenter.NursePresentation.PostNurse, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageNurses))
+	nurses.PUT("", presenter.NursePresentation.PutEditNurse, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageNurses))
+	nurses.PUT("/password", presenter.NursePresentation.PutEditNursePassword, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageNurses))
+	nurses.PUT("/image-profile", presenter.NursePresentation.PutEditImageProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageNurses))
+	nurses.DELETE("/:nurseId", presenter.NursePresentation.DeleteNurse, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageNurses))
+	nurses.DELETE("/:nurseId/image-profile", presenter.NursePresentation.DeleteImageProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageNurses))
 
-	nurses.GET("/:nurseId/work-schedules", presenter.SchedulePresentation.GetNurseWorkSchedules, middleware.IsAuth())
+	nurses.GET("/me", presenter.NursePresentation.GetOwnProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnNurse))
+	nurses.PUT("/me", presenter.NursePresentation.PutEditOwnProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnNurse))
+	nurses.PUT("/me/password", presenter.NursePresentation.PutEditOwnPassword, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnNurse))
+	nurses.PUT("/me/image-profile", presenter.NursePresentation.PutEditOwnImageProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnNurse))
+	nurses.DELETE("/me/image-profile", presenter.NursePresentation.DeleteOwnImageProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnNurse))
+
+	nurses.GET("/:nurseId/work-schedules", presenter.SchedulePresentation.GetNurseWorkSchedules, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewWorkSchedules))
 }
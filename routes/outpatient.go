@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -9,12 +10,18 @@ import (
 func setupOutpatientRoutes(e *echo.Echo, presenter *factory.Presenter) {
 	outpatients := e.Group("/outpatients")
 
-	outpatients.GET("", presenter.SchedulePresentation.GetOutpatients, middleware.IsAuth())
-	outpatients.GET("/:outpatientId", presenter.SchedulePresentation.GetDetailOutpatient, middleware.IsAuth())
-	outpatients.POST("", presenter.SchedulePresentation.PostOutpatient, middleware.IsAdmin())
-	outpatients.PUT("", presenter.SchedulePresentation.PutEditOutpatient, middleware.IsAdmin())
-	outpatients.PUT("/cancel", presenter.SchedulePresentation.PutCancelOutpatient, middleware.IsAuth())
-	outpatients.PUT("/examine", presenter.SchedulePresentation.PutExamineOutpatient, middleware.IsAuth())
-	outpatients.PUT("/finish", presenter.SchedulePresentation.PutFinishOutpatient, middleware.IsAuth())
-	outpatients.DELETE("/:outpatientId", presenter.SchedulePresentation.DeleteOutpatient, middleware.IsAdmin())
+	outpatients.GET("", presenter.SchedulePresentation.GetOutpatients, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewOutpatients))
+	outpatients.GET("/:outpatientId", presenter.SchedulePresentation.GetDetailOutpatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewOutpatients))
+	outpatients.GET("/:outpatientId/queue", presenter.SchedulePresentation.GetOutpatientQueue, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewOutpatients))
+	outpatients.POST("", presenter.SchedulePresentation.PostOutpatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOutpatients))
+	outpatients.PUT("", presenter.SchedulePresentation.PutEditOutpatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOutpatients))
+	outpatients.PUT("/reschedule", presenter.SchedulePresentation.PutRescheduleOutpatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOutpatients))
+	outpatients.PUT("/cancel", presenter.SchedulePresentation.PutCancelOutpatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionCancelOutpatients))
+	outpatients.PUT("/examine", presenter.SchedulePresentation.PutExamineOutpatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionExamineOutpatients))
+	outpatients.PUT("/finish", presenter.SchedulePresentation.PutFinishOutpatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionFinishOutpatients))
+	outpatients.GET("/prescriptions/:prescriptionId/versions", presenter.SchedulePresentation.GetPrescriptionVersions, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewOutpatients))
+	outpatients.PUT("/prescriptions/amend", presenter.SchedulePresentation.PutAmendPrescription, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManagePrescriptions))
+	outpatients.PUT("/prescriptions/void", presenter.SchedulePresentation.PutVoidPrescription, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManagePrescriptions))
+	outpatients.PUT("/prescriptions/dispense", presenter.SchedulePresentation.PutDispensePrescriptions, middleware.IsAuth(), middleware.HasPermission(permissions.ActionDispensePrescriptions))
+	outpatients.DELETE("/:outpatientId", presenter.SchedulePresentation.DeleteOutpatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOutpatients))
 }
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -9,11 +10,12 @@ import (
 func setupPatientRoutes(e *echo.Echo, presenter *factory.Presenter) {
 	patient := e.Group("/patients")
 
-	patient.GET("", presenter.PatientPresentation.GetPatients, middleware.IsAuth())
-	patient.GET("/:patientId", presenter.PatientPresentation.GetDetailPatient, middleware.IsAuth())
-	patient.POST("", presenter.PatientPresentation.PostPatient, middleware.IsAdmin())
-	patient.PUT("", presenter.PatientPresentation.PutEditPatient, middleware.IsAdmin())
-	patient.DELETE("/:patientId", presenter.PatientPresentation.DeletePatient, middleware.IsAdmin())
+	patient.GET("", presenter.PatientPresentation.GetPatients, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewPatients))
+	patient.GET("/:patientId", presenter.PatientPresentation.GetDetailPatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewPatients))
+	patient.POST("", presenter.PatientPresentation.PostPatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManagePatients))
+	patient.PUT("", presenter.PatientPresentation.PutEditPatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManagePatients))
+	patient.DELETE("/:patientId", presenter.PatientPresentation.DeletePatient, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManagePatients))
 
-	patient.GET("/:patientId/outpatients", presenter.SchedulePresentation.GetPatientOutpatients, middleware.IsAuth())
+	patient.GET("/:patientId/outpatients", presenter.SchedulePresentation.GetPatientOutpatients, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewOutpatients))
+	patient.GET("/:patientId/access-logs", presenter.AuditPresentation.GetPatientAccessLogs, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewAccessLogs))
 }
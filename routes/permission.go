@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func setupPermissionRoutes(e *echo.Echo, presenter *factory.Presenter) {
+	permission := e.Group("/permissions")
+
+	permission.GET("", presenter.PermissionPresentation.GetPermissions, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewPermissions))
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// setupPortalRoutes are used by patients signed in with their NIK
+func setupPortalRoutes(e *echo.Echo, presenter *factory.Presenter) {
+	portal := e.Group("/portal")
+
+	portal.GET("/availability", presenter.SchedulePresentation.GetAvailability, middleware.IsAuth(), middleware.HasPermission(permissions.ActionBookOwnVisits))
+	portal.GET("/visits", presenter.SchedulePresentation.GetOwnVisits, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewOwnVisits))
+	portal.POST("/visits", presenter.SchedulePresentation.PostBookVisit, middleware.IsAuth(), middleware.HasPermission(permissions.ActionBookOwnVisits))
+	portal.PUT("/visits/cancel", presenter.SchedulePresentation.PutCancelVisit, middleware.IsAuth(), middleware.HasPermission(permissions.ActionBookOwnVisits))
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -9,8 +10,9 @@ import (
 func setupRoomRoutes(e *echo.Echo, presenter *factory.Presenter) {
 	room := e.Group("/rooms")
 
-	room.GET("", presenter.DoctorPresentation.GetRooms, middleware.IsAuth())
-	room.POST("", presenter.DoctorPresentation.PostRoom, middleware.IsAdmin())
-	room.PUT("", presenter.DoctorPresentation.PutEditRoom, middleware.IsAdmin())
-	room.DELETE("/:roomId", presenter.DoctorPresentation.DeleteRoom, middleware.IsAdmin())
+	room.GET("", presenter.DoctorPresentation.GetRooms, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewRooms))
+	room.POST("", presenter.DoctorPresentation.PostRoom, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageRooms))
+	room.PUT("", presenter.DoctorPresentation.PutEditRoom, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageRooms))
+	room.DELETE("/:roomId", presenter.DoctorPresentation.DeleteRoom, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageRooms))
+	room.GET("/:roomId/queue/stream", presenter.SchedulePresentation.GetRoomQueueStream, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewWorkSchedules))
 }
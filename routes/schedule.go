@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -9,10 +10,17 @@ import (
 func setupScheduleRoutes(e *echo.Echo, presenter *factory.Presenter) {
 	schedule := e.Group("/work-schedules")
 
-	schedule.GET("", presenter.SchedulePresentation.GetWorkSchedules, middleware.IsAuth())
-	schedule.POST("", presenter.SchedulePresentation.PostWorkSchedules, middleware.IsAdmin())
-	schedule.PUT("", presenter.SchedulePresentation.PutEditWorkSchedule, middleware.IsAdmin())
-	schedule.DELETE("/:workScheduleId", presenter.SchedulePresentation.DeleteWorkSchedule, middleware.IsAdmin())
+	schedule.GET("", presenter.SchedulePresentation.GetWorkSchedules, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewWorkSchedules))
+	schedule.GET("/availability", presenter.SchedulePresentation.GetAvailability, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewWorkSchedules))
+	schedule.POST("", presenter.SchedulePresentation.PostWorkSchedules, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageWorkSchedules))
+	schedule.PUT("", presenter.SchedulePresentation.PutEditWorkSchedule, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageWorkSchedules))
+	schedule.DELETE("/:workScheduleId", presenter.SchedulePresentation.DeleteWorkSchedule, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageWorkSchedules))
+	schedule.POST("/:workScheduleId/transfer", presenter.SchedulePresentation.PostTransferOutpatients, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOutpatients))
 
-	schedule.GET("/:workScheduleId", presenter.SchedulePresentation.GetWorkScheduleOutpatients, middleware.IsAuth())
+	schedule.GET("/groups/:group", presenter.SchedulePresentation.GetWorkScheduleSeries, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewWorkSchedules))
+	schedule.PUT("/groups/:group", presenter.SchedulePresentation.PutEditWorkScheduleSeries, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageWorkSchedules))
+	schedule.DELETE("/groups/:group", presenter.SchedulePresentation.DeleteWorkScheduleSeries, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageWorkSchedules))
+
+	schedule.GET("/:workScheduleId", presenter.SchedulePresentation.GetWorkScheduleOutpatients, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewWorkSchedules))
+	schedule.GET("/:workScheduleId/queue/stream", presenter.SchedulePresentation.GetWorkScheduleQueueStream, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewWorkSchedules))
 }
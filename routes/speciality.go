@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
 	"github.com/final-project-alterra/hospital-management-system-api/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -9,9 +10,9 @@ import (
 func setupSpecialityRoutes(e *echo.Echo, presenter *factory.Presenter) {
 	speciality := e.Group("/specialities")
 
-	speciality.GET("", presenter.DoctorPresentation.GetSpecialities, middleware.IsAuth())
-	speciality.GET("/:specialityId", presenter.DoctorPresentation.GetDetailSpeciality, middleware.IsAuth())
-	speciality.POST("", presenter.DoctorPresentation.PostSpeciality, middleware.IsAdmin())
-	speciality.PUT("", presenter.DoctorPresentation.PutEditSpeciality, middleware.IsAdmin())
-	speciality.DELETE("/:specialityId", presenter.DoctorPresentation.DeleteSpeciality, middleware.IsAdmin())
+	speciality.GET("", presenter.DoctorPresentation.GetSpecialities, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewSpecialities))
+	speciality.GET("/:specialityId", presenter.DoctorPresentation.GetDetailSpeciality, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewSpecialities))
+	speciality.POST("", presenter.DoctorPresentation.PostSpeciality, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageSpecialities))
+	speciality.PUT("", presenter.DoctorPresentation.PutEditSpeciality, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageSpecialities))
+	speciality.DELETE("/:specialityId", presenter.DoctorPresentation.DeleteSpeciality, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageSpecialities))
 }
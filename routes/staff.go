@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/final-project-alterra/hospital-management-system-api/factory"
+	"github.com/final-project-alterra/hospital-management-system-api/features/permissions"
+	"github.com/final-project-alterra/hospital-management-system-api/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func setupStaffRoutes(e *echo.Echo, presenter *factory.Presenter) {
+	staffs := e.Group("/staffs")
+
+	staffs.GET("", presenter.StaffPresentation.GetStaffs, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewStaffs))
+	staffs.GET("/:staffId", presenter.StaffPresentation.GetDetailStaff, middleware.IsAuth(), middleware.HasPermission(permissions.ActionViewStaffs))
+	staffs.POST("", presenter.StaffPresentation.PostStaff, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageStaffs))
+	staffs.PUT("", presenter.StaffPresentation.PutEditStaff, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageStaffs))
+	staffs.PUT("/password", presenter.StaffPresentation.PutEditStaffPassword, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageStaffs))
+	staffs.DELETE("/:staffId", presenter.StaffPresentation.DeleteStaff, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageStaffs))
+
+	staffs.GET("/me", presenter.StaffPresentation.GetOwnProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnStaff))
+	staffs.PUT("/me", presenter.StaffPresentation.PutEditOwnProfile, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnStaff))
+	staffs.PUT("/me/password", presenter.StaffPresentation.PutEditOwnPassword, middleware.IsAuth(), middleware.HasPermission(permissions.ActionManageOwnStaff))
+}
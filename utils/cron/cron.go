@@ -0,0 +1,184 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+)
+
+// Standard five field cron expressions: minute, hour, day of month, month and day of
+// week (0 or 7 is sunday). Fields take *, numbers, ranges (1-5), steps (*/15, 1-30/2)
+// and lists of them (1,15). Names (JAN, MON) are not supported. When both day of month
+// and day of week are restricted either of them matches, like cron does.
+//
+// Descriptors are accepted as well: @yearly, @monthly, @weekly, @daily and @hourly.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+const MAX_YEARS = 5 // how far Next looks for a matching time
+
+type field struct {
+	name string
+	min  int
+	max  int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Schedule holds the allowed values of every field as bits
+type Schedule struct {
+	Expression string
+
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	anyDom bool // day of month is *
+	anyDow bool // day of week is *
+}
+
+// Parse reads a cron expression or a descriptor
+func Parse(expression string) (Schedule, error) {
+	const op errors.Op = "cron.Parse"
+	var errMessage errors.ErrClientMessage = "Invalid cron expression"
+
+	value := strings.TrimSpace(expression)
+	if named, ok := descriptors[strings.ToLower(value)]; ok {
+		value = named
+	}
+
+	parts := strings.Fields(value)
+	if len(parts) != len(fields) {
+		err := fmt.Errorf("expected %d fields, got %d", len(fields), len(parts))
+		return Schedule{}, errors.E(err, op, errMessage, errors.KindBadRequest)
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, part := range parts {
+		var err error
+		bits[i], err = parseField(part, fields[i])
+		if err != nil {
+			return Schedule{}, errors.E(err, op, errMessage, errors.KindBadRequest)
+		}
+	}
+
+	// sunday is both 0 and 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return Schedule{
+		Expression: strings.TrimSpace(expression),
+		minute:     bits[0],
+		hour:       bits[1],
+		dom:        bits[2],
+		month:      bits[3],
+		dow:        bits[4],
+		anyDom:     parts[2] == "*",
+		anyDow:     parts[4] == "*",
+	}, nil
+}
+
+// Next is the first matching minute after t, in the location of t. The zero time is
+// returned when nothing matches within MAX_YEARS (e.g. 30 February).
+func (s Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(MAX_YEARS, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !has(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s Schedule) matchDay(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+
+	if s.anyDom || s.anyDow {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
+
+// parseField reads a list of *, n, a-b with an optional /step
+func parseField(value string, f field) (uint64, error) {
+	var bits uint64
+
+	for _, item := range strings.Split(value, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			rangePart = item[:i]
+
+			var err error
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in %s %q", f.name, item)
+			}
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err1, err2 error
+			start, err1 = strconv.Atoi(bounds[0])
+			end, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil || start > end {
+				return 0, fmt.Errorf("invalid range in %s %q", f.name, item)
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value in %s %q", f.name, item)
+			}
+			start = n
+			end = n
+			if step > 1 {
+				end = f.max // n/step runs to the end, like cron
+			}
+		}
+
+		if start < f.min || end > f.max {
+			return 0, fmt.Errorf("%s %q is out of %d-%d", f.name, item, f.min, f.max)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
@@ -0,0 +1,97 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/utils/cron"
+	"github.com/stretchr/testify/assert"
+)
+
+const LAYOUT = "2006-01-02 15:04"
+
+func next(t *testing.T, expression string, after string) string {
+	schedule, err := cron.Parse(expression)
+	assert.Nil(t, err)
+
+	start, err := time.Parse(LAYOUT, after)
+	assert.Nil(t, err)
+	return schedule.Next(start).Format(LAYOUT)
+}
+
+func TestNext(t *testing.T) {
+	t.Run("valid - every few minutes", func(t *testing.T) {
+		assert.Equal(t, "2030-01-01 10:05", next(t, "*/5 * * * *", "2030-01-01 10:00"))
+		assert.Equal(t, "2030-01-01 10:05", next(t, "*/5 * * * *", "2030-01-01 10:04"))
+		assert.Equal(t, "2030-01-01 11:00", next(t, "*/5 * * * *", "2030-01-01 10:59"))
+	})
+
+	t.Run("valid - seconds are ignored and the same minute is not repeated", func(t *testing.T) {
+		schedule, err := cron.Parse("* * * * *")
+		assert.Nil(t, err)
+
+		after := time.Date(2030, 1, 1, 10, 0, 30, 0, time.UTC)
+		assert.Equal(t, time.Date(2030, 1, 1, 10, 1, 0, 0, time.UTC), schedule.Next(after))
+	})
+
+	t.Run("valid - daily and descriptors", func(t *testing.T) {
+		assert.Equal(t, "2030-01-02 02:30", next(t, "30 2 * * *", "2030-01-01 02:30"))
+		assert.Equal(t, "2030-01-02 00:00", next(t, "@daily", "2030-01-01 08:00"))
+		assert.Equal(t, "2030-01-01 09:00", next(t, "@hourly", "2030-01-01 08:00"))
+		assert.Equal(t, "2031-01-01 00:00", next(t, "@yearly", "2030-01-01 00:00"))
+	})
+
+	t.Run("valid - ranges, lists and steps", func(t *testing.T) {
+		assert.Equal(t, "2030-01-01 17:00", next(t, "0 8-17/3 * * *", "2030-01-01 14:00"))
+		assert.Equal(t, "2030-01-15 00:00", next(t, "0 0 1,15 * *", "2030-01-02 00:00"))
+		assert.Equal(t, "2030-01-01 12:20", next(t, "20/20 * * * *", "2030-01-01 12:00"))
+	})
+
+	t.Run("valid - day of week, sunday is 0 and 7", func(t *testing.T) {
+		// 1 January 2030 is a tuesday
+		assert.Equal(t, "2030-01-06 07:00", next(t, "0 7 * * 0", "2030-01-01 00:00"))
+		assert.Equal(t, "2030-01-06 07:00", next(t, "0 7 * * 7", "2030-01-01 00:00"))
+		assert.Equal(t, "2030-01-04 07:00", next(t, "0 7 * * 1-5", "2030-01-03 07:00"))
+	})
+
+	t.Run("valid - day of month or day of week when both are restricted", func(t *testing.T) {
+		assert.Equal(t, "2030-01-06 00:00", next(t, "0 0 13 * 0", "2030-01-01 00:00"))
+		assert.Equal(t, "2030-01-13 00:00", next(t, "0 0 13 * 0", "2030-01-06 00:00"))
+	})
+
+	t.Run("valid - months without the day are skipped", func(t *testing.T) {
+		assert.Equal(t, "2030-03-31 00:00", next(t, "0 0 31 * *", "2030-01-31 00:00"))
+		assert.Equal(t, "2032-02-29 00:00", next(t, "0 0 29 2 *", "2030-01-01 00:00"))
+	})
+
+	t.Run("valid - zero time when nothing matches", func(t *testing.T) {
+		schedule, err := cron.Parse("0 0 30 2 *")
+		assert.Nil(t, err)
+		assert.True(t, schedule.Next(time.Now()).IsZero())
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("valid - when expression is invalid", func(t *testing.T) {
+		invalidExpressions := []string{
+			"",
+			"* * * *",
+			"* * * * * *",
+			"60 * * * *",
+			"* 24 * * *",
+			"* * 0 * *",
+			"* * * 13 *",
+			"* * * * 8",
+			"*/0 * * * *",
+			"5-1 * * * *",
+			"a * * * *",
+			"@every5m",
+		}
+		for _, e := range invalidExpressions {
+			_, err := cron.Parse(e)
+			assert.Error(t, err, e)
+			assert.Equal(t, errors.KindBadRequest, errors.Kind(err), e)
+		}
+	})
+}
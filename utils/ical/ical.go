@@ -0,0 +1,266 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+)
+
+// Supported subset of iCalendar (RFC 5545): VEVENTs with DTSTART, DTEND or DURATION in
+// days or weeks, SUMMARY and STATUS. Times are reduced to their date, an event that ends
+// at midnight does not cover the day it ends on. Recurring events (RRULE, RDATE) are
+// rejected, holiday calendars export every year as its own event.
+const (
+	MAX_EVENT_DAYS = 366
+
+	DATE_LAYOUT = "2006-01-02"
+)
+
+// Event covers the dates from Start up to End (exclusive)
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// Dates lists every day the event covers
+func (e Event) Dates() []string {
+	dates := []string{}
+	for d := e.Start; d.Before(e.End); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d.Format(DATE_LAYOUT))
+	}
+	return dates
+}
+
+// property of a content line, parameters (e.g. VALUE=DATE, TZID) are not needed
+type property struct {
+	name  string
+	value string
+}
+
+// Parse reads the events of an iCalendar file, cancelled events are left out
+func Parse(r io.Reader) ([]Event, error) {
+	const op errors.Op = "ical.Parse"
+	var errMessage errors.ErrClientMessage = "Invalid iCalendar file"
+
+	invalid := func(line int, format string, args ...interface{}) ([]Event, error) {
+		err := fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+		return nil, errors.E(err, op, errMessage, errors.KindBadRequest)
+	}
+
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, errors.E(err, op, errMessage, errors.KindBadRequest)
+	}
+
+	events := []Event{}
+	inCalendar := false
+	var current []property
+	var startLine int
+
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		p, err := parseProperty(line)
+		if err != nil {
+			return invalid(i+1, err.Error())
+		}
+
+		switch {
+		case p.name == "BEGIN" && strings.EqualFold(p.value, "VCALENDAR"):
+			inCalendar = true
+		case p.name == "END" && strings.EqualFold(p.value, "VCALENDAR"):
+			inCalendar = false
+		case !inCalendar:
+			return invalid(i+1, "content outside of VCALENDAR")
+		case p.name == "BEGIN" && strings.EqualFold(p.value, "VEVENT"):
+			if current != nil {
+				return invalid(i+1, "nested VEVENT")
+			}
+			current = []property{}
+			startLine = i + 1
+		case p.name == "END" && strings.EqualFold(p.value, "VEVENT"):
+			if current == nil {
+				return invalid(i+1, "END:VEVENT without BEGIN")
+			}
+			event, cancelled, err := toEvent(current)
+			if err != nil {
+				return invalid(startLine, err.Error())
+			}
+			if !cancelled {
+				events = append(events, event)
+			}
+			current = nil
+		case current != nil:
+			current = append(current, p)
+		}
+	}
+
+	if current != nil {
+		return invalid(startLine, "VEVENT is not closed")
+	}
+	return events, nil
+}
+
+// unfold joins continuation lines, they start with a space or a tab
+func unfold(r io.Reader) ([]string, error) {
+	lines := []string{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseProperty splits NAME;PARAM=VALUE;PARAM="VA:LUE":VALUE
+func parseProperty(line string) (property, error) {
+	quoted := false
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		}
+		if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 1 {
+		return property{}, errors.New("malformed content line")
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	for _, param := range parts[1:] {
+		if !strings.Contains(param, "=") {
+			return property{}, errors.New("malformed parameter")
+		}
+	}
+	return property{name: strings.ToUpper(parts[0]), value: line[colon+1:]}, nil
+}
+
+func toEvent(props []property) (Event, bool, error) {
+	event := Event{}
+	var hasEnd, endIsTime bool
+	var duration string
+
+	for _, p := range props {
+		var err error
+		switch p.name {
+		case "UID":
+			event.UID = p.value
+		case "SUMMARY":
+			event.Summary = unescape(p.value)
+		case "STATUS":
+			if strings.EqualFold(p.value, "CANCELLED") {
+				return Event{}, true, nil
+			}
+		case "DTSTART":
+			event.Start, _, err = parseDate(p.value)
+			if err != nil {
+				return Event{}, false, fmt.Errorf("invalid DTSTART %q", p.value)
+			}
+			event.Start = truncate(event.Start)
+		case "DTEND":
+			hasEnd = true
+			event.End, endIsTime, err = parseDate(p.value)
+			if err != nil {
+				return Event{}, false, fmt.Errorf("invalid DTEND %q", p.value)
+			}
+		case "DURATION":
+			duration = p.value
+		case "RRULE", "RDATE":
+			return Event{}, false, errors.New("recurring events are not supported")
+		}
+	}
+
+	if event.Start.IsZero() {
+		return Event{}, false, errors.New("DTSTART is required")
+	}
+
+	switch {
+	case hasEnd && endIsTime:
+		// the day it ends on is covered unless it ends at midnight
+		end := event.End
+		event.End = truncate(end)
+		if !end.Equal(event.End) {
+			event.End = event.End.AddDate(0, 0, 1)
+		}
+	case hasEnd:
+	case duration != "":
+		days, err := parseDuration(duration)
+		if err != nil {
+			return Event{}, false, fmt.Errorf("invalid DURATION %q", duration)
+		}
+		event.End = event.Start.AddDate(0, 0, days)
+	default:
+		event.End = event.Start.AddDate(0, 0, 1)
+	}
+
+	if !event.End.After(event.Start) {
+		event.End = event.Start.AddDate(0, 0, 1)
+	}
+	if event.End.Sub(event.Start) > MAX_EVENT_DAYS*24*time.Hour {
+		return Event{}, false, fmt.Errorf("event is longer than %d days", MAX_EVENT_DAYS)
+	}
+	return event, false, nil
+}
+
+// parseDate accepts DATE (20300101) and DATE-TIME (20300101T090000, with or without Z).
+// Local times are kept in their own zone, only the date matters.
+func parseDate(value string) (time.Time, bool, error) {
+	t, err := time.Parse("20060102", value)
+	if err == nil {
+		return t, false, nil
+	}
+
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405"} {
+		t, err = time.Parse(layout, value)
+		if err == nil {
+			return t, true, nil
+		}
+	}
+	return time.Time{}, false, err
+}
+
+// parseDuration accepts whole days or weeks, e.g. P1D, P2W or P1DT0H0M0S
+func parseDuration(value string) (int, error) {
+	var n int
+	var unit string
+
+	value = strings.TrimSuffix(strings.ToUpper(value), "T0H0M0S")
+	_, err := fmt.Sscanf(value, "P%d%s", &n, &unit)
+	if err != nil || n < 1 {
+		return 0, errors.New("unsupported duration")
+	}
+
+	switch unit {
+	case "D":
+		return n, nil
+	case "W":
+		return n * 7, nil
+	default:
+		return 0, errors.New("unsupported duration")
+	}
+}
+
+func unescape(value string) string {
+	replacer := strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, " ", `\N`, " ")
+	return strings.TrimSpace(replacer.Replace(value))
+}
+
+func truncate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,108 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/utils/ical"
+	"github.com/stretchr/testify/assert"
+)
+
+func calendar(lines ...string) string {
+	all := append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...)
+	all = append(all, "END:VCALENDAR")
+	return strings.Join(all, "\r\n")
+}
+
+func TestParse(t *testing.T) {
+	t.Run("valid - all day events", func(t *testing.T) {
+		events, err := ical.Parse(strings.NewReader(calendar(
+			"BEGIN:VEVENT",
+			"UID:1@holidays",
+			"DTSTART;VALUE=DATE:20300101",
+			"DTEND;VALUE=DATE:20300102",
+			"SUMMARY:New Year's Day",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:2@holidays",
+			"DTSTART;VALUE=DATE:20300505",
+			"SUMMARY:Eid al-Fitr",
+			"END:VEVENT",
+		)))
+		assert.Nil(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, "New Year's Day", events[0].Summary)
+		assert.Equal(t, []string{"2030-01-01"}, events[0].Dates())
+		assert.Equal(t, []string{"2030-05-05"}, events[1].Dates())
+	})
+
+	t.Run("valid - multi day event", func(t *testing.T) {
+		events, err := ical.Parse(strings.NewReader(calendar(
+			"BEGIN:VEVENT",
+			"DTSTART;VALUE=DATE:20300605",
+			"DURATION:P3D",
+			"SUMMARY:Renovation",
+			"END:VEVENT",
+		)))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"2030-06-05", "2030-06-06", "2030-06-07"}, events[0].Dates())
+	})
+
+	t.Run("valid - timed event covers the day it ends on unless midnight", func(t *testing.T) {
+		events, err := ical.Parse(strings.NewReader(calendar(
+			"BEGIN:VEVENT",
+			"DTSTART;TZID=\"Asia/Jakarta\":20300101T080000",
+			"DTEND;TZID=\"Asia/Jakarta\":20300102T120000",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"DTSTART:20300201T080000Z",
+			"DTEND:20300202T000000Z",
+			"END:VEVENT",
+		)))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"2030-01-01", "2030-01-02"}, events[0].Dates())
+		assert.Equal(t, []string{"2030-02-01"}, events[1].Dates())
+	})
+
+	t.Run("valid - folded and escaped summary", func(t *testing.T) {
+		events, err := ical.Parse(strings.NewReader(calendar(
+			"BEGIN:VEVENT",
+			"DTSTART;VALUE=DATE:20300817",
+			"SUMMARY:Independence Day\\, ",
+			" national holiday",
+			"END:VEVENT",
+		)))
+		assert.Nil(t, err)
+		assert.Equal(t, "Independence Day, national holiday", events[0].Summary)
+	})
+
+	t.Run("valid - cancelled event is left out", func(t *testing.T) {
+		events, err := ical.Parse(strings.NewReader(calendar(
+			"BEGIN:VEVENT",
+			"DTSTART;VALUE=DATE:20300817",
+			"STATUS:CANCELLED",
+			"END:VEVENT",
+		)))
+		assert.Nil(t, err)
+		assert.Len(t, events, 0)
+	})
+
+	t.Run("valid - when file is invalid", func(t *testing.T) {
+		invalidFiles := []string{
+			"not a calendar",
+			"BEGIN:VEVENT\r\nDTSTART:20300101\r\nEND:VEVENT",
+			calendar("BEGIN:VEVENT", "SUMMARY:No start", "END:VEVENT"),
+			calendar("BEGIN:VEVENT", "DTSTART:2030-01-01", "END:VEVENT"),
+			calendar("BEGIN:VEVENT", "DTSTART:20300101", "RRULE:FREQ=YEARLY", "END:VEVENT"),
+			calendar("BEGIN:VEVENT", "DTSTART:20300101", "DURATION:PT2H", "END:VEVENT"),
+			calendar("BEGIN:VEVENT", "DTSTART:20300101", "DTEND:20320101", "END:VEVENT"),
+			calendar("BEGIN:VEVENT", "DTSTART:20300101"),
+		}
+		for _, f := range invalidFiles {
+			_, err := ical.Parse(strings.NewReader(f))
+			assert.Error(t, err, f)
+			assert.Equal(t, errors.KindBadRequest, errors.Kind(err), f)
+		}
+	})
+}
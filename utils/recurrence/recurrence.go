@@ -0,0 +1,299 @@
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+)
+
+// Supported subset of iCalendar RRULE (RFC 5545), e.g.
+//
+//	FREQ=MONTHLY;BYMONTHDAY=15            15th of every month
+//	FREQ=MONTHLY;BYDAY=2TU                second tuesday of every month
+//	FREQ=MONTHLY;BYDAY=-1FR               last friday of every month
+//	FREQ=WEEKLY;BYDAY=MO,WE,FR            monday, wednesday and friday
+//	FREQ=WEEKLY;INTERVAL=2;COUNT=10       every other week, 10 times
+//	FREQ=DAILY;UNTIL=20300101             every day until 1 January 2030
+//
+// Weeks start on monday (WKST=MO), other parts are rejected.
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+
+	MAX_OCCURRENCES = 1000
+	MAX_YEARS       = 10 // how far an open ended rule (only COUNT) is expanded
+)
+
+const DATE_LAYOUT = "2006-01-02"
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Weekday of BYDAY, Nth is the occurrence within the month (negative counts from the end),
+// zero means every such weekday
+type Weekday struct {
+	Nth int
+	Day time.Weekday
+}
+
+type Rule struct {
+	Freq       string
+	Interval   int
+	ByDay      []Weekday
+	ByMonthDay []int // negative counts from the end of month
+	Count      int
+	Until      time.Time
+}
+
+// Parse reads an RRULE value, with or without the "RRULE:" prefix
+func Parse(value string) (Rule, error) {
+	const op errors.Op = "recurrence.Parse"
+	var errMessage errors.ErrClientMessage = "Invalid repeat rule"
+
+	invalid := func(format string, args ...interface{}) (Rule, error) {
+		return Rule{}, errors.E(fmt.Errorf(format, args...), op, errMessage, errors.KindBadRequest)
+	}
+
+	rule := Rule{Interval: 1}
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	if value == "" {
+		return invalid("empty rule")
+	}
+
+	for _, part := range strings.Split(value, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return invalid("malformed part %q", part)
+		}
+
+		var err error
+		switch key, val := kv[0], kv[1]; key {
+		case "FREQ":
+			if val != FreqDaily && val != FreqWeekly && val != FreqMonthly {
+				return invalid("unsupported FREQ %q", val)
+			}
+			rule.Freq = val
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err != nil || rule.Interval < 1 {
+				return invalid("invalid INTERVAL %q", val)
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+			if err != nil || rule.Count < 1 {
+				return invalid("invalid COUNT %q", val)
+			}
+		case "UNTIL":
+			rule.Until, err = parseUntil(val)
+			if err != nil {
+				return invalid("invalid UNTIL %q", val)
+			}
+		case "BYDAY":
+			for _, d := range strings.Split(val, ",") {
+				weekday, err := parseWeekday(d)
+				if err != nil {
+					return invalid("invalid BYDAY %q", d)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(val, ",") {
+				day, err := strconv.Atoi(d)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return invalid("invalid BYMONTHDAY %q", d)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, day)
+			}
+		case "WKST":
+			if val != "MO" {
+				return invalid("only WKST=MO is supported")
+			}
+		default:
+			return invalid("unsupported part %q", key)
+		}
+	}
+
+	switch {
+	case rule.Freq == "":
+		return invalid("FREQ is required")
+	case rule.Count > 0 && !rule.Until.IsZero():
+		return invalid("COUNT and UNTIL can not be used together")
+	case rule.Freq == FreqMonthly && len(rule.ByDay) > 0 && len(rule.ByMonthDay) > 0:
+		return invalid("BYDAY and BYMONTHDAY can not be used together")
+	case rule.Freq != FreqMonthly && len(rule.ByMonthDay) > 0:
+		return invalid("BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+	for _, d := range rule.ByDay {
+		if d.Nth != 0 && rule.Freq != FreqMonthly {
+			return invalid("BYDAY with position is only supported with FREQ=MONTHLY")
+		}
+	}
+	return rule, nil
+}
+
+// Bounded tells whether the rule ends by itself, otherwise an end date is needed
+func (r Rule) Bounded() bool {
+	return r.Count > 0 || !r.Until.IsZero()
+}
+
+// Dates expands the rule from start up to end (inclusive, zero for no end). Start only
+// anchors the rule, it is not an occurrence unless it matches. Excluded dates are removed
+// after COUNT is applied, like EXDATE.
+func (r Rule) Dates(start time.Time, end time.Time, exclude []time.Time) []time.Time {
+	start = truncate(start)
+
+	limit := start.AddDate(MAX_YEARS, 0, 0)
+	if !end.IsZero() && truncate(end).Before(limit) {
+		limit = truncate(end)
+	}
+	if !r.Until.IsZero() && truncate(r.Until).Before(limit) {
+		limit = truncate(r.Until)
+	}
+
+	excluded := make(map[string]bool)
+	for _, e := range exclude {
+		excluded[e.Format(DATE_LAYOUT)] = true
+	}
+
+	dates := []time.Time{}
+	matched := 0
+	for d := start; !d.After(limit); d = d.AddDate(0, 0, 1) {
+		if !r.matches(start, d) {
+			continue
+		}
+
+		matched++
+		if !excluded[d.Format(DATE_LAYOUT)] {
+			dates = append(dates, d)
+		}
+		if matched == r.Count || matched == MAX_OCCURRENCES {
+			break
+		}
+	}
+	return dates
+}
+
+func (r Rule) matches(start time.Time, d time.Time) bool {
+	switch r.Freq {
+	case FreqDaily:
+		days := int(d.Sub(start).Hours() / 24)
+		return days%r.Interval == 0 && r.matchesWeekday(d)
+
+	case FreqWeekly:
+		weeks := int(weekStart(d).Sub(weekStart(start)).Hours() / 24 / 7)
+		if weeks%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return d.Weekday() == start.Weekday()
+		}
+		return r.matchesWeekday(d)
+
+	case FreqMonthly:
+		months := (d.Year()-start.Year())*12 + int(d.Month()-start.Month())
+		if months%r.Interval != 0 {
+			return false
+		}
+		switch {
+		case len(r.ByMonthDay) > 0:
+			return r.matchesMonthDay(d)
+		case len(r.ByDay) > 0:
+			return r.matchesWeekday(d)
+		default:
+			// months without the day (e.g. the 31st) are skipped, never shifted
+			return d.Day() == start.Day()
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesWeekday(d time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	lastDay := daysIn(d)
+	for _, w := range r.ByDay {
+		if w.Day != d.Weekday() {
+			continue
+		}
+		switch {
+		case w.Nth == 0:
+			return true
+		case w.Nth > 0 && (d.Day()-1)/7+1 == w.Nth:
+			return true
+		case w.Nth < 0 && (lastDay-d.Day())/7+1 == -w.Nth:
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesMonthDay(d time.Time) bool {
+	lastDay := daysIn(d)
+	for _, day := range r.ByMonthDay {
+		if day < 0 {
+			day = lastDay + day + 1
+		}
+		if d.Day() == day {
+			return true
+		}
+	}
+	return false
+}
+
+func parseWeekday(value string) (Weekday, error) {
+	if len(value) < 2 {
+		return Weekday{}, errors.New("weekday is too short")
+	}
+
+	day, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		return Weekday{}, errors.New("unknown weekday")
+	}
+
+	nth := 0
+	if prefix := value[:len(value)-2]; prefix != "" {
+		var err error
+		nth, err = strconv.Atoi(prefix)
+		if err != nil || nth == 0 || nth < -5 || nth > 5 {
+			return Weekday{}, errors.New("invalid weekday position")
+		}
+	}
+	return Weekday{Nth: nth, Day: day}, nil
+}
+
+// parseUntil accepts the RRULE date (20300101), date time (20300101T000000Z) or YYYY-MM-DD
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102", "20060102T150405Z", "20060102T150405", DATE_LAYOUT} {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("unknown date format")
+}
+
+func truncate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7 // monday is 0
+	return t.AddDate(0, 0, -offset)
+}
+
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,127 @@
+package recurrence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+	"github.com/final-project-alterra/hospital-management-system-api/utils/recurrence"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(value string) time.Time {
+	t, err := time.Parse(recurrence.DATE_LAYOUT, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func format(dates []time.Time) []string {
+	result := make([]string, len(dates))
+	for i := range dates {
+		result[i] = dates[i].Format(recurrence.DATE_LAYOUT)
+	}
+	return result
+}
+
+func expand(t *testing.T, rrule string, start string, end string, exclude ...time.Time) []string {
+	rule, err := recurrence.Parse(rrule)
+	assert.Nil(t, err)
+
+	var endDate time.Time
+	if end != "" {
+		endDate = date(end)
+	}
+	return format(rule.Dates(date(start), endDate, exclude))
+}
+
+func TestParse(t *testing.T) {
+	t.Run("valid - when rule has prefix and lower case", func(t *testing.T) {
+		rule, err := recurrence.Parse("rrule:freq=weekly;interval=2;byday=mo,we,fr")
+		assert.Nil(t, err)
+		assert.Equal(t, recurrence.FreqWeekly, rule.Freq)
+		assert.Equal(t, 2, rule.Interval)
+		assert.Len(t, rule.ByDay, 3)
+	})
+
+	t.Run("valid - when rule is invalid", func(t *testing.T) {
+		invalidRules := []string{
+			"",
+			"daily",
+			"FREQ=YEARLY",
+			"INTERVAL=2",
+			"FREQ=DAILY;INTERVAL=0",
+			"FREQ=DAILY;COUNT=2;UNTIL=20300101",
+			"FREQ=WEEKLY;BYDAY=2MO",
+			"FREQ=WEEKLY;BYMONTHDAY=1",
+			"FREQ=MONTHLY;BYMONTHDAY=32",
+			"FREQ=MONTHLY;BYDAY=XX",
+			"FREQ=MONTHLY;BYDAY=MO;BYMONTHDAY=1",
+			"FREQ=MONTHLY;BYSETPOS=1",
+		}
+		for _, r := range invalidRules {
+			_, err := recurrence.Parse(r)
+			assert.Error(t, err, r)
+			assert.Equal(t, errors.KindBadRequest, errors.Kind(err), r)
+		}
+	})
+}
+
+func TestDates(t *testing.T) {
+	t.Run("valid - monthly keeps the same day of month", func(t *testing.T) {
+		dates := expand(t, "FREQ=MONTHLY", "2030-01-15", "2030-06-30")
+		assert.Equal(t, []string{"2030-01-15", "2030-02-15", "2030-03-15", "2030-04-15", "2030-05-15", "2030-06-15"}, dates)
+	})
+
+	t.Run("valid - monthly skips months without the day", func(t *testing.T) {
+		dates := expand(t, "FREQ=MONTHLY", "2030-01-31", "2030-05-31")
+		assert.Equal(t, []string{"2030-01-31", "2030-03-31", "2030-05-31"}, dates)
+	})
+
+	t.Run("valid - nth weekday of month", func(t *testing.T) {
+		dates := expand(t, "FREQ=MONTHLY;BYDAY=2TU", "2030-01-01", "2030-03-31")
+		assert.Equal(t, []string{"2030-01-08", "2030-02-12", "2030-03-12"}, dates)
+	})
+
+	t.Run("valid - last weekday of month", func(t *testing.T) {
+		dates := expand(t, "FREQ=MONTHLY;BYDAY=-1FR", "2030-01-01", "2030-03-31")
+		assert.Equal(t, []string{"2030-01-25", "2030-02-22", "2030-03-29"}, dates)
+	})
+
+	t.Run("valid - last day of month", func(t *testing.T) {
+		dates := expand(t, "FREQ=MONTHLY;BYMONTHDAY=-1", "2030-01-01", "2030-03-31")
+		assert.Equal(t, []string{"2030-01-31", "2030-02-28", "2030-03-31"}, dates)
+	})
+
+	t.Run("valid - chosen weekdays", func(t *testing.T) {
+		// 2030-01-07 is a monday
+		dates := expand(t, "FREQ=WEEKLY;BYDAY=MO,WE,FR", "2030-01-07", "2030-01-13")
+		assert.Equal(t, []string{"2030-01-07", "2030-01-09", "2030-01-11"}, dates)
+	})
+
+	t.Run("valid - every other week", func(t *testing.T) {
+		dates := expand(t, "FREQ=WEEKLY;INTERVAL=2", "2030-01-09", "2030-02-28")
+		assert.Equal(t, []string{"2030-01-09", "2030-01-23", "2030-02-06", "2030-02-20"}, dates)
+	})
+
+	t.Run("valid - count without end date", func(t *testing.T) {
+		dates := expand(t, "FREQ=DAILY;INTERVAL=3;COUNT=3", "2030-01-01", "")
+		assert.Equal(t, []string{"2030-01-01", "2030-01-04", "2030-01-07"}, dates)
+	})
+
+	t.Run("valid - until is before end date", func(t *testing.T) {
+		dates := expand(t, "FREQ=DAILY;UNTIL=20300103", "2030-01-01", "2030-12-31")
+		assert.Equal(t, []string{"2030-01-01", "2030-01-02", "2030-01-03"}, dates)
+	})
+
+	t.Run("valid - excluded dates still count", func(t *testing.T) {
+		dates := expand(t, "FREQ=DAILY;COUNT=3", "2030-01-01", "", date("2030-01-02"))
+		assert.Equal(t, []string{"2030-01-01", "2030-01-03"}, dates)
+	})
+
+	t.Run("valid - open ended rule is capped", func(t *testing.T) {
+		dates := expand(t, "FREQ=DAILY;COUNT=5000", "2030-01-01", "")
+		assert.Len(t, dates, recurrence.MAX_OCCURRENCES)
+	})
+}
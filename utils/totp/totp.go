@@ -0,0 +1,92 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/errors"
+)
+
+// Defaults used by authenticator apps (Google Authenticator, Authy, etc)
+const (
+	DIGITS      = 6
+	PERIOD      = 30 // seconds
+	SECRET_SIZE = 20 // bytes, same as the SHA1 block output
+	SKEW        = 1  // accepted steps before and after the current one
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewSecret returns a random base32 encoded secret
+func NewSecret() (string, error) {
+	const op errors.Op = "totp.NewSecret"
+
+	b := make([]byte, SECRET_SIZE)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.E(err, op, errors.KindServerError)
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// Step is the RFC 6238 time counter of t
+func Step(t time.Time) int64 {
+	return t.Unix() / PERIOD
+}
+
+// Code computes the HOTP value (RFC 4226) of secret for the given step
+func Code(secret []byte, step int64, digits int) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
+
+// Verify checks code against the base32 secret around t and returns the matched step,
+// so the caller can reject a code that has already been used
+func Verify(secret string, code string, t time.Time) (int64, bool) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != DIGITS {
+		return 0, false
+	}
+
+	current := Step(t)
+	for step := current - SKEW; step <= current+SKEW; step++ {
+		expected := Code(key, step, DIGITS)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// URL builds the otpauth:// uri that authenticator apps read from a QR code
+func URL(issuer string, account string, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("digits", fmt.Sprint(DIGITS))
+	query.Set("period", fmt.Sprint(PERIOD))
+
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
@@ -0,0 +1,81 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/final-project-alterra/hospital-management-system-api/utils/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+// SHA1 test vectors from RFC 6238 appendix B
+var rfcSecret = []byte("12345678901234567890")
+
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "94287082"},
+	{1111111109, "07081804"},
+	{1111111111, "14050471"},
+	{1234567890, "89005924"},
+	{2000000000, "69279037"},
+	{20000000000, "65353130"},
+}
+
+func TestCode(t *testing.T) {
+	t.Run("valid - matches RFC 6238 test vectors", func(t *testing.T) {
+		for _, v := range rfcVectors {
+			step := totp.Step(time.Unix(v.unix, 0))
+			assert.Equal(t, v.code, totp.Code(rfcSecret, step, 8), "unix time %d", v.unix)
+		}
+	})
+}
+
+func TestVerify(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(rfcSecret)
+	now := time.Unix(1111111111, 0)
+	code := totp.Code(rfcSecret, totp.Step(now), totp.DIGITS)
+
+	t.Run("valid - when code is current", func(t *testing.T) {
+		step, ok := totp.Verify(secret, code, now)
+		assert.True(t, ok)
+		assert.Equal(t, totp.Step(now), step)
+	})
+
+	t.Run("valid - when clock is one step behind", func(t *testing.T) {
+		_, ok := totp.Verify(secret, code, now.Add(totp.PERIOD*time.Second))
+		assert.True(t, ok)
+	})
+
+	t.Run("valid - when code is too old", func(t *testing.T) {
+		_, ok := totp.Verify(secret, code, now.Add(3*totp.PERIOD*time.Second))
+		assert.False(t, ok)
+	})
+
+	t.Run("valid - when code is wrong", func(t *testing.T) {
+		_, ok := totp.Verify(secret, "000000", now)
+		assert.False(t, ok)
+	})
+
+	t.Run("valid - when secret is not base32", func(t *testing.T) {
+		_, ok := totp.Verify("not base32!", code, now)
+		assert.False(t, ok)
+	})
+}
+
+func TestNewSecret(t *testing.T) {
+	t.Run("valid - secret can be used to verify its own code", func(t *testing.T) {
+		secret, err := totp.NewSecret()
+		assert.Nil(t, err)
+
+		key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+		assert.Nil(t, err)
+		assert.Len(t, key, totp.SECRET_SIZE)
+
+		now := time.Now()
+		_, ok := totp.Verify(secret, totp.Code(key, totp.Step(now), totp.DIGITS), now)
+		assert.True(t, ok)
+	})
+}